@@ -28,9 +28,11 @@ func (h Horizontal) String() string {
 
 // horizontalNames maps Horizontal values to human readable names.
 var horizontalNames = map[Horizontal]string{
-	HorizontalLeft:   "HorizontalLeft",
-	HorizontalCenter: "HorizontalCenter",
-	HorizontalRight:  "HorizontalRight",
+	HorizontalLeft:        "HorizontalLeft",
+	HorizontalCenter:      "HorizontalCenter",
+	HorizontalRight:       "HorizontalRight",
+	HorizontalJustified:   "HorizontalJustified",
+	HorizontalDistributed: "HorizontalDistributed",
 }
 
 const (
@@ -40,6 +42,16 @@ const (
 	HorizontalCenter
 	// HorizontalRight is right alignment along the horizontal axis.
 	HorizontalRight
+	// HorizontalJustified spreads multiple items along the horizontal axis so
+	// that the first one starts at the left edge, the last one ends at the
+	// right edge and the spaces between them are even.
+	// A single item is aligned left.
+	HorizontalJustified
+	// HorizontalDistributed spreads multiple items along the horizontal axis
+	// so that the spaces between them and the spaces before the first and
+	// after the last item are even.
+	// A single item is aligned center.
+	HorizontalDistributed
 )
 
 // Vertical indicates the type of vertical alignment.
@@ -55,9 +67,10 @@ func (v Vertical) String() string {
 
 // verticalNames maps Vertical values to human readable names.
 var verticalNames = map[Vertical]string{
-	VerticalTop:    "VerticalTop",
-	VerticalMiddle: "VerticalMiddle",
-	VerticalBottom: "VerticalBottom",
+	VerticalTop:      "VerticalTop",
+	VerticalMiddle:   "VerticalMiddle",
+	VerticalBottom:   "VerticalBottom",
+	VerticalBaseline: "VerticalBaseline",
 }
 
 const (
@@ -67,4 +80,8 @@ const (
 	VerticalMiddle
 	// VerticalBottom is bottom alignment along the vertical axis.
 	VerticalBottom
+	// VerticalBaseline aligns the last line of an item onto the baseline,
+	// i.e. the middle line of the available space. Items of different heights
+	// aligned this way all end on the same line.
+	VerticalBaseline
 )
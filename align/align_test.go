@@ -42,6 +42,16 @@ func TestHorizontal(t *testing.T) {
 			align: HorizontalRight,
 			want:  "HorizontalRight",
 		},
+		{
+			desc:  "justified",
+			align: HorizontalJustified,
+			want:  "HorizontalJustified",
+		},
+		{
+			desc:  "distributed",
+			align: HorizontalDistributed,
+			want:  "HorizontalDistributed",
+		},
 	}
 
 	for _, tc := range tests {
@@ -79,6 +89,11 @@ func TestVertical(t *testing.T) {
 			align: VerticalBottom,
 			want:  "VerticalBottom",
 		},
+		{
+			desc:  "baseline",
+			align: VerticalBaseline,
+			want:  "VerticalBaseline",
+		},
 	}
 
 	for _, tc := range tests {
@@ -32,6 +32,9 @@ type Options struct {
 	Inverse       bool
 	Blink         bool
 	Dim           bool
+	// Link is the URL of a hyperlink the cell is part of.
+	// Empty if the cell isn't part of a hyperlink.
+	Link string
 }
 
 // Set allows existing options to be passed as an option.
@@ -118,3 +121,13 @@ func Dim() Option {
 		co.Dim = true
 	})
 }
+
+// Link marks the cell as part of a hyperlink to the provided URL.
+// Terminals that support OSC 8 hyperlinks make the cell's text clickable,
+// other terminals display it as plain text. Only works when using the tcell
+// backend.
+func Link(url string) Option {
+	return option(func(co *Options) {
+		co.Link = url
+	})
+}
@@ -83,6 +83,7 @@ func TestNewOptions(t *testing.T) {
 				Inverse(),
 				Blink(),
 				Dim(),
+				Link("https://example.com"),
 			},
 			want: &Options{
 				Bold:          true,
@@ -92,6 +93,7 @@ func TestNewOptions(t *testing.T) {
 				Inverse:       true,
 				Blink:         true,
 				Dim:           true,
+				Link:          "https://example.com",
 			},
 		},
 	}
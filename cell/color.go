@@ -16,6 +16,7 @@ package cell
 
 import (
 	"fmt"
+	"math"
 )
 
 // color.go defines constants for cell colors.
@@ -28,6 +29,10 @@ func (cc Color) String() string {
 	if n, ok := colorNames[cc]; ok {
 		return n
 	}
+	if cc.IsRGB24() {
+		r, g, b := cc.RGB()
+		return fmt.Sprintf("ColorRGB24(%d, %d, %d)", r, g, b)
+	}
 	return fmt.Sprintf("Color:%d", cc)
 }
 
@@ -106,11 +111,18 @@ func ColorRGB6(r, g, b int) Color {
 	return Color(0x10 + 36*r + 6*g + b + 1) // Colors are off-by-one due to ColorDefault being zero.
 }
 
-// ColorRGB24 sets a color using the 24 bit web color scheme.
-// Make sure your terminal is set to the terminalapi.ColorMode256 mode.
+// rgb24Flag marks colors that carry a 24 bit RGB value in their lower 24 bits
+// instead of a number from the terminal color palette.
+const rgb24Flag Color = 1 << 24
+
+// ColorRGB24 sets a true color using the 24 bit web color scheme.
 // The provided values (r, g, b) must be in the range 0-255.
 // Larger or smaller values will be reset to the default color.
 //
+// The color is sent to the terminal as is when it is set to the
+// terminalapi.ColorModeTrueColor mode. In all the other modes, the color is
+// replaced with the nearest color the mode supports.
+//
 // For reference on these colors see the RGB column in:
 // https://jonasjacek.github.io/colors/
 func ColorRGB24(r, g, b int) Color {
@@ -119,5 +131,77 @@ func ColorRGB24(r, g, b int) Color {
 			return ColorDefault
 		}
 	}
-	return ColorRGB6(r/51, g/51, b/51)
+	return rgb24Flag | Color(r<<16|g<<8|b)
+}
+
+// IsRGB24 asserts whether this is a true color created by ColorRGB24.
+func (cc Color) IsRGB24() bool {
+	return cc&rgb24Flag != 0
+}
+
+// xtermBase are the RGB values of the 16 base Xterm colors.
+var xtermBase = [16][3]int{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the intensities of the red, green and blue components of the
+// colors in the 6x6x6 color cube, i.e. of the colors created by ColorRGB6.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// RGB returns the red, green and blue components of the color.
+// For true colors created by ColorRGB24, these are the provided values. For
+// colors from the 256 color palette these are the values of the Xterm color.
+// The ColorDefault and invalid colors are reported as black.
+func (cc Color) RGB() (r, g, b int) {
+	if cc.IsRGB24() {
+		v := int(cc &^ rgb24Flag)
+		return v >> 16 & 0xff, v >> 8 & 0xff, v & 0xff
+	}
+
+	n := int(cc) - 1 // Colors are off-by-one due to ColorDefault being zero.
+	switch {
+	case n < 0 || n > 255:
+		return 0, 0, 0
+	case n < 16:
+		c := xtermBase[n]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	default:
+		v := 8 + (n-232)*10
+		return v, v, v
+	}
+}
+
+// Blend returns the color of fg drawn with the opacity alpha over bg, e.g.
+// to derive a translucent fill from a base color. The alpha must be in the
+// range 0 <= alpha <= 1, values outside of the range are clamped. An alpha of
+// one returns fg, an alpha of zero returns bg.
+//
+// The result is a true color created by ColorRGB24, even if both colors are
+// from the 256 color palette. Terminals that don't display true colors
+// replace it with the nearest color supported by their color mode.
+// The ColorDefault has no known RGB value, if either color is ColorDefault,
+// fg is returned unchanged.
+func Blend(fg, bg Color, alpha float64) Color {
+	if fg == ColorDefault || bg == ColorDefault {
+		return fg
+	}
+	switch {
+	case alpha >= 1:
+		return fg
+	case alpha <= 0 || math.IsNaN(alpha):
+		return bg
+	}
+
+	fr, fgr, fb := fg.RGB()
+	br, bgr, bb := bg.RGB()
+	mix := func(f, b int) int {
+		return int(math.Round(float64(f)*alpha + float64(b)*(1-alpha)))
+	}
+	return ColorRGB24(mix(fr, br), mix(fgr, bgr), mix(fb, bb))
 }
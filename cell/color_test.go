@@ -181,18 +181,18 @@ func TestColorRGB24(t *testing.T) {
 			want: ColorDefault,
 		},
 		{
-			desc: "translates black",
+			desc: "encodes black",
 			r:    0,
 			g:    0,
 			b:    0,
-			want: Color(17),
+			want: Color(1 << 24),
 		},
 		{
-			desc: "adds one to value",
+			desc: "encodes the components",
 			r:    95,
 			g:    255,
 			b:    135,
-			want: Color(85),
+			want: Color(1<<24 | 95<<16 | 255<<8 | 135),
 		},
 	}
 
@@ -202,6 +202,175 @@ func TestColorRGB24(t *testing.T) {
 			if got != tc.want {
 				t.Errorf("ColorRGB24(%v, %v, %v) => %v, want %v", tc.r, tc.g, tc.b, got, tc.want)
 			}
+			if got == ColorDefault {
+				return
+			}
+
+			if !got.IsRGB24() {
+				t.Errorf("ColorRGB24(%v, %v, %v).IsRGB24 => false, want true", tc.r, tc.g, tc.b)
+			}
+			r, g, b := got.RGB()
+			if r != tc.r || g != tc.g || b != tc.b {
+				t.Errorf("ColorRGB24(%v, %v, %v).RGB => %v, %v, %v, want the same values", tc.r, tc.g, tc.b, r, g, b)
+			}
+		})
+	}
+}
+
+func TestRGB(t *testing.T) {
+	tests := []struct {
+		desc       string
+		color      Color
+		wantIsRGB  bool
+		r, g, b    int
+		wantString string
+	}{
+		{
+			desc:       "default color is black",
+			color:      ColorDefault,
+			wantString: "ColorDefault",
+		},
+		{
+			desc:       "base color",
+			color:      ColorRed,
+			r:          255,
+			wantString: "ColorRed",
+		},
+		{
+			desc:       "color from the 6x6x6 cube",
+			color:      ColorRGB6(1, 2, 5),
+			r:          95,
+			g:          135,
+			b:          255,
+			wantString: "Color:70",
+		},
+		{
+			desc:       "shade of grey",
+			color:      ColorNumber(255),
+			r:          238,
+			g:          238,
+			b:          238,
+			wantString: "Color:256",
+		},
+		{
+			desc:       "true color",
+			color:      ColorRGB24(1, 2, 3),
+			wantIsRGB:  true,
+			r:          1,
+			g:          2,
+			b:          3,
+			wantString: "ColorRGB24(1, 2, 3)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.color.IsRGB24(); got != tc.wantIsRGB {
+				t.Errorf("IsRGB24 => %v, want %v", got, tc.wantIsRGB)
+			}
+			r, g, b := tc.color.RGB()
+			if r != tc.r || g != tc.g || b != tc.b {
+				t.Errorf("RGB => %v, %v, %v, want %v, %v, %v", r, g, b, tc.r, tc.g, tc.b)
+			}
+			if got := tc.color.String(); got != tc.wantString {
+				t.Errorf("String => %q, want %q", got, tc.wantString)
+			}
+		})
+	}
+}
+
+func TestBlend(t *testing.T) {
+	tests := []struct {
+		desc  string
+		fg    Color
+		bg    Color
+		alpha float64
+		want  Color
+	}{
+		{
+			desc:  "fully opaque returns the foreground",
+			fg:    ColorRGB24(10, 20, 30),
+			bg:    ColorRGB24(200, 200, 200),
+			alpha: 1,
+			want:  ColorRGB24(10, 20, 30),
+		},
+		{
+			desc:  "fully transparent returns the background",
+			fg:    ColorRGB24(10, 20, 30),
+			bg:    ColorRGB24(200, 200, 200),
+			alpha: 0,
+			want:  ColorRGB24(200, 200, 200),
+		},
+		{
+			desc:  "clamps alpha above one",
+			fg:    ColorRGB24(10, 20, 30),
+			bg:    ColorRGB24(200, 200, 200),
+			alpha: 1.5,
+			want:  ColorRGB24(10, 20, 30),
+		},
+		{
+			desc:  "clamps alpha below zero",
+			fg:    ColorRGB24(10, 20, 30),
+			bg:    ColorRGB24(200, 200, 200),
+			alpha: -1,
+			want:  ColorRGB24(200, 200, 200),
+		},
+		{
+			desc:  "half blend of black and white",
+			fg:    ColorRGB24(0, 0, 0),
+			bg:    ColorRGB24(255, 255, 255),
+			alpha: 0.5,
+			want:  ColorRGB24(128, 128, 128),
+		},
+		{
+			desc:  "quarter blend of red over blue",
+			fg:    ColorRGB24(255, 0, 0),
+			bg:    ColorRGB24(0, 0, 255),
+			alpha: 0.25,
+			want:  ColorRGB24(64, 0, 191),
+		},
+		{
+			desc:  "blend of true color and palette color is a true color",
+			fg:    ColorRGB24(100, 100, 100),
+			bg:    ColorRGB6(0, 0, 5),
+			alpha: 0.5,
+			want:  ColorRGB24(50, 50, 178),
+		},
+		{
+			desc:  "blend of palette colors is a true color",
+			fg:    ColorRGB6(5, 0, 0),
+			bg:    ColorRGB6(0, 0, 0),
+			alpha: 0.5,
+			want:  ColorRGB24(128, 0, 0),
+		},
+		{
+			desc:  "blend of a shade of grey and a base color is a true color",
+			fg:    ColorNumber(244),
+			bg:    ColorBlack,
+			alpha: 0.5,
+			want:  ColorRGB24(64, 64, 64),
+		},
+		{
+			desc:  "default foreground is returned unchanged",
+			fg:    ColorDefault,
+			bg:    ColorRGB24(200, 200, 200),
+			alpha: 0.5,
+			want:  ColorDefault,
+		},
+		{
+			desc:  "default background returns the foreground",
+			fg:    ColorRGB24(10, 20, 30),
+			bg:    ColorDefault,
+			alpha: 0.5,
+			want:  ColorRGB24(10, 20, 30),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := Blend(tc.fg, tc.bg, tc.alpha); got != tc.want {
+				t.Errorf("Blend(%v, %v, %v) => %v, want %v", tc.fg, tc.bg, tc.alpha, got, tc.want)
+			}
 		})
 	}
 }
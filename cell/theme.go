@@ -0,0 +1,34 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+// theme.go defines a set of colors shared by containers and widgets.
+
+// Theme is a named set of colors. Containers and widgets use the colors of
+// the theme in place of their defaults for the color options that weren't
+// set, see container.ApplyTheme.
+type Theme struct {
+	// Name identifies the theme.
+	Name string
+	// Foreground is the color of text.
+	Foreground Color
+	// Background is the background color.
+	Background Color
+	// Border is the color of borders.
+	Border Color
+	// Accent is the color that highlights content, e.g. the border of the
+	// focused container or the data displayed by widgets.
+	Accent Color
+}
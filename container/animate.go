@@ -0,0 +1,202 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// animate.go contains code that animates changes of the split percentage.
+
+import (
+	"fmt"
+	"time"
+)
+
+// splitAnimationInterval is how often the containers request to be redrawn
+// while any split is being animated.
+const splitAnimationInterval = 40 * time.Millisecond
+
+// splitAnimation is a change of the split percentage of one container over
+// time.
+type splitAnimation struct {
+	// from is the split percentage when the animation started.
+	from int
+	// to is the split percentage when the animation completes.
+	to int
+	// start is the time when the animation started.
+	start time.Time
+	// duration is how long the animation takes.
+	duration time.Duration
+	// done is called when the animation completes, can be nil.
+	done func()
+}
+
+// percentAt returns the split percentage at the specified time and true if
+// the animation is complete at that time.
+func (sa *splitAnimation) percentAt(t time.Time) (int, bool) {
+	elapsed := t.Sub(sa.start)
+	if elapsed >= sa.duration {
+		return sa.to, true
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	diff := float64(sa.to-sa.from) * float64(elapsed) / float64(sa.duration)
+	if diff < 0 {
+		return sa.from + int(diff-0.5), false
+	}
+	return sa.from + int(diff+0.5), false
+}
+
+// splitAnimator tracks the split animations of the containers in the tree.
+// This is not thread-safe, the implementation assumes that the owner of
+// splitAnimator performs locking.
+type splitAnimator struct {
+	// active are the animations in progress indexed by the animated
+	// container.
+	active map[*Container]*splitAnimation
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+}
+
+// newSplitAnimator returns a new splitAnimator.
+func newSplitAnimator() *splitAnimator {
+	return &splitAnimator{
+		active: map[*Container]*splitAnimation{},
+		now:    time.Now,
+	}
+}
+
+// animating asserts whether any split is being animated.
+func (sa *splitAnimator) animating() bool {
+	return len(sa.active) > 0
+}
+
+// advance updates the split percentages of the animated containers in the
+// tree with the root to their values at the current time. Returns the
+// completion functions of the animations that completed, in no particular
+// order. Animations of containers that are no longer split percentage
+// containers in the tree are dropped without completing.
+func (sa *splitAnimator) advance(root *Container) []func() {
+	now := sa.now()
+	var done []func()
+	for c, anim := range sa.active {
+		if !c.isAnimatable() || !attached(c, root) {
+			delete(sa.active, c)
+			continue
+		}
+
+		percent, complete := anim.percentAt(now)
+		if percent != c.opts.splitPercent {
+			c.opts.splitPercent = percent
+			root.clearNeeded = true
+		}
+		if complete {
+			delete(sa.active, c)
+			if anim.done != nil {
+				done = append(done, anim.done)
+			}
+		}
+	}
+	return done
+}
+
+// attached asserts whether the container is part of the tree with the root.
+// Containers removed from the tree still point to their former parent, but
+// the parent no longer points to them.
+func attached(c, root *Container) bool {
+	cur := c
+	for ; cur.parent != nil; cur = cur.parent {
+		p := cur.parent
+		if p.first == cur || p.second == cur {
+			continue
+		}
+		var isTab bool
+		for _, t := range p.tabs {
+			if t.cont == cur {
+				isTab = true
+			}
+		}
+		if !isTab {
+			return false
+		}
+	}
+	return cur == root
+}
+
+// isAnimatable asserts whether the split of the container can be animated.
+func (c *Container) isAnimatable() bool {
+	return !c.isLeaf() && (c.opts.split == splitTypeVertical || c.opts.split == splitTypeHorizontal)
+}
+
+// AnimateSplit gradually changes the split percentage of the container with
+// the specified id to the percent over the duration. The split moves a bit
+// on each call to Draw, the containers request redraws while any split is
+// being animated, see RedrawInterval.
+//
+// The percent is clamped into the range 0 <= percent <= 100, the first (left
+// or top) sub container has no space at zero percent and the second (right or
+// bottom) sub container at one hundred percent. This can be used to animate
+// collapsing of a sidebar. The minimum sizes of SplitPercentWithMin still
+// apply. Splits created with SplitFixed, SplitEvenVertical,
+// SplitEvenHorizontal or SplitWeights become percentage splits when the
+// animation starts.
+// A zero or negative duration changes the split on the next call to Draw.
+//
+// The done function, if not nil, is called exactly once from the call to
+// Draw that sets the final percentage, after the container tree is unlocked.
+// It isn't called if the animation is replaced by another call to
+// AnimateSplit for the same container or if the container stops being split.
+//
+// The argument id must match exactly one container that was created with
+// matching ID() option and split with SplitVertical or SplitHorizontal.
+func (c *Container) AnimateSplit(id string, percent int, d time.Duration, done func()) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findID(c, id)
+	if err != nil {
+		return err
+	}
+	if !target.isAnimatable() {
+		return fmt.Errorf("container with ID %q isn't split vertically or horizontally, cannot animate its split", id)
+	}
+
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+
+	from := target.opts.splitPercent
+	if target.opts.splitFixed > DefaultSplitFixed || target.opts.splitEven > 0 || target.opts.splitWeightFirst > 0 {
+		// Start from the current size of the first sub container.
+		if cells, total, err := target.splitCells(); err == nil && total > 0 {
+			from = cells * 100 / total
+		}
+		target.opts.splitPercent = from
+		target.opts.splitFixed = DefaultSplitFixed
+		target.opts.splitEven = 0
+		target.opts.splitWeightFirst = 0
+		target.opts.splitWeightSecond = 0
+	}
+
+	c.splitAnimator.active[target] = &splitAnimation{
+		from:     from,
+		to:       percent,
+		start:    c.splitAnimator.now(),
+		duration: d,
+		done:     done,
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/private/faketerm"
+)
+
+// animStep advances the fake clock, draws the container and checks the width
+// of its second sub container.
+type animStep struct {
+	// at is the time since the start of the animation.
+	at time.Duration
+	// wantSecondWidth is the expected width of the second sub container.
+	wantSecondWidth int
+	// wantDone is the expected number of calls of the completion function so
+	// far.
+	wantDone int
+}
+
+func TestAnimateSplit(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []Option
+		percent  int
+		duration time.Duration
+		steps    []animStep
+	}{
+		{
+			desc: "collapses the first sub container",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right")), SplitPercent(20)),
+			},
+			percent:  0,
+			duration: 100 * time.Millisecond,
+			steps: []animStep{
+				{at: 0, wantSecondWidth: 16},
+				{at: 25 * time.Millisecond, wantSecondWidth: 17},
+				{at: 50 * time.Millisecond, wantSecondWidth: 18},
+				{at: 75 * time.Millisecond, wantSecondWidth: 19},
+				{at: 100 * time.Millisecond, wantSecondWidth: 20, wantDone: 1},
+				{at: 200 * time.Millisecond, wantSecondWidth: 20, wantDone: 1},
+			},
+		},
+		{
+			desc: "expands the first sub container",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right")), SplitPercent(20)),
+			},
+			percent:  60,
+			duration: 40 * time.Millisecond,
+			steps: []animStep{
+				{at: 10 * time.Millisecond, wantSecondWidth: 14},
+				{at: 30 * time.Millisecond, wantSecondWidth: 10},
+				{at: 50 * time.Millisecond, wantSecondWidth: 8, wantDone: 1},
+			},
+		},
+		{
+			desc: "clamps the percentage",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right")), SplitPercent(50)),
+			},
+			percent:  150,
+			duration: 10 * time.Millisecond,
+			steps: []animStep{
+				{at: 10 * time.Millisecond, wantSecondWidth: 0, wantDone: 1},
+			},
+		},
+		{
+			desc: "zero duration changes the split on the next draw",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right")), SplitPercent(50)),
+			},
+			percent: 25,
+			steps: []animStep{
+				{at: 0, wantSecondWidth: 15, wantDone: 1},
+			},
+		},
+		{
+			desc: "fixed split becomes a percentage split",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right")), SplitFixed(10)),
+			},
+			percent:  100,
+			duration: 100 * time.Millisecond,
+			steps: []animStep{
+				{at: 0, wantSecondWidth: 10},
+				{at: 50 * time.Millisecond, wantSecondWidth: 5},
+				{at: 100 * time.Millisecond, wantSecondWidth: 0, wantDone: 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{20, 4})
+			cont, err := New(ft, append([]Option{ID("root")}, tc.opts...)...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			now := start
+			cont.splitAnimator.now = func() time.Time { return now }
+
+			var gotDone int
+			if err := cont.AnimateSplit("root", tc.percent, tc.duration, func() { gotDone++ }); err != nil {
+				t.Fatalf("AnimateSplit => unexpected error: %v", err)
+			}
+
+			for _, step := range tc.steps {
+				now = start.Add(step.at)
+				if err := cont.Draw(); err != nil {
+					t.Fatalf("Draw at %v => unexpected error: %v", step.at, err)
+				}
+
+				var gotWidth int
+				if step.wantSecondWidth > 0 {
+					ar, err := cont.Rect("right")
+					if err != nil {
+						t.Fatalf("Rect at %v => unexpected error: %v", step.at, err)
+					}
+					gotWidth = ar.Dx()
+				} else if ar, err := cont.Rect("right"); err == nil {
+					gotWidth = ar.Dx()
+				}
+				if gotWidth != step.wantSecondWidth {
+					t.Errorf("at %v the second sub container is %d cells wide, want %d", step.at, gotWidth, step.wantSecondWidth)
+				}
+				if gotDone != step.wantDone {
+					t.Errorf("at %v the completion function was called %d times, want %d", step.at, gotDone, step.wantDone)
+				}
+			}
+		})
+	}
+}
+
+func TestAnimateSplitFails(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 4})
+	cont, err := New(
+		ft,
+		ID("root"),
+		SplitVertical(Left(ID("left")), Right(ID("right"))),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if err := cont.AnimateSplit("missing", 0, time.Second, nil); err == nil {
+		t.Errorf("AnimateSplit(missing) => nil error, want an error")
+	}
+	if err := cont.AnimateSplit("left", 0, time.Second, nil); err == nil {
+		t.Errorf("AnimateSplit(leaf container) => nil error, want an error")
+	}
+}
+
+func TestAnimateSplitReplaced(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 4})
+	cont, err := New(
+		ft,
+		ID("root"),
+		SplitVertical(Left(ID("left")), Right(ID("right")), SplitPercent(20)),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cont.splitAnimator.now = func() time.Time { return now }
+
+	var collapsed, expanded int
+	if err := cont.AnimateSplit("root", 0, 100*time.Millisecond, func() { collapsed++ }); err != nil {
+		t.Fatalf("AnimateSplit => unexpected error: %v", err)
+	}
+	if got, want := cont.RedrawInterval(), splitAnimationInterval; got != want {
+		t.Errorf("RedrawInterval during the animation => %v, want %v", got, want)
+	}
+
+	now = now.Add(50 * time.Millisecond)
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	// Expanding the sidebar again before it collapsed starts from where the
+	// first animation left off.
+	if err := cont.AnimateSplit("root", 20, 100*time.Millisecond, func() {
+		expanded++
+		// The completion function can update the containers.
+		if err := cont.Update("left", Clear()); err != nil {
+			t.Errorf("Update => unexpected error: %v", err)
+		}
+	}); err != nil {
+		t.Fatalf("AnimateSplit => unexpected error: %v", err)
+	}
+
+	now = now.Add(50 * time.Millisecond)
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if ar, err := cont.Rect("right"); err != nil || ar.Dx() != 17 {
+		t.Errorf("Rect => %v, %v, want a rectangle 17 cells wide", ar, err)
+	}
+
+	now = now.Add(50 * time.Millisecond)
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if ar, err := cont.Rect("right"); err != nil || ar.Dx() != 16 {
+		t.Errorf("Rect => %v, %v, want a rectangle 16 cells wide", ar, err)
+	}
+	if collapsed != 0 || expanded != 1 {
+		t.Errorf("completion functions called %d and %d times, want 0 and 1", collapsed, expanded)
+	}
+	if got := cont.RedrawInterval(); got != 0 {
+		t.Errorf("RedrawInterval after the animation => %v, want zero", got)
+	}
+}
+
+func TestAnimateSplitRemovedContainer(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 4})
+	cont, err := New(
+		ft,
+		ID("root"),
+		SplitVertical(
+			Left(ID("left")),
+			Right(
+				ID("right"),
+				SplitVertical(Left(ID("inner")), Right()),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var done int
+	if err := cont.AnimateSplit("right", 0, time.Second, func() { done++ }); err != nil {
+		t.Fatalf("AnimateSplit => unexpected error: %v", err)
+	}
+	if err := cont.RemoveSplit("right"); err != nil {
+		t.Fatalf("RemoveSplit => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if cont.splitAnimator.animating() {
+		t.Errorf("animating => true after the split was removed, want false")
+	}
+	if done != 0 {
+		t.Errorf("completion function called %d times, want 0", done)
+	}
+}
+
+func TestAnimateSplitThenUpdate(t *testing.T) {
+	tests := []struct {
+		desc  string
+		split SplitOption
+		// wantLeftWidth is the expected width of the first sub container after
+		// the update.
+		wantLeftWidth int
+	}{
+		{
+			desc:          "weighted split",
+			split:         SplitWeights(1, 3),
+			wantLeftWidth: 5,
+		},
+		{
+			desc:          "fixed split",
+			split:         SplitFixed(4),
+			wantLeftWidth: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{20, 4})
+			cont, err := New(
+				ft,
+				ID("root"),
+				SplitVertical(Left(ID("left")), Right(ID("right")), SplitWeights(1, 1)),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.AnimateSplit("root", 25, 0, nil); err != nil {
+				t.Fatalf("AnimateSplit => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if err := cont.Update("root", SplitVertical(Left(ID("left")), Right(ID("right")), tc.split)); err != nil {
+				t.Fatalf("Update => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			ar, err := cont.Rect("left")
+			if err != nil {
+				t.Fatalf("Rect => unexpected error: %v", err)
+			}
+			if got := ar.Dx(); got != tc.wantLeftWidth {
+				t.Errorf("width of the left container => %d, want %d", got, tc.wantLeftWidth)
+			}
+		})
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"fmt"
 	"image"
 	"sync"
+	"time"
 
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/area"
@@ -45,6 +47,14 @@ type Container struct {
 	first  *Container
 	second *Container
 
+	// tabs are the sub containers of a container split into tabs, see
+	// SplitTabs. The sub container of the active tab is also the first sub
+	// container, the sub containers of the other tabs are hidden. Hidden sub
+	// containers aren't drawn and don't receive any events.
+	tabs []*tab
+	// activeTab is the index of the active tab.
+	activeTab int
+
 	// term is the terminal this container is placed on.
 	// All containers in the tree share the same terminal.
 	term terminalapi.Terminal
@@ -53,10 +63,31 @@ type Container struct {
 	// All containers in the tree share the same tracker.
 	focusTracker *focusTracker
 
+	// keySeqTracker tracks the keys pressed as part of key sequences.
+	// All containers in the tree share the same tracker.
+	keySeqTracker *keySeqTracker
+
+	// resizeTracker tracks the split being resized with the mouse.
+	// All containers in the tree share the same tracker.
+	resizeTracker *resizeTracker
+
+	// splitAnimator tracks the split animations, see AnimateSplit.
+	// All containers in the tree share the same animator.
+	splitAnimator *splitAnimator
+
+	// scroll is the offset of the visible area within the virtual canvas of
+	// a scrollable container, see Scrollable.
+	scroll image.Point
+
 	// area is the area of the terminal this container has access to.
 	// Initialized the first time Draw is called.
 	area image.Rectangle
 
+	// drawn is the sequence number of the last Draw call that computed the
+	// area of this container. The root container counts the Draw calls that
+	// computed the layout.
+	drawn int
+
 	// opts are the options provided to the container.
 	opts *options
 
@@ -88,6 +119,9 @@ func New(t terminalapi.Terminal, opts ...Option) (*Container, error) {
 
 	// Initially the root is focused.
 	root.focusTracker = newFocusTracker(root)
+	root.keySeqTracker = newKeySeqTracker()
+	root.resizeTracker = newResizeTracker()
+	root.splitAnimator = newSplitAnimator()
 	if err := applyOptions(root, opts...); err != nil {
 		return nil, err
 	}
@@ -100,11 +134,14 @@ func New(t terminalapi.Terminal, opts ...Option) (*Container, error) {
 // newChild creates a new child container of the given parent.
 func newChild(parent *Container, opts []Option) (*Container, error) {
 	child := &Container{
-		parent:       parent,
-		term:         parent.term,
-		focusTracker: parent.focusTracker,
-		opts:         newOptions(parent.opts),
-		mu:           parent.mu,
+		parent:        parent,
+		term:          parent.term,
+		focusTracker:  parent.focusTracker,
+		keySeqTracker: parent.keySeqTracker,
+		resizeTracker: parent.resizeTracker,
+		splitAnimator: parent.splitAnimator,
+		opts:          newOptions(parent.opts),
+		mu:            parent.mu,
 	}
 	if err := applyOptions(child, opts...); err != nil {
 		return nil, err
@@ -122,6 +159,18 @@ func (c *Container) hasWidget() bool {
 	return c.opts.widget != nil
 }
 
+// isHidden determines if this container is hidden, see the Hidden and
+// Collapsed options.
+func (c *Container) isHidden() bool {
+	return c.opts.hidden || c.opts.collapsed
+}
+
+// hasCollapsed determines if any of the sub containers of this container is
+// collapsed.
+func (c *Container) hasCollapsed() bool {
+	return (c.first != nil && c.first.opts.collapsed) || (c.second != nil && c.second.opts.collapsed)
+}
+
 // isLeaf determines if this container is a leaf container in the binary tree of containers.
 // Only leaf containers are guaranteed to be "visible" on the screen, because
 // they are on the top of other non-leaf containers.
@@ -174,10 +223,85 @@ func (c *Container) widgetArea() (image.Rectangle, error) {
 // split splits the container's usable area into child areas.
 // Panics if the container isn't configured for a split.
 func (c *Container) split() (image.Rectangle, image.Rectangle, error) {
+	first, second, _, err := c.splitWithDivider()
+	return first, second, err
+}
+
+// splitWithDivider splits the container's usable area into child areas and
+// the area of the divider between them. The area of the divider is zero if
+// the container doesn't have a divider.
+func (c *Container) splitWithDivider() (image.Rectangle, image.Rectangle, image.Rectangle, error) {
+	first, second, err := c.splitAreas()
+	if err != nil || c.opts.split == splitTypeTabs || c.opts.dividerStyle == linestyle.None || c.hasCollapsed() {
+		return first, second, image.ZR, err
+	}
+
+	var divider image.Rectangle
+	if c.opts.split == splitTypeVertical {
+		switch {
+		case second.Dx() > 0:
+			divider = image.Rect(second.Min.X, second.Min.Y, second.Min.X+1, second.Max.Y)
+			second.Min.X++
+		case first.Dx() > 0:
+			divider = image.Rect(first.Max.X-1, first.Min.Y, first.Max.X, first.Max.Y)
+			first.Max.X--
+		}
+	} else {
+		switch {
+		case second.Dy() > 0:
+			divider = image.Rect(second.Min.X, second.Min.Y, second.Max.X, second.Min.Y+1)
+			second.Min.Y++
+		case first.Dy() > 0:
+			divider = image.Rect(first.Min.X, first.Max.Y-1, first.Max.X, first.Max.Y)
+			first.Max.Y--
+		}
+	}
+	return first, second, divider, nil
+}
+
+// splitAreas splits the container's usable area into child areas ignoring any
+// divider.
+func (c *Container) splitAreas() (image.Rectangle, image.Rectangle, error) {
 	ar, err := c.opts.padding.apply(c.usable())
 	if err != nil {
 		return image.ZR, image.ZR, err
 	}
+	if c.opts.split == splitTypeTabs {
+		// The first line is reserved for the tab strip.
+		_, content, err := area.HSplitCells(ar, 1)
+		return content, image.ZR, err
+	}
+
+	// A collapsed sub container gives all of its space to its sibling.
+	if c.hasCollapsed() {
+		first, second := ar, ar
+		if c.first != nil && c.first.opts.collapsed {
+			first = image.ZR
+		}
+		if c.second != nil && c.second.opts.collapsed {
+			second = image.ZR
+		}
+		return first, second, nil
+	}
+	if first, ok := c.fitSplit(ar); ok {
+		if c.opts.split == splitTypeVertical {
+			return area.VSplitCells(ar, first)
+		}
+		return area.HSplitCells(ar, first)
+	}
+	if n := c.opts.splitEven; n > 0 {
+		// Rounding up gives the remainder to the first sub containers.
+		if c.opts.split == splitTypeVertical {
+			return area.VSplitCells(ar, (ar.Dx()+n-1)/n)
+		}
+		return area.HSplitCells(ar, (ar.Dy()+n-1)/n)
+	}
+	if wf, ws := c.opts.splitWeightFirst, c.opts.splitWeightSecond; wf > 0 {
+		if c.opts.split == splitTypeVertical {
+			return area.VSplitCells(ar, weightedSize(ar.Dx(), wf, ws))
+		}
+		return area.HSplitCells(ar, weightedSize(ar.Dy(), wf, ws))
+	}
 	if c.opts.splitFixed > DefaultSplitFixed {
 		if c.opts.split == splitTypeVertical {
 			return area.VSplitCells(ar, c.opts.splitFixed)
@@ -185,12 +309,106 @@ func (c *Container) split() (image.Rectangle, image.Rectangle, error) {
 		return area.HSplitCells(ar, c.opts.splitFixed)
 	}
 
+	if c.opts.splitMinFirst > 0 || c.opts.splitMinSecond > 0 {
+		if c.opts.split == splitTypeVertical {
+			return area.VSplitCells(ar, splitSize(ar.Dx(), c.opts.splitPercent, c.opts.splitMinFirst, c.opts.splitMinSecond))
+		}
+		return area.HSplitCells(ar, splitSize(ar.Dy(), c.opts.splitPercent, c.opts.splitMinFirst, c.opts.splitMinSecond))
+	}
+
 	if c.opts.split == splitTypeVertical {
 		return area.VSplit(ar, c.opts.splitPercent)
 	}
 	return area.HSplit(ar, c.opts.splitPercent)
 }
 
+// fitSplit returns the size in cells of the first sub container when one of
+// the sub containers shrinks to fit its widget. Returns false if neither of
+// them does.
+func (c *Container) fitSplit(ar image.Rectangle) (int, bool) {
+	total := ar.Dy()
+	if c.opts.split == splitTypeVertical {
+		total = ar.Dx()
+	}
+
+	var first int
+	if size, ok := c.first.fitSize(c.opts.split); ok {
+		first = size
+	} else if size, ok := c.second.fitSize(c.opts.split); ok {
+		first = total - size
+		if c.opts.dividerStyle != linestyle.None {
+			// The divider is taken from the second sub container.
+			first--
+		}
+	} else {
+		return 0, false
+	}
+
+	if first < 0 {
+		first = 0
+	}
+	if first > total {
+		first = total
+	}
+	return first, true
+}
+
+// fitSize returns the size in cells the container needs along the axis of
+// the split to fit the preferred size of its widget. Returns false if the
+// container doesn't shrink to fit or its widget has no preferred size along
+// the axis.
+func (c *Container) fitSize(split splitType) (int, bool) {
+	if c == nil || !c.opts.shrinkToFit || !c.hasWidget() {
+		return 0, false
+	}
+	ps, ok := c.opts.widget.(widgetapi.PreferredSizer)
+	if !ok {
+		return 0, false
+	}
+
+	pref := ps.PreferredSize()
+	p, m := c.opts.padding, c.opts.margin
+	size, extra := pref.Y, p.topCells+p.bottomCells+m.topCells+m.bottomCells
+	if split == splitTypeVertical {
+		size, extra = pref.X, p.leftCells+p.rightCells+m.leftCells+m.rightCells
+	}
+	if size <= 0 {
+		return 0, false
+	}
+	size += extra
+	if c.hasBorder() {
+		size += 2
+	}
+	return size, true
+}
+
+// weightedSize returns the size in cells of the first sub container when
+// dividing the total cells in proportion to the weights of the sub
+// containers. The size is rounded to the nearest cell, halves are rounded up.
+func weightedSize(total, first, second int) int {
+	sum := first + second
+	return (2*total*first + sum) / (2 * sum)
+}
+
+// splitSize returns the size in cells of the first sub container when
+// splitting the total cells at the percentage while honoring the minimum sizes
+// of both sub containers. If total is smaller than the sum of the minimums,
+// the cells are divided in proportion to the minimums.
+func splitSize(total, percent, minFirst, minSecond int) int {
+	if minFirst+minSecond > total {
+		return total * minFirst / (minFirst + minSecond)
+	}
+
+	first := total * percent / 100
+	if first < minFirst {
+		first = minFirst
+	}
+	if total-first < minSecond {
+		first = total - minSecond
+	}
+	return first
+}
+
 // createFirst creates and returns the first sub container of this container.
 func (c *Container) createFirst(opts []Option) error {
 	first, err := newChild(c, opts)
@@ -213,9 +431,18 @@ func (c *Container) createSecond(opts []Option) error {
 
 // Draw draws this container and all of its sub containers.
 func (c *Container) Draw() error {
+	// The completion functions of split animations run after the lock is
+	// released, so they can update the containers.
+	var done []func()
+	defer func() {
+		for _, fn := range done {
+			fn()
+		}
+	}()
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	done = c.splitAnimator.advance(c)
 	if c.clearNeeded {
 		if err := c.term.Clear(); err != nil {
 			return fmt.Errorf("term.Clear => error: %v", err)
@@ -230,6 +457,12 @@ func (c *Container) Draw() error {
 		return err
 	}
 	c.focusTracker.updateArea(ar)
+
+	if tooSmall(c) {
+		// The layout needs a clean terminal once it fits again.
+		c.clearNeeded = true
+		return drawMinSizeMessage(c)
+	}
 	return drawTree(c)
 }
 
@@ -257,13 +490,197 @@ func (c *Container) Update(id string, opts ...Option) error {
 	}
 
 	// The currently focused container might not be reachable anymore, because
-	// it was under the target. If that is so, move the focus up to the target.
+	// it was under the target. If that is so, move the focus up to the target
+	// or to the closest ancestor that isn't hidden if the target got hidden.
+	if !c.focusTracker.reachableFrom(c) {
+		focus := target
+		for cur := target; cur != nil; cur = cur.parent {
+			if cur.isHidden() && cur.parent != nil {
+				focus = cur.parent
+			}
+		}
+		c.focusTracker.setActive(focus)
+	}
+	return nil
+}
+
+// AddSplit splits the leaf container with the specified id into two sub
+// containers. The split argument must be either the SplitVertical or the
+// SplitHorizontal option. The widget placed in the container before the split
+// moves into the first (left or top) sub container, unless the provided
+// options place another widget or sub containers there.
+// Use RemoveSplit to collapse the container back.
+//
+// The argument id must match exactly one container that was created with
+// matching ID() option. The layout change is visible on the next call to
+// Draw.
+func (c *Container) AddSplit(id string, split Option) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findID(c, id)
+	if err != nil {
+		return err
+	}
+	if !target.isLeaf() {
+		return fmt.Errorf("container with ID %q is already split, only leaf containers can be split", id)
+	}
+	c.clearNeeded = true
+
+	// The target is restored if the split fails, so that a rejected option
+	// doesn't leave the container partially modified.
+	saved := *target.opts
+	restore := func(err error) error {
+		*target.opts = saved
+		target.first = nil
+		target.second = nil
+		target.tabs = nil
+		target.activeTab = 0
+		return err
+	}
+	if err := applyOptions(target, split); err != nil {
+		return restore(err)
+	}
+	if target.isLeaf() {
+		return restore(fmt.Errorf("the option provided to AddSplit for container with ID %q must be SplitVertical or SplitHorizontal", id))
+	}
+	if first := target.first; first.isLeaf() && !first.hasWidget() {
+		first.opts.widget = saved.widget
+		first.opts.hAlign = saved.hAlign
+		first.opts.vAlign = saved.vAlign
+	}
+	if err := validateOptions(c); err != nil {
+		return restore(err)
+	}
+
+	// The focus moves to the sub container that now holds the widget.
+	if c.focusTracker.isActive(target) {
+		c.focusTracker.setActive(target.first)
+	}
+	return nil
+}
+
+// RemoveSplit collapses the split container with the specified id, reversing
+// the effect of AddSplit. The second (right or bottom) sub container is
+// removed together with its widgets and sub containers. The first (left or
+// top) sub container takes the place of the container with the specified id,
+// i.e. its widget or its own sub containers and all of its options such as
+// the border or the padding replace those of the container. Only the ID and
+// the options inherited from the parent container are kept.
+//
+// The argument id must match exactly one container that was created with
+// matching ID() option. The layout change is visible on the next call to
+// Draw. The removed widgets no longer receive any events.
+func (c *Container) RemoveSplit(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findID(c, id)
+	if err != nil {
+		return err
+	}
+	if target.isLeaf() {
+		return fmt.Errorf("container with ID %q isn't split, cannot remove the split", id)
+	}
+	c.clearNeeded = true
+
+	// The target takes over all the options of the first sub container, only
+	// the identity of the target and its place in the tree are kept.
+	first := target.first
+	opts := *first.opts
+	opts.id = target.opts.id
+	opts.global = target.opts.global
+	opts.inherited = target.opts.inherited
+	*target.opts = opts
+	target.scroll = first.scroll
+	target.first = first.first
+	target.second = first.second
+	target.tabs = first.tabs
+	target.activeTab = first.activeTab
+	for _, child := range []*Container{target.first, target.second} {
+		if child != nil {
+			child.parent = target
+		}
+	}
+	for _, t := range target.tabs {
+		t.cont.parent = target
+	}
+
+	// The currently focused container might not be reachable anymore, because
+	// it was removed. If that is so, move the focus up to the target.
 	if !c.focusTracker.reachableFrom(c) {
 		c.focusTracker.setActive(target)
 	}
 	return nil
 }
 
+// RedrawInterval returns the shortest widgetapi.Options.RedrawInterval
+// requested by the widgets in the visible containers or zero if none of them
+// requests to be redrawn periodically. Splits being animated request frequent
+// redraws, see AnimateSplit.
+func (c *Container) RedrawInterval() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		errStr   string
+		interval time.Duration
+	)
+	if c.splitAnimator.animating() {
+		interval = splitAnimationInterval
+	}
+	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
+		if cur.opts.widget == nil {
+			return nil
+		}
+		if d := cur.opts.widget.Options().RedrawInterval; d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+		return nil
+	}))
+	return interval
+}
+
+// FocusedID returns the ID of the currently focused container or an empty
+// string if it was created without the ID() option. Together with
+// SetFocusByID it can be used to preserve the focus when the layout is
+// rebuilt by calling Update.
+func (c *Container) FocusedID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.focusTracker.active().opts.id
+}
+
+// SetFocusByID focuses the container with the specified id.
+// The argument id must match exactly one container that was created with
+// matching ID() option. Returns an error if the container isn't focusable,
+// i.e. if it is hidden or in a tab that isn't active.
+func (c *Container) SetFocusByID(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findID(c, id)
+	if err != nil {
+		return err
+	}
+
+	var (
+		errStr  string
+		visible bool
+	)
+	preOrder(rootCont(c), &errStr, visitFunc(func(cur *Container) error {
+		if cur == target {
+			visible = true
+		}
+		return nil
+	}))
+	if !visible {
+		return fmt.Errorf("container with ID %q isn't focusable, it is hidden or in a tab that isn't active", id)
+	}
+	c.focusTracker.setActive(target)
+	return nil
+}
+
 // updateFocusFromMouse processes the mouse event and determines if it changes
 // the focused container.
 // Caller must hold c.mu.
@@ -301,6 +718,8 @@ func (c *Container) updateFocusFromKeyboard(k *terminalapi.Keyboard) {
 		c.focusTracker.next( /* group = */ nil)
 	case active.opts.global.keyFocusPrevious != nil && *active.opts.global.keyFocusPrevious == k.Key:
 		c.focusTracker.previous( /* group = */ nil)
+	case active.opts.global.keyFocusEscapeScope != nil && *active.opts.global.keyFocusEscapeScope == k.Key:
+		c.focusTracker.escapeScope()
 	case isGroupKeyForNext && nextMatchesContGroup:
 		c.focusTracker.next(&nextG)
 	case isGroupKeyForPrev && prevMatchesContGroup:
@@ -310,6 +729,16 @@ func (c *Container) updateFocusFromKeyboard(k *terminalapi.Keyboard) {
 
 // processEvent processes events delivered to the container.
 func (c *Container) processEvent(ev terminalapi.Event) error {
+	// Keyboard events first go to the keyboard hook which can consume them.
+	if k, ok := ev.(*terminalapi.Keyboard); ok && !k.Release {
+		c.mu.Lock()
+		hook := c.opts.global.keyboardHook
+		c.mu.Unlock()
+		if hook != nil && hook(k) {
+			return nil
+		}
+	}
+
 	// This is done in two stages.
 	// 1) under lock we traverse the container and identify all targets
 	//    (widgets) that should receive the event.
@@ -333,6 +762,16 @@ func (c *Container) processEvent(ev terminalapi.Event) error {
 func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error) {
 	switch e := ev.(type) {
 	case *terminalapi.Mouse:
+		if c.resizeTracker.mouse(c, e) {
+			// The mouse is dragging a seam, the widgets don't get the event.
+			return func() error { return nil }, nil
+		}
+		if c.scrollFromMouse(e) {
+			// The mouse wheel scrolled a container, the widgets don't get
+			// the event.
+			return func() error { return nil }, nil
+		}
+		c.updateTabFromMouse(e)
 		c.updateFocusFromMouse(ev.(*terminalapi.Mouse))
 
 		targets, err := c.mouseEvTargets(e)
@@ -349,10 +788,37 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 		}, nil
 
 	case *terminalapi.Keyboard:
+		if e.Release {
+			// Key releases don't trigger any actions of the container.
+			targets := c.keyReleaseEvTargets()
+			return func() error {
+				for _, kt := range targets {
+					if err := kt.widget.Keyboard(e, kt.meta); err != nil {
+						return err
+					}
+				}
+				return nil
+			}, nil
+		}
+		if w, ok := c.capturedBy(e.Key); ok {
+			meta := &widgetapi.EventMeta{Focused: true}
+			return func() error {
+				return w.Keyboard(e, meta)
+			}, nil
+		}
 		c.updateFocusFromKeyboard(ev.(*terminalapi.Keyboard))
+		c.updateTabFromKeyboard(e)
+		c.updateScrollFromKeyboard(e)
 
+		global := c.opts.global
+		seqFn := c.keySeqTracker.key(e.Key, global.keySequences, global.keySequenceTimeout)
 		targets := c.keyEvTargets()
 		return func() error {
+			if seqFn != nil {
+				if err := seqFn(); err != nil {
+					return err
+				}
+			}
 			for _, kt := range targets {
 				if err := kt.widget.Keyboard(e, kt.meta); err != nil {
 					return err
@@ -361,11 +827,54 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 			return nil
 		}, nil
 
+	case *terminalapi.Paste:
+		targets := c.keyEvTargets()
+		return func() error {
+			for _, kt := range targets {
+				if err := pasteTo(kt.widget, e, kt.meta); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+
+	case *terminalapi.Resize:
+		targets := c.resizeEvTargets()
+		return func() error {
+			for _, rt := range targets {
+				if err := rt.widget.Resize(e, rt.meta); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("container received an unsupported event type %T", ev)
 	}
 }
 
+// capturedBy returns the widget in the focused container if it captures the
+// key, see widgetapi.Options.CaptureKeysOnFocus. The bool return value is
+// false if the key isn't captured.
+// Caller must hold c.mu.
+func (c *Container) capturedBy(k keyboard.Key) (widgetapi.Widget, bool) {
+	active := c.focusTracker.active()
+	if !active.hasWidget() {
+		return nil, false
+	}
+	wOpt := active.opts.widget.Options()
+	if wOpt.WantKeyboard == widgetapi.KeyScopeNone {
+		return nil, false
+	}
+	for _, captured := range wOpt.CaptureKeysOnFocus {
+		if captured == k {
+			return active.opts.widget, true
+		}
+	}
+	return nil, false
+}
+
 // keyEvTarget contains a widget that should receive an event and the metadata
 // for the event.
 type keyEvTarget struct {
@@ -435,6 +944,84 @@ func (c *Container) keyEvTargets() []*keyEvTarget {
 	return targets
 }
 
+// keyReleaseEvTargets returns those widgets found in the container that should
+// receive a keyboard event that reports a key release.
+// Caller must hold c.mu.
+func (c *Container) keyReleaseEvTargets() []*keyEvTarget {
+	var targets []*keyEvTarget
+	for _, kt := range c.keyEvTargets() {
+		if kt.widget.Options().WantKeyRelease {
+			targets = append(targets, kt)
+		}
+	}
+	return targets
+}
+
+// pasteTo delivers the paste event to the widget. Widgets that don't
+// implement widgetapi.Paster receive a keyboard event for every character of
+// the pasted text instead.
+func pasteTo(w widgetapi.Widget, p *terminalapi.Paste, meta *widgetapi.EventMeta) error {
+	if pw, ok := w.(widgetapi.Paster); ok {
+		return pw.Paste(p, meta)
+	}
+
+	for _, r := range p.Text {
+		var k keyboard.Key
+		switch r {
+		case '\r':
+			// Windows line endings are delivered as a single KeyEnter.
+			continue
+		case '\n':
+			k = keyboard.KeyEnter
+		case '\t':
+			k = keyboard.KeyTab
+		default:
+			k = keyboard.Key(r)
+		}
+		if err := w.Keyboard(&terminalapi.Keyboard{Key: k}, meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resizeEvTarget contains a widget that should receive a terminal resize
+// event and the metadata for the event.
+type resizeEvTarget struct {
+	// widget is the widget that should receive the resize event.
+	widget widgetapi.Resizer
+	// meta is the metadata about the event.
+	meta *widgetapi.EventMeta
+}
+
+// resizeEvTargets returns those widgets found in the container that should
+// receive terminal resize events.
+// Caller must hold c.mu.
+func (c *Container) resizeEvTargets() []*resizeEvTarget {
+	var (
+		errStr  string
+		targets []*resizeEvTarget
+	)
+	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
+		if !cur.hasWidget() || !cur.opts.widget.Options().WantResize {
+			return nil
+		}
+		r, ok := cur.opts.widget.(widgetapi.Resizer)
+		if !ok {
+			return nil
+		}
+
+		targets = append(targets, &resizeEvTarget{
+			widget: r,
+			meta: &widgetapi.EventMeta{
+				Focused: cur.focusTracker.isActive(cur),
+			},
+		})
+		return nil
+	}))
+	return targets
+}
+
 // mouseEvTarget contains a mouse event adjusted relative to the widget's area,
 // the widget that should receive it and metadata about the event.
 type mouseEvTarget struct {
@@ -476,10 +1063,16 @@ func (c *Container) mouseEvTargets(m *terminalapi.Mouse) ([]*mouseEvTarget, erro
 		if err != nil {
 			return err
 		}
+		if cur.isScrollable() {
+			if wa, _, err = cur.scrollAreas(); err != nil {
+				return err
+			}
+		}
 
 		meta := &widgetapi.EventMeta{
 			Focused: cur.focusTracker.isActive(cur),
 		}
+		n := len(widgets)
 		switch wOpts.WantMouse {
 		case widgetapi.MouseScopeNone:
 			// Widget doesn't want any mouse events.
@@ -501,6 +1094,9 @@ func (c *Container) mouseEvTargets(m *terminalapi.Mouse) ([]*mouseEvTarget, erro
 			// Widget wants all mouse events.
 			widgets = append(widgets, newMouseEvTarget(cur.opts.widget, wa, m, meta))
 		}
+		if cur.isScrollable() && len(widgets) > n {
+			cur.scrollMouseEv(widgets[n])
+		}
 		return nil
 	}))
 
@@ -526,6 +1122,8 @@ func (c *Container) Subscribe(eds *event.DistributionSystem) {
 	want := []terminalapi.Event{
 		&terminalapi.Keyboard{},
 		&terminalapi.Mouse{},
+		&terminalapi.Paste{},
+		&terminalapi.Resize{},
 	}
 	eds.Subscribe(want, func(ev terminalapi.Event) {
 		if err := c.processEvent(ev); err != nil {
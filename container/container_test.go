@@ -15,17 +15,20 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/testcanvas"
 	"github.com/mum4k/termdash/private/draw"
 	"github.com/mum4k/termdash/private/draw/testdraw"
@@ -36,6 +39,7 @@ import (
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/barchart"
+	"github.com/mum4k/termdash/widgets/segmentdisplay"
 )
 
 // Example demonstrates how to use the Container API.
@@ -91,6 +95,250 @@ func TestNew(t *testing.T) {
 			},
 			wantContainerErr: true,
 		},
+		{
+			desc:     "vertical split with a divider",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Divider(linestyle.Light, cell.ColorRed),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{10, 0}, End: image.Point{10, 5}},
+				}, draw.HVLineCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(cvs, ft)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(11, 0, 20, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "horizontal split with a divider",
+			termSize: image.Point{10, 9},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Bottom(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Divider(linestyle.Double, cell.ColorBlue),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{0, 4}, End: image.Point{9, 4}},
+				},
+					draw.HVLineStyle(linestyle.Double),
+					draw.HVLineCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testcanvas.MustApply(cvs, ft)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 4)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 5, 10, 9)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "divider takes space from the second container of a fixed split",
+			termSize: image.Point{30, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						SplitFixed(8),
+						Divider(linestyle.Light, cell.ColorRed),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{8, 0}, End: image.Point{8, 5}},
+				}, draw.HVLineCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(cvs, ft)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 8, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(9, 0, 30, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "divider takes space from the first container when the second has none",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						SplitFixed(20),
+						Divider(linestyle.Light, cell.ColorRed),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{19, 0}, End: image.Point{19, 5}},
+				}, draw.HVLineCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(cvs, ft)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 19, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "fails on BorderTitle without a Border",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, BorderTitle("ab"))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on BorderTitle with the border removed",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Border(linestyle.Light), BorderTitle("ab"), Border(linestyle.None))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on negative MinimumSize",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, MinimumSize(image.Point{-1, 1}))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on empty MinimumSizeMessage",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, MinimumSizeMessage(""))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on MinimumSizeMessage with a newline",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, MinimumSizeMessage("too\nsmall"))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "draws the layout when the terminal has the minimum size",
+			termSize: image.Point{10, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					MinimumSize(image.Point{10, 4}),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws the message when the terminal is smaller than the minimum size",
+			termSize: image.Point{20, 3},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					MinimumSize(image.Point{10, 4}),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "terminal too small", image.Point{1, 1})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws custom message trimmed to the terminal",
+			termSize: image.Point{5, 2},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					MinimumSize(image.Point{10, 4}),
+					MinimumSizeMessage("too small", cell.FgColor(cell.ColorRed)),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "too …", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "fails on invalid option on the first vertical child container",
 			termSize: image.Point{10, 10},
@@ -467,6 +715,22 @@ func TestNew(t *testing.T) {
 			},
 			wantContainerErr: true,
 		},
+		{
+			desc:     "fails on Padding too low",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Padding(-1))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails when both Padding and a percentage padding specified",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, PaddingBottomPercent(1), Padding(1))
+			},
+			wantContainerErr: true,
+		},
 		{
 			desc:     "fails on empty ID specified",
 			termSize: image.Point{10, 10},
@@ -687,90 +951,85 @@ func TestNew(t *testing.T) {
 			},
 		},
 		{
-			desc:     "horizontal unequal split",
-			termSize: image.Point{10, 20},
+			desc:     "vertical split with min honors the percentage",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitHorizontal(
-						Top(
+					SplitVertical(
+						Left(
 							Border(linestyle.Light),
 						),
-						Bottom(
+						Right(
 							Border(linestyle.Light),
 						),
-						SplitPercent(20),
+						SplitPercentWithMin(40, MinSize(4)),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 4))
-				testdraw.MustBorder(cvs, image.Rect(0, 4, 10, 20))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 8, 10))
+				testdraw.MustBorder(cvs, image.Rect(8, 0, 20, 10))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "horizontal unequal split",
-			termSize: image.Point{10, 20},
+			desc:     "vertical split with min pins the first container to its minimum",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitHorizontal(
-						Top(
+					SplitVertical(
+						Left(
 							Border(linestyle.Light),
 						),
-						Bottom(
+						Right(
 							Border(linestyle.Light),
 						),
-						SplitFixed(4),
+						SplitPercentWithMin(20, MinSize(8)),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 4))
-				testdraw.MustBorder(cvs, image.Rect(0, 4, 10, 20))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 8, 10))
+				testdraw.MustBorder(cvs, image.Rect(8, 0, 20, 10))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "horizontal split, parent and children have borders",
-			termSize: image.Point{10, 10},
+			desc:     "vertical split with min pins the second container to its minimum",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					Border(linestyle.Light),
-					SplitHorizontal(
-						Top(
+					SplitVertical(
+						Left(
 							Border(linestyle.Light),
 						),
-						Bottom(
+						Right(
 							Border(linestyle.Light),
 						),
+						SplitPercentWithMin(80, MinSizeSecond(8)),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(0, 0, 10, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
-				)
-				testdraw.MustBorder(cvs, image.Rect(1, 1, 9, 5))
-				testdraw.MustBorder(cvs, image.Rect(1, 5, 9, 9))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 12, 10))
+				testdraw.MustBorder(cvs, image.Rect(12, 0, 20, 10))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "vertical split, children have borders",
+			desc:     "vertical split with min divides proportionally when minimums don't fit",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
@@ -782,32 +1041,27 @@ func TestNew(t *testing.T) {
 						Right(
 							Border(linestyle.Light),
 						),
+						SplitPercentWithMin(50, MinSizeFirst(12), MinSizeSecond(8)),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
-				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 6, 10))
+				testdraw.MustBorder(cvs, image.Rect(6, 0, 10, 10))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "fails on vertical split too small",
-			termSize: image.Point{20, 10},
+			desc:     "fails on an even split with less than two sub containers",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitVertical(
-						Left(
-							Border(linestyle.Light),
-						),
-						Right(
-							Border(linestyle.Light),
-						),
-						SplitPercent(0),
+					SplitEvenVertical(
+						[]Option{Border(linestyle.Light)},
 					),
 				)
 			},
@@ -817,259 +1071,264 @@ func TestNew(t *testing.T) {
 			},
 		},
 		{
-			desc:     "fails on vertical split too large",
-			termSize: image.Point{20, 10},
+			desc:     "even vertical split into three, remainder goes to the first",
+			termSize: image.Point{20, 4},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitVertical(
-						Left(
-							Border(linestyle.Light),
-						),
-						Right(
-							Border(linestyle.Light),
-						),
-						SplitPercent(100),
+					SplitEvenVertical(
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
 					),
 				)
 			},
-			wantContainerErr: true,
 			want: func(size image.Point) *faketerm.Terminal {
-				return faketerm.MustNew(size)
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 7, 4))
+				testdraw.MustBorder(cvs, image.Rect(7, 0, 14, 4))
+				testdraw.MustBorder(cvs, image.Rect(14, 0, 20, 4))
+				testcanvas.MustApply(cvs, ft)
+				return ft
 			},
 		},
 		{
-			desc:     "vertical unequal split",
-			termSize: image.Point{20, 10},
+			desc:     "even horizontal split into five, remainder goes to the first ones",
+			termSize: image.Point{4, 17},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitVertical(
-						Left(
-							Border(linestyle.Light),
-						),
-						Right(
-							Border(linestyle.Light),
-						),
-						SplitPercent(20),
+					SplitEvenHorizontal(
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 10))
-				testdraw.MustBorder(cvs, image.Rect(4, 0, 20, 10))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 4))
+				testdraw.MustBorder(cvs, image.Rect(0, 4, 4, 8))
+				testdraw.MustBorder(cvs, image.Rect(0, 8, 4, 11))
+				testdraw.MustBorder(cvs, image.Rect(0, 11, 4, 14))
+				testdraw.MustBorder(cvs, image.Rect(0, 14, 4, 17))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "vertical fixed splits",
-			termSize: image.Point{20, 10},
+			desc:     "even vertical split into five, remainder goes to the first ones",
+			termSize: image.Point{23, 3},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitVertical(
-						Left(
-							Border(linestyle.Light),
-						),
-						Right(
-							Border(linestyle.Light),
-						),
-						SplitFixed(4),
+					SplitEvenVertical(
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
+						[]Option{Border(linestyle.Light)},
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 10))
-				testdraw.MustBorder(cvs, image.Rect(4, 0, 20, 10))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 3))
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 3))
+				testdraw.MustBorder(cvs, image.Rect(10, 0, 15, 3))
+				testdraw.MustBorder(cvs, image.Rect(15, 0, 19, 3))
+				testdraw.MustBorder(cvs, image.Rect(19, 0, 23, 3))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "vertical split, parent and children have borders",
+			desc:     "fails on non-positive split weight",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					Border(linestyle.Light),
-					SplitVertical(
-						Left(
-							Border(linestyle.Light),
-						),
-						Right(
-							Border(linestyle.Light),
-						),
+					SplitHorizontal(
+						Top(),
+						Bottom(),
+						SplitWeights(2, 0),
+					),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "fails on split weights combined with SplitFixed",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(),
+						Bottom(),
+						SplitWeights(2, 1),
+						SplitFixed(4),
+					),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "horizontal split with weights 2:1",
+			termSize: image.Point{10, 11},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(Border(linestyle.Light)),
+						Bottom(Border(linestyle.Light)),
+						SplitWeights(2, 1),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(0, 0, 10, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
-				)
-				testdraw.MustBorder(cvs, image.Rect(1, 1, 5, 9))
-				testdraw.MustBorder(cvs, image.Rect(5, 1, 9, 9))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 7))
+				testdraw.MustBorder(cvs, image.Rect(0, 7, 10, 11))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "multi level split",
-			termSize: image.Point{10, 16},
+			desc:     "vertical split with weights 1:2",
+			termSize: image.Point{10, 4},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
 					SplitVertical(
-						Left(
-							SplitHorizontal(
-								Top(
-									Border(linestyle.Light),
-								),
-								Bottom(
-									SplitHorizontal(
-										Top(
-											Border(linestyle.Light),
-										),
-										Bottom(
-											Border(linestyle.Light),
-										),
-									),
-								),
-							),
-						),
-						Right(
-							Border(linestyle.Light),
-						),
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitWeights(1, 2),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 8))
-				testdraw.MustBorder(cvs, image.Rect(0, 8, 5, 12))
-				testdraw.MustBorder(cvs, image.Rect(0, 12, 5, 16))
-				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 16))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 3, 4))
+				testdraw.MustBorder(cvs, image.Rect(3, 0, 10, 4))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "inherits border and focused color",
+			desc:     "fails on negative split minimum",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					Border(linestyle.Light),
-					BorderColor(cell.ColorRed),
-					FocusedColor(cell.ColorBlue),
 					SplitVertical(
-						Left(
+						Left(),
+						Right(),
+						SplitPercentWithMin(50, MinSize(-1)),
+					),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "fails on split minimum combined with SplitFixed",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+						SplitFixed(4),
+						SplitPercentWithMin(50, MinSize(2)),
+					),
+				)
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "horizontal unequal split",
+			termSize: image.Point{10, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
 							Border(linestyle.Light),
 						),
-						Right(
+						Bottom(
 							Border(linestyle.Light),
 						),
+						SplitPercent(20),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(0, 0, 10, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(1, 1, 5, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(5, 1, 9, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 4))
+				testdraw.MustBorder(cvs, image.Rect(0, 4, 10, 20))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "sets border title on root container of different color",
-			termSize: image.Point{10, 10},
+			desc:     "horizontal unequal split",
+			termSize: image.Point{10, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					Border(linestyle.Light),
-					BorderTitle("Ab"),
-					BorderColor(cell.ColorRed),
-					FocusedColor(cell.ColorBlue),
-					TitleColor(cell.ColorMagenta),
-					TitleFocusedColor(cell.ColorCyan),
-					SplitVertical(
-						Left(
+					SplitHorizontal(
+						Top(
 							Border(linestyle.Light),
 						),
-						Right(
+						Bottom(
 							Border(linestyle.Light),
 						),
+						SplitFixed(4),
 					),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(0, 0, 10, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(1, 1, 5, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(5, 1, 9, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
-				testdraw.MustText(
-					cvs,
-					"Ab",
-					image.Point{1, 0},
-					draw.TextCellOpts(cell.FgColor(cell.ColorCyan)),
-				)
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 4))
+				testdraw.MustBorder(cvs, image.Rect(0, 4, 10, 20))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "sets different color title on left child container",
+			desc:     "horizontal split, parent and children have borders",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
 					Border(linestyle.Light),
-					BorderColor(cell.ColorRed),
-					FocusedColor(cell.ColorBlue),
-					SplitVertical(
-						Left(
+					SplitHorizontal(
+						Top(
 							Border(linestyle.Light),
-							BorderTitle("Ab"),
-							TitleColor(cell.ColorMagenta),
-							TitleFocusedColor(cell.ColorCyan),
 						),
-						Right(
+						Bottom(
 							Border(linestyle.Light),
 						),
 					),
@@ -1081,41 +1340,23 @@ func TestNew(t *testing.T) {
 				testdraw.MustBorder(
 					cvs,
 					image.Rect(0, 0, 10, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(1, 1, 5, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(5, 1, 9, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
-				testdraw.MustText(
-					cvs,
-					"Ab",
-					image.Point{2, 1},
-					draw.TextCellOpts(cell.FgColor(cell.ColorMagenta)),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
 				)
+				testdraw.MustBorder(cvs, image.Rect(1, 1, 9, 5))
+				testdraw.MustBorder(cvs, image.Rect(1, 5, 9, 9))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "inherits the border color for the title on left child container when TitleColor is not set",
+			desc:     "vertical split, children have borders",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					Border(linestyle.Light),
-					BorderColor(cell.ColorRed),
-					FocusedColor(cell.ColorBlue),
 					SplitVertical(
 						Left(
 							Border(linestyle.Light),
-							BorderTitle("Ab"),
 						),
 						Right(
 							Border(linestyle.Light),
@@ -1126,39 +1367,18 @@ func TestNew(t *testing.T) {
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(0, 0, 10, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(1, 1, 5, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(5, 1, 9, 9),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
-				)
-				testdraw.MustText(
-					cvs,
-					"Ab",
-					image.Point{2, 1},
-					draw.TextCellOpts(cell.FgColor(cell.ColorRed)),
-				)
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "splitting a container removes the widget",
-			termSize: image.Point{10, 10},
+			desc:     "fails on vertical split too small",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					Border(linestyle.Light),
-					PlaceWidget(fakewidget.New(widgetapi.Options{})),
 					SplitVertical(
 						Left(
 							Border(linestyle.Light),
@@ -1166,26 +1386,40 @@ func TestNew(t *testing.T) {
 						Right(
 							Border(linestyle.Light),
 						),
+						SplitPercent(0),
 					),
 				)
 			},
+			wantContainerErr: true,
 			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(size)
-				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					ft.Area(),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "fails on vertical split too large",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+						SplitPercent(100),
+					),
 				)
-				testdraw.MustBorder(cvs, image.Rect(1, 1, 5, 9))
-				testdraw.MustBorder(cvs, image.Rect(5, 1, 9, 9))
-				testcanvas.MustApply(cvs, ft)
-				return ft
+			},
+			wantContainerErr: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
 			},
 		},
 		{
-			desc:     "placing a widget removes container split",
-			termSize: image.Point{10, 10},
+			desc:     "vertical unequal split",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
@@ -1196,228 +1430,1498 @@ func TestNew(t *testing.T) {
 						Right(
 							Border(linestyle.Light),
 						),
+						SplitPercent(20),
 					),
-					PlaceWidget(fakewidget.New(widgetapi.Options{})),
 				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				fakewidget.MustDraw(
-					ft,
-					cvs,
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{},
-				)
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 10))
+				testdraw.MustBorder(cvs, image.Rect(4, 0, 20, 10))
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			got, err := faketerm.New(tc.termSize)
-			if err != nil {
-				t.Fatalf("faketerm.New => unexpected error: %v", err)
-			}
-
-			cont, err := tc.container(got)
-			if (err != nil) != tc.wantContainerErr {
-				t.Errorf("tc.container => unexpected error:%v, wantErr:%v", err, tc.wantContainerErr)
-			}
-			if err != nil {
-				return
-			}
-			contStr := cont.String()
-			t.Logf("For container: %v", contStr)
-			if err := cont.Draw(); err != nil {
-				t.Fatalf("Draw => unexpected error: %v", err)
-			}
-
-			var want *faketerm.Terminal
-			if tc.want != nil {
-				want = tc.want(tc.termSize)
-			} else {
-				w, err := faketerm.New(tc.termSize)
-				if err != nil {
-					t.Fatalf("faketerm.New => unexpected error: %v", err)
-				}
-				want = w
-			}
-			if diff := faketerm.Diff(want, got); diff != "" {
-				t.Errorf("Draw => %v", diff)
-			}
-		})
-	}
-
-}
-
-// errorHandler just stores the last error received.
-type errorHandler struct {
-	err error
-	mu  sync.Mutex
-}
-
-func (eh *errorHandler) get() error {
-	eh.mu.Lock()
-	defer eh.mu.Unlock()
-	return eh.err
-}
-
-func (eh *errorHandler) handle(err error) {
-	eh.mu.Lock()
-	defer eh.mu.Unlock()
-	eh.err = err
-}
-
-func TestKeyboard(t *testing.T) {
-	tests := []struct {
-		desc      string
-		termSize  image.Point
-		container func(ft *faketerm.Terminal) (*Container, error)
-		events    []terminalapi.Event
-		// If specified, waits for this number of events.
-		// Otherwise waits for len(events).
-		wantProcessed int
-		want          func(size image.Point) *faketerm.Terminal
-		wantErr       bool
-	}{
 		{
-			desc:     "event not forwarded if container has no widget",
-			termSize: image.Point{10, 10},
+			desc:     "vertical fixed splits",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				return New(ft)
-			},
-			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+						SplitFixed(4),
+					),
+				)
 			},
 			want: func(size image.Point) *faketerm.Terminal {
-				return faketerm.MustNew(size)
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 10))
+				testdraw.MustBorder(cvs, image.Rect(4, 0, 20, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
 			},
 		},
 		{
-			desc:     "event forwarded to focused container only",
-			termSize: image.Point{40, 20},
+			desc:     "vertical split, parent and children have borders",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
+					Border(linestyle.Light),
 					SplitVertical(
 						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+							Border(linestyle.Light),
 						),
 						Right(
-							SplitHorizontal(
-								Top(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-								),
-								Bottom(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-								),
-							),
+							Border(linestyle.Light),
 						),
 					),
 				)
 			},
-			events: []terminalapi.Event{
-				// Move focus to the target container.
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
-				// Send the keyboard event.
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
-			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
-				// Widgets that aren't focused don't get the key.
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-				)
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-				)
-
-				// The focused widget receives the key.
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
 				)
+				testdraw.MustBorder(cvs, image.Rect(1, 1, 5, 9))
+				testdraw.MustBorder(cvs, image.Rect(5, 1, 9, 9))
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "event forwarded to all widgets that requested global key scope",
-			termSize: image.Point{40, 20},
+			desc:     "multi level split",
+			termSize: image.Point{10, 16},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
 					SplitVertical(
 						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
-						),
-						Right(
 							SplitHorizontal(
 								Top(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+									Border(linestyle.Light),
 								),
 								Bottom(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+									SplitHorizontal(
+										Top(
+											Border(linestyle.Light),
+										),
+										Bottom(
+											Border(linestyle.Light),
+										),
+									),
 								),
 							),
 						),
+						Right(
+							Border(linestyle.Light),
+						),
 					),
 				)
 			},
-			events: []terminalapi.Event{
-				// Move focus to the target container.
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
-				// Send the keyboard event.
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
-			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
-				// Widget that isn't focused, but registered for global
-				// keyboard events.
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
-						Meta: &widgetapi.EventMeta{},
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 8))
+				testdraw.MustBorder(cvs, image.Rect(0, 8, 5, 12))
+				testdraw.MustBorder(cvs, image.Rect(0, 12, 5, 16))
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 16))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "inherits border and focused color",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderColor(cell.ColorRed),
+					FocusedColor(cell.ColorBlue),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "applies theme colors to the borders and inherits them",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					ApplyTheme(cell.Theme{
+						Border: cell.ColorRed,
+						Accent: cell.ColorBlue,
+					}),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "border and focused color options take precedence over the theme",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					FocusedColor(cell.ColorMagenta),
+					ApplyTheme(cell.Theme{
+						Border: cell.ColorRed,
+						Accent: cell.ColorBlue,
+					}),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+							BorderColor(cell.ColorGreen),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorGreen)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "sets border title on root container of different color",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderTitle("Ab"),
+					BorderColor(cell.ColorRed),
+					FocusedColor(cell.ColorBlue),
+					TitleColor(cell.ColorMagenta),
+					TitleFocusedColor(cell.ColorCyan),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustText(
+					cvs,
+					"Ab",
+					image.Point{1, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorCyan)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "sets different color title on left child container",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderColor(cell.ColorRed),
+					FocusedColor(cell.ColorBlue),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+							BorderTitle("Ab"),
+							TitleColor(cell.ColorMagenta),
+							TitleFocusedColor(cell.ColorCyan),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustText(
+					cvs,
+					"Ab",
+					image.Point{2, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorMagenta)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "inherits the border color for the title on left child container when TitleColor is not set",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderColor(cell.ColorRed),
+					FocusedColor(cell.ColorBlue),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+							BorderTitle("Ab"),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(1, 1, 5, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(5, 1, 9, 9),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustText(
+					cvs,
+					"Ab",
+					image.Point{2, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "splitting a container removes the widget",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testdraw.MustBorder(cvs, image.Rect(1, 1, 5, 9))
+				testdraw.MustBorder(cvs, image.Rect(5, 1, 9, 9))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "placing a widget removes container split",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				fakewidget.MustDraw(
+					ft,
+					cvs,
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			cont, err := tc.container(got)
+			if (err != nil) != tc.wantContainerErr {
+				t.Errorf("tc.container => unexpected error:%v, wantErr:%v", err, tc.wantContainerErr)
+			}
+			if err != nil {
+				return
+			}
+			contStr := cont.String()
+			t.Logf("For container: %v", contStr)
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			var want *faketerm.Terminal
+			if tc.want != nil {
+				want = tc.want(tc.termSize)
+			} else {
+				w, err := faketerm.New(tc.termSize)
+				if err != nil {
+					t.Fatalf("faketerm.New => unexpected error: %v", err)
+				}
+				want = w
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+
+}
+
+// themeWidget is a widget that records the theme provided on the last call to
+// Draw.
+type themeWidget struct {
+	theme *cell.Theme
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (tw *themeWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	tw.theme = meta.Theme
+	return nil
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (*themeWidget) Keyboard(*terminalapi.Keyboard, *widgetapi.EventMeta) error { return nil }
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (*themeWidget) Mouse(*terminalapi.Mouse, *widgetapi.EventMeta) error { return nil }
+
+// Options implements widgetapi.Widget.Options.
+func (*themeWidget) Options() widgetapi.Options { return widgetapi.Options{} }
+
+func TestTheme(t *testing.T) {
+	dark := cell.Theme{
+		Name:       "dark",
+		Foreground: cell.ColorWhite,
+		Background: cell.ColorBlack,
+		Border:     cell.ColorGray,
+		Accent:     cell.ColorCyan,
+	}
+	light := cell.Theme{
+		Name:       "light",
+		Foreground: cell.ColorBlack,
+		Background: cell.ColorWhite,
+		Border:     cell.ColorSilver,
+		Accent:     cell.ColorBlue,
+	}
+
+	tests := []struct {
+		desc      string
+		container func(ft *faketerm.Terminal, left, right widgetapi.Widget) (*Container, error)
+		wantLeft  *cell.Theme
+		wantRight *cell.Theme
+	}{
+		{
+			desc: "widgets get no theme by default",
+			container: func(ft *faketerm.Terminal, left, right widgetapi.Widget) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(PlaceWidget(left)),
+						Right(PlaceWidget(right)),
+					),
+				)
+			},
+		},
+		{
+			desc: "widgets inherit the theme from the root container",
+			container: func(ft *faketerm.Terminal, left, right widgetapi.Widget) (*Container, error) {
+				return New(
+					ft,
+					ApplyTheme(dark),
+					SplitVertical(
+						Left(PlaceWidget(left)),
+						Right(PlaceWidget(right)),
+					),
+				)
+			},
+			wantLeft:  &dark,
+			wantRight: &dark,
+		},
+		{
+			desc: "sub container overrides the inherited theme",
+			container: func(ft *faketerm.Terminal, left, right widgetapi.Widget) (*Container, error) {
+				return New(
+					ft,
+					ApplyTheme(dark),
+					SplitVertical(
+						Left(PlaceWidget(left)),
+						Right(
+							ApplyTheme(light),
+							PlaceWidget(right),
+						),
+					),
+				)
+			},
+			wantLeft:  &dark,
+			wantRight: &light,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{10, 10})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			left, right := &themeWidget{}, &themeWidget{}
+			c, err := tc.container(ft, left, right)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if diff := pretty.Compare(tc.wantLeft, left.theme); diff != "" {
+				t.Errorf("left widget got theme, unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantRight, right.theme); diff != "" {
+				t.Errorf("right widget got theme, unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// errorHandler just stores the last error received.
+type errorHandler struct {
+	err error
+	mu  sync.Mutex
+}
+
+func (eh *errorHandler) get() error {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	return eh.err
+}
+
+func (eh *errorHandler) handle(err error) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	eh.err = err
+}
+
+// keyRecorder is a widget that records the keyboard events it receives.
+type keyRecorder struct {
+	*fakewidget.Mirror
+
+	opts widgetapi.Options
+
+	mu     sync.Mutex
+	events []terminalapi.Keyboard
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (kr *keyRecorder) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.events = append(kr.events, *k)
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (kr *keyRecorder) Options() widgetapi.Options {
+	return kr.opts
+}
+
+func TestKeyRelease(t *testing.T) {
+	opts := widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal}
+	wantsRelease := &keyRecorder{
+		Mirror: fakewidget.New(opts),
+		opts: widgetapi.Options{
+			WantKeyboard:   widgetapi.KeyScopeGlobal,
+			WantKeyRelease: true,
+		},
+	}
+	pressOnly := &keyRecorder{
+		Mirror: fakewidget.New(opts),
+		opts:   opts,
+	}
+
+	var hooked []terminalapi.Keyboard
+	ft := faketerm.MustNew(image.Point{20, 10})
+	cont, err := New(
+		ft,
+		SplitVertical(
+			Left(ID("left"), PlaceWidget(wantsRelease)),
+			Right(ID("right"), PlaceWidget(pressOnly)),
+		),
+		KeyFocusNext('n'),
+		KeyboardHook(func(k *terminalapi.Keyboard) bool {
+			hooked = append(hooked, *k)
+			return false
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.SetFocusByID("left"); err != nil {
+		t.Fatalf("SetFocusByID => unexpected error: %v", err)
+	}
+
+	for _, ev := range []*terminalapi.Keyboard{
+		{Key: 'a'},
+		{Key: 'a', Release: true},
+		{Key: 'n', Release: true},
+	} {
+		if err := cont.processEvent(ev); err != nil {
+			t.Fatalf("processEvent(%v) => unexpected error: %v", ev, err)
+		}
+	}
+
+	wantAll := []terminalapi.Keyboard{
+		{Key: 'a'},
+		{Key: 'a', Release: true},
+		{Key: 'n', Release: true},
+	}
+	if diff := pretty.Compare(wantAll, wantsRelease.events); diff != "" {
+		t.Errorf("widget with WantKeyRelease => unexpected events, diff (-want, +got):\n%s", diff)
+	}
+	wantPresses := []terminalapi.Keyboard{{Key: 'a'}}
+	if diff := pretty.Compare(wantPresses, pressOnly.events); diff != "" {
+		t.Errorf("widget without WantKeyRelease => unexpected events, diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare(wantPresses, hooked); diff != "" {
+		t.Errorf("KeyboardHook => unexpected events, diff (-want, +got):\n%s", diff)
+	}
+	// Releasing the focus key doesn't move the focus.
+	if got := cont.focusTracker.active().opts.id; got != "left" {
+		t.Errorf("focused container => %q, want %q", got, "left")
+	}
+}
+
+// keysOnly wraps a widget hiding its implementation of widgetapi.Paster.
+type keysOnly struct {
+	widgetapi.Widget
+}
+
+func TestKeyboard(t *testing.T) {
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		events    []terminalapi.Event
+		// If specified, waits for this number of events.
+		// Otherwise waits for len(events).
+		wantProcessed int
+		want          func(size image.Point) *faketerm.Terminal
+		wantErr       bool
+	}{
+		{
+			desc:     "event not forwarded if container has no widget",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "event forwarded to focused container only",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							SplitHorizontal(
+								Top(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+								),
+								Bottom(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+								),
+							),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the target container.
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
+				// Send the keyboard event.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				// Widgets that aren't focused don't get the key.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+
+				// The focused widget receives the key.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "paste event forwarded to the focused widget in one step",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Focused(),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "abc"},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Paste{Text: "abc"},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "paste event forwarded as keys to widgets that don't accept pastes",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(keysOnly{fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})}),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "ab\n"},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "keyboard hook consumes the key before widget delivery",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+					KeyboardHook(func(k *terminalapi.Keyboard) bool {
+						return k.Key == '?'
+					}),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: '?'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				// Only the key that wasn't consumed reaches the widget.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "keys consumed by the keyboard hook don't move the focus",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Focused(),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+					),
+					KeyFocusNext(keyboard.KeyTab),
+					KeyboardHook(func(k *terminalapi.Keyboard) bool {
+						return k.Key == keyboard.KeyTab
+					}),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "event forwarded to all widgets that requested global key scope",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+						Right(
+							SplitHorizontal(
+								Top(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+								),
+								Bottom(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+								),
+							),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the target container.
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
+				// Send the keyboard event.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				// Widget that isn't focused, but registered for global
+				// keyboard events.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{},
+					},
+				)
+
+				// Widget that isn't focused and only wants focused events.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+
+				// The focused widget receives the key.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "keyboard event forwarded to exclusive widget only when focused",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+						Right(
+							SplitHorizontal(
+								Top(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+								),
+								Bottom(
+									PlaceWidget(fakewidget.New(
+										widgetapi.Options{
+											WantKeyboard:             widgetapi.KeyScopeFocused,
+											ExclusiveKeyboardOnFocus: true,
+										},
+									)),
+								),
+							),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the target container.
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
+				// Send the keyboard event.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				// Widget that isn't focused, but registered for global
+				// keyboard events.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+				)
+
+				// Widget that isn't focused and only wants focused events.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+
+				// The focused widget receives the key.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "the ExclusiveKeyboardOnFocus option has no effect when widget not focused",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+						Right(
+							SplitHorizontal(
+								Top(
+									PlaceWidget(fakewidget.New(
+										widgetapi.Options{
+											WantKeyboard:             widgetapi.KeyScopeFocused,
+											ExclusiveKeyboardOnFocus: true,
+										},
+									)),
+								),
+								Bottom(
+									PlaceWidget(fakewidget.New(
+										widgetapi.Options{
+											WantKeyboard: widgetapi.KeyScopeFocused,
+										},
+									)),
+								),
+							),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the target container.
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
+				// Send the keyboard event.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				// Widget that isn't focused, but registered for global
+				// keyboard events.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: false},
+					},
+				)
+
+				// Widget that isn't focused and only wants focused events.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+
+				// The focused widget receives the key.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "event not forwarded if the widget didn't request it",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeNone})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "widget returns an error when processing the event",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+			},
+			wantProcessed: 2, // The error is also an event.
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			eh := &errorHandler{}
+			// Subscribe to receive errors.
+			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
+				eh.handle(ev.(*terminalapi.Error).Error())
+			})
+
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			var wantEv int
+			if tc.wantProcessed != 0 {
+				wantEv = tc.wantProcessed
+			} else {
+				wantEv = len(tc.events)
+			}
+
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), wantEv; got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if err := eh.get(); (err != nil) != tc.wantErr {
+				t.Errorf("errorHandler => unexpected error %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		events    []terminalapi.Event
+		want      func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "event not forwarded to widget that doesn't want it",
+			termSize: image.Point{30, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{30, 10}},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "event forwarded to all widgets that want it",
+			termSize: image.Point{60, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantResize: true})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantResize: true})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{60, 10}},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 30, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantResize: true},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Resize{Size: image.Point{60, 10}},
+						Meta: &widgetapi.EventMeta{},
+					},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(30, 0, 60, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantResize: true},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Resize{Size: image.Point{60, 10}},
+						Meta: &widgetapi.EventMeta{},
 					},
 				)
-
-				// Widget that isn't focused and only wants focused events.
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestMouse(t *testing.T) {
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		events    []terminalapi.Event
+		// If specified, waits for this number of events.
+		// Otherwise waits for len(events).
+		wantProcessed int
+		want          func(size image.Point) *faketerm.Terminal
+		wantErr       bool
+	}{
+		{
+			desc:     "mouse click outside of the terminal is ignored",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{10, 10}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "event not forwarded if container has no widget",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "event forwarded to container at that point",
+			termSize: image.Point{50, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+						),
+						Right(
+							SplitHorizontal(
+								Top(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+								),
+								Bottom(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+								),
+							),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{49, 9}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{49, 9}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				// Widgets that aren't targeted don't get the mouse clicks.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
+					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
 					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(25, 10, 50, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
 				)
 
-				// The focused widget receives the key.
+				// The target widget receives the mouse event.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
+					testcanvas.MustNew(image.Rect(25, 0, 50, 10)),
 					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Ev:   &terminalapi.Mouse{Position: image.Point{24, 9}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{},
+					},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{24, 9}, Button: mouse.ButtonRelease},
 						Meta: &widgetapi.EventMeta{Focused: true},
 					},
 				)
@@ -1425,145 +2929,285 @@ func TestKeyboard(t *testing.T) {
 			},
 		},
 		{
-			desc:     "keyboard event forwarded to exclusive widget only when focused",
-			termSize: image.Point{40, 20},
+			desc:     "event focuses the target container after terminal resize (falls onto the new area), regression for #169",
+			termSize: image.Point{50, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				return New(
+				// Decrease the terminal size, so when container is created, it
+				// only sees width of 30.
+				if err := ft.Resize(image.Point{30, 20}); err != nil {
+					return nil, err
+				}
+				c, err := New(
 					ft,
 					SplitVertical(
 						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
 						),
 						Right(
 							SplitHorizontal(
 								Top(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+									Border(linestyle.Light),
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
 								),
 								Bottom(
-									PlaceWidget(fakewidget.New(
-										widgetapi.Options{
-											WantKeyboard:             widgetapi.KeyScopeFocused,
-											ExclusiveKeyboardOnFocus: true,
-										},
-									)),
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
 								),
 							),
 						),
 					),
 				)
+				if err != nil {
+					return nil, err
+				}
+				// Increase the width back to 50 so the mouse clicks land on the "new" area.
+				if err := ft.Resize(image.Point{50, 20}); err != nil {
+					return nil, err
+				}
+				// Draw once so the container has a chance to update the tracked area.
+				if err := c.Draw(); err != nil {
+					return nil, err
+				}
+				return c, nil
 			},
 			events: []terminalapi.Event{
-				// Move focus to the target container.
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
-				// Send the keyboard event.
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{48, 8}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{48, 8}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				// The yellow border signifies that the container was focused.
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(25, 0, 50, 10),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+
+				// Widgets that aren't targeted don't get the mouse clicks.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(25, 10, 50, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+				)
+
+				// The target widget receives the mouse event.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(26, 1, 49, 9)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{22, 7}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{},
+					},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{22, 7}, Button: mouse.ButtonRelease},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "key event focuses the next container, widget with KeyScopeFocused gets the key as it is now focused",
+			termSize: image.Point{50, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				c, err := New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+					),
+					KeyFocusNext(keyboard.KeyTab),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return c, nil
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "key event focuses the previous container, option set on both parent and child, the last option is used since focus keys are global options",
+			termSize: image.Point{50, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				c, err := New(
+					ft,
+					KeyFocusPrevious(keyboard.KeyEnter),
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+							KeyFocusPrevious(keyboard.KeyBacktab),
+						),
+					),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return c, nil
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "key event focuses the next container, widget with KeyScopeGlobal also gets the key",
+			termSize: image.Point{50, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				c, err := New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+					),
+					KeyFocusNext(keyboard.KeyTab),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return c, nil
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
-				// Widget that isn't focused, but registered for global
-				// keyboard events.
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
-				)
-
-				// Widget that isn't focused and only wants focused events.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
-					&widgetapi.Meta{},
+					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					&widgetapi.Meta{Focused: true},
 					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
 				)
-
-				// The focused widget receives the key.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
-						Meta: &widgetapi.EventMeta{Focused: true},
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						Meta: &widgetapi.EventMeta{},
 					},
 				)
 				return ft
 			},
 		},
 		{
-			desc:     "the ExclusiveKeyboardOnFocus option has no effect when widget not focused",
-			termSize: image.Point{40, 20},
+			desc:     "key event moves focus from a widget with KeyScopeFocused, the newly focused widget gets the key",
+			termSize: image.Point{50, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				return New(
+				c, err := New(
 					ft,
 					SplitVertical(
 						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
 						),
 						Right(
-							SplitHorizontal(
-								Top(
-									PlaceWidget(fakewidget.New(
-										widgetapi.Options{
-											WantKeyboard:             widgetapi.KeyScopeFocused,
-											ExclusiveKeyboardOnFocus: true,
-										},
-									)),
-								),
-								Bottom(
-									PlaceWidget(fakewidget.New(
-										widgetapi.Options{
-											WantKeyboard: widgetapi.KeyScopeFocused,
-										},
-									)),
-								),
-							),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
 						),
 					),
+					KeyFocusNext(keyboard.KeyTab),
 				)
+				if err != nil {
+					return nil, err
+				}
+				return c, nil
 			},
 			events: []terminalapi.Event{
-				// Move focus to the target container.
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
-				// Send the keyboard event.
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				// Focus the left container.
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				// Move focus from left to right.
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
-				// Widget that isn't focused, but registered for global
-				// keyboard events.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
 					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					// Also gets the key, since we are sending two events above.
 					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
-						Meta: &widgetapi.EventMeta{Focused: false},
+						Ev: &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						// Also is focused at the time of the first event.
+						Meta: &widgetapi.EventMeta{Focused: true},
 					},
 				)
-
-				// Widget that isn't focused and only wants focused events.
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(20, 0, 40, 10)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-				)
-
-				// The focused widget receives the key.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(20, 10, 40, 20)),
+					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
 					&widgetapi.Meta{Focused: true},
 					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
 						Meta: &widgetapi.EventMeta{Focused: true},
 					},
 				)
@@ -1572,15 +3216,15 @@ func TestKeyboard(t *testing.T) {
 		},
 		{
 			desc:     "event not forwarded if the widget didn't request it",
-			termSize: image.Point{40, 20},
+			termSize: image.Point{20, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeNone})),
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeNone})),
 				)
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1595,122 +3239,143 @@ func TestKeyboard(t *testing.T) {
 			},
 		},
 		{
-			desc:     "widget returns an error when processing the event",
-			termSize: image.Point{40, 20},
+			desc:     "MouseScopeWidget, event not forwarded if it falls on the container's border",
+			termSize: image.Point{20, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+					Border(linestyle.Light),
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget}),
+					),
 				)
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
-			wantProcessed: 2, // The error is also an event.
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(ft.Area()),
+					testcanvas.MustNew(image.Rect(1, 1, 19, 19)),
 					&widgetapi.Meta{Focused: true},
 					widgetapi.Options{},
 				)
 				return ft
 			},
-			wantErr: true,
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			got, err := faketerm.New(tc.termSize)
-			if err != nil {
-				t.Fatalf("faketerm.New => unexpected error: %v", err)
-			}
-
-			c, err := tc.container(got)
-			if err != nil {
-				t.Fatalf("tc.container => unexpected error: %v", err)
-			}
-
-			eds := event.NewDistributionSystem()
-			eh := &errorHandler{}
-			// Subscribe to receive errors.
-			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
-				eh.handle(ev.(*terminalapi.Error).Error())
-			})
-
-			c.Subscribe(eds)
-			// Initial draw to determine sizes of containers.
-			if err := c.Draw(); err != nil {
-				t.Fatalf("Draw => unexpected error: %v", err)
-			}
-			for _, ev := range tc.events {
-				eds.Event(ev)
-			}
-			var wantEv int
-			if tc.wantProcessed != 0 {
-				wantEv = tc.wantProcessed
-			} else {
-				wantEv = len(tc.events)
-			}
-
-			if err := testevent.WaitFor(5*time.Second, func() error {
-				if got, want := eds.Processed(), wantEv; got != want {
-					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
-				}
-				return nil
-			}); err != nil {
-				t.Fatalf("testevent.WaitFor => %v", err)
-			}
+		{
+			desc:     "MouseScopeContainer, event forwarded if it falls on the container's border",
+			termSize: image.Point{23, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeContainer}),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
 
-			if err := c.Draw(); err != nil {
-				t.Fatalf("Draw => unexpected error: %v", err)
-			}
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
 
-			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
-				t.Errorf("Draw => %v", diff)
-			}
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(1, 1, 22, 19)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "MouseScopeGlobal, event forwarded if it falls on the container's border",
+			termSize: image.Point{23, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal}),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
 
-			if err := eh.get(); (err != nil) != tc.wantErr {
-				t.Errorf("errorHandler => unexpected error %v, wantErr: %v", err, tc.wantErr)
-			}
-		})
-	}
-}
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
 
-func TestMouse(t *testing.T) {
-	tests := []struct {
-		desc      string
-		termSize  image.Point
-		container func(ft *faketerm.Terminal) (*Container, error)
-		events    []terminalapi.Event
-		// If specified, waits for this number of events.
-		// Otherwise waits for len(events).
-		wantProcessed int
-		want          func(size image.Point) *faketerm.Terminal
-		wantErr       bool
-	}{
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(1, 1, 22, 19)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
 		{
-			desc:     "mouse click outside of the terminal is ignored",
-			termSize: image.Point{10, 10},
+			desc:     "MouseScopeWidget event not forwarded if it falls outside of widget's canvas",
+			termSize: image.Point{20, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{
+							WantMouse: widgetapi.MouseScopeWidget,
+							Ratio:     image.Point{2, 1},
+						}),
+					),
+					AlignVertical(align.VerticalMiddle),
+					AlignHorizontal(align.HorizontalCenter),
 				)
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{10, 10}, Button: mouse.ButtonRelease},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(ft.Area()),
+					testcanvas.MustNew(image.Rect(0, 5, 20, 15)),
 					&widgetapi.Meta{Focused: true},
 					widgetapi.Options{},
 				)
@@ -1718,74 +3383,69 @@ func TestMouse(t *testing.T) {
 			},
 		},
 		{
-			desc:     "event not forwarded if container has no widget",
-			termSize: image.Point{10, 10},
+			desc:     "MouseScopeContainer event forwarded if it falls outside of widget's canvas",
+			termSize: image.Point{22, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				return New(ft)
+				return New(
+					ft,
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{
+							WantMouse: widgetapi.MouseScopeContainer,
+							Ratio:     image.Point{2, 1},
+						}),
+					),
+					AlignVertical(align.VerticalMiddle),
+					AlignHorizontal(align.HorizontalCenter),
+				)
 			},
 			events: []terminalapi.Event{
 				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
-				return faketerm.MustNew(size)
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 4, 22, 15)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
 			},
 		},
 		{
-			desc:     "event forwarded to container at that point",
-			termSize: image.Point{50, 20},
+			desc:     "MouseScopeGlobal event forwarded if it falls outside of widget's canvas",
+			termSize: image.Point{22, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitVertical(
-						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
-						),
-						Right(
-							SplitHorizontal(
-								Top(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
-								),
-								Bottom(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
-								),
-							),
-						),
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{
+							WantMouse: widgetapi.MouseScopeGlobal,
+							Ratio:     image.Point{2, 1},
+						}),
 					),
+					AlignVertical(align.VerticalMiddle),
+					AlignHorizontal(align.HorizontalCenter),
 				)
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{49, 9}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{49, 9}, Button: mouse.ButtonRelease},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-				// Widgets that aren't targeted don't get the mouse clicks.
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{},
-				)
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(25, 10, 50, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-				)
 
-				// The target widget receives the mouse event.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(25, 0, 50, 10)),
+					testcanvas.MustNew(image.Rect(0, 4, 22, 15)),
 					&widgetapi.Meta{Focused: true},
 					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{24, 9}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{},
-					},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{24, 9}, Button: mouse.ButtonRelease},
+						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
 						Meta: &widgetapi.EventMeta{Focused: true},
 					},
 				)
@@ -1793,285 +3453,181 @@ func TestMouse(t *testing.T) {
 			},
 		},
 		{
-			desc:     "event focuses the target container after terminal resize (falls onto the new area), regression for #169",
-			termSize: image.Point{50, 20},
+			desc:     "MouseScopeWidget event not forwarded if it falls to another container",
+			termSize: image.Point{20, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				// Decrease the terminal size, so when container is created, it
-				// only sees width of 30.
-				if err := ft.Resize(image.Point{30, 20}); err != nil {
-					return nil, err
-				}
-				c, err := New(
+				return New(
 					ft,
-					SplitVertical(
-						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
-						),
-						Right(
-							SplitHorizontal(
-								Top(
-									Border(linestyle.Light),
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
-								),
-								Bottom(
-									PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
-								),
+					SplitHorizontal(
+						Top(),
+						Bottom(
+							PlaceWidget(
+								fakewidget.New(widgetapi.Options{
+									WantMouse: widgetapi.MouseScopeWidget,
+									Ratio:     image.Point{2, 1},
+								}),
 							),
+							AlignVertical(align.VerticalMiddle),
+							AlignHorizontal(align.HorizontalCenter),
 						),
 					),
 				)
-				if err != nil {
-					return nil, err
-				}
-				// Increase the width back to 50 so the mouse clicks land on the "new" area.
-				if err := ft.Resize(image.Point{50, 20}); err != nil {
-					return nil, err
-				}
-				// Draw once so the container has a chance to update the tracked area.
-				if err := c.Draw(); err != nil {
-					return nil, err
-				}
-				return c, nil
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{48, 8}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{48, 8}, Button: mouse.ButtonRelease},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-				// The yellow border signifies that the container was focused.
-				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(25, 0, 50, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
-				)
-				testcanvas.MustApply(cvs, ft)
 
-				// Widgets that aren't targeted don't get the mouse clicks.
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					testcanvas.MustNew(image.Rect(0, 10, 20, 20)),
 					&widgetapi.Meta{},
 					widgetapi.Options{},
 				)
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(25, 10, 50, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-				)
-
-				// The target widget receives the mouse event.
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(26, 1, 49, 9)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{22, 7}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{},
-					},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{22, 7}, Button: mouse.ButtonRelease},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
 				return ft
 			},
 		},
 		{
-			desc:     "key event focuses the next container, widget with KeyScopeFocused gets the key as it is now focused",
-			termSize: image.Point{50, 20},
+			desc:     "MouseScopeContainer event not forwarded if it falls to another container",
+			termSize: image.Point{20, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				c, err := New(
+				return New(
 					ft,
-					SplitVertical(
-						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-						),
-						Right(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+					SplitHorizontal(
+						Top(),
+						Bottom(
+							PlaceWidget(
+								fakewidget.New(widgetapi.Options{
+									WantMouse: widgetapi.MouseScopeContainer,
+									Ratio:     image.Point{2, 1},
+								}),
+							),
+							AlignVertical(align.VerticalMiddle),
+							AlignHorizontal(align.HorizontalCenter),
 						),
 					),
-					KeyFocusNext(keyboard.KeyTab),
 				)
-				if err != nil {
-					return nil, err
-				}
-				return c, nil
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
+					testcanvas.MustNew(image.Rect(0, 10, 20, 20)),
 					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					widgetapi.Options{},
 				)
 				return ft
 			},
 		},
 		{
-			desc:     "key event focuses the previous container, option set on both parent and child, the last option is used since focus keys are global options",
-			termSize: image.Point{50, 20},
+			desc:     "MouseScopeGlobal event forwarded if it falls to another container",
+			termSize: image.Point{20, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				c, err := New(
+				return New(
 					ft,
-					KeyFocusPrevious(keyboard.KeyEnter),
-					SplitVertical(
-						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-						),
-						Right(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-							KeyFocusPrevious(keyboard.KeyBacktab),
+					SplitHorizontal(
+						Top(),
+						Bottom(
+							PlaceWidget(
+								fakewidget.New(widgetapi.Options{
+									WantMouse: widgetapi.MouseScopeGlobal,
+									Ratio:     image.Point{2, 1},
+								}),
+							),
+							AlignVertical(align.VerticalMiddle),
+							AlignHorizontal(align.HorizontalCenter),
 						),
 					),
 				)
-				if err != nil {
-					return nil, err
-				}
-				return c, nil
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					testcanvas.MustNew(image.Rect(0, 10, 20, 20)),
 					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-				)
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyBacktab},
-						Meta: &widgetapi.EventMeta{Focused: true},
+						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{},
 					},
 				)
 				return ft
 			},
 		},
 		{
-			desc:     "key event focuses the next container, widget with KeyScopeGlobal also gets the key",
-			termSize: image.Point{50, 20},
+			desc:     "mouse position adjusted relative to widget's canvas, vertical offset",
+			termSize: image.Point{20, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				c, err := New(
+				return New(
 					ft,
-					SplitVertical(
-						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-						),
-						Right(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
-						),
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{
+							WantMouse: widgetapi.MouseScopeWidget,
+							Ratio:     image.Point{2, 1},
+						}),
 					),
-					KeyFocusNext(keyboard.KeyTab),
+					AlignVertical(align.VerticalMiddle),
+					AlignHorizontal(align.HorizontalCenter),
 				)
-				if err != nil {
-					return nil, err
-				}
-				return c, nil
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Mouse{Position: image.Point{0, 5}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
+					testcanvas.MustNew(image.Rect(0, 5, 20, 15)),
 					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						Ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 						Meta: &widgetapi.EventMeta{Focused: true},
 					},
 				)
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
-						Meta: &widgetapi.EventMeta{},
-					},
-				)
 				return ft
 			},
 		},
 		{
-			desc:     "key event moves focus from a widget with KeyScopeFocused, the newly focused widget gets the key",
-			termSize: image.Point{50, 20},
+			desc:     "mouse position adjusted relative to widget's canvas, horizontal offset",
+			termSize: image.Point{40, 30},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				c, err := New(
+				return New(
 					ft,
-					SplitVertical(
-						Left(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-						),
-						Right(
-							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
-						),
+					PlaceWidget(
+						fakewidget.New(widgetapi.Options{
+							WantMouse: widgetapi.MouseScopeWidget,
+							Ratio:     image.Point{9, 10},
+						}),
 					),
-					KeyFocusNext(keyboard.KeyTab),
+					AlignVertical(align.VerticalMiddle),
+					AlignHorizontal(align.HorizontalCenter),
 				)
-				if err != nil {
-					return nil, err
-				}
-				return c, nil
 			},
 			events: []terminalapi.Event{
-				// Focus the left container.
-				&terminalapi.Keyboard{Key: keyboard.KeyTab},
-				// Move focus from left to right.
-				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Mouse{Position: image.Point{6, 0}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 0, 25, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-					// Also gets the key, since we are sending two events above.
-					&fakewidget.Event{
-						Ev: &terminalapi.Keyboard{Key: keyboard.KeyTab},
-						// Also is focused at the time of the first event.
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(25, 0, 50, 20)),
+					testcanvas.MustNew(image.Rect(6, 0, 33, 30)),
 					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						Ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 						Meta: &widgetapi.EventMeta{Focused: true},
 					},
 				)
@@ -2079,17 +3635,18 @@ func TestMouse(t *testing.T) {
 			},
 		},
 		{
-			desc:     "event not forwarded if the widget didn't request it",
-			termSize: image.Point{20, 20},
+			desc:     "widget returns an error when processing the event",
+			termSize: image.Point{40, 20},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeNone})),
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
 				)
 			},
 			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRight},
 			},
+			wantProcessed: 2, // The error is also an event.
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
@@ -2101,327 +3658,452 @@ func TestMouse(t *testing.T) {
 				)
 				return ft
 			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			eh := &errorHandler{}
+			// Subscribe to receive errors.
+			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
+				eh.handle(ev.(*terminalapi.Error).Error())
+			})
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			var wantEv int
+			if tc.wantProcessed != 0 {
+				wantEv = tc.wantProcessed
+			} else {
+				wantEv = len(tc.events)
+			}
+
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), wantEv; got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if err := eh.get(); (err != nil) != tc.wantErr {
+				t.Errorf("errorHandler => unexpected error %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		desc       string
+		termSize   image.Point
+		container  func(ft *faketerm.Terminal) (*Container, error)
+		updateID   string
+		updateOpts []Option
+		// events are events delivered before the update.
+		beforeEvents []terminalapi.Event
+		// events are events delivered after the update.
+		afterEvents   []terminalapi.Event
+		wantUpdateErr bool
+		want          func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "fails on empty updateID",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			wantUpdateErr: true,
 		},
 		{
-			desc:     "MouseScopeWidget, event not forwarded if it falls on the container's border",
-			termSize: image.Point{20, 20},
+			desc:     "fails when no container with the ID is found",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			updateID:      "myID",
+			wantUpdateErr: true,
+		},
+		{
+			desc:     "no changes when no options are provided",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
+					ID("myID"),
 					Border(linestyle.Light),
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget}),
-					),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
-			},
+			updateID: "myID",
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
 				cvs := testcanvas.MustNew(ft.Area())
 				testdraw.MustBorder(
 					cvs,
-					ft.Area(),
+					image.Rect(0, 0, 10, 10),
 					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
 				)
 				testcanvas.MustApply(cvs, ft)
-
-				fakewidget.MustDraw(
+				return ft
+			},
+		},
+		{
+			desc:     "fails on invalid options",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ID("myID"),
+					Border(linestyle.Light),
+				)
+			},
+			updateID: "myID",
+			updateOpts: []Option{
+				MarginTop(-1),
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:     "fails when update introduces a duplicate ID",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
 					ft,
-					testcanvas.MustNew(image.Rect(1, 1, 19, 19)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{},
+					ID("myID"),
+					Border(linestyle.Light),
 				)
-				return ft
 			},
+			updateID: "myID",
+			updateOpts: []Option{
+				SplitVertical(
+					Left(
+						ID("left"),
+					),
+					Right(
+						ID("myID"),
+					),
+				),
+			},
+			wantUpdateErr: true,
 		},
 		{
-			desc:     "MouseScopeContainer, event forwarded if it falls on the container's border",
-			termSize: image.Point{23, 20},
+			desc:     "removes border from the container",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
+					ID("myID"),
 					Border(linestyle.Light),
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeContainer}),
-					),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			updateID: "myID",
+			updateOpts: []Option{
+				Border(linestyle.None),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(size)
-
-				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					ft.Area(),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
-				)
-				testcanvas.MustApply(cvs, ft)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(1, 1, 22, 19)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
-				return ft
+				return faketerm.MustNew(size)
 			},
 		},
 		{
-			desc:     "MouseScopeGlobal, event forwarded if it falls on the container's border",
-			termSize: image.Point{23, 20},
+			desc:     "places widget into a sub-container container",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					Border(linestyle.Light),
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal}),
+					ID("myRoot"),
+					SplitVertical(
+						Left(
+							ID("left"),
+						),
+						Right(
+							ID("right"),
+						),
 					),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			updateID: "right",
+			updateOpts: []Option{
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					ft.Area(),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
-				)
+				wAr := image.Rect(10, 0, 20, 10)
+				wCvs := testcanvas.MustNew(wAr)
+				fakewidget.MustDraw(ft, wCvs, &widgetapi.Meta{}, widgetapi.Options{})
+				testcanvas.MustCopyTo(wCvs, cvs)
 				testcanvas.MustApply(cvs, ft)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(1, 1, 22, 19)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
 				return ft
 			},
 		},
 		{
-			desc:     "MouseScopeWidget event not forwarded if it falls outside of widget's canvas",
-			termSize: image.Point{20, 20},
+			desc:     "places widget into root which removes children",
+			termSize: image.Point{20, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{
-							WantMouse: widgetapi.MouseScopeWidget,
-							Ratio:     image.Point{2, 1},
-						}),
+					ID("myRoot"),
+					SplitVertical(
+						Left(
+							ID("left"),
+							Border(linestyle.Light),
+						),
+						Right(
+							ID("right"),
+							Border(linestyle.Light),
+						),
 					),
-					AlignVertical(align.VerticalMiddle),
-					AlignHorizontal(align.HorizontalCenter),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			updateID: "myRoot",
+			updateOpts: []Option{
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 5, 20, 15)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{},
-				)
+				cvs := testcanvas.MustNew(ft.Area())
+				fakewidget.MustDraw(ft, cvs, &widgetapi.Meta{Focused: true}, widgetapi.Options{})
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "MouseScopeContainer event forwarded if it falls outside of widget's canvas",
-			termSize: image.Point{22, 20},
+			desc:     "changes container splits",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{
-							WantMouse: widgetapi.MouseScopeContainer,
-							Ratio:     image.Point{2, 1},
-						}),
+					ID("myRoot"),
+					SplitVertical(
+						Left(
+							ID("left"),
+							Border(linestyle.Light),
+						),
+						Right(
+							ID("right"),
+							Border(linestyle.Light),
+						),
 					),
-					AlignVertical(align.VerticalMiddle),
-					AlignHorizontal(align.HorizontalCenter),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			updateID: "myRoot",
+			updateOpts: []Option{
+				SplitHorizontal(
+					Top(
+						ID("left"),
+						Border(linestyle.Light),
+					),
+					Bottom(
+						ID("right"),
+						Border(linestyle.Light),
+					),
+				),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 4, 22, 15)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 5))
+				testdraw.MustBorder(cvs, image.Rect(0, 5, 10, 10))
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "MouseScopeGlobal event forwarded if it falls outside of widget's canvas",
-			termSize: image.Point{22, 20},
+			desc:     "update retains original focused container if it still exists",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{
-							WantMouse: widgetapi.MouseScopeGlobal,
-							Ratio:     image.Point{2, 1},
-						}),
+					ID("myRoot"),
+					SplitVertical(
+						Left(
+							ID("left"),
+							Border(linestyle.Light),
+						),
+						Right(
+							ID("right"),
+							Border(linestyle.Light),
+							SplitHorizontal(
+								Top(
+									ID("rightTop"),
+									Border(linestyle.Light),
+								),
+								Bottom(
+									ID("rightBottom"),
+									Border(linestyle.Light),
+								),
+							),
+						),
 					),
-					AlignVertical(align.VerticalMiddle),
-					AlignHorizontal(align.HorizontalCenter),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			beforeEvents: []terminalapi.Event{
+				// Move focus to container with ID "right".
+				// It will continue to exist after the update.
+				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+			},
+			updateID: "right",
+			updateOpts: []Option{
+				Clear(),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 4, 22, 15)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "MouseScopeWidget event not forwarded if it falls to another container",
-			termSize: image.Point{20, 20},
+			desc:     "update moves focus to the nearest parent when focused container is destroyed",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitHorizontal(
-						Top(),
-						Bottom(
-							PlaceWidget(
-								fakewidget.New(widgetapi.Options{
-									WantMouse: widgetapi.MouseScopeWidget,
-									Ratio:     image.Point{2, 1},
-								}),
+					ID("myRoot"),
+					SplitVertical(
+						Left(
+							ID("left"),
+							Border(linestyle.Light),
+						),
+						Right(
+							ID("right"),
+							Border(linestyle.Light),
+							SplitHorizontal(
+								Top(
+									ID("rightTop"),
+									Border(linestyle.Light),
+								),
+								Bottom(
+									ID("rightBottom"),
+									Border(linestyle.Light),
+								),
 							),
-							AlignVertical(align.VerticalMiddle),
-							AlignHorizontal(align.HorizontalCenter),
 						),
 					),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			beforeEvents: []terminalapi.Event{
+				// Move focus to container with ID "rightTop".
+				// It will be destroyed by calling update.
+				&terminalapi.Mouse{Position: image.Point{6, 1}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{6, 1}, Button: mouse.ButtonRelease},
+			},
+			updateID: "right",
+			updateOpts: []Option{
+				Clear(),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(0, 10, 20, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{},
-				)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "MouseScopeContainer event not forwarded if it falls to another container",
-			termSize: image.Point{20, 20},
+			desc:     "newly placed widget gets keyboard events",
+			termSize: image.Point{12, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitHorizontal(
-						Top(),
-						Bottom(
-							PlaceWidget(
-								fakewidget.New(widgetapi.Options{
-									WantMouse: widgetapi.MouseScopeContainer,
-									Ratio:     image.Point{2, 1},
-								}),
-							),
-							AlignVertical(align.VerticalMiddle),
-							AlignHorizontal(align.HorizontalCenter),
-						),
-					),
+					ID("myRoot"),
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
 				)
 			},
-			events: []terminalapi.Event{
+			beforeEvents: []terminalapi.Event{
+				// Move focus to the target container.
 				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+			},
+			afterEvents: []terminalapi.Event{
+				// Send the keyboard event.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			updateID: "myRoot",
+			updateOpts: []Option{
+				PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
+				cvs := testcanvas.MustNew(ft.Area())
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 10, 20, 20)),
-					&widgetapi.Meta{},
-					widgetapi.Options{},
+					cvs,
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
 				)
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "MouseScopeGlobal event forwarded if it falls to another container",
-			termSize: image.Point{20, 20},
+			desc:     "hidden container keeps its space blank and receives no events",
+			termSize: image.Point{22, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					SplitHorizontal(
-						Top(),
-						Bottom(
-							PlaceWidget(
-								fakewidget.New(widgetapi.Options{
-									WantMouse: widgetapi.MouseScopeGlobal,
-									Ratio:     image.Point{2, 1},
-								}),
-							),
-							AlignVertical(align.VerticalMiddle),
-							AlignHorizontal(align.HorizontalCenter),
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
 						),
 					),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			afterEvents: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			updateID: "left",
+			updateOpts: []Option{
+				Hidden(true),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
+				cvs := testcanvas.MustNew(image.Rect(11, 0, 22, 10))
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 10, 20, 20)),
+					cvs,
 					&widgetapi.Meta{},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
 						Meta: &widgetapi.EventMeta{},
 					},
 				)
@@ -2429,100 +4111,112 @@ func TestMouse(t *testing.T) {
 			},
 		},
 		{
-			desc:     "mouse position adjusted relative to widget's canvas, vertical offset",
-			termSize: image.Point{20, 20},
+			desc:     "collapsed container gives its space to the sibling",
+			termSize: image.Point{40, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{
-							WantMouse: widgetapi.MouseScopeWidget,
-							Ratio:     image.Point{2, 1},
-						}),
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+						SplitPercent(30),
+						Divider(linestyle.Light, cell.ColorWhite),
 					),
-					AlignVertical(align.VerticalMiddle),
-					AlignHorizontal(align.HorizontalCenter),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 5}, Button: mouse.ButtonLeft},
+			afterEvents: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			updateID: "left",
+			updateOpts: []Option{
+				Collapsed(true),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
+				cvs := testcanvas.MustNew(ft.Area())
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(image.Rect(0, 5, 20, 15)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					cvs,
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
 					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{Focused: true},
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{},
 					},
 				)
 				return ft
 			},
 		},
 		{
-			desc:     "mouse position adjusted relative to widget's canvas, horizontal offset",
-			termSize: image.Point{40, 30},
+			desc:     "hidden container is shown again",
+			termSize: image.Point{22, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					PlaceWidget(
-						fakewidget.New(widgetapi.Options{
-							WantMouse: widgetapi.MouseScopeWidget,
-							Ratio:     image.Point{9, 10},
-						}),
+					SplitVertical(
+						Left(
+							ID("left"),
+							Hidden(true),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
 					),
-					AlignVertical(align.VerticalMiddle),
-					AlignHorizontal(align.HorizontalCenter),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{6, 0}, Button: mouse.ButtonLeft},
+			updateID: "left",
+			updateOpts: []Option{
+				Hidden(false),
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(image.Rect(6, 0, 33, 30)),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
-				)
+				left := testcanvas.MustNew(image.Rect(0, 0, 11, 10))
+				fakewidget.MustDraw(ft, left, &widgetapi.Meta{}, widgetapi.Options{})
+				right := testcanvas.MustNew(image.Rect(11, 0, 22, 10))
+				fakewidget.MustDraw(ft, right, &widgetapi.Meta{}, widgetapi.Options{})
 				return ft
 			},
 		},
 		{
-			desc:     "widget returns an error when processing the event",
-			termSize: image.Point{40, 20},
+			desc:     "newly placed widget gets mouse events",
+			termSize: image.Point{22, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
+					ID("myRoot"),
 					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
 				)
 			},
-			events: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRight},
+			afterEvents: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+			},
+			updateID: "myRoot",
+			updateOpts: []Option{
+				PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
 			},
-			wantProcessed: 2, // The error is also an event.
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-
+				cvs := testcanvas.MustNew(ft.Area())
 				fakewidget.MustDraw(
 					ft,
-					testcanvas.MustNew(ft.Area()),
+					cvs,
 					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
 				)
+				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
-			wantErr: true,
 		},
 	}
 
@@ -2533,7 +4227,7 @@ func TestMouse(t *testing.T) {
 				t.Fatalf("faketerm.New => unexpected error: %v", err)
 			}
 
-			c, err := tc.container(got)
+			cont, err := tc.container(got)
 			if err != nil {
 				t.Fatalf("tc.container => unexpected error: %v", err)
 			}
@@ -2544,21 +4238,40 @@ func TestMouse(t *testing.T) {
 			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
 				eh.handle(ev.(*terminalapi.Error).Error())
 			})
-			c.Subscribe(eds)
+			cont.Subscribe(eds)
 			// Initial draw to determine sizes of containers.
-			if err := c.Draw(); err != nil {
+			if err := cont.Draw(); err != nil {
 				t.Fatalf("Draw => unexpected error: %v", err)
 			}
-			for _, ev := range tc.events {
+
+			// Deliver the before events.
+			for _, ev := range tc.beforeEvents {
 				eds.Event(ev)
 			}
-			var wantEv int
-			if tc.wantProcessed != 0 {
-				wantEv = tc.wantProcessed
-			} else {
-				wantEv = len(tc.events)
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.beforeEvents); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			{
+				err := cont.Update(tc.updateID, tc.updateOpts...)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("Update => unexpected error:%v, wantErr:%v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
 			}
 
+			// Deliver the after events.
+			for _, ev := range tc.afterEvents {
+				eds.Event(ev)
+			}
+			wantEv := len(tc.beforeEvents) + len(tc.afterEvents)
 			if err := testevent.WaitFor(5*time.Second, func() error {
 				if got, want := eds.Processed(), wantEv; got != want {
 					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
@@ -2568,402 +4281,389 @@ func TestMouse(t *testing.T) {
 				t.Fatalf("testevent.WaitFor => %v", err)
 			}
 
-			if err := c.Draw(); err != nil {
+			if err := cont.Draw(); err != nil {
 				t.Fatalf("Draw => unexpected error: %v", err)
 			}
-
-			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+
+			var want *faketerm.Terminal
+			if tc.want != nil {
+				want = tc.want(tc.termSize)
+			} else {
+				w, err := faketerm.New(tc.termSize)
+				if err != nil {
+					t.Fatalf("faketerm.New => unexpected error: %v", err)
+				}
+				want = w
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
 				t.Errorf("Draw => %v", diff)
 			}
-
-			if err := eh.get(); (err != nil) != tc.wantErr {
-				t.Errorf("errorHandler => unexpected error %v, wantErr: %v", err, tc.wantErr)
-			}
 		})
 	}
+
 }
 
-func TestUpdate(t *testing.T) {
+func TestAddRemoveSplit(t *testing.T) {
 	tests := []struct {
-		desc       string
-		termSize   image.Point
-		container  func(ft *faketerm.Terminal) (*Container, error)
-		updateID   string
-		updateOpts []Option
-		// events are events delivered before the update.
-		beforeEvents []terminalapi.Event
-		// events are events delivered after the update.
-		afterEvents   []terminalapi.Event
-		wantUpdateErr bool
-		want          func(size image.Point) *faketerm.Terminal
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		// update performs the layout change on the container.
+		update  func(c *Container) error
+		wantErr bool
+		want    func(size image.Point) *faketerm.Terminal
 	}{
 		{
-			desc:     "fails on empty updateID",
+			desc:     "AddSplit fails when no container with the ID is found",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(ft)
 			},
-			wantUpdateErr: true,
+			update: func(c *Container) error {
+				return c.AddSplit("myID", SplitVertical(Left(), Right()))
+			},
+			wantErr: true,
 		},
 		{
-			desc:     "fails when no container with the ID is found",
+			desc:     "AddSplit fails when the option isn't a split",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				return New(ft)
+				return New(ft, ID("myID"))
 			},
-			updateID:      "myID",
-			wantUpdateErr: true,
+			update: func(c *Container) error {
+				return c.AddSplit("myID", Border(linestyle.Light))
+			},
+			wantErr: true,
 		},
 		{
-			desc:     "no changes when no options are provided",
+			desc:     "AddSplit doesn't apply an option that isn't a split",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
-				return New(
-					ft,
-					ID("myID"),
-					Border(linestyle.Light),
-				)
+				return New(ft, ID("myID"))
 			},
-			updateID: "myID",
-			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(size)
-				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(
-					cvs,
-					image.Rect(0, 0, 10, 10),
-					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
-				)
-				testcanvas.MustApply(cvs, ft)
-				return ft
+			update: func(c *Container) error {
+				if err := c.AddSplit("myID", Border(linestyle.Light)); err == nil {
+					return errors.New("AddSplit => got nil error, want an error")
+				}
+				return nil
 			},
 		},
 		{
-			desc:     "fails on invalid options",
+			desc:     "AddSplit fails when the container is already split",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
 					ID("myID"),
-					Border(linestyle.Light),
+					SplitVertical(Left(), Right()),
 				)
 			},
-			updateID: "myID",
-			updateOpts: []Option{
-				MarginTop(-1),
+			update: func(c *Container) error {
+				return c.AddSplit("myID", SplitHorizontal(Top(), Bottom()))
 			},
-			wantUpdateErr: true,
+			wantErr: true,
 		},
 		{
-			desc:     "fails when update introduces a duplicate ID",
+			desc:     "AddSplit moves the widget into the first sub container",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
 					ID("myID"),
-					Border(linestyle.Light),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
 				)
 			},
-			updateID: "myID",
-			updateOpts: []Option{
-				SplitVertical(
-					Left(
-						ID("left"),
-					),
-					Right(
-						ID("myID"),
-					),
-				),
+			update: func(c *Container) error {
+				return c.AddSplit("myID", SplitHorizontal(
+					Top(),
+					Bottom(Border(linestyle.Light)),
+				))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 5, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 5)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
 			},
-			wantUpdateErr: true,
 		},
 		{
-			desc:     "removes border from the container",
+			desc:     "AddSplit doesn't move the widget when the first sub container has content",
 			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
 					ID("myID"),
-					Border(linestyle.Light),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
 				)
 			},
-			updateID: "myID",
-			updateOpts: []Option{
-				Border(linestyle.None),
+			update: func(c *Container) error {
+				return c.AddSplit("myID", SplitHorizontal(
+					Top(Border(linestyle.Light), SplitVertical(Left(), Right())),
+					Bottom(Border(linestyle.Light)),
+				))
 			},
 			want: func(size image.Point) *faketerm.Terminal {
-				return faketerm.MustNew(size)
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					image.Rect(0, 0, 10, 5),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testdraw.MustBorder(cvs, image.Rect(0, 5, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
 			},
 		},
 		{
-			desc:     "places widget into a sub-container container",
-			termSize: image.Point{20, 10},
+			desc:     "RemoveSplit fails when no container with the ID is found",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			update: func(c *Container) error {
+				return c.RemoveSplit("myID")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "RemoveSplit fails when the container isn't split",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ID("myID"))
+			},
+			update: func(c *Container) error {
+				return c.RemoveSplit("myID")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "RemoveSplit reverses AddSplit",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					ID("myRoot"),
-					SplitVertical(
-						Left(
-							ID("left"),
-						),
-						Right(
-							ID("right"),
-						),
-					),
+					ID("myID"),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
 				)
 			},
-			updateID: "right",
-			updateOpts: []Option{
-				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			update: func(c *Container) error {
+				if err := c.AddSplit("myID", SplitVertical(
+					Left(),
+					Right(ID("added"), Border(linestyle.Light)),
+				)); err != nil {
+					return err
+				}
+				return c.RemoveSplit("myID")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
-				cvs := testcanvas.MustNew(ft.Area())
-				wAr := image.Rect(10, 0, 20, 10)
-				wCvs := testcanvas.MustNew(wAr)
-				fakewidget.MustDraw(ft, wCvs, &widgetapi.Meta{}, widgetapi.Options{})
-				testcanvas.MustCopyTo(wCvs, cvs)
-				testcanvas.MustApply(cvs, ft)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
 				return ft
 			},
 		},
 		{
-			desc:     "places widget into root which removes children",
-			termSize: image.Point{20, 10},
+			desc:     "RemoveSplit keeps the sub containers of the first sub container",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					ID("myRoot"),
-					SplitVertical(
-						Left(
-							ID("left"),
-							Border(linestyle.Light),
+					ID("myID"),
+					SplitHorizontal(
+						Top(
+							SplitVertical(
+								Left(Border(linestyle.Light)),
+								Right(Border(linestyle.Light)),
+							),
 						),
-						Right(
-							ID("right"),
-							Border(linestyle.Light),
+						Bottom(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
 						),
 					),
 				)
 			},
-			updateID: "myRoot",
-			updateOpts: []Option{
-				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			update: func(c *Container) error {
+				return c.RemoveSplit("myID")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				fakewidget.MustDraw(ft, cvs, &widgetapi.Meta{Focused: true}, widgetapi.Options{})
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
+				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "changes container splits",
-			termSize: image.Point{10, 10},
+			desc:     "RemoveSplit keeps the even split of the first sub container",
+			termSize: image.Point{12, 4},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					ID("myRoot"),
+					ID("myID"),
 					SplitVertical(
 						Left(
-							ID("left"),
-							Border(linestyle.Light),
-						),
-						Right(
-							ID("right"),
-							Border(linestyle.Light),
+							SplitEvenVertical(
+								[]Option{Border(linestyle.Light)},
+								[]Option{Border(linestyle.Light)},
+								[]Option{Border(linestyle.Light)},
+							),
 						),
+						Right(),
 					),
 				)
 			},
-			updateID: "myRoot",
-			updateOpts: []Option{
-				SplitHorizontal(
-					Top(
-						ID("left"),
-						Border(linestyle.Light),
-					),
-					Bottom(
-						ID("right"),
-						Border(linestyle.Light),
-					),
-				),
+			update: func(c *Container) error {
+				return c.RemoveSplit("myID")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 5))
-				testdraw.MustBorder(cvs, image.Rect(0, 5, 10, 10))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 4, 4))
+				testdraw.MustBorder(cvs, image.Rect(4, 0, 8, 4))
+				testdraw.MustBorder(cvs, image.Rect(8, 0, 12, 4))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "update retains original focused container if it still exists",
-			termSize: image.Point{10, 10},
+			desc:     "RemoveSplit keeps the split weights of the first sub container",
+			termSize: image.Point{12, 4},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					ID("myRoot"),
+					ID("myID"),
 					SplitVertical(
 						Left(
-							ID("left"),
-							Border(linestyle.Light),
-						),
-						Right(
-							ID("right"),
-							Border(linestyle.Light),
-							SplitHorizontal(
-								Top(
-									ID("rightTop"),
-									Border(linestyle.Light),
-								),
-								Bottom(
-									ID("rightBottom"),
-									Border(linestyle.Light),
-								),
+							SplitVertical(
+								Left(Border(linestyle.Light)),
+								Right(Border(linestyle.Light)),
+								SplitWeights(1, 3),
 							),
 						),
+						Right(),
 					),
 				)
 			},
-			beforeEvents: []terminalapi.Event{
-				// Move focus to container with ID "right".
-				// It will continue to exist after the update.
-				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
-			},
-			updateID: "right",
-			updateOpts: []Option{
-				Clear(),
+			update: func(c *Container) error {
+				return c.RemoveSplit("myID")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
-				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 3, 4))
+				testdraw.MustBorder(cvs, image.Rect(3, 0, 12, 4))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "update moves focus to the nearest parent when focused container is destroyed",
-			termSize: image.Point{10, 10},
+			desc:     "RemoveSplit keeps the divider of the first sub container",
+			termSize: image.Point{20, 6},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					ID("myRoot"),
+					ID("myID"),
 					SplitVertical(
 						Left(
-							ID("left"),
-							Border(linestyle.Light),
-						),
-						Right(
-							ID("right"),
-							Border(linestyle.Light),
-							SplitHorizontal(
-								Top(
-									ID("rightTop"),
-									Border(linestyle.Light),
-								),
-								Bottom(
-									ID("rightBottom"),
-									Border(linestyle.Light),
-								),
+							SplitVertical(
+								Left(),
+								Right(),
+								Divider(linestyle.Light, cell.ColorRed),
 							),
 						),
+						Right(),
 					),
-				)
-			},
-			beforeEvents: []terminalapi.Event{
-				// Move focus to container with ID "rightTop".
-				// It will be destroyed by calling update.
-				&terminalapi.Mouse{Position: image.Point{6, 1}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{6, 1}, Button: mouse.ButtonRelease},
-			},
-			updateID: "right",
-			updateOpts: []Option{
-				Clear(),
+				)
+			},
+			update: func(c *Container) error {
+				return c.RemoveSplit("myID")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				testdraw.MustBorder(cvs, image.Rect(0, 0, 5, 10))
-				testdraw.MustBorder(cvs, image.Rect(5, 0, 10, 10), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{10, 0}, End: image.Point{10, 5}},
+				}, draw.HVLineCellOpts(cell.FgColor(cell.ColorRed)))
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "newly placed widget gets keyboard events",
-			termSize: image.Point{12, 10},
+			desc:     "RemoveSplit keeps the border and the padding of the first sub container",
+			termSize: image.Point{30, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					ID("myRoot"),
-					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+					ID("myID"),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+							PaddingLeft(2),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(),
+					),
 				)
 			},
-			beforeEvents: []terminalapi.Event{
-				// Move focus to the target container.
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
-			},
-			afterEvents: []terminalapi.Event{
-				// Send the keyboard event.
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
-			},
-			updateID: "myRoot",
-			updateOpts: []Option{
-				PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+			update: func(c *Container) error {
+				return c.RemoveSplit("myID")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
 				fakewidget.MustDraw(
 					ft,
-					cvs,
+					testcanvas.MustNew(image.Rect(3, 1, 29, 9)),
 					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
+					widgetapi.Options{},
 				)
-				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:     "newly placed widget gets mouse events",
-			termSize: image.Point{22, 10},
+			desc:     "RemoveSplit moves focus to the collapsed container when the focused one is removed",
+			termSize: image.Point{10, 10},
 			container: func(ft *faketerm.Terminal) (*Container, error) {
 				return New(
 					ft,
-					ID("myRoot"),
-					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+					ID("myID"),
+					Border(linestyle.Light),
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+					),
 				)
 			},
-			afterEvents: []terminalapi.Event{
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
-				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
-			},
-			updateID: "myRoot",
-			updateOpts: []Option{
-				PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+			update: func(c *Container) error {
+				c.focusTracker.setActive(c.second)
+				return c.RemoveSplit("myID")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
-				fakewidget.MustDraw(
-					ft,
+				testdraw.MustBorder(
 					cvs,
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
-					&fakewidget.Event{
-						Ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
-						Meta: &widgetapi.EventMeta{Focused: true},
-					},
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
 				)
 				testcanvas.MustApply(cvs, ft)
 				return ft
@@ -2982,56 +4682,21 @@ func TestUpdate(t *testing.T) {
 			if err != nil {
 				t.Fatalf("tc.container => unexpected error: %v", err)
 			}
-
-			eds := event.NewDistributionSystem()
-			eh := &errorHandler{}
-			// Subscribe to receive errors.
-			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
-				eh.handle(ev.(*terminalapi.Error).Error())
-			})
-			cont.Subscribe(eds)
 			// Initial draw to determine sizes of containers.
 			if err := cont.Draw(); err != nil {
 				t.Fatalf("Draw => unexpected error: %v", err)
 			}
 
-			// Deliver the before events.
-			for _, ev := range tc.beforeEvents {
-				eds.Event(ev)
-			}
-			if err := testevent.WaitFor(5*time.Second, func() error {
-				if got, want := eds.Processed(), len(tc.beforeEvents); got != want {
-					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
-				}
-				return nil
-			}); err != nil {
-				t.Fatalf("testevent.WaitFor => %v", err)
-			}
-
 			{
-				err := cont.Update(tc.updateID, tc.updateOpts...)
-				if (err != nil) != tc.wantUpdateErr {
-					t.Errorf("Update => unexpected error:%v, wantErr:%v", err, tc.wantUpdateErr)
+				err := tc.update(cont)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("update => unexpected error:%v, wantErr:%v", err, tc.wantErr)
 				}
 				if err != nil {
 					return
 				}
 			}
 
-			// Deliver the after events.
-			for _, ev := range tc.afterEvents {
-				eds.Event(ev)
-			}
-			wantEv := len(tc.beforeEvents) + len(tc.afterEvents)
-			if err := testevent.WaitFor(5*time.Second, func() error {
-				if got, want := eds.Processed(), wantEv; got != want {
-					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
-				}
-				return nil
-			}); err != nil {
-				t.Fatalf("testevent.WaitFor => %v", err)
-			}
-
 			if err := cont.Draw(); err != nil {
 				t.Fatalf("Draw => unexpected error: %v", err)
 			}
@@ -3040,16 +4705,265 @@ func TestUpdate(t *testing.T) {
 			if tc.want != nil {
 				want = tc.want(tc.termSize)
 			} else {
-				w, err := faketerm.New(tc.termSize)
-				if err != nil {
-					t.Fatalf("faketerm.New => unexpected error: %v", err)
-				}
-				want = w
+				want = faketerm.MustNew(tc.termSize)
 			}
 			if diff := faketerm.Diff(want, got); diff != "" {
 				t.Errorf("Draw => %v", diff)
 			}
 		})
 	}
+}
+
+func TestRemoveSplitKeepsOptions(t *testing.T) {
+	ft, err := faketerm.New(image.Point{10, 10})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	cont, err := New(
+		ft,
+		ID("myID"),
+		SplitVertical(
+			Left(
+				ID("first"),
+				Border(linestyle.Double),
+				Scrollable(),
+				ShrinkToFit(),
+				FocusScope(),
+				KeyFocusSkip(),
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			),
+			Right(),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	want := *cont.first.opts
+	want.id = cont.opts.id
+	want.global = cont.opts.global
+	want.inherited = cont.opts.inherited
+
+	if err := cont.RemoveSplit("myID"); err != nil {
+		t.Fatalf("RemoveSplit => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare(&want, cont.opts); diff != "" {
+		t.Errorf("RemoveSplit => unexpected options, diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRedrawInterval(t *testing.T) {
+	tests := []struct {
+		desc      string
+		container func(ft *faketerm.Terminal) (*Container, error)
+		want      time.Duration
+	}{
+		{
+			desc: "zero when no widget requests redraws",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, PlaceWidget(fakewidget.New(widgetapi.Options{})))
+			},
+		},
+		{
+			desc: "shortest interval among the widgets",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{RedrawInterval: time.Second}))),
+						Right(
+							SplitHorizontal(
+								Top(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+								Bottom(PlaceWidget(fakewidget.New(widgetapi.Options{RedrawInterval: 100 * time.Millisecond}))),
+							),
+						),
+					),
+				)
+			},
+			want: 100 * time.Millisecond,
+		},
+		{
+			desc: "ignores widgets in hidden containers",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{RedrawInterval: time.Second}))),
+						Right(
+							Hidden(true),
+							PlaceWidget(fakewidget.New(widgetapi.Options{RedrawInterval: 100 * time.Millisecond})),
+						),
+					),
+				)
+			},
+			want: time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{20, 20})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			c, err := tc.container(ft)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+			if got := c.RedrawInterval(); got != tc.want {
+				t.Errorf("RedrawInterval => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWeightedSize(t *testing.T) {
+	tests := []struct {
+		desc   string
+		total  int
+		first  int
+		second int
+		want   int
+	}{
+		{desc: "2:1 on zero cells", total: 0, first: 2, second: 1, want: 0},
+		{desc: "2:1 on one cell", total: 1, first: 2, second: 1, want: 1},
+		{desc: "2:1 divides exactly", total: 9, first: 2, second: 1, want: 6},
+		{desc: "2:1 rounds up", total: 10, first: 2, second: 1, want: 7},
+		{desc: "2:1 rounds down", total: 11, first: 2, second: 1, want: 7},
+		{desc: "2:1 divides exactly again", total: 12, first: 2, second: 1, want: 8},
+		{desc: "1:2 rounds down", total: 10, first: 1, second: 2, want: 3},
+		{desc: "1:1 rounds the half up", total: 5, first: 1, second: 1, want: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := weightedSize(tc.total, tc.first, tc.second); got != tc.want {
+				t.Errorf("weightedSize(%d, %d, %d) => %d, want %d", tc.total, tc.first, tc.second, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestShrinkToFit(t *testing.T) {
+	// display returns a segment display with text that needs 13 columns and
+	// 5 rows.
+	display := func() *segmentdisplay.SegmentDisplay {
+		sd, err := segmentdisplay.New()
+		if err != nil {
+			t.Fatalf("segmentdisplay.New => unexpected error: %v", err)
+		}
+		if err := sd.Write([]*segmentdisplay.TextChunk{segmentdisplay.NewChunk("12")}); err != nil {
+			t.Fatalf("Write => unexpected error: %v", err)
+		}
+		return sd
+	}
+
+	tests := []struct {
+		desc       string
+		termSize   image.Point
+		container  func(ft *faketerm.Terminal) (*Container, error)
+		wantFirst  image.Rectangle
+		wantSecond image.Rectangle
+	}{
+		{
+			desc:     "first container fits the natural width of the segment display",
+			termSize: image.Point{40, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(ShrinkToFit(), PlaceWidget(display())),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			wantFirst:  image.Rect(0, 0, 13, 5),
+			wantSecond: image.Rect(13, 0, 40, 5),
+		},
+		{
+			desc:     "second container fits the natural width of the segment display",
+			termSize: image.Point{40, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Right(ShrinkToFit(), PlaceWidget(display())),
+						SplitPercent(20),
+					),
+				)
+			},
+			wantFirst:  image.Rect(0, 0, 27, 5),
+			wantSecond: image.Rect(27, 0, 40, 5),
+		},
+		{
+			desc:     "accounts for the border and the padding",
+			termSize: image.Point{40, 7},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ShrinkToFit(),
+							Border(linestyle.Light),
+							PaddingLeft(1),
+							PlaceWidget(display()),
+						),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			wantFirst:  image.Rect(0, 0, 16, 7),
+			wantSecond: image.Rect(16, 0, 40, 7),
+		},
+		{
+			desc:     "fits the natural height in a horizontal split",
+			termSize: image.Point{20, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(ShrinkToFit(), PlaceWidget(display())),
+						Bottom(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			wantFirst:  image.Rect(0, 0, 20, 5),
+			wantSecond: image.Rect(0, 5, 20, 20),
+		},
+		{
+			desc:     "no effect on widgets without a preferred size",
+			termSize: image.Point{40, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(ShrinkToFit(), PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			wantFirst:  image.Rect(0, 0, 20, 5),
+			wantSecond: image.Rect(20, 0, 40, 5),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(tc.termSize)
+			cont, err := tc.container(ft)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
 
+			if got := cont.first.area; got != tc.wantFirst {
+				t.Errorf("first container area => %v, want %v", got, tc.wantFirst)
+			}
+			if got := cont.second.area; got != tc.wantSecond {
+				t.Errorf("second container area => %v, want %v", got, tc.wantSecond)
+			}
+		})
+	}
 }
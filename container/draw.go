@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"image"
 
+	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/area"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/draw"
@@ -39,13 +41,15 @@ func drawTree(c *Container) error {
 		return err
 	}
 	root.area = ar
+	root.drawn++
 
 	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+		c.drawn = root.drawn
 		first, second, err := c.split()
 		if err != nil {
 			return err
 		}
-		if c.first != nil {
+		if c.first != nil && !c.first.isHidden() {
 			ar, err := c.first.opts.margin.apply(first)
 			if err != nil {
 				return err
@@ -53,7 +57,7 @@ func drawTree(c *Container) error {
 			c.first.area = ar
 		}
 
-		if c.second != nil {
+		if c.second != nil && !c.second.isHidden() {
 			ar, err := c.second.opts.margin.apply(second)
 			if err != nil {
 				return err
@@ -68,6 +72,47 @@ func drawTree(c *Container) error {
 	return nil
 }
 
+// tooSmall determines if the terminal is smaller than the minimum size
+// required to draw the container tree.
+func tooSmall(c *Container) bool {
+	min := c.opts.global.minSize
+	size := c.term.Size()
+	return size.X < min.X || size.Y < min.Y
+}
+
+// drawMinSizeMessage clears the terminal and draws the message indicating that
+// the terminal is too small centered on it.
+func drawMinSizeMessage(c *Container) error {
+	if err := c.term.Clear(); err != nil {
+		return fmt.Errorf("term.Clear => error: %v", err)
+	}
+
+	ar, err := area.FromSize(c.term.Size())
+	if err != nil {
+		return err
+	}
+	if ar.Empty() {
+		return nil // Nowhere to draw the message.
+	}
+	cvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+
+	global := c.opts.global
+	start, err := alignfor.Text(ar, global.minSizeMsg, align.HorizontalCenter, align.VerticalMiddle)
+	if err != nil {
+		return err
+	}
+	if err := draw.Text(cvs, global.minSizeMsg, start,
+		draw.TextCellOpts(global.minSizeMsgCellOpts...),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	); err != nil {
+		return err
+	}
+	return cvs.Apply(c.term)
+}
+
 // drawBorder draws the border around the container if requested.
 func drawBorder(c *Container) error {
 	if !c.hasBorder() {
@@ -84,16 +129,17 @@ func drawBorder(c *Container) error {
 		return err
 	}
 
+	borderColor, focusedColor := c.opts.inherited.borderColors()
 	var cOpts, titleCOpts []cell.Option
 	if c.focusTracker.isActive(c) {
-		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.focusedColor))
+		cOpts = append(cOpts, cell.FgColor(focusedColor))
 		if c.opts.inherited.titleFocusedColor != nil {
 			titleCOpts = append(titleCOpts, cell.FgColor(*c.opts.inherited.titleFocusedColor))
 		} else {
 			titleCOpts = cOpts
 		}
 	} else {
-		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.borderColor))
+		cOpts = append(cOpts, cell.FgColor(borderColor))
 		if c.opts.inherited.titleColor != nil {
 			titleCOpts = append(titleCOpts, cell.FgColor(*c.opts.inherited.titleColor))
 		} else {
@@ -112,8 +158,65 @@ func drawBorder(c *Container) error {
 	return cvs.Apply(c.term)
 }
 
+// drawBackground fills the usable area of the container with the background
+// color if requested.
+func drawBackground(c *Container) error {
+	bg, ok := c.opts.inherited.backgroundColor()
+	if !ok {
+		return nil
+	}
+
+	cvs, err := canvas.New(c.usable())
+	if err != nil {
+		return err
+	}
+	if err := fillBackground(cvs, bg); err != nil {
+		return err
+	}
+	return cvs.Apply(c.term)
+}
+
+// fillBackground fills the whole canvas with the background color.
+func fillBackground(cvs *canvas.Canvas, bg cell.Color) error {
+	return cvs.SetAreaCells(cvs.Area(), ' ', cell.BgColor(bg))
+}
+
+// drawDivider draws the line between the sub containers if requested.
+// Dividers shorter than two cells aren't drawn.
+func drawDivider(c *Container) error {
+	if c.isLeaf() || c.hasTabs() {
+		return nil
+	}
+	_, _, divider, err := c.splitWithDivider()
+	if err != nil {
+		return err
+	}
+	if divider.Dx() < 1 || divider.Dy() < 1 || (divider.Dx() < 2 && divider.Dy() < 2) {
+		return nil
+	}
+
+	cvs, err := canvas.New(divider)
+	if err != nil {
+		return err
+	}
+	size := cvs.Size()
+	line := draw.HVLine{
+		End: image.Point{size.X - 1, size.Y - 1},
+	}
+	if err := draw.HVLines(cvs, []draw.HVLine{line},
+		draw.HVLineStyle(c.opts.dividerStyle),
+		draw.HVLineCellOpts(cell.FgColor(c.opts.dividerColor)),
+	); err != nil {
+		return err
+	}
+	return cvs.Apply(c.term)
+}
+
 // drawWidget requests the widget to draw on the canvas.
 func drawWidget(c *Container) error {
+	if c.isScrollable() {
+		return drawScrollable(c)
+	}
 	widgetArea, err := c.widgetArea()
 	if err != nil {
 		return err
@@ -140,9 +243,15 @@ func drawWidget(c *Container) error {
 	if err != nil {
 		return err
 	}
+	if bg, ok := c.opts.inherited.backgroundColor(); ok {
+		if err := fillBackground(cvs, bg); err != nil {
+			return err
+		}
+	}
 
 	meta := &widgetapi.Meta{
 		Focused: c.focusTracker.isActive(c),
+		Theme:   c.opts.inherited.theme,
 	}
 
 	if err := c.opts.widget.Draw(cvs, meta); err != nil {
@@ -178,6 +287,20 @@ func drawCont(c *Container) error {
 		return fmt.Errorf("unable to draw container border: %v", err)
 	}
 
+	if err := drawBackground(c); err != nil {
+		return fmt.Errorf("unable to draw the container background: %v", err)
+	}
+
+	if err := drawDivider(c); err != nil {
+		return fmt.Errorf("unable to draw the divider: %v", err)
+	}
+
+	if c.hasTabs() {
+		if err := drawTabs(c); err != nil {
+			return fmt.Errorf("unable to draw the tab strip: %v", err)
+		}
+	}
+
 	if err := drawWidget(c); err != nil {
 		return fmt.Errorf("unable to draw widget %T: %v", c.opts.widget, err)
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/mum4k/termdash/private/faketerm"
 	"github.com/mum4k/termdash/private/fakewidget"
 	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/gauge"
 )
 
 func TestDrawWidget(t *testing.T) {
@@ -64,6 +65,29 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "draws container with a round border",
+			termSize: image.Point{5, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Round),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				opts := draw.TextCellOpts(cell.FgColor(cell.ColorYellow))
+				testdraw.MustText(cvs, "╭───╮", image.Point{0, 0}, opts)
+				testdraw.MustText(cvs, "│", image.Point{0, 1}, opts)
+				testdraw.MustText(cvs, "│", image.Point{4, 1}, opts)
+				testdraw.MustText(cvs, "│", image.Point{0, 2}, opts)
+				testdraw.MustText(cvs, "│", image.Point{4, 2}, opts)
+				testdraw.MustText(cvs, "╰───╯", image.Point{0, 3}, opts)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "absolute margin on root container",
 			termSize: image.Point{20, 10},
@@ -251,6 +275,36 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "draws padded widget, same padding on all sides",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+					Padding(2),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+
+				wAr := image.Rect(3, 3, 17, 7)
+				wCvs := testcanvas.MustNew(wAr)
+				// Fake widget border.
+				fakewidget.MustDraw(ft, wCvs, &widgetapi.Meta{}, widgetapi.Options{})
+				testcanvas.MustCopyTo(wCvs, cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "draws padded widget, relative padding",
 			termSize: image.Point{20, 20},
@@ -432,6 +486,38 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "truncates a title that doesn't fit onto the border",
+			termSize: image.Point{9, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderTitle("abcdefghij"),
+					BorderTitleAlignCenter(),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testdraw.MustText(cvs, "abcdef…", image.Point{1, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+
+				// Fake widget border.
+				testdraw.MustBorder(cvs, image.Rect(1, 1, 8, 4))
+				testdraw.MustText(cvs, "(7,3)", image.Point{2, 2})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "draws widget with container border and title aligned on the right",
 			termSize: image.Point{9, 5},
@@ -540,6 +626,115 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "fills the area inside the border with the background",
+			termSize: image.Point{6, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					Background(cell.ColorBlue),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 5, 3), ' ', cell.BgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "fills the area with the background of the theme",
+			termSize: image.Point{6, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ApplyTheme(cell.Theme{Background: cell.ColorNavy}),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(cvs, cvs.Area(), ' ', cell.BgColor(cell.ColorNavy))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "background option takes precedence over the theme",
+			termSize: image.Point{6, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ApplyTheme(cell.Theme{Background: cell.ColorNavy}),
+					Background(cell.ColorBlue),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(cvs, cvs.Area(), ' ', cell.BgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "background is inherited by sub containers that don't set their own",
+			termSize: image.Point{10, 3},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Background(cell.ColorBlue),
+					SplitVertical(
+						Left(),
+						Right(Background(cell.ColorRed)),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 5, 3), ' ', cell.BgColor(cell.ColorBlue))
+				testcanvas.MustSetAreaCells(cvs, image.Rect(5, 0, 10, 3), ' ', cell.BgColor(cell.ColorRed))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "widget that sets its own background overrides the background",
+			termSize: image.Point{9, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Background(cell.ColorBlue),
+					AlignHorizontal(align.HorizontalLeft),
+					AlignVertical(align.VerticalTop),
+					PlaceWidget(fakewidget.New(widgetapi.Options{
+						MaximumSize: image.Point{7, 3},
+					})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(cvs, cvs.Area(), ' ', cell.BgColor(cell.ColorBlue))
+
+				// The fake widget clears its canvas.
+				widgetAr := image.Rect(0, 0, 7, 3)
+				testcanvas.MustSetAreaCells(cvs, widgetAr, ' ', cell.BgColor(cell.ColorDefault))
+				testdraw.MustBorder(cvs, widgetAr)
+				testdraw.MustText(cvs, "(7,3)", image.Point{1, 1})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "draws widget without container border",
 			termSize: image.Point{9, 5},
@@ -611,6 +806,27 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "draws the resize placeholder instead of an undersized gauge",
+			termSize: image.Point{10, 2},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				g, err := gauge.New(gauge.Border(linestyle.Light))
+				if err != nil {
+					return nil, err
+				}
+				if err := g.Percent(50); err != nil {
+					return nil, err
+				}
+				return New(ft, PlaceWidget(g))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "⇄", image.Point{0, 0})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "widget's canvas is limited to the requested maximum size",
 			termSize: image.Point{22, 22},
@@ -745,6 +961,36 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "widget with 1:1 aspect ratio is centered in a wide area",
+			termSize: image.Point{32, 12},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					PlaceWidget(fakewidget.New(widgetapi.Options{
+						Ratio: image.Point{1, 1}},
+					)),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				contCvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					contCvs,
+					contCvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(contCvs, ft)
+
+				// Fake widget.
+				cvs := testcanvas.MustNew(image.Rect(11, 1, 21, 11))
+				fakewidget.MustDraw(ft, cvs, &widgetapi.Meta{Focused: true}, widgetapi.Options{})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "widget's canvas is limited to the requested maximum size and ratio",
 			termSize: image.Point{22, 22},
@@ -994,6 +1240,44 @@ func TestDrawWidget(t *testing.T) {
 					widgetapi.Options{},
 				)
 
+				testcanvas.MustCopyTo(wCvs, cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "vertical baseline align for the widget",
+			termSize: image.Point{22, 22},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					AlignVertical(align.VerticalBaseline),
+					PlaceWidget(fakewidget.New(widgetapi.Options{
+						Ratio: image.Point{4, 1}},
+					)),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+
+				// Fake widget border, its last line is the middle line of the
+				// container.
+				wCvs := testcanvas.MustNew(image.Rect(1, 6, 21, 11))
+				fakewidget.MustDraw(
+					ft,
+					wCvs,
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+
 				testcanvas.MustCopyTo(wCvs, cvs)
 				testcanvas.MustApply(cvs, ft)
 				return ft
@@ -1183,3 +1467,27 @@ func TestDrawHandlesTerminalResize(t *testing.T) {
 		})
 	}
 }
+
+// zeroSizeTerm is a terminal that reports zero size, e.g. while the terminal
+// window is minimized.
+type zeroSizeTerm struct {
+	*faketerm.Terminal
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (zeroSizeTerm) Size() image.Point {
+	return image.ZP
+}
+
+func TestDrawMinSizeMessageOnZeroSizeTerminal(t *testing.T) {
+	c, err := New(
+		zeroSizeTerm{faketerm.MustNew(image.Point{1, 1})},
+		MinimumSize(image.Point{10, 4}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := c.Draw(); err != nil {
+		t.Errorf("Draw => unexpected error: %v", err)
+	}
+}
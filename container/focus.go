@@ -83,7 +83,37 @@ func (ft *focusTracker) setActive(c *Container) {
 	ft.container = c
 }
 
-// next moves focus to the next container.
+// keyFocusable asserts whether the container can receive the keyboard focus
+// when KeyFocusNext or KeyFocusPrevious is pressed. Containers without a
+// widget are skipped unless they opted in with KeyFocusEmpty.
+func (c *Container) keyFocusable() bool {
+	return !c.opts.keyFocusSkip && (c.hasWidget() || c.opts.keyFocusEmpty)
+}
+
+// focusScope returns the root of the focus scope the container belongs to.
+// This is the closest container configured with FocusScope, starting with the
+// container itself, or the root container if there is none.
+func focusScope(c *Container) *Container {
+	for cur := c; cur != nil; cur = cur.parent {
+		if cur.opts.focusScope {
+			return cur
+		}
+	}
+	return rootCont(c)
+}
+
+// within asserts whether the container is in the subtree of the node.
+func within(c, node *Container) bool {
+	for cur := c; cur != nil; cur = cur.parent {
+		if cur == node {
+			return true
+		}
+	}
+	return false
+}
+
+// next moves focus to the next container within the focus scope of the
+// currently focused container.
 // If group is not nil, focus will only move between containers with a matching
 // focus group number.
 func (ft *focusTracker) next(group *FocusGroup) {
@@ -93,7 +123,7 @@ func (ft *focusTracker) next(group *FocusGroup) {
 		nextCont  *Container
 		focusNext bool
 	)
-	preOrder(rootCont(ft.container), &errStr, visitFunc(func(c *Container) error {
+	preOrder(focusScope(ft.container), &errStr, visitFunc(func(c *Container) error {
 		if nextCont != nil {
 			// Already found the next container, nothing to do.
 			return nil
@@ -103,7 +133,7 @@ func (ft *focusTracker) next(group *FocusGroup) {
 			// Remember the first eligible container in case we "wrap" over,
 			// i.e. finish the iteration before finding the next container.
 			switch {
-			case group == nil && !c.opts.keyFocusSkip:
+			case group == nil && c.keyFocusable():
 				fallthrough
 			case group != nil && c.inFocusGroup(*group):
 				firstCont = c
@@ -119,7 +149,7 @@ func (ft *focusTracker) next(group *FocusGroup) {
 
 		if focusNext && c.isLeaf() {
 			switch {
-			case group == nil && !c.opts.keyFocusSkip:
+			case group == nil && c.keyFocusable():
 				fallthrough
 			case group != nil && c.inFocusGroup(*group):
 				nextCont = c
@@ -137,7 +167,8 @@ func (ft *focusTracker) next(group *FocusGroup) {
 	}
 }
 
-// previous moves focus to the previous container.
+// previous moves focus to the previous container within the focus scope of
+// the currently focused container.
 // If group is not nil, focus will only move between containers with a matching
 // focus group number.
 func (ft *focusTracker) previous(group *FocusGroup) {
@@ -147,14 +178,14 @@ func (ft *focusTracker) previous(group *FocusGroup) {
 		lastCont    *Container
 		visitedCurr bool
 	)
-	preOrder(rootCont(ft.container), &errStr, visitFunc(func(c *Container) error {
+	preOrder(focusScope(ft.container), &errStr, visitFunc(func(c *Container) error {
 		if ft.container == c {
 			visitedCurr = true
 		}
 
 		if c.isLeaf() {
 			switch {
-			case group == nil && !c.opts.keyFocusSkip:
+			case group == nil && c.keyFocusable():
 				fallthrough
 			case group != nil && c.inFocusGroup(*group):
 				if !visitedCurr {
@@ -175,6 +206,45 @@ func (ft *focusTracker) previous(group *FocusGroup) {
 	}
 }
 
+// escapeScope moves focus out of the focus scope of the currently focused
+// container to the next container in the enclosing focus scope. Does nothing
+// if the focused container isn't within a focus scope.
+func (ft *focusTracker) escapeScope() {
+	scope := focusScope(ft.container)
+	if scope.parent == nil {
+		return
+	}
+
+	var (
+		errStr     string
+		firstCont  *Container
+		nextCont   *Container
+		afterScope bool
+	)
+	preOrder(focusScope(scope.parent), &errStr, visitFunc(func(c *Container) error {
+		if c == scope {
+			afterScope = true
+		}
+		if within(c, scope) || !c.isLeaf() || !c.keyFocusable() {
+			return nil
+		}
+
+		if firstCont == nil {
+			firstCont = c
+		}
+		if afterScope && nextCont == nil {
+			nextCont = c
+		}
+		return nil
+	}))
+
+	if nextCont != nil {
+		ft.setActive(nextCont)
+	} else if firstCont != nil {
+		ft.setActive(firstCont)
+	}
+}
+
 // mouse identifies mouse events that change the focused container and track
 // the focused container in the tree.
 // The argument c is the container onto which the mouse event landed.
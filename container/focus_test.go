@@ -28,7 +28,10 @@ import (
 	"github.com/mum4k/termdash/private/event"
 	"github.com/mum4k/termdash/private/event/testevent"
 	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
 	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/textinput"
 )
 
 // pointCase is a test case for the pointCont function.
@@ -533,9 +536,13 @@ func TestFocusTrackerNextAndPrevious(t *testing.T) {
 	)
 
 	tests := []struct {
-		desc          string
-		contSize      contSize
-		container     func(ft *faketerm.Terminal) (*Container, error)
+		desc      string
+		contSize  contSize
+		container func(ft *faketerm.Terminal) (*Container, error)
+		// skipEmpty when true, containers without widgets keep the default
+		// behavior and are skipped by the keyboard focus. Otherwise they are
+		// opted into the keyboard focus.
+		skipEmpty     bool
 		events        []*terminalapi.Keyboard
 		wantFocused   contLoc
 		wantProcessed int
@@ -866,6 +873,48 @@ func TestFocusTrackerNextAndPrevious(t *testing.T) {
 			wantFocused:   contLocB,
 			wantProcessed: 2,
 		},
+		{
+			desc: "hidden container is skipped on key based focus changes, using next",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Hidden(true),
+						),
+						Right(),
+					),
+					KeyFocusNext(keyNext),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyNext},
+			},
+			wantFocused:   contLocC,
+			wantProcessed: 2,
+		},
+		{
+			desc: "collapsed container is skipped on key based focus changes, using previous",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(
+							Collapsed(true),
+						),
+					),
+					KeyFocusPrevious(keyPrevious),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyPrevious},
+				{Key: keyPrevious},
+			},
+			wantFocused:   contLocB,
+			wantProcessed: 2,
+		},
 		{
 			desc: "all containers request to be skipped on key based focus changes, using next",
 			container: func(ft *faketerm.Terminal) (*Container, error) {
@@ -1446,6 +1495,257 @@ func TestFocusTrackerNextAndPrevious(t *testing.T) {
 			wantFocused:   contLocC,
 			wantProcessed: 2,
 		},
+		{
+			desc:     "keyNext skips a container without a widget",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							SplitVertical(
+								Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+								Right(),
+							),
+						),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+					KeyFocusNext(keyNext),
+				)
+			},
+			skipEmpty: true,
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext}, // focuses contLocD
+				{Key: keyNext}, // skips contLocE, focuses contLocC
+			},
+			wantFocused:   contLocC,
+			wantProcessed: 2,
+		},
+		{
+			desc:     "keyPrevious skips a container without a widget",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							SplitVertical(
+								Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+								Right(),
+							),
+						),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+					KeyFocusPrevious(keyPrevious),
+				)
+			},
+			skipEmpty: true,
+			events: []*terminalapi.Keyboard{
+				{Key: keyPrevious}, // focuses contLocC
+				{Key: keyPrevious}, // skips contLocE, focuses contLocD
+			},
+			wantFocused:   contLocD,
+			wantProcessed: 2,
+		},
+		{
+			desc:     "container without a widget that opted in receives the focus",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							SplitVertical(
+								Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+								Right(KeyFocusEmpty()),
+							),
+						),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+					KeyFocusNext(keyNext),
+				)
+			},
+			skipEmpty: true,
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext}, // focuses contLocD
+				{Key: keyNext}, // focuses contLocE
+			},
+			wantFocused:   contLocE,
+			wantProcessed: 2,
+		},
+		{
+			desc:     "next wraps within the focus scope",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New( // contLocA
+					ft,
+					SplitVertical(
+						Left( // contLocB
+							FocusScope(),
+							SplitVertical(
+								Left(),  // contLocD
+								Right(), // contLocE
+							),
+						),
+						Right(), // contLocC
+					),
+					KeyFocusNext(keyNext),
+					KeyFocusPrevious(keyPrevious),
+					KeyFocusEscapeScope(keyboard.KeyEsc),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext}, // focuses contLocD
+				{Key: keyNext}, // focuses contLocE
+				{Key: keyNext}, // wraps to contLocD
+			},
+			wantFocused:   contLocD,
+			wantProcessed: 3,
+		},
+		{
+			desc:     "previous wraps within the focus scope",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New( // contLocA
+					ft,
+					SplitVertical(
+						Left( // contLocB
+							FocusScope(),
+							SplitVertical(
+								Left(),  // contLocD
+								Right(), // contLocE
+							),
+						),
+						Right(), // contLocC
+					),
+					KeyFocusNext(keyNext),
+					KeyFocusPrevious(keyPrevious),
+					KeyFocusEscapeScope(keyboard.KeyEsc),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},     // focuses contLocD
+				{Key: keyPrevious}, // wraps to contLocE
+			},
+			wantFocused:   contLocE,
+			wantProcessed: 2,
+		},
+		{
+			desc:     "escape key moves the focus out of the focus scope",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New( // contLocA
+					ft,
+					SplitVertical(
+						Left( // contLocB
+							FocusScope(),
+							SplitVertical(
+								Left(),  // contLocD
+								Right(), // contLocE
+							),
+						),
+						Right(), // contLocC
+					),
+					KeyFocusNext(keyNext),
+					KeyFocusPrevious(keyPrevious),
+					KeyFocusEscapeScope(keyboard.KeyEsc),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},         // focuses contLocD
+				{Key: keyboard.KeyEsc}, // focuses contLocC
+			},
+			wantFocused:   contLocC,
+			wantProcessed: 2,
+		},
+		{
+			desc:     "next enters the focus scope from the outside",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New( // contLocA
+					ft,
+					SplitVertical(
+						Left( // contLocB
+							FocusScope(),
+							SplitVertical(
+								Left(),  // contLocD
+								Right(), // contLocE
+							),
+						),
+						Right(), // contLocC
+					),
+					KeyFocusNext(keyNext),
+					KeyFocusPrevious(keyPrevious),
+					KeyFocusEscapeScope(keyboard.KeyEsc),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},         // focuses contLocD
+				{Key: keyboard.KeyEsc}, // focuses contLocC
+				{Key: keyNext},         // wraps to contLocD
+			},
+			wantFocused:   contLocD,
+			wantProcessed: 3,
+		},
+		{
+			desc:     "escape key wraps around in the enclosing focus scope",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New( // contLocA
+					ft,
+					SplitVertical(
+						Left( // contLocB
+							SplitVertical(
+								Left(),  // contLocD
+								Right(), // contLocE
+							),
+						),
+						Right( // contLocC
+							FocusScope(),
+						),
+					),
+					KeyFocusNext(keyNext),
+					KeyFocusPrevious(keyPrevious),
+					KeyFocusEscapeScope(keyboard.KeyEsc),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},         // focuses contLocD
+				{Key: keyNext},         // focuses contLocE
+				{Key: keyNext},         // focuses contLocC
+				{Key: keyNext},         // stays on contLocC
+				{Key: keyboard.KeyEsc}, // wraps to contLocD
+			},
+			wantFocused:   contLocD,
+			wantProcessed: 5,
+		},
+		{
+			desc:     "escape key does nothing outside of a focus scope",
+			contSize: contSize5,
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New( // contLocA
+					ft,
+					SplitVertical(
+						Left( // contLocB
+							SplitVertical(
+								Left(),  // contLocD
+								Right(), // contLocE
+							),
+						),
+						Right(), // contLocC
+					),
+					KeyFocusNext(keyNext),
+					KeyFocusPrevious(keyPrevious),
+					KeyFocusEscapeScope(keyboard.KeyEsc),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},         // focuses contLocD
+				{Key: keyboard.KeyEsc}, // stays on contLocD
+			},
+			wantFocused:   contLocD,
+			wantProcessed: 2,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1454,6 +1754,14 @@ func TestFocusTrackerNextAndPrevious(t *testing.T) {
 			if err != nil {
 				t.Fatalf("tc.container => unexpected error: %v", err)
 			}
+			if !tc.skipEmpty {
+				// Most of these tests don't place any widgets.
+				var errStr string
+				preOrder(root, &errStr, visitFunc(func(c *Container) error {
+					c.opts.keyFocusEmpty = true
+					return nil
+				}))
+			}
 
 			eds := event.NewDistributionSystem()
 			root.Subscribe(eds)
@@ -1516,3 +1824,329 @@ func TestFocusTrackerNextAndPrevious(t *testing.T) {
 		})
 	}
 }
+
+func TestSetFocusByID(t *testing.T) {
+	tests := []struct {
+		desc        string
+		opts        []Option
+		id          string
+		wantFocused string
+		wantErr     bool
+	}{
+		{
+			desc: "fails on empty ID",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right"))),
+			},
+			id:      "",
+			wantErr: true,
+		},
+		{
+			desc: "fails on unknown ID",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right"))),
+			},
+			id:      "unknown",
+			wantErr: true,
+		},
+		{
+			desc: "fails on container in a tab that isn't active",
+			opts: []Option{
+				SplitTabs(
+					Tab("a", ID("first")),
+					Tab("b", ID("second")),
+				),
+			},
+			id:      "second",
+			wantErr: true,
+		},
+		{
+			desc: "fails on a hidden container",
+			opts: []Option{
+				SplitVertical(Left(ID("left"), Hidden(true)), Right(ID("right"))),
+			},
+			id:      "left",
+			wantErr: true,
+		},
+		{
+			desc: "fails on a sub container of a collapsed container",
+			opts: []Option{
+				SplitVertical(
+					Left(
+						Collapsed(true),
+						SplitHorizontal(Top(ID("top")), Bottom(ID("bottom"))),
+					),
+					Right(ID("right")),
+				),
+			},
+			id:      "bottom",
+			wantErr: true,
+		},
+		{
+			desc: "focuses a leaf container",
+			opts: []Option{
+				SplitVertical(Left(ID("left")), Right(ID("right"))),
+			},
+			id:          "right",
+			wantFocused: "right",
+		},
+		{
+			desc: "focuses the root container",
+			opts: []Option{
+				ID("root"),
+				SplitVertical(Left(ID("left")), Right(ID("right"))),
+			},
+			id:          "root",
+			wantFocused: "root",
+		},
+		{
+			desc: "focuses a container in the active tab",
+			opts: []Option{
+				SplitTabs(
+					Tab("a", ID("first")),
+					Tab("b", ID("second")),
+				),
+			},
+			id:          "first",
+			wantFocused: "first",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{20, 20})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			cont, err := New(ft, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			err = cont.SetFocusByID(tc.id)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SetFocusByID => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := cont.FocusedID(); got != tc.wantFocused {
+				t.Errorf("FocusedID => %q, want %q", got, tc.wantFocused)
+			}
+		})
+	}
+}
+
+func TestFocusByIDAcrossRebuild(t *testing.T) {
+	ft, err := faketerm.New(image.Point{20, 20})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	layout := func() Option {
+		return SplitVertical(
+			Left(ID("left")),
+			Right(
+				SplitHorizontal(Top(ID("top")), Bottom(ID("bottom"))),
+			),
+		)
+	}
+	cont, err := New(ft, ID("root"), layout())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if got, want := cont.FocusedID(), "root"; got != want {
+		t.Errorf("FocusedID => %q, want %q", got, want)
+	}
+	if err := cont.SetFocusByID("bottom"); err != nil {
+		t.Fatalf("SetFocusByID => unexpected error: %v", err)
+	}
+	saved := cont.FocusedID()
+
+	// Rebuilding the layout replaces the focused container, the focus moves
+	// up to the updated one.
+	if err := cont.Update("root", layout()); err != nil {
+		t.Fatalf("Update => unexpected error: %v", err)
+	}
+	if got, want := cont.FocusedID(), "root"; got != want {
+		t.Errorf("after Update FocusedID => %q, want %q", got, want)
+	}
+
+	if err := cont.SetFocusByID(saved); err != nil {
+		t.Fatalf("SetFocusByID => unexpected error: %v", err)
+	}
+	if got, want := cont.FocusedID(), "bottom"; got != want {
+		t.Errorf("after restore FocusedID => %q, want %q", got, want)
+	}
+	if !cont.focusTracker.isActive(cont.second.second) {
+		t.Errorf("isActive(bottom) => false, the new bottom container should be focused")
+	}
+}
+
+func TestFocusLeavesHiddenContainer(t *testing.T) {
+	tests := []struct {
+		desc        string
+		opts        []Option
+		updateID    string
+		updateOpts  []Option
+		wantFocused string
+	}{
+		{
+			desc: "focus moves to the parent of the hidden container",
+			opts: []Option{
+				ID("root"),
+				SplitVertical(Left(ID("left"), Focused()), Right(ID("right"))),
+			},
+			updateID:    "left",
+			updateOpts:  []Option{Hidden(true)},
+			wantFocused: "root",
+		},
+		{
+			desc: "focus moves to the parent of the collapsed ancestor",
+			opts: []Option{
+				ID("root"),
+				SplitVertical(
+					Left(
+						ID("left"),
+						SplitHorizontal(Top(ID("top"), Focused()), Bottom(ID("bottom"))),
+					),
+					Right(ID("right")),
+				),
+			},
+			updateID:    "left",
+			updateOpts:  []Option{Collapsed(true)},
+			wantFocused: "root",
+		},
+		{
+			desc: "focus stays when another container is hidden",
+			opts: []Option{
+				ID("root"),
+				SplitVertical(Left(ID("left"), Focused()), Right(ID("right"))),
+			},
+			updateID:    "right",
+			updateOpts:  []Option{Hidden(true)},
+			wantFocused: "left",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{20, 20})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			cont, err := New(ft, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			if err := cont.Update(tc.updateID, tc.updateOpts...); err != nil {
+				t.Fatalf("Update => unexpected error: %v", err)
+			}
+			if got := cont.FocusedID(); got != tc.wantFocused {
+				t.Errorf("FocusedID => %q, want %q", got, tc.wantFocused)
+			}
+		})
+	}
+}
+
+func TestCaptureKeysOnFocus(t *testing.T) {
+	// keys returns keyboard events for the keys.
+	keys := func(ks ...keyboard.Key) []terminalapi.Event {
+		var evs []terminalapi.Event
+		for _, k := range ks {
+			evs = append(evs, &terminalapi.Keyboard{Key: k})
+		}
+		return evs
+	}
+
+	tests := []struct {
+		desc      string
+		leftOpts  []textinput.Option
+		focus     string
+		events    []terminalapi.Event
+		wantLeft  string
+		wantRight string
+		wantFocus string
+	}{
+		{
+			desc: "captured arrow keys move the cursor instead of the focus",
+			leftOpts: []textinput.Option{
+				textinput.CaptureKeysOnFocus(keyboard.KeyArrowLeft, keyboard.KeyArrowRight),
+			},
+			focus:     "left",
+			events:    keys('a', 'b', keyboard.KeyArrowLeft, 'c', keyboard.KeyArrowRight, 'd'),
+			wantLeft:  "acbd",
+			wantFocus: "left",
+		},
+		{
+			desc:      "arrow keys move the focus when not captured",
+			focus:     "left",
+			events:    keys('a', keyboard.KeyArrowRight, 'b'),
+			wantLeft:  "a",
+			wantRight: "b",
+			wantFocus: "right",
+		},
+		{
+			desc: "keys are only captured while the capturing widget is focused",
+			leftOpts: []textinput.Option{
+				textinput.CaptureKeysOnFocus(keyboard.KeyArrowLeft, keyboard.KeyArrowRight),
+			},
+			focus:     "right",
+			events:    keys('a', keyboard.KeyArrowLeft, 'b', keyboard.KeyArrowRight),
+			wantLeft:  "b",
+			wantRight: "a",
+			wantFocus: "left",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{40, 10})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			left, err := textinput.New(tc.leftOpts...)
+			if err != nil {
+				t.Fatalf("textinput.New => unexpected error: %v", err)
+			}
+			right, err := textinput.New()
+			if err != nil {
+				t.Fatalf("textinput.New => unexpected error: %v", err)
+			}
+
+			cont, err := New(
+				ft,
+				SplitVertical(
+					Left(ID("left"), PlaceWidget(left)),
+					Right(ID("right"), PlaceWidget(right)),
+				),
+				KeyFocusNext(keyboard.KeyArrowRight),
+				KeyFocusPrevious(keyboard.KeyArrowLeft),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.SetFocusByID(tc.focus); err != nil {
+				t.Fatalf("SetFocusByID => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				if err := cont.processEvent(ev); err != nil {
+					t.Fatalf("processEvent(%v) => unexpected error: %v", ev, err)
+				}
+			}
+
+			if got := left.Read(); got != tc.wantLeft {
+				t.Errorf("left.Read => %q, want %q", got, tc.wantLeft)
+			}
+			if got := right.Read(); got != tc.wantRight {
+				t.Errorf("right.Read => %q, want %q", got, tc.wantRight)
+			}
+			if got := cont.FocusedID(); got != tc.wantFocus {
+				t.Errorf("FocusedID => %q, want %q", got, tc.wantFocus)
+			}
+		})
+	}
+}
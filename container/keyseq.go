@@ -0,0 +1,104 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// keyseq.go contains code that tracks sequences of keys.
+
+import (
+	"time"
+
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// keySequence is a sequence of keys that calls a function when completed.
+type keySequence struct {
+	// keys are the keys in the sequence.
+	keys []keyboard.Key
+	// fn is called when the sequence is completed.
+	fn func() error
+}
+
+// matches asserts whether the keys equal the sequence or are its prefix.
+func (ks *keySequence) matches(keys []keyboard.Key) (complete, prefix bool) {
+	if len(keys) > len(ks.keys) {
+		return false, false
+	}
+	for i, k := range keys {
+		if ks.keys[i] != k {
+			return false, false
+		}
+	}
+	return len(keys) == len(ks.keys), true
+}
+
+// keySeqTracker tracks the keys pressed as part of a key sequence.
+// This is not thread-safe, the implementation assumes that the owner of
+// keySeqTracker performs locking.
+type keySeqTracker struct {
+	// pressed are the keys pressed so far that form a prefix of at least one
+	// of the sequences.
+	pressed []keyboard.Key
+	// last is the time when the last key was pressed.
+	last time.Time
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+}
+
+// newKeySeqTracker returns a new keySeqTracker.
+func newKeySeqTracker() *keySeqTracker {
+	return &keySeqTracker{
+		now: time.Now,
+	}
+}
+
+// match returns the function of the sequence the pressed keys complete or
+// nil if they don't complete any. The bool return value indicates if the
+// pressed keys are a prefix of any of the sequences.
+func (kst *keySeqTracker) match(seqs []*keySequence) (func() error, bool) {
+	var isPrefix bool
+	for _, seq := range seqs {
+		complete, prefix := seq.matches(kst.pressed)
+		if complete {
+			return seq.fn, true
+		}
+		isPrefix = isPrefix || prefix
+	}
+	return nil, isPrefix
+}
+
+// key processes a pressed key. Returns the function of the sequence completed
+// by this key or nil if no sequence was completed.
+// The pressed keys are forgotten if the key arrives after the timeout since
+// the previous key or if the key doesn't continue any of the sequences.
+func (kst *keySeqTracker) key(k keyboard.Key, seqs []*keySequence, timeout time.Duration) func() error {
+	now := kst.now()
+	if len(kst.pressed) > 0 && now.Sub(kst.last) > timeout {
+		kst.pressed = nil
+	}
+	kst.last = now
+
+	kst.pressed = append(kst.pressed, k)
+	fn, prefix := kst.match(seqs)
+	if !prefix && len(kst.pressed) > 1 {
+		// The key interrupted a sequence, but it might start a new one.
+		kst.pressed = []keyboard.Key{k}
+		fn, prefix = kst.match(seqs)
+	}
+
+	if fn != nil || !prefix {
+		kst.pressed = nil
+	}
+	return fn
+}
@@ -0,0 +1,201 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// keyAt is a key pressed at the specified offset from the start of the test.
+type keyAt struct {
+	key keyboard.Key
+	at  time.Duration
+}
+
+func TestKeySequence(t *testing.T) {
+	tests := []struct {
+		desc string
+		// opts are the options in addition to the registered sequences.
+		opts []Option
+		keys []keyAt
+		// want are the names of the sequences that were called in order.
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "completed sequence calls the function",
+			keys: []keyAt{
+				{key: 'g', at: 0},
+				{key: 'g', at: 100 * time.Millisecond},
+			},
+			want: []string{"gg"},
+		},
+		{
+			desc: "single key sequence",
+			keys: []keyAt{
+				{key: keyboard.KeyCtrlS, at: 0},
+			},
+			want: []string{"ctrl+s"},
+		},
+		{
+			desc: "sequence can be repeated",
+			keys: []keyAt{
+				{key: 'g', at: 0},
+				{key: 'g', at: 100 * time.Millisecond},
+				{key: 'g', at: 200 * time.Millisecond},
+				{key: 'g', at: 300 * time.Millisecond},
+			},
+			want: []string{"gg", "gg"},
+		},
+		{
+			desc: "longer sequence",
+			keys: []keyAt{
+				{key: 'd', at: 0},
+				{key: 'i', at: 100 * time.Millisecond},
+				{key: 'w', at: 200 * time.Millisecond},
+			},
+			want: []string{"diw"},
+		},
+		{
+			desc: "timed-out partial sequence is forgotten",
+			keys: []keyAt{
+				{key: 'g', at: 0},
+				{key: 'g', at: 2 * time.Second},
+			},
+		},
+		{
+			desc: "timed-out partial sequence can start again",
+			keys: []keyAt{
+				{key: 'g', at: 0},
+				{key: 'g', at: 2 * time.Second},
+				{key: 'g', at: 2*time.Second + 100*time.Millisecond},
+			},
+			want: []string{"gg"},
+		},
+		{
+			desc: "respects custom timeout",
+			opts: []Option{
+				KeySequenceTimeout(50 * time.Millisecond),
+			},
+			keys: []keyAt{
+				{key: 'g', at: 0},
+				{key: 'g', at: 100 * time.Millisecond},
+			},
+		},
+		{
+			desc: "interrupting unrelated key forgets the partial sequence",
+			keys: []keyAt{
+				{key: 'g', at: 0},
+				{key: 'x', at: 100 * time.Millisecond},
+				{key: 'g', at: 200 * time.Millisecond},
+			},
+		},
+		{
+			desc: "interrupting key can start another sequence",
+			keys: []keyAt{
+				{key: 'g', at: 0},
+				{key: 'd', at: 100 * time.Millisecond},
+				{key: 'i', at: 200 * time.Millisecond},
+				{key: 'w', at: 300 * time.Millisecond},
+			},
+			want: []string{"diw"},
+		},
+		{
+			desc: "function error is returned",
+			keys: []keyAt{
+				{key: 'e', at: 0},
+			},
+			want:    []string{"e"},
+			wantErr: true,
+		},
+		{
+			desc: "fails on empty sequence",
+			opts: []Option{
+				KeySequence(nil, func() error { return nil }),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on nil function",
+			opts: []Option{
+				KeySequence([]keyboard.Key{'a'}, nil),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on invalid timeout",
+			opts: []Option{
+				KeySequenceTimeout(0),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []string
+			called := func(name string, err error) func() error {
+				return func() error {
+					got = append(got, name)
+					return err
+				}
+			}
+
+			ft, err := faketerm.New(image.Point{10, 10})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			opts := []Option{
+				KeySequence([]keyboard.Key{'g', 'g'}, called("gg", nil)),
+				KeySequence([]keyboard.Key{'d', 'i', 'w'}, called("diw", nil)),
+				KeySequence([]keyboard.Key{keyboard.KeyCtrlS}, called("ctrl+s", nil)),
+				KeySequence([]keyboard.Key{'e'}, called("e", errors.New("error"))),
+			}
+			cont, err := New(ft, append(opts, tc.opts...)...)
+			if err != nil {
+				if !tc.wantErr {
+					t.Errorf("New => unexpected error: %v", err)
+				}
+				return
+			}
+
+			start := time.Now()
+			var now time.Time
+			cont.keySeqTracker.now = func() time.Time { return now }
+
+			var gotErr error
+			for _, k := range tc.keys {
+				now = start.Add(k.at)
+				if err := cont.processEvent(&terminalapi.Keyboard{Key: k.key}); err != nil {
+					gotErr = err
+				}
+			}
+			if (gotErr != nil) != tc.wantErr {
+				t.Errorf("processEvent => unexpected error: %v, wantErr: %v", gotErr, tc.wantErr)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("called sequences => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// layout.go contains code that reports the layout computed when the container
+// tree was last drawn.
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// Rect returns the area of the terminal assigned to the container with the
+// specified id when the container tree was last drawn. The area includes the
+// border of the container, but not its margin.
+// The argument id must match exactly one container that was created with
+// matching ID() option. Returns an error if the container wasn't drawn by the
+// last call to Draw, e.g. if Draw wasn't called yet, the container is hidden
+// or it was added by Update after the last call to Draw.
+// This is intended for testing and debugging of layouts.
+func (c *Container) Rect(id string) (image.Rectangle, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findID(c, id)
+	if err != nil {
+		return image.ZR, err
+	}
+	if !isDrawn(target) {
+		return image.ZR, fmt.Errorf("container with ID %q wasn't drawn by the last call to Draw", id)
+	}
+	return target.area, nil
+}
+
+// Layout returns a human readable dump of the areas assigned to the visible
+// containers when the container tree was last drawn. Each container is on a
+// separate line, sub containers are indented under their parent container.
+// Returns an error if Draw wasn't called yet.
+// This is intended for testing and debugging of layouts.
+func (c *Container) Layout() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	root := rootCont(c)
+	if root.drawn == 0 {
+		return "", errors.New("the container wasn't drawn yet, call Draw first")
+	}
+
+	var b strings.Builder
+	writeLayout(&b, root, 0)
+	return b.String(), nil
+}
+
+// isDrawn determines if the area of the container was computed by the last
+// call to Draw.
+func isDrawn(c *Container) bool {
+	root := rootCont(c)
+	return root.drawn > 0 && c.drawn == root.drawn
+}
+
+// writeLayout writes one line for the container and each of its visible sub
+// containers that were drawn.
+func writeLayout(b *strings.Builder, c *Container, depth int) {
+	if c == nil || c.isHidden() || !isDrawn(c) {
+		return
+	}
+
+	id := c.opts.id
+	if id == "" {
+		id = "<no ID>"
+	}
+	fmt.Fprintf(b, "%s%s %v\n", strings.Repeat("  ", depth), id, c.area)
+	writeLayout(b, c.first, depth+1)
+	writeLayout(b, c.second, depth+1)
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/private/faketerm"
+)
+
+// knownLayout returns options of a layout with a known set of areas in a
+// terminal of 20x10 cells.
+func knownLayout() []Option {
+	return []Option{
+		ID("root"),
+		SplitVertical(
+			Left(
+				ID("left"),
+				Border(linestyle.Light),
+			),
+			Right(
+				ID("right"),
+				SplitHorizontal(
+					Top(ID("top")),
+					Bottom(
+						ID("bottom"),
+						MarginTop(1),
+					),
+					SplitPercent(30),
+				),
+			),
+		),
+	}
+}
+
+func TestRect(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		// draw indicates whether Draw is called before Rect.
+		draw bool
+		// update is applied with Update after Draw when not nil.
+		update  func(*Container) error
+		id      string
+		want    image.Rectangle
+		wantErr bool
+	}{
+		{
+			desc:    "fails before the first draw",
+			opts:    knownLayout(),
+			id:      "left",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on unknown ID",
+			opts:    knownLayout(),
+			draw:    true,
+			id:      "unknown",
+			wantErr: true,
+		},
+		{
+			desc: "area of the root container",
+			opts: knownLayout(),
+			draw: true,
+			id:   "root",
+			want: image.Rect(0, 0, 20, 10),
+		},
+		{
+			desc: "area of a container with a border",
+			opts: knownLayout(),
+			draw: true,
+			id:   "left",
+			want: image.Rect(0, 0, 10, 10),
+		},
+		{
+			desc: "area of a container that is split",
+			opts: knownLayout(),
+			draw: true,
+			id:   "right",
+			want: image.Rect(10, 0, 20, 10),
+		},
+		{
+			desc: "area of a leaf container",
+			opts: knownLayout(),
+			draw: true,
+			id:   "top",
+			want: image.Rect(10, 0, 20, 3),
+		},
+		{
+			desc: "area excludes the margin",
+			opts: knownLayout(),
+			draw: true,
+			id:   "bottom",
+			want: image.Rect(10, 4, 20, 10),
+		},
+		{
+			desc: "fails on a hidden container",
+			opts: []Option{
+				SplitVertical(
+					Left(ID("left"), Hidden(true)),
+					Right(ID("right")),
+				),
+			},
+			draw:    true,
+			id:      "left",
+			wantErr: true,
+		},
+		{
+			desc: "fails on a container in a tab that isn't active",
+			opts: []Option{
+				SplitTabs(
+					Tab("a", ID("first")),
+					Tab("b", ID("second")),
+				),
+			},
+			draw:    true,
+			id:      "second",
+			wantErr: true,
+		},
+		{
+			desc: "fails on a container added by Update after the draw",
+			opts: knownLayout(),
+			draw: true,
+			update: func(c *Container) error {
+				return c.Update("left", SplitHorizontal(Top(ID("new")), Bottom()))
+			},
+			id:      "new",
+			wantErr: true,
+		},
+		{
+			desc: "fails on a container hidden by Update after the draw",
+			opts: knownLayout(),
+			draw: true,
+			update: func(c *Container) error {
+				if err := c.Update("left", Hidden(true)); err != nil {
+					return err
+				}
+				return c.Draw()
+			},
+			id:      "left",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{20, 10})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			cont, err := New(ft, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.draw {
+				if err := cont.Draw(); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+			if tc.update != nil {
+				if err := tc.update(cont); err != nil {
+					t.Fatalf("update => unexpected error: %v", err)
+				}
+			}
+
+			got, err := cont.Rect(tc.id)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Rect => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want {
+				t.Errorf("Rect => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		draw    bool
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:    "fails before the first draw",
+			opts:    knownLayout(),
+			wantErr: true,
+		},
+		{
+			desc: "dumps the layout",
+			opts: knownLayout(),
+			draw: true,
+			want: "root (0,0)-(20,10)\n" +
+				"  left (0,0)-(10,10)\n" +
+				"  right (10,0)-(20,10)\n" +
+				"    top (10,0)-(20,3)\n" +
+				"    bottom (10,4)-(20,10)\n",
+		},
+		{
+			desc: "skips hidden containers and marks containers without ID",
+			opts: []Option{
+				SplitVertical(
+					Left(ID("left"), Hidden(true)),
+					Right(),
+				),
+			},
+			draw: true,
+			want: "<no ID> (0,0)-(20,10)\n" +
+				"  <no ID> (10,0)-(20,10)\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{20, 10})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			cont, err := New(ft, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.draw {
+				if err := cont.Draw(); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			got, err := cont.Layout()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Layout => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Layout => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
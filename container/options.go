@@ -20,12 +20,15 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"strings"
+	"time"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/private/area"
+	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -60,21 +63,46 @@ func validateSplits(c *Container) error {
 			c.opts.splitPercent,
 		)
 	}
+	if c.opts.splitWeightFirst > 0 && (c.opts.splitFixed > DefaultSplitFixed || c.opts.splitPercent != DefaultSplitPercent || c.opts.splitMinFirst > 0 || c.opts.splitMinSecond > 0) {
+		return fmt.Errorf(
+			"SplitWeights(%d, %d) cannot be combined with SplitFixed, SplitPercent or SplitPercentWithMin",
+			c.opts.splitWeightFirst,
+			c.opts.splitWeightSecond,
+		)
+	}
+	if c.opts.splitFixed > DefaultSplitFixed && (c.opts.splitMinFirst > 0 || c.opts.splitMinSecond > 0) {
+		return fmt.Errorf(
+			"splitFixed `%v` cannot be combined with the minimum sizes of SplitPercentWithMin",
+			c.opts.splitFixed,
+		)
+	}
 
 	return nil
 }
 
+// validateBorderTitle ensures that containers with a border title also have a
+// border, the title is drawn on top of it.
+func validateBorderTitle(c *Container) error {
+	if c.opts.borderTitle != "" && !c.hasBorder() {
+		return fmt.Errorf("the BorderTitle %q requires the container to have a Border", c.opts.borderTitle)
+	}
+	return nil
+}
+
 // validateOptions validates options set in the container tree.
 func validateOptions(c *Container) error {
 	var errStr string
 	seenID := map[string]bool{}
-	preOrder(c, &errStr, func(c *Container) error {
+	preOrderAll(c, &errStr, func(c *Container) error {
 		if err := validateIds(c, seenID); err != nil {
 			return err
 		}
 		if err := validateSplits(c); err != nil {
 			return err
 		}
+		if err := validateBorderTitle(c); err != nil {
+			return err
+		}
 
 		return nil
 	})
@@ -111,6 +139,25 @@ type options struct {
 	split        splitType
 	splitPercent int
 	splitFixed   int
+	// splitMinFirst and splitMinSecond are the minimum sizes in cells of the
+	// sub containers of a percentage based split.
+	splitMinFirst  int
+	splitMinSecond int
+	// splitWeightFirst and splitWeightSecond are the relative weights of the
+	// sub containers set by SplitWeights. Zero if the split isn't weighted.
+	splitWeightFirst  int
+	splitWeightSecond int
+	// splitEven is the number of sub containers created by SplitEvenVertical
+	// or SplitEvenHorizontal that share the area of this container. The
+	// first sub container gets its even share, the second one holds the rest.
+	// Zero if the split isn't even.
+	splitEven int
+	// dividerStyle is the style of the line drawn between the sub containers.
+	dividerStyle linestyle.LineStyle
+	// dividerColor is the color of the line drawn between the sub containers.
+	dividerColor cell.Color
+	// tabsAlign is the alignment of the labels on the tab strip.
+	tabsAlign align.Horizontal
 
 	// widget is the widget in the container.
 	// A container can have either two sub containers (left and right) or a
@@ -136,8 +183,29 @@ type options struct {
 	// keyFocusSkip asserts whether this container should be skipped when focus
 	// is being moved using either of KeyFocusNext or KeyFocusPrevious.
 	keyFocusSkip bool
+	// keyFocusEmpty asserts whether this container can receive the keyboard
+	// focus via KeyFocusNext or KeyFocusPrevious even without a widget.
+	keyFocusEmpty bool
 	// keyFocusGroups are the focus groups this container belongs to.
 	keyFocusGroups []FocusGroup
+	// focusScope indicates that the keyboard focus moves only between the
+	// containers in the subtree of this container.
+	focusScope bool
+
+	// hidden indicates that the container isn't drawn and doesn't receive
+	// any events, its space stays blank.
+	hidden bool
+	// collapsed is like hidden, but the space of the container is given to
+	// its sibling.
+	collapsed bool
+
+	// scrollable indicates that the widget draws on a virtual canvas that
+	// can be larger than the container and is scrolled.
+	scrollable bool
+
+	// shrinkToFit indicates that the container is sized to the preferred
+	// size of its widget.
+	shrinkToFit bool
 }
 
 // margin stores the configured margin for the container.
@@ -194,10 +262,48 @@ type inherited struct {
 	borderColor cell.Color
 	// focusedColor is the color used for the border when focused.
 	focusedColor cell.Color
+	// focusedColorSet indicates if the focusedColor was set by the
+	// FocusedColor option.
+	focusedColorSet bool
 	// titleColor is the color used for the title.
 	titleColor *cell.Color
 	// titleFocusedColor is the color used for the title when focused.
 	titleFocusedColor *cell.Color
+	// theme if set provides colors for options that weren't set.
+	theme *cell.Theme
+	// background if set is the color the area of the container is filled
+	// with.
+	background *cell.Color
+}
+
+// borderColors returns the colors of the border when the container isn't and
+// is focused. Colors of the theme are used unless the BorderColor or the
+// FocusedColor option was set.
+func (i *inherited) borderColors() (border, focused cell.Color) {
+	border, focused = i.borderColor, i.focusedColor
+	if i.theme == nil {
+		return border, focused
+	}
+	if border == cell.ColorDefault {
+		border = i.theme.Border
+	}
+	if !i.focusedColorSet {
+		focused = i.theme.Accent
+	}
+	return border, focused
+}
+
+// backgroundColor returns the color the area of the container is filled with.
+// The Background color of the theme is used unless the Background option was
+// set. The bool return value is false if the area isn't filled.
+func (i *inherited) backgroundColor() (cell.Color, bool) {
+	if i.background != nil {
+		return *i.background, true
+	}
+	if i.theme != nil && i.theme.Background != cell.ColorDefault {
+		return i.theme.Background, true
+	}
+	return cell.ColorDefault, false
 }
 
 // focusGroups maps focus group numbers that have the same key assigned.
@@ -225,6 +331,9 @@ type globalOptions struct {
 	keyFocusNext *keyboard.Key
 	// keyFocusPrevious when set is the key that moves the focus to the previous container.
 	keyFocusPrevious *keyboard.Key
+	// keyFocusEscapeScope when set is the key that moves the focus out of
+	// the current focus scope.
+	keyFocusEscapeScope *keyboard.Key
 	// keysFocusGroupNext maps keyboard keys that move to the next container
 	// within a focus group to the focus groups they should work on in the
 	// order they were configured.
@@ -233,6 +342,26 @@ type globalOptions struct {
 	// container within a focus group to the focus groups they should work on
 	// in the order they were configured.
 	keyFocusGroupsPrevious map[keyboard.Key]focusGroups
+	// keySequences are the registered key sequences.
+	keySequences []*keySequence
+	// keySequenceTimeout is the maximum time between two keys of a key
+	// sequence.
+	keySequenceTimeout time.Duration
+	// keyboardHook when set sees all keyboard events before the container
+	// and the widgets, see KeyboardHook.
+	keyboardHook func(*terminalapi.Keyboard) bool
+	// keyTabNext when set is the key that activates the next tab.
+	keyTabNext *keyboard.Key
+	// keyTabPrevious when set is the key that activates the previous tab.
+	keyTabPrevious *keyboard.Key
+	// minSize is the minimum size of the terminal required to draw the
+	// container tree.
+	minSize image.Point
+	// minSizeMsg is the message displayed when the terminal is smaller than
+	// minSize.
+	minSizeMsg string
+	// minSizeMsgCellOpts are the cell options for minSizeMsg.
+	minSizeMsgCellOpts []cell.Option
 }
 
 // newOptions returns a new options instance with the default values.
@@ -243,6 +372,8 @@ func newOptions(parent *options) *options {
 		global: &globalOptions{
 			keyFocusGroupsNext:     map[keyboard.Key]focusGroups{},
 			keyFocusGroupsPrevious: map[keyboard.Key]focusGroups{},
+			keySequenceTimeout:     DefaultKeySequenceTimeout,
+			minSizeMsg:             DefaultMinimumSizeMessage,
 		},
 		inherited: inherited{
 			focusedColor: cell.ColorYellow,
@@ -287,6 +418,19 @@ const DefaultSplitPercent = 50
 // DefaultSplitFixed is the default value for the SplitFixed option.
 const DefaultSplitFixed = -1
 
+// resetSplitSize restores the default sizes of the sub containers, so that a
+// split option replaces any sizes set before, including those set when the
+// split was resized or animated.
+func (o *options) resetSplitSize() {
+	o.splitPercent = DefaultSplitPercent
+	o.splitFixed = DefaultSplitFixed
+	o.splitMinFirst = 0
+	o.splitMinSecond = 0
+	o.splitWeightFirst = 0
+	o.splitWeightSecond = 0
+	o.splitEven = 0
+}
+
 // SplitPercent sets the relative size of the split as percentage of the available space.
 // When using SplitVertical, the provided size is applied to the new left
 // container, the new right container gets the reminder of the size.
@@ -300,6 +444,86 @@ func SplitPercent(p int) SplitOption {
 			return fmt.Errorf("invalid split percentage %d, must be in range %d < p < %d", p, min, max)
 		}
 		opts.splitPercent = p
+		opts.splitMinFirst = 0
+		opts.splitMinSecond = 0
+		return nil
+	})
+}
+
+// SplitMinOption is used to provide minimum sizes to SplitPercentWithMin.
+type SplitMinOption interface {
+	// setMin sets the provided minimum size option.
+	setMin(*options) error
+}
+
+// splitMinOption implements SplitMinOption.
+type splitMinOption func(*options) error
+
+// setMin implements SplitMinOption.setMin.
+func (smo splitMinOption) setMin(opts *options) error {
+	return smo(opts)
+}
+
+// MinSize sets the minimum size in cells of both sub containers.
+// The provided value must be a zero or a positive integer.
+func MinSize(cells int) SplitMinOption {
+	return splitMinOption(func(opts *options) error {
+		if cells < 0 {
+			return fmt.Errorf("invalid MinSize(%d), must be in range %d <= cells", cells, 0)
+		}
+		opts.splitMinFirst = cells
+		opts.splitMinSecond = cells
+		return nil
+	})
+}
+
+// MinSizeFirst sets the minimum size in cells of the first sub container, i.e.
+// the left one when using SplitVertical and the top one when using
+// SplitHorizontal.
+// The provided value must be a zero or a positive integer.
+func MinSizeFirst(cells int) SplitMinOption {
+	return splitMinOption(func(opts *options) error {
+		if cells < 0 {
+			return fmt.Errorf("invalid MinSizeFirst(%d), must be in range %d <= cells", cells, 0)
+		}
+		opts.splitMinFirst = cells
+		return nil
+	})
+}
+
+// MinSizeSecond sets the minimum size in cells of the second sub container,
+// i.e. the right one when using SplitVertical and the bottom one when using
+// SplitHorizontal.
+// The provided value must be a zero or a positive integer.
+func MinSizeSecond(cells int) SplitMinOption {
+	return splitMinOption(func(opts *options) error {
+		if cells < 0 {
+			return fmt.Errorf("invalid MinSizeSecond(%d), must be in range %d <= cells", cells, 0)
+		}
+		opts.splitMinSecond = cells
+		return nil
+	})
+}
+
+// SplitPercentWithMin is like SplitPercent, but the sub containers don't
+// shrink below the provided minimum sizes in cells. The split honors the
+// percentage until one of the sub containers would become smaller than its
+// minimum, then that sub container is pinned to its minimum and the other one
+// gets the remaining space.
+// If the available space is smaller than the sum of both minimums, the space
+// is divided in proportion to the minimums.
+// The provided percentage must be in the range 0 < p < 100.
+// Cannot be combined with SplitFixed.
+func SplitPercentWithMin(p int, mins ...SplitMinOption) SplitOption {
+	return splitOption(func(opts *options) error {
+		if err := SplitPercent(p).setSplit(opts); err != nil {
+			return err
+		}
+		for _, m := range mins {
+			if err := m.setMin(opts); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
@@ -323,13 +547,53 @@ func SplitFixed(cells int) SplitOption {
 	})
 }
 
+// SplitWeights sets the sizes of the sub containers relative to each other,
+// the space is divided in proportion to the provided weights. E.g.
+// SplitWeights(2, 1) makes the first sub container twice as large as the
+// second one.
+// When the space can't be divided exactly, the first sub container gets its
+// share rounded to the nearest cell, with halves rounded up.
+// Both weights must be positive integers.
+// Cannot be combined with SplitFixed, SplitPercent or SplitPercentWithMin.
+func SplitWeights(first, second int) SplitOption {
+	return splitOption(func(opts *options) error {
+		if min := 1; first < min || second < min {
+			return fmt.Errorf("invalid SplitWeights(%d, %d), both weights must be in range %d <= weight", first, second, min)
+		}
+		opts.splitWeightFirst = first
+		opts.splitWeightSecond = second
+		return nil
+	})
+}
+
+// Divider draws a single line of the provided style and color on the seam
+// between the two sub containers instead of bordering each of them.
+// The line takes one column of a vertical split or one row of a horizontal
+// split from the second (right or bottom) sub container, or from the first
+// one if the second one has no space left.
+// Providing linestyle.None removes the divider.
+// The user can resize the split by dragging the divider with the left mouse
+// button, the same works with the adjacent borders of sub containers that
+// have a border.
+func Divider(ls linestyle.LineStyle, color cell.Color) SplitOption {
+	return splitOption(func(opts *options) error {
+		opts.dividerStyle = ls
+		opts.dividerColor = color
+		return nil
+	})
+}
+
 // SplitVertical splits the container along the vertical axis into two sub
 // containers. The use of this option removes any widget placed at this
 // container, containers with sub containers cannot contain widgets.
+// The sizes of the sub containers are set only by the provided opts, sizes set
+// by earlier split options or by resizing the split don't carry over.
 func SplitVertical(l LeftOption, r RightOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeVertical
+		c.opts.resetSplitSize()
 		c.opts.widget = nil
+		c.tabs = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -346,10 +610,14 @@ func SplitVertical(l LeftOption, r RightOption, opts ...SplitOption) Option {
 // SplitHorizontal splits the container along the horizontal axis into two sub
 // containers. The use of this option removes any widget placed at this
 // container, containers with sub containers cannot contain widgets.
+// The sizes of the sub containers are set only by the provided opts, sizes set
+// by earlier split options or by resizing the split don't carry over.
 func SplitHorizontal(t TopOption, b BottomOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeHorizontal
+		c.opts.resetSplitSize()
 		c.opts.widget = nil
+		c.tabs = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -364,6 +632,134 @@ func SplitHorizontal(t TopOption, b BottomOption, opts ...SplitOption) Option {
 	})
 }
 
+// SplitEvenVertical splits the container along the vertical axis into
+// len(children) sub containers of equal width, ordered from left to right.
+// Each element of children are the options of one sub container.
+// When the width isn't divisible by the number of sub containers, the
+// remaining columns are given one each to the leftmost sub containers.
+// At least two sub containers must be provided. The use of this option
+// removes any widget placed at this container.
+func SplitEvenVertical(children ...[]Option) Option {
+	return splitEvenly(splitTypeVertical, children)
+}
+
+// SplitEvenHorizontal splits the container along the horizontal axis into
+// len(children) sub containers of equal height, ordered from top to bottom.
+// Each element of children are the options of one sub container.
+// When the height isn't divisible by the number of sub containers, the
+// remaining rows are given one each to the topmost sub containers.
+// At least two sub containers must be provided. The use of this option
+// removes any widget placed at this container.
+func SplitEvenHorizontal(children ...[]Option) Option {
+	return splitEvenly(splitTypeHorizontal, children)
+}
+
+// splitEvenly returns an option that splits the container into the provided
+// children. The first child becomes the first sub container, the remaining
+// children are evenly split inside the second sub container.
+func splitEvenly(st splitType, children [][]Option) Option {
+	return option(func(c *Container) error {
+		if min := 2; len(children) < min {
+			return fmt.Errorf("invalid number of evenly split sub containers %d, must be %d <= n", len(children), min)
+		}
+		c.opts.split = st
+		c.opts.resetSplitSize()
+		c.opts.splitEven = len(children)
+		c.opts.widget = nil
+		c.tabs = nil
+
+		if err := c.createFirst(children[0]); err != nil {
+			return err
+		}
+		rest := children[1]
+		if len(children) > 2 {
+			rest = []Option{splitEvenly(st, children[1:])}
+		}
+		return c.createSecond(rest)
+	})
+}
+
+// TabOption is used to provide the name and options to a sub container that
+// is displayed as a tab, see SplitTabs.
+type TabOption interface {
+	// tab returns the name and the options of the tab.
+	tab() (string, []Option)
+}
+
+// tabOption implements TabOption.
+type tabOption func() (string, []Option)
+
+// tab implements TabOption.tab.
+func (to tabOption) tab() (string, []Option) {
+	return to()
+}
+
+// Tab creates a tab with the provided name displayed on the tab strip and
+// applies the options to its sub container.
+func Tab(name string, opts ...Option) TabOption {
+	return tabOption(func() (string, []Option) {
+		return name, opts
+	})
+}
+
+// SplitTabs splits the container into tabs. Each tab has its own sub
+// container, but only the sub container of the active tab is visible at a
+// time. The first line of the container displays a tab strip with the names
+// of the tabs, the active tab is displayed inverted. Initially the first tab
+// is active.
+//
+// The active tab can be switched by clicking on its name with the left mouse
+// button or by using the keyboard, see KeyTabNext and KeyTabPrevious.
+// Containers and widgets in the hidden tabs aren't drawn and don't receive any
+// events, but retain their state. When the active tab switches and the focused
+// container was in the previously active tab, the focus moves to the first
+// container in the newly active tab.
+//
+// At least one tab must be provided. The use of this option removes any
+// widget placed at this container, containers with sub containers cannot
+// contain widgets.
+func SplitTabs(tabs ...TabOption) Option {
+	return option(func(c *Container) error {
+		if len(tabs) == 0 {
+			return errors.New("SplitTabs requires at least one tab")
+		}
+		c.opts.split = splitTypeTabs
+		c.opts.widget = nil
+		c.second = nil
+		c.tabs = nil
+		c.activeTab = 0
+		for _, to := range tabs {
+			name, opts := to.tab()
+			child, err := newChild(c, opts)
+			if err != nil {
+				return err
+			}
+			c.tabs = append(c.tabs, &tab{
+				name: name,
+				cont: child,
+			})
+		}
+		c.first = c.tabs[0].cont
+		return nil
+	})
+}
+
+// TabsAlign sets the horizontal alignment of the tab labels on the tab strip
+// of a container split into tabs, see SplitTabs. The labels are placed next to
+// each other when aligned left, center or right. The align.HorizontalJustified
+// alignment spreads them across the whole tab strip and
+// align.HorizontalDistributed also leaves even space before the first and
+// after the last label.
+// Labels that don't fit onto the tab strip are aligned left. Has no effect if
+// the container isn't split into tabs.
+// Defaults to alignment on the left.
+func TabsAlign(h align.Horizontal) Option {
+	return option(func(c *Container) error {
+		c.opts.tabsAlign = h
+		return nil
+	})
+}
+
 // ID sets an identifier for this container.
 // This ID can be later used to perform dynamic layout changes by passing new
 // options to this container. When provided, it must be a non-empty string that
@@ -386,6 +782,7 @@ func Clear() Option {
 		c.opts.widget = nil
 		c.first = nil
 		c.second = nil
+		c.tabs = nil
 		return nil
 	})
 }
@@ -398,6 +795,7 @@ func PlaceWidget(w widgetapi.Widget) Option {
 		c.opts.widget = w
 		c.first = nil
 		c.second = nil
+		c.tabs = nil
 		return nil
 	})
 }
@@ -602,6 +1000,26 @@ func PaddingLeft(cells int) Option {
 	})
 }
 
+// Padding sets the same reserved space between container and all four sides
+// of its widget. This is equivalent to providing the PaddingTop, PaddingRight,
+// PaddingBottom and PaddingLeft options with the same value and the same
+// restrictions apply.
+func Padding(cells int) Option {
+	return option(func(c *Container) error {
+		for _, opt := range []Option{
+			PaddingTop(cells),
+			PaddingRight(cells),
+			PaddingBottom(cells),
+			PaddingLeft(cells),
+		} {
+			if err := opt.set(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // PaddingTopPercent sets reserved space between container and the top side of
 // its widget. The widget's area size is decreased to accommodate the padding.
 // The provided number is a relative padding defined as percentage of the
@@ -703,6 +1121,9 @@ func Border(ls linestyle.LineStyle) Option {
 }
 
 // BorderTitle sets a text title within the border.
+// The title is drawn on the top line of the border and requires the container
+// to have a border, see the Border option. Titles that don't fit between the
+// corners of the border are truncated with an ellipsis.
 func BorderTitle(title string) Option {
 	return option(func(c *Container) error {
 		c.opts.borderTitle = title
@@ -749,6 +1170,36 @@ func BorderColor(color cell.Color) Option {
 func FocusedColor(color cell.Color) Option {
 	return option(func(c *Container) error {
 		c.opts.inherited.focusedColor = color
+		c.opts.inherited.focusedColorSet = true
+		return nil
+	})
+}
+
+// ApplyTheme applies the theme to the container.
+// The container uses the Border color of the theme for its border unless the
+// BorderColor option is set and the Accent color when focused unless the
+// FocusedColor option is set. The container is filled with the Background
+// color of the theme unless the Background option is set or the color is
+// cell.ColorDefault. The theme is also provided to the widget in
+// widgetapi.Meta, widgets that support themes use its colors for their color
+// options that weren't set.
+// This option is inherited to sub containers created by container splits.
+func ApplyTheme(theme cell.Theme) Option {
+	return option(func(c *Container) error {
+		c.opts.inherited.theme = &theme
+		return nil
+	})
+}
+
+// Background fills the area of the container inside of its border with the
+// color before the widget draws. The widget's canvas starts with this
+// background color, cells the widget draws with their own background color
+// override it. Takes precedence over the Background color of the theme, see
+// ApplyTheme.
+// This option is inherited to sub containers created by container splits.
+func Background(color cell.Color) Option {
+	return option(func(c *Container) error {
+		c.opts.inherited.background = &color
 		return nil
 	})
 }
@@ -787,11 +1238,13 @@ func (st splitType) String() string {
 var splitTypeNames = map[splitType]string{
 	splitTypeVertical:   "splitTypeVertical",
 	splitTypeHorizontal: "splitTypeHorizontal",
+	splitTypeTabs:       "splitTypeTabs",
 }
 
 const (
 	splitTypeVertical splitType = iota
 	splitTypeHorizontal
+	splitTypeTabs
 )
 
 // LeftOption is used to provide options to the left sub container after a
@@ -932,6 +1385,8 @@ func KeyFocusPrevious(key keyboard.Key) Option {
 
 // KeyFocusSkip indicates that this container should never receive the keyboard
 // focus when KeyFocusNext or KeyFocusPrevious is pressed.
+// Containers without a widget are skipped even without this option, see
+// KeyFocusEmpty.
 //
 // A container configured like this would still receive the keyboard focus when
 // directly clicked on with a mouse or when via KeysFocusGroupNext or
@@ -943,6 +1398,114 @@ func KeyFocusSkip() Option {
 	})
 }
 
+// KeyFocusEmpty indicates that this container should receive the keyboard
+// focus when KeyFocusNext or KeyFocusPrevious is pressed even though it has
+// no widget. By default, containers without a widget are skipped.
+//
+// Has no effect on containers configured with KeyFocusSkip.
+func KeyFocusEmpty() Option {
+	return option(func(c *Container) error {
+		c.opts.keyFocusEmpty = true
+		return nil
+	})
+}
+
+// FocusScope makes this container the root of a focus scope. While the
+// keyboard focus is on one of the containers in its subtree, KeyFocusNext and
+// KeyFocusPrevious only move the focus between the containers in the
+// subtree, wrapping around at its ends. The same applies to the keys moving
+// the focus within focus groups.
+//
+// Focus scopes can be nested, the focus follows the closest enclosing scope.
+// Containers outside of any focus scope move the focus across the entire
+// tree, including into the focus scopes. Use KeyFocusEscapeScope to move the
+// focus out of a focus scope.
+func FocusScope() Option {
+	return option(func(c *Container) error {
+		c.opts.focusScope = true
+		return nil
+	})
+}
+
+// KeyFocusEscapeScope configures a key that moves the keyboard focus out of
+// the focus scope of the focused container, see FocusScope. The focus moves
+// to the next container of the enclosing focus scope that is outside of the
+// current one. Does nothing if the focused container isn't in a focus scope.
+//
+// This option is global and applies to all created containers.
+func KeyFocusEscapeScope(key keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.global.keyFocusEscapeScope = &key
+		return nil
+	})
+}
+
+// Hidden hides or shows this container together with all of its sub
+// containers. A hidden container and its widgets aren't drawn, don't receive
+// any events and can't be focused. The space allocated to a hidden container
+// remains blank, use Collapsed to give it to the sibling container instead.
+//
+// Use Update to toggle the visibility of an existing container. If the
+// focused container gets hidden, the focus moves to the closest ancestor that
+// isn't hidden.
+func Hidden(hidden bool) Option {
+	return option(func(c *Container) error {
+		c.opts.hidden = hidden
+		return nil
+	})
+}
+
+// Collapsed is like Hidden, but the sibling container, i.e. the other sub
+// container of the parent's split, takes all the space of the parent
+// container. No divider is drawn while one of the sub containers is
+// collapsed. Collapsing the root container or a sub container of a container
+// split into tabs has the same effect as hiding it.
+func Collapsed(collapsed bool) Option {
+	return option(func(c *Container) error {
+		c.opts.collapsed = collapsed
+		return nil
+	})
+}
+
+// Scrollable makes the container scrollable. The widget in a scrollable
+// container draws on a virtual canvas that is as large as the MinimumSize the
+// widget requests or its preferred size if the widget implements
+// widgetapi.PreferredSizer, but at least as large as the container. When the
+// virtual canvas doesn't fit, the container shows a window into it and draws
+// scrollbars along its right and bottom edge that indicate the position of
+// the window. The MaximumSize and Ratio the widget requests and the alignment
+// options don't apply to scrollable containers.
+//
+// The mouse wheel scrolls the container under the mouse pointer vertically,
+// the widget doesn't receive the wheel events. While the container is focused
+// and its widget doesn't want keyboard events, the arrow keys scroll it by one
+// cell and the page up and page down keys by the height of the window. Widgets
+// that want keyboard events receive these keys instead and the container
+// isn't scrolled by them.
+func Scrollable() Option {
+	return option(func(c *Container) error {
+		c.opts.scrollable = true
+		return nil
+	})
+}
+
+// ShrinkToFit sizes this container to fit the content of its widget instead
+// of the size determined by the split of the parent container. The remaining
+// space is given to the sibling container. Takes precedence over the split
+// options of the parent container, if both sub containers shrink to fit, the
+// first one does.
+//
+// Only has effect on containers whose widget implements
+// widgetapi.PreferredSizer and only along the axis of the parent's split. The
+// border, the cells of padding and margin of the container are added to the
+// preferred size, padding and margin specified in percent aren't.
+func ShrinkToFit() Option {
+	return option(func(c *Container) error {
+		c.opts.shrinkToFit = true
+		return nil
+	})
+}
+
 // FocusGroup represents a group of containers that can have the keyboard focus
 // moved between them sharing the same keyboard key.
 type FocusGroup int
@@ -1057,6 +1620,160 @@ func KeyFocusGroupsPrevious(key keyboard.Key, groups ...FocusGroup) Option {
 	})
 }
 
+// KeySequence registers a sequence of keys, e.g. 'g' followed by 'g', that
+// calls the provided function when the keys are pressed one after another.
+// Each key must be pressed within the KeySequenceTimeout after the previous
+// one, otherwise the keys pressed so far are forgotten. Pressing a key that
+// doesn't continue the sequence also forgets the pressed keys.
+// If one sequence is the prefix of another, the shorter sequence is called as
+// soon as it is completed.
+//
+// The keys are still delivered to the widgets that want keyboard events.
+// The function is called before the widgets receive the last key of the
+// sequence. If it returns an error, the error is reported to the error
+// handler of termdash.
+//
+// This option is global and applies to all created containers.
+// Registering the same sequence again replaces its function.
+func KeySequence(keys []keyboard.Key, fn func() error) Option {
+	return option(func(c *Container) error {
+		if len(keys) == 0 {
+			return errors.New("the KeySequence must contain at least one key")
+		}
+		if fn == nil {
+			return errors.New("the function of a KeySequence cannot be nil")
+		}
+
+		seq := &keySequence{
+			keys: make([]keyboard.Key, len(keys)),
+			fn:   fn,
+		}
+		copy(seq.keys, keys)
+		for i, ks := range c.opts.global.keySequences {
+			if complete, _ := ks.matches(seq.keys); complete {
+				c.opts.global.keySequences[i] = seq
+				return nil
+			}
+		}
+		c.opts.global.keySequences = append(c.opts.global.keySequences, seq)
+		return nil
+	})
+}
+
+// DefaultKeySequenceTimeout is the default value for the KeySequenceTimeout
+// option.
+const DefaultKeySequenceTimeout = time.Second
+
+// KeySequenceTimeout sets the maximum time between two keys of a sequence
+// registered with the KeySequence option. Must be a positive duration.
+//
+// This option is global and applies to all created containers.
+func KeySequenceTimeout(d time.Duration) Option {
+	return option(func(c *Container) error {
+		if d <= 0 {
+			return fmt.Errorf("invalid KeySequenceTimeout(%v), must be a positive duration", d)
+		}
+		c.opts.global.keySequenceTimeout = d
+		return nil
+	})
+}
+
+// KeyboardHook registers a function that receives every keyboard event
+// before it is processed by the containers or delivered to any widget. This
+// can be used to implement application wide hotkeys that work regardless of
+// which container is focused.
+//
+// The function returns true if it handled (consumed) the event. A consumed
+// event doesn't move the focus, doesn't switch tabs, doesn't count towards key
+// sequences and isn't delivered to any widget. Events the function doesn't
+// consume are processed as usual.
+//
+// The function is called synchronously from the goroutine that processes
+// keyboard events, without holding the container's lock, so it is safe to
+// call methods of the container from it.
+//
+// This option is global and applies to all created containers.
+// Registering a function replaces any previously registered one, a nil
+// function removes it.
+func KeyboardHook(fn func(*terminalapi.Keyboard) bool) Option {
+	return option(func(c *Container) error {
+		c.opts.global.keyboardHook = fn
+		return nil
+	})
+}
+
+// KeyTabNext configures a key that activates the next tab of a container
+// split into tabs, see SplitTabs. The key switches the tabs of the focused
+// container or of its closest ancestor that is split into tabs. If the last
+// tab is active, the first tab is activated.
+//
+// This option is global and applies to all created containers.
+// If neither of (KeyTabNext, KeyTabPrevious) is specified, the tabs can only
+// be switched by using the mouse.
+func KeyTabNext(key keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.global.keyTabNext = &key
+		return nil
+	})
+}
+
+// KeyTabPrevious configures a key that activates the previous tab of a
+// container split into tabs, see SplitTabs. The key switches the tabs of the
+// focused container or of its closest ancestor that is split into tabs. If
+// the first tab is active, the last tab is activated.
+//
+// This option is global and applies to all created containers.
+// If neither of (KeyTabNext, KeyTabPrevious) is specified, the tabs can only
+// be switched by using the mouse.
+func KeyTabPrevious(key keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.global.keyTabPrevious = &key
+		return nil
+	})
+}
+
+// MinimumSize sets the minimum size of the terminal in cells that is required
+// to draw the container tree. When the terminal is smaller, the layout isn't
+// drawn. Instead the terminal is cleared and a centered message is displayed,
+// see MinimumSizeMessage.
+//
+// This option is global and applies to all created containers.
+// Defaults to a zero size, i.e. the layout is always drawn.
+func MinimumSize(size image.Point) Option {
+	return option(func(c *Container) error {
+		if size.X < 0 || size.Y < 0 {
+			return fmt.Errorf("invalid MinimumSize %v, the size cannot be negative", size)
+		}
+		c.opts.global.minSize = size
+		return nil
+	})
+}
+
+// DefaultMinimumSizeMessage is the default message displayed when the
+// terminal is smaller than the size set with MinimumSize.
+const DefaultMinimumSizeMessage = "terminal too small"
+
+// MinimumSizeMessage sets the message and its cell options displayed when the
+// terminal is smaller than the size set with MinimumSize.
+// The message is trimmed if it doesn't fit the terminal and cannot contain
+// newline characters.
+//
+// This option is global and applies to all created containers.
+// Defaults to DefaultMinimumSizeMessage.
+func MinimumSizeMessage(msg string, cOpts ...cell.Option) Option {
+	return option(func(c *Container) error {
+		if msg == "" {
+			return errors.New("the message for MinimumSizeMessage cannot be empty")
+		}
+		if strings.ContainsRune(msg, '\n') {
+			return fmt.Errorf("the message %q for MinimumSizeMessage cannot contain newline characters", msg)
+		}
+		c.opts.global.minSizeMsg = msg
+		c.opts.global.minSizeMsgCellOpts = cOpts
+		return nil
+	})
+}
+
 // Focused moves the keyboard focus to this container.
 // If not specified, termdash will start with the root container focused.
 // If specified on multiple containers, the last container with this option
@@ -0,0 +1,217 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// resize.go contains code that resizes splits when the user drags the seam
+// between the sub containers with the mouse.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// resizeTracker tracks the split that is being resized by dragging its seam
+// with the mouse.
+// This is not thread-safe, the implementation assumes that the owner of
+// resizeTracker performs locking.
+type resizeTracker struct {
+	// cont is the container whose split is being resized.
+	// Nil when the user isn't dragging any seam.
+	cont *Container
+
+	// start is the position of the mouse when the drag started.
+	start image.Point
+
+	// startCells is the size of the first sub container in cells when the
+	// drag started.
+	startCells int
+}
+
+// newResizeTracker returns a new resizeTracker.
+func newResizeTracker() *resizeTracker {
+	return &resizeTracker{}
+}
+
+// mouse processes the mouse event and resizes the split if the user is
+// dragging a seam. Returns true if the event was consumed by the drag and
+// shouldn't be processed any further.
+// The root argument is the root of the container tree.
+func (rt *resizeTracker) mouse(root *Container, m *terminalapi.Mouse) bool {
+	if rt.cont == nil {
+		if m.Button != mouse.ButtonLeft {
+			return false
+		}
+		target := seamCont(root, m.Position)
+		if target == nil {
+			return false
+		}
+		cells, _, err := target.splitCells()
+		if err != nil {
+			return false
+		}
+		rt.cont, rt.start, rt.startCells = target, m.Position, cells
+		// The press itself is processed as usual, e.g. it can focus a
+		// container.
+		return false
+	}
+
+	if m.Button != mouse.ButtonLeft {
+		rt.cont = nil
+		return false
+	}
+
+	delta := m.Position.Sub(rt.start)
+	by := delta.Y
+	if rt.cont.opts.split == splitTypeVertical {
+		by = delta.X
+	}
+	rt.cont.resizeSplit(rt.startCells + by)
+	return true
+}
+
+// seamCont returns the split container whose seam is at the point or nil if
+// there isn't any.
+func seamCont(c *Container, p image.Point) *Container {
+	var (
+		errStr string
+		cont   *Container
+	)
+	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
+		if cont != nil {
+			return nil
+		}
+		if seam, err := cur.seam(); err == nil && p.In(seam) {
+			cont = cur
+		}
+		return nil
+	}))
+	return cont
+}
+
+// seam returns the area between the sub containers the user can drag to
+// resize the split. This is either the divider or the adjacent borders of
+// the sub containers. Returns a zero area if the split has no visible seam.
+func (c *Container) seam() (image.Rectangle, error) {
+	if c.isLeaf() || c.opts.split == splitTypeTabs || c.hasCollapsed() {
+		return image.ZR, nil
+	}
+	first, second, divider, err := c.splitWithDivider()
+	if err != nil {
+		return image.ZR, err
+	}
+	if !divider.Empty() {
+		return divider, nil
+	}
+	if first.Empty() || second.Empty() {
+		return image.ZR, nil
+	}
+
+	firstBorder := c.first != nil && c.first.hasBorder()
+	secondBorder := c.second != nil && c.second.hasBorder()
+	if !firstBorder && !secondBorder {
+		return image.ZR, nil
+	}
+
+	var seam image.Rectangle
+	if c.opts.split == splitTypeVertical {
+		seam = image.Rect(first.Max.X, first.Min.Y, second.Min.X, first.Max.Y)
+		if firstBorder {
+			seam.Min.X--
+		}
+		if secondBorder {
+			seam.Max.X++
+		}
+	} else {
+		seam = image.Rect(first.Min.X, first.Max.Y, first.Max.X, second.Min.Y)
+		if firstBorder {
+			seam.Min.Y--
+		}
+		if secondBorder {
+			seam.Max.Y++
+		}
+	}
+	return seam, nil
+}
+
+// splitCells returns the size of the first sub container in cells and the
+// total size in cells available to both sub containers along the axis of the
+// split.
+func (c *Container) splitCells() (int, int, error) {
+	ar, err := c.opts.padding.apply(c.usable())
+	if err != nil {
+		return 0, 0, err
+	}
+	first, _, err := c.splitAreas()
+	if err != nil {
+		return 0, 0, err
+	}
+	if c.opts.split == splitTypeVertical {
+		return first.Dx(), ar.Dx(), nil
+	}
+	return first.Dy(), ar.Dy(), nil
+}
+
+// resizeSplit resizes the split so that the first sub container gets the
+// specified number of cells. The size is clamped so that both sub containers
+// stay visible and honor their minimum sizes, see MinSize.
+// Splits with a fixed size keep a fixed size, all other splits are converted
+// to a percentage split.
+// Caller must hold c.mu.
+func (c *Container) resizeSplit(cells int) {
+	cur, total, err := c.splitCells()
+	if err != nil || total < 2 {
+		return
+	}
+
+	min, max := 1, total-1
+	if c.opts.splitMinFirst > min {
+		min = c.opts.splitMinFirst
+	}
+	if m := total - c.opts.splitMinSecond; m < max {
+		max = m
+	}
+	if min > max {
+		return
+	}
+	if cells < min {
+		cells = min
+	}
+	if cells > max {
+		cells = max
+	}
+	if cells == cur {
+		return
+	}
+	// Dragging the seam stops any animation of the split.
+	delete(c.splitAnimator.active, c)
+
+	if c.opts.splitFixed > DefaultSplitFixed {
+		c.opts.splitFixed = cells
+	} else {
+		// Rounding up the percentage makes the split land exactly on the
+		// requested cell.
+		percent := (cells*100 + total - 1) / total
+		if percent > 99 {
+			percent = 99
+		}
+		c.opts.splitPercent = percent
+		c.opts.splitEven = 0
+		c.opts.splitWeightFirst = 0
+		c.opts.splitWeightSecond = 0
+	}
+	rootCont(c).clearNeeded = true
+}
@@ -0,0 +1,320 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// drag returns mouse events that drag the mouse from one point to another.
+func drag(from, to image.Point) []terminalapi.Event {
+	return []terminalapi.Event{
+		&terminalapi.Mouse{Position: from, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: to, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: to, Button: mouse.ButtonRelease},
+	}
+}
+
+func TestResizeByDrag(t *testing.T) {
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		events    []terminalapi.Event
+		// wantFirst is the area of the first sub container after the events.
+		wantFirst   image.Rectangle
+		wantPercent int
+		wantFixed   int
+	}{
+		{
+			desc:     "dragging the divider of a vertical split",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right(), Divider(linestyle.Light, cell.ColorDefault)),
+				)
+			},
+			events:      drag(image.Point{10, 2}, image.Point{14, 2}),
+			wantFirst:   image.Rect(0, 0, 14, 5),
+			wantPercent: 70,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "dragging the divider of a horizontal split",
+			termSize: image.Point{5, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(Top(), Bottom(), Divider(linestyle.Light, cell.ColorDefault)),
+				)
+			},
+			events:      drag(image.Point{2, 5}, image.Point{2, 3}),
+			wantFirst:   image.Rect(0, 0, 5, 3),
+			wantPercent: 30,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "dragging the adjacent borders of the sub containers",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+					),
+				)
+			},
+			events:      drag(image.Point{9, 2}, image.Point{5, 2}),
+			wantFirst:   image.Rect(0, 0, 6, 5),
+			wantPercent: 30,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "clamps at the left extreme",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right(), Divider(linestyle.Light, cell.ColorDefault)),
+				)
+			},
+			events:      drag(image.Point{10, 2}, image.Point{-5, 2}),
+			wantFirst:   image.Rect(0, 0, 1, 5),
+			wantPercent: 5,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "clamps at the right extreme",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right(), Divider(linestyle.Light, cell.ColorDefault)),
+				)
+			},
+			events:      drag(image.Point{10, 2}, image.Point{30, 2}),
+			wantFirst:   image.Rect(0, 0, 19, 5),
+			wantPercent: 95,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "clamps at the minimum sizes",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+						SplitPercentWithMin(50, MinSize(6)),
+						Divider(linestyle.Light, cell.ColorDefault),
+					),
+				)
+			},
+			events:      drag(image.Point{10, 2}, image.Point{18, 2}),
+			wantFirst:   image.Rect(0, 0, 14, 5),
+			wantPercent: 70,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "fixed split stays fixed",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+						SplitFixed(8),
+						Divider(linestyle.Light, cell.ColorDefault),
+					),
+				)
+			},
+			events:      drag(image.Point{8, 2}, image.Point{11, 2}),
+			wantFirst:   image.Rect(0, 0, 11, 5),
+			wantPercent: DefaultSplitPercent,
+			wantFixed:   11,
+		},
+		{
+			desc:     "weighted split becomes a percentage split",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+						SplitWeights(1, 3),
+						Divider(linestyle.Light, cell.ColorDefault),
+					),
+				)
+			},
+			events:      drag(image.Point{5, 2}, image.Point{8, 2}),
+			wantFirst:   image.Rect(0, 0, 8, 5),
+			wantPercent: 40,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "dragging outside of the seam doesn't resize",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right(), Divider(linestyle.Light, cell.ColorDefault)),
+				)
+			},
+			events:      drag(image.Point{5, 2}, image.Point{14, 2}),
+			wantFirst:   image.Rect(0, 0, 10, 5),
+			wantPercent: DefaultSplitPercent,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "split without a divider or borders has no seam",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right()),
+				)
+			},
+			events:      drag(image.Point{10, 2}, image.Point{14, 2}),
+			wantFirst:   image.Rect(0, 0, 10, 5),
+			wantPercent: DefaultSplitPercent,
+			wantFixed:   DefaultSplitFixed,
+		},
+		{
+			desc:     "releasing the button ends the drag",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right(), Divider(linestyle.Light, cell.ColorDefault)),
+				)
+			},
+			events: append(
+				drag(image.Point{10, 2}, image.Point{12, 2}),
+				&terminalapi.Mouse{Position: image.Point{16, 2}, Button: mouse.ButtonLeft},
+			),
+			wantFirst:   image.Rect(0, 0, 12, 5),
+			wantPercent: 60,
+			wantFixed:   DefaultSplitFixed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			c, err := tc.container(ft)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := c.processEvent(ev); err != nil {
+					t.Fatalf("processEvent(%v) => unexpected error: %v", ev, err)
+				}
+				if err := c.Draw(); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			if got := c.first.area; got != tc.wantFirst {
+				t.Errorf("first sub container area => %v, want %v", got, tc.wantFirst)
+			}
+			if got := c.opts.splitPercent; got != tc.wantPercent {
+				t.Errorf("splitPercent => %d, want %d", got, tc.wantPercent)
+			}
+			if got := c.opts.splitFixed; got != tc.wantFixed {
+				t.Errorf("splitFixed => %d, want %d", got, tc.wantFixed)
+			}
+		})
+	}
+}
+
+func TestResizeByDragThenUpdate(t *testing.T) {
+	tests := []struct {
+		desc  string
+		split SplitOption
+		// wantFirst is the area of the first sub container after the update.
+		wantFirst image.Rectangle
+	}{
+		{
+			desc:      "weighted split",
+			split:     SplitWeights(1, 3),
+			wantFirst: image.Rect(0, 0, 5, 5),
+		},
+		{
+			desc:      "fixed split",
+			split:     SplitFixed(4),
+			wantFirst: image.Rect(0, 0, 4, 5),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{20, 5})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			c, err := New(
+				ft,
+				ID("root"),
+				SplitVertical(
+					Left(),
+					Right(),
+					SplitWeights(1, 1),
+					Divider(linestyle.Light, cell.ColorDefault),
+				),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range drag(image.Point{10, 2}, image.Point{14, 2}) {
+				if err := c.processEvent(ev); err != nil {
+					t.Fatalf("processEvent(%v) => unexpected error: %v", ev, err)
+				}
+			}
+
+			if err := c.Update("root", SplitVertical(Left(), Right(), tc.split)); err != nil {
+				t.Fatalf("Update => unexpected error: %v", err)
+			}
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if got := c.first.area; got != tc.wantFirst {
+				t.Errorf("first sub container area => %v, want %v", got, tc.wantFirst)
+			}
+		})
+	}
+}
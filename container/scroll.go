@@ -0,0 +1,334 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// scroll.go contains code that draws and scrolls the widgets of scrollable
+// containers.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+const (
+	// scrollTrackRune is the rune that draws the track of a scrollbar.
+	scrollTrackRune = '░'
+	// scrollThumbRune is the rune that draws the thumb of a scrollbar that
+	// indicates the position of the visible area.
+	scrollThumbRune = '█'
+)
+
+// scrollAreas returns the area of the terminal where the widget of a
+// scrollable container is visible and the size of the virtual canvas the
+// widget draws on.
+// The virtual canvas is at least as large as the visible area, as the
+// minimum size the widget requests and as its preferred size if the widget
+// implements widgetapi.PreferredSizer. The last column and the last row of
+// the container are reserved for the scrollbars when the virtual canvas
+// doesn't fit.
+func (c *Container) scrollAreas() (image.Rectangle, image.Point, error) {
+	visible, err := c.opts.padding.apply(c.usable())
+	if err != nil {
+		return image.ZR, image.ZP, err
+	}
+
+	need := c.opts.widget.Options().MinimumSize
+	if ps, ok := c.opts.widget.(widgetapi.PreferredSizer); ok {
+		pref := ps.PreferredSize()
+		if pref.X > need.X {
+			need.X = pref.X
+		}
+		if pref.Y > need.Y {
+			need.Y = pref.Y
+		}
+	}
+	var vBar, hBar bool
+	// Showing one scrollbar takes space which can make the other one
+	// necessary too.
+	for i := 0; i < 2; i++ {
+		if !vBar && need.Y > visible.Dy() {
+			vBar = true
+			visible.Max.X--
+		}
+		if !hBar && need.X > visible.Dx() {
+			hBar = true
+			visible.Max.Y--
+		}
+	}
+	if visible.Dx() < 0 || visible.Dy() < 0 {
+		return image.ZR, image.ZP, nil
+	}
+
+	size := visible.Size()
+	if need.X > size.X {
+		size.X = need.X
+	}
+	if need.Y > size.Y {
+		size.Y = need.Y
+	}
+	return visible, size, nil
+}
+
+// maxScroll returns the largest offset of the visible area within the virtual
+// canvas.
+func maxScroll(visible image.Rectangle, size image.Point) image.Point {
+	return size.Sub(visible.Size())
+}
+
+// scrollBy moves the visible area of a scrollable container by the specified
+// number of cells. The offset is clamped so that the visible area stays
+// within the virtual canvas.
+// Caller must hold c.mu.
+func (c *Container) scrollBy(by image.Point) {
+	visible, size, err := c.scrollAreas()
+	if err != nil {
+		return
+	}
+	c.scroll = c.scroll.Add(by)
+	c.clampScroll(visible, size)
+}
+
+// clampScroll clamps the scroll offset into the virtual canvas.
+func (c *Container) clampScroll(visible image.Rectangle, size image.Point) {
+	max := maxScroll(visible, size)
+	if c.scroll.X > max.X {
+		c.scroll.X = max.X
+	}
+	if c.scroll.Y > max.Y {
+		c.scroll.Y = max.Y
+	}
+	if c.scroll.X < 0 {
+		c.scroll.X = 0
+	}
+	if c.scroll.Y < 0 {
+		c.scroll.Y = 0
+	}
+}
+
+// updateScrollFromKeyboard scrolls the focused container if it is scrollable
+// and its widget doesn't want keyboard events, otherwise the keys would both
+// scroll the container and act in the widget.
+// The arrow keys scroll by one cell, the page up and page down keys by the
+// height of the visible area.
+// Caller must hold c.mu.
+func (c *Container) updateScrollFromKeyboard(k *terminalapi.Keyboard) {
+	target := c.focusTracker.active()
+	if !target.isScrollable() {
+		return
+	}
+	if w := target.opts.widget; w != nil && w.Options().WantKeyboard != widgetapi.KeyScopeNone {
+		return
+	}
+
+	visible, _, err := target.scrollAreas()
+	if err != nil {
+		return
+	}
+	var by image.Point
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		by.Y = -1
+	case keyboard.KeyArrowDown:
+		by.Y = 1
+	case keyboard.KeyArrowLeft:
+		by.X = -1
+	case keyboard.KeyArrowRight:
+		by.X = 1
+	case keyboard.KeyPgUp:
+		by.Y = -visible.Dy()
+	case keyboard.KeyPgDn:
+		by.Y = visible.Dy()
+	default:
+		return
+	}
+	target.scrollBy(by)
+}
+
+// scrollFromMouse scrolls the scrollable container under the mouse pointer
+// when the mouse wheel is used. Returns true if the event was consumed by
+// scrolling and shouldn't be processed any further.
+// Caller must hold c.mu.
+func (c *Container) scrollFromMouse(m *terminalapi.Mouse) bool {
+	var by image.Point
+	switch m.Button {
+	case mouse.ButtonWheelUp:
+		by.Y = -1
+	case mouse.ButtonWheelDown:
+		by.Y = 1
+	default:
+		return false
+	}
+
+	target := pointCont(c, m.Position)
+	if !target.isScrollable() {
+		return false
+	}
+	target.scrollBy(by)
+	return true
+}
+
+// isScrollable asserts whether the container is scrollable and has a widget
+// to scroll.
+func (c *Container) isScrollable() bool {
+	return c != nil && c.opts.scrollable && c.hasWidget()
+}
+
+// scrollMouseEv translates the mouse event adjusted to the visible area of a
+// scrollable container onto the virtual canvas of its widget.
+func (c *Container) scrollMouseEv(mt *mouseEvTarget) {
+	if mt.ev.Position == (image.Point{-1, -1}) {
+		return
+	}
+	mt.ev.Position = mt.ev.Position.Add(c.scroll)
+}
+
+// drawScrollable draws the widget of a scrollable container onto a virtual
+// canvas and the visible part of it onto the terminal together with the
+// scrollbars.
+func drawScrollable(c *Container) error {
+	visible, size, err := c.scrollAreas()
+	if err != nil {
+		return err
+	}
+	if visible.Dx() < 1 || visible.Dy() < 1 {
+		return drawResize(c, c.usable())
+	}
+	// The virtual canvas or the terminal might have changed size since the
+	// last scroll.
+	c.clampScroll(visible, size)
+
+	virtual, err := canvas.New(image.Rectangle{Max: size})
+	if err != nil {
+		return err
+	}
+	if bg, ok := c.opts.inherited.backgroundColor(); ok {
+		if err := fillBackground(virtual, bg); err != nil {
+			return err
+		}
+	}
+	meta := &widgetapi.Meta{
+		Focused: c.focusTracker.isActive(c),
+		Theme:   c.opts.inherited.theme,
+	}
+	if err := c.opts.widget.Draw(virtual, meta); err != nil {
+		return err
+	}
+
+	win, err := canvas.New(visible)
+	if err != nil {
+		return err
+	}
+	if err := copyWindow(virtual, win, c.scroll); err != nil {
+		return err
+	}
+	if err := win.Apply(c.term); err != nil {
+		return err
+	}
+	return drawScrollbars(c, visible, size)
+}
+
+// copyWindow copies the part of the source canvas that starts at the offset
+// and has the size of the destination canvas onto the destination canvas.
+// Full-width runes that don't fit into the last column are replaced with a
+// space.
+func copyWindow(src, dst *canvas.Canvas, offset image.Point) error {
+	dstSize := dst.Size()
+	for y := 0; y < dstSize.Y; y++ {
+		for x := 0; x < dstSize.X; x++ {
+			c, err := src.Cell(image.Point{x, y}.Add(offset))
+			if err != nil {
+				return err
+			}
+			r := c.Rune
+			if x == dstSize.X-1 && runewidth.RuneWidth(r) > 1 {
+				r = ' '
+			}
+			cells, err := dst.SetCell(image.Point{x, y}, r, c.Opts)
+			if err != nil {
+				return err
+			}
+			// Skip over the cell occupied by the second half of a
+			// full-width rune.
+			x += cells - 1
+		}
+	}
+	return nil
+}
+
+// drawScrollbars draws the scrollbars of a scrollable container in the
+// column right of and the row below the visible area. Only the scrollbars
+// along the axes where the virtual canvas doesn't fit are drawn.
+func drawScrollbars(c *Container, visible image.Rectangle, size image.Point) error {
+	border, _ := c.opts.inherited.borderColors()
+	opts := []cell.Option{cell.FgColor(border)}
+
+	if size.Y > visible.Dy() {
+		ar := image.Rect(visible.Max.X, visible.Min.Y, visible.Max.X+1, visible.Max.Y)
+		start, length := scrollThumb(visible.Dy(), size.Y, c.scroll.Y)
+		thumb := image.Rect(0, start, 1, start+length)
+		if err := drawScrollbar(c, ar, thumb, opts); err != nil {
+			return err
+		}
+	}
+	if size.X > visible.Dx() {
+		ar := image.Rect(visible.Min.X, visible.Max.Y, visible.Max.X, visible.Max.Y+1)
+		start, length := scrollThumb(visible.Dx(), size.X, c.scroll.X)
+		thumb := image.Rect(start, 0, start+length, 1)
+		if err := drawScrollbar(c, ar, thumb, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawScrollbar draws one scrollbar with its track in the area and the thumb
+// in the thumb area which is relative to the track.
+func drawScrollbar(c *Container, ar, thumb image.Rectangle, opts []cell.Option) error {
+	cvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+	if err := cvs.SetAreaCells(cvs.Area(), scrollTrackRune, opts...); err != nil {
+		return err
+	}
+	if err := cvs.SetAreaCells(thumb, scrollThumbRune, opts...); err != nil {
+		return err
+	}
+	return cvs.Apply(c.term)
+}
+
+// scrollThumb returns the start and the length in cells of the scrollbar
+// thumb on a track of the specified length. The thumb is proportional to the
+// visible part of the virtual canvas and its position reflects the offset.
+func scrollThumb(track, total, offset int) (int, int) {
+	length := track * track / total
+	if length < 1 {
+		length = 1
+	}
+	maxStart := track - length
+	maxOffset := total - track
+	if maxOffset <= 0 {
+		return 0, track
+	}
+	start := (offset*maxStart + maxOffset/2) / maxOffset
+	return start, length
+}
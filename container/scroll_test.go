@@ -0,0 +1,376 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// rowsWidget is a widget that draws numbered rows and records the keyboard
+// and mouse events it receives.
+type rowsWidget struct {
+	// size is the minimum size the widget requests.
+	size image.Point
+	// keyScope is the scope of the keyboard events the widget requests.
+	keyScope widgetapi.KeyScope
+	// keys are the received keyboard events.
+	keys []terminalapi.Keyboard
+	// mouse are the received mouse events.
+	mouse []terminalapi.Mouse
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (rw *rowsWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	for row := 0; row < cvs.Area().Dy(); row++ {
+		testdraw.MustText(cvs, fmt.Sprintf("r%d", row), image.Point{0, row})
+	}
+	return nil
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (rw *rowsWidget) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	rw.keys = append(rw.keys, *k)
+	return nil
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (rw *rowsWidget) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	rw.mouse = append(rw.mouse, *m)
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (rw *rowsWidget) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  rw.size,
+		WantKeyboard: rw.keyScope,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
+
+// wantRows returns the expected content of a terminal of the specified height
+// showing rows of the rowsWidget starting at the first row, with a vertical
+// scrollbar in the column x whose thumb covers the thumb rows.
+func wantRows(size image.Point, first, x int, thumb image.Rectangle) *faketerm.Terminal {
+	ft := faketerm.MustNew(size)
+	cvs := testcanvas.MustNew(ft.Area())
+	for row := 0; row < size.Y; row++ {
+		testdraw.MustText(cvs, fmt.Sprintf("r%d", first+row), image.Point{0, row})
+	}
+	testcanvas.MustSetAreaCells(cvs, image.Rect(x, 0, x+1, size.Y), scrollTrackRune)
+	testcanvas.MustSetAreaCells(cvs, thumb, scrollThumbRune)
+	testcanvas.MustApply(cvs, ft)
+	return ft
+}
+
+func TestScrollable(t *testing.T) {
+	wheel := func(b mouse.Button) *terminalapi.Mouse {
+		return &terminalapi.Mouse{Position: image.Point{1, 1}, Button: b}
+	}
+
+	tests := []struct {
+		desc       string
+		events     []terminalapi.Event
+		wantScroll image.Point
+		want       *faketerm.Terminal
+	}{
+		{
+			desc:       "shows the top of the content with the thumb at the top",
+			wantScroll: image.Point{0, 0},
+			want:       wantRows(image.Point{6, 4}, 0, 5, image.Rect(5, 0, 6, 1)),
+		},
+		{
+			desc: "mouse wheel scrolls down and reveals lower content",
+			events: []terminalapi.Event{
+				wheel(mouse.ButtonWheelDown),
+				wheel(mouse.ButtonWheelDown),
+				wheel(mouse.ButtonWheelDown),
+			},
+			wantScroll: image.Point{0, 3},
+			want:       wantRows(image.Point{6, 4}, 3, 5, image.Rect(5, 2, 6, 3)),
+		},
+		{
+			desc: "mouse wheel scrolls back up",
+			events: []terminalapi.Event{
+				wheel(mouse.ButtonWheelDown),
+				wheel(mouse.ButtonWheelDown),
+				wheel(mouse.ButtonWheelUp),
+			},
+			wantScroll: image.Point{0, 1},
+			want:       wantRows(image.Point{6, 4}, 1, 5, image.Rect(5, 1, 6, 2)),
+		},
+		{
+			desc: "arrow key scrolls the focused container",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			wantScroll: image.Point{0, 1},
+			want:       wantRows(image.Point{6, 4}, 1, 5, image.Rect(5, 1, 6, 2)),
+		},
+		{
+			desc: "page down scrolls by the height and stops at the bottom",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+			},
+			wantScroll: image.Point{0, 6},
+			want:       wantRows(image.Point{6, 4}, 6, 5, image.Rect(5, 3, 6, 4)),
+		},
+		{
+			desc: "doesn't scroll above the top",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			wantScroll: image.Point{0, 0},
+			want:       wantRows(image.Point{6, 4}, 0, 5, image.Rect(5, 0, 6, 1)),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{6, 4})
+			cont, err := New(
+				ft,
+				Scrollable(),
+				PlaceWidget(&rowsWidget{size: image.Point{2, 10}}),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := cont.processEvent(ev); err != nil {
+					t.Fatalf("processEvent(%v) => unexpected error: %v", ev, err)
+				}
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if cont.scroll != tc.wantScroll {
+				t.Errorf("scroll => %v, want %v", cont.scroll, tc.wantScroll)
+			}
+			if diff := faketerm.Diff(tc.want, ft); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestScrollableHorizontal(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{4, 3})
+	cont, err := New(
+		ft,
+		Scrollable(),
+		PlaceWidget(&rowsWidget{size: image.Point{6, 1}}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := cont.processEvent(&terminalapi.Keyboard{Key: keyboard.KeyArrowRight}); err != nil {
+			t.Fatalf("processEvent => unexpected error: %v", err)
+		}
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if want := (image.Point{2, 0}); cont.scroll != want {
+		t.Errorf("scroll => %v, want %v", cont.scroll, want)
+	}
+	want := faketerm.MustNew(ft.Size())
+	cvs := testcanvas.MustNew(want.Area())
+	// The rows "r0" and "r1" scrolled out of view on the left.
+	testcanvas.MustSetAreaCells(cvs, image.Rect(0, 2, 4, 3), scrollTrackRune)
+	testcanvas.MustSetAreaCells(cvs, image.Rect(2, 2, 4, 3), scrollThumbRune)
+	testcanvas.MustApply(cvs, want)
+	if diff := faketerm.Diff(want, ft); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
+
+func TestScrollableKeyboardWidget(t *testing.T) {
+	w := &rowsWidget{size: image.Point{2, 10}, keyScope: widgetapi.KeyScopeFocused}
+	ft := faketerm.MustNew(image.Point{6, 4})
+	cont, err := New(ft, Scrollable(), PlaceWidget(w))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	ev := &terminalapi.Keyboard{Key: keyboard.KeyArrowDown}
+	if err := cont.processEvent(ev); err != nil {
+		t.Fatalf("processEvent => unexpected error: %v", err)
+	}
+
+	// The key goes to the widget that wants it instead of scrolling the
+	// container.
+	if want := (image.Point{0, 0}); cont.scroll != want {
+		t.Errorf("scroll => %v, want %v", cont.scroll, want)
+	}
+	if diff := pretty.Compare([]terminalapi.Keyboard{*ev}, w.keys); diff != "" {
+		t.Errorf("keyboard events => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestScrollableText(t *testing.T) {
+	// The text doesn't scroll itself, so the keyboard scrolls the container.
+	txt, err := text.New(text.DisableScrolling())
+	if err != nil {
+		t.Fatalf("text.New => unexpected error: %v", err)
+	}
+	if err := txt.Write("r0\nr1\nr2\nr3\nr4\nr5"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+
+	ft := faketerm.MustNew(image.Point{6, 4})
+	cont, err := New(ft, Scrollable(), PlaceWidget(txt))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	// The virtual canvas fits all the lines, so the text doesn't draw its own
+	// scroll markers.
+	if diff := faketerm.Diff(wantRows(image.Point{6, 4}, 0, 5, image.Rect(5, 0, 6, 2)), ft); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+
+	if err := cont.processEvent(&terminalapi.Keyboard{Key: keyboard.KeyPgDn}); err != nil {
+		t.Fatalf("processEvent => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if want := (image.Point{0, 2}); cont.scroll != want {
+		t.Errorf("scroll => %v, want %v", cont.scroll, want)
+	}
+	if diff := faketerm.Diff(wantRows(image.Point{6, 4}, 2, 5, image.Rect(5, 2, 6, 4)), ft); diff != "" {
+		t.Errorf("Draw after scrolling => %v", diff)
+	}
+}
+
+func TestScrollableMouse(t *testing.T) {
+	w := &rowsWidget{size: image.Point{2, 10}}
+	ft := faketerm.MustNew(image.Point{6, 4})
+	cont, err := New(ft, Scrollable(), PlaceWidget(w))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	for _, ev := range []terminalapi.Event{
+		&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonWheelDown},
+		&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonWheelDown},
+		&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+		// Falls onto the scrollbar.
+		&terminalapi.Mouse{Position: image.Point{5, 1}, Button: mouse.ButtonLeft},
+	} {
+		if err := cont.processEvent(ev); err != nil {
+			t.Fatalf("processEvent(%v) => unexpected error: %v", ev, err)
+		}
+	}
+
+	want := []terminalapi.Mouse{
+		{Position: image.Point{1, 3}, Button: mouse.ButtonLeft},
+	}
+	if diff := pretty.Compare(want, w.mouse); diff != "" {
+		t.Errorf("Mouse => unexpected events, diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestScrollThumb(t *testing.T) {
+	tests := []struct {
+		desc       string
+		track      int
+		total      int
+		offset     int
+		wantStart  int
+		wantLength int
+	}{
+		{
+			desc:       "content fits",
+			track:      4,
+			total:      4,
+			wantStart:  0,
+			wantLength: 4,
+		},
+		{
+			desc:       "thumb proportional to the visible part",
+			track:      10,
+			total:      20,
+			wantStart:  0,
+			wantLength: 5,
+		},
+		{
+			desc:       "thumb in the middle",
+			track:      10,
+			total:      20,
+			offset:     5,
+			wantStart:  3,
+			wantLength: 5,
+		},
+		{
+			desc:       "thumb at the end",
+			track:      10,
+			total:      20,
+			offset:     10,
+			wantStart:  5,
+			wantLength: 5,
+		},
+		{
+			desc:       "thumb is at least one cell long",
+			track:      4,
+			total:      100,
+			offset:     40,
+			wantStart:  1,
+			wantLength: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotStart, gotLength := scrollThumb(tc.track, tc.total, tc.offset)
+			if gotStart != tc.wantStart || gotLength != tc.wantLength {
+				t.Errorf("scrollThumb(%d, %d, %d) => %d, %d, want %d, %d", tc.track, tc.total, tc.offset, gotStart, gotLength, tc.wantStart, tc.wantLength)
+			}
+		})
+	}
+}
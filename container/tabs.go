@@ -0,0 +1,223 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// tabs.go contains code that manages containers split into tabs.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/area"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// tab is one tab of a container split into tabs.
+type tab struct {
+	// name is the name displayed on the tab strip.
+	name string
+	// cont is the sub container displayed when the tab is active.
+	cont *Container
+}
+
+// tabLabel returns the text displayed for the tab on the tab strip.
+func tabLabel(name string) string {
+	return " " + name + " "
+}
+
+// hasTabs determines if this container is split into tabs.
+func (c *Container) hasTabs() bool {
+	return len(c.tabs) > 0
+}
+
+// tabStrip returns the area of the tab strip, i.e. the first line of the
+// padded usable area of the container.
+func (c *Container) tabStrip() (image.Rectangle, error) {
+	ar, err := c.opts.padding.apply(c.usable())
+	if err != nil {
+		return image.ZR, err
+	}
+	strip, _, err := area.HSplitCells(ar, 1)
+	if err != nil {
+		return image.ZR, err
+	}
+	return strip, nil
+}
+
+// tabLabels returns the X coordinates where the tab labels start on the tab
+// strip and the widths of the labels.
+func (c *Container) tabLabels(strip image.Rectangle) (xs, widths []int) {
+	widths = make([]int, len(c.tabs))
+	for i, t := range c.tabs {
+		widths[i] = runewidth.StringWidth(tabLabel(t.name))
+	}
+
+	xs, err := alignfor.Row(strip, widths, c.opts.tabsAlign)
+	if err != nil {
+		// The labels don't fit, place them next to each other from the left,
+		// the ones that overrun the strip get trimmed.
+		xs = make([]int, len(widths))
+		x := strip.Min.X
+		for i, w := range widths {
+			xs[i] = x
+			x += w
+		}
+	}
+	return xs, widths
+}
+
+// tabAt returns the index of the tab whose label is displayed at the point.
+// The bool return value is false if the point doesn't fall on any label.
+func (c *Container) tabAt(p image.Point) (int, bool) {
+	strip, err := c.tabStrip()
+	if err != nil || !p.In(strip) {
+		return 0, false
+	}
+
+	xs, widths := c.tabLabels(strip)
+	for i, x := range xs {
+		if p.X >= x && p.X < x+widths[i] {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// setActiveTab makes the i-th tab of this container the active one.
+// If the focused container was in the previously active tab, the focus moves
+// to the first leaf container in the newly active tab.
+// Caller must hold c.mu.
+func (c *Container) setActiveTab(i int) {
+	if i == c.activeTab {
+		return
+	}
+	c.activeTab = i
+	c.first = c.tabs[i].cont
+
+	root := rootCont(c)
+	// The new tab might not cover all the content of the previous one.
+	root.clearNeeded = true
+	if !c.focusTracker.reachableFrom(root) {
+		c.focusTracker.setActive(firstLeaf(c.first))
+	}
+}
+
+// firstLeaf returns the first leaf container in a DFS traversal of the tree
+// rooted at the provided container.
+func firstLeaf(c *Container) *Container {
+	for !c.isLeaf() {
+		if c.first != nil {
+			c = c.first
+		} else {
+			c = c.second
+		}
+	}
+	return c
+}
+
+// updateTabFromMouse processes the mouse event and determines if it switches
+// the active tab of any visible container split into tabs.
+// Caller must hold c.mu.
+func (c *Container) updateTabFromMouse(m *terminalapi.Mouse) {
+	if m.Button != mouse.ButtonLeft {
+		return
+	}
+
+	var (
+		errStr string
+		target *Container
+		idx    int
+	)
+	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
+		if !cur.hasTabs() || target != nil {
+			return nil
+		}
+		if i, ok := cur.tabAt(m.Position); ok {
+			target, idx = cur, i
+		}
+		return nil
+	}))
+	if target != nil {
+		target.setActiveTab(idx)
+	}
+}
+
+// updateTabFromKeyboard processes the keyboard event and determines if it
+// switches the active tab of the container split into tabs that is the
+// closest to the focused container, i.e. either the focused container itself
+// or its closest ancestor.
+// Caller must hold c.mu.
+func (c *Container) updateTabFromKeyboard(k *terminalapi.Keyboard) {
+	global := c.opts.global
+	var step int
+	switch {
+	case global.keyTabNext != nil && *global.keyTabNext == k.Key:
+		step = 1
+	case global.keyTabPrevious != nil && *global.keyTabPrevious == k.Key:
+		step = -1
+	default:
+		return
+	}
+
+	for cur := c.focusTracker.active(); cur != nil; cur = cur.parent {
+		if cur.hasTabs() {
+			n := len(cur.tabs)
+			cur.setActiveTab((cur.activeTab + step + n) % n)
+			return
+		}
+	}
+}
+
+// drawTabs draws the tab strip of a container split into tabs.
+// The label of the active tab is drawn inverted.
+func drawTabs(c *Container) error {
+	strip, err := c.tabStrip()
+	if err != nil {
+		return err
+	}
+	if strip.Dx() < 1 || strip.Dy() < 1 {
+		return nil
+	}
+
+	cvs, err := canvas.New(strip)
+	if err != nil {
+		return err
+	}
+
+	xs, _ := c.tabLabels(strip)
+	for i, t := range c.tabs {
+		x := xs[i] - strip.Min.X
+		if x >= strip.Dx() {
+			break
+		}
+
+		var cOpts []cell.Option
+		if i == c.activeTab {
+			cOpts = append(cOpts, cell.Inverse())
+		}
+		if err := draw.Text(cvs, tabLabel(t.name), image.Point{x, 0},
+			draw.TextCellOpts(cOpts...),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+	return cvs.Apply(c.term)
+}
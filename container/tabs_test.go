@@ -0,0 +1,529 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mustDrawTabStrip draws a tab strip with the provided names on the first
+// line of the area.
+func mustDrawTabStrip(ft *faketerm.Terminal, ar image.Rectangle, active int, names ...string) {
+	c := testcanvas.MustNew(image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X, ar.Min.Y+1))
+	var x int
+	for i, n := range names {
+		var cOpts []cell.Option
+		if i == active {
+			cOpts = append(cOpts, cell.Inverse())
+		}
+		label := tabLabel(n)
+		testdraw.MustText(c, label, image.Point{x, 0}, draw.TextCellOpts(cOpts...))
+		x += len(label)
+	}
+	testcanvas.MustApply(c, ft)
+}
+
+// mustDrawTabLabels draws the tab labels with the provided names starting at
+// the X coordinates on the first line of the area.
+func mustDrawTabLabels(ft *faketerm.Terminal, ar image.Rectangle, active int, xs []int, names ...string) {
+	c := testcanvas.MustNew(image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X, ar.Min.Y+1))
+	for i, n := range names {
+		var cOpts []cell.Option
+		if i == active {
+			cOpts = append(cOpts, cell.Inverse())
+		}
+		testdraw.MustText(c, tabLabel(n), image.Point{xs[i], 0}, draw.TextCellOpts(cOpts...))
+	}
+	testcanvas.MustApply(c, ft)
+}
+
+func TestTabs(t *testing.T) {
+	keyboardOpts := widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal}
+	focusedOpts := widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused}
+
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		events    []terminalapi.Event
+		want      func(size image.Point) *faketerm.Terminal
+		wantErr   bool
+	}{
+		{
+			desc:     "fails without tabs",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, SplitTabs())
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on duplicate IDs in hidden tabs",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitTabs(
+						Tab("a", ID("id")),
+						Tab("b", ID("id")),
+					),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "draws the tab strip and the first tab",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(keyboardOpts))),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabStrip(ft, image.Rect(0, 0, 20, 10), 0, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "tab strip respects the border",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(keyboardOpts))),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, ft.Area(), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				testcanvas.MustApply(cvs, ft)
+
+				mustDrawTabStrip(ft, image.Rect(1, 1, 19, 9), 0, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(1, 2, 19, 9)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "justified tab labels",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					TabsAlign(align.HorizontalJustified),
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("c", PlaceWidget(fakewidget.New(keyboardOpts))),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabLabels(ft, image.Rect(0, 0, 20, 10), 0, []int{0, 9, 17}, "a", "b", "c")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "distributed tab labels",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					TabsAlign(align.HorizontalDistributed),
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("c", PlaceWidget(fakewidget.New(keyboardOpts))),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabLabels(ft, image.Rect(0, 0, 20, 10), 0, []int{3, 9, 15}, "a", "b", "c")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "tab labels that don't fit are aligned left",
+			termSize: image.Point{8, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					TabsAlign(align.HorizontalRight),
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("bcd", PlaceWidget(fakewidget.New(keyboardOpts))),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(image.Rect(0, 0, 8, 1))
+				testdraw.MustText(c, tabLabel("a"), image.Point{0, 0}, draw.TextCellOpts(cell.Inverse()))
+				testdraw.MustText(c, tabLabel("bcd"), image.Point{3, 0}, draw.TextOverrunMode(draw.OverrunModeThreeDot))
+				testcanvas.MustApply(c, ft)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 8, 10)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "mouse click on an aligned tab label switches the tab",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					TabsAlign(align.HorizontalJustified),
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(focusedOpts))),
+						Tab("c", PlaceWidget(fakewidget.New(focusedOpts))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{18, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{18, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabLabels(ft, image.Rect(0, 0, 20, 10), 2, []int{0, 9, 17}, "a", "b", "c")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					focusedOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "mouse click on the tab strip switches the tab",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(focusedOpts))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{4, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{4, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabStrip(ft, image.Rect(0, 0, 20, 10), 1, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					focusedOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "mouse click outside of the tab labels doesn't switch",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(focusedOpts))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{10, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{10, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabStrip(ft, image.Rect(0, 0, 20, 10), 0, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "keyboard switches tabs, hidden tabs don't receive events and retain state",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabNext(keyboard.KeyTab),
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(keyboardOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(keyboardOpts))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabStrip(ft, image.Rect(0, 0, 20, 10), 0, "a", "b")
+				// The widget in the first tab didn't get the Enter key
+				// pressed while the second tab was active.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{},
+					},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						Meta: &widgetapi.EventMeta{},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "previous tab wraps around to the last tab",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabPrevious(keyboard.KeyBacktab),
+					SplitTabs(
+						Tab("a", PlaceWidget(fakewidget.New(focusedOpts))),
+						Tab("b", PlaceWidget(fakewidget.New(focusedOpts))),
+						Tab("c", PlaceWidget(fakewidget.New(keyboardOpts))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabStrip(ft, image.Rect(0, 0, 20, 10), 2, "a", "b", "c")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+					&widgetapi.Meta{},
+					keyboardOpts,
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+						Meta: &widgetapi.EventMeta{},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "focus moves to the first container of the newly active tab",
+			termSize: image.Point{30, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabNext(keyboard.KeyTab),
+					SplitTabs(
+						Tab("a",
+							PlaceWidget(fakewidget.New(focusedOpts)),
+							Focused(),
+						),
+						Tab("b",
+							SplitVertical(
+								Left(PlaceWidget(fakewidget.New(focusedOpts))),
+								Right(PlaceWidget(fakewidget.New(focusedOpts))),
+							),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawTabStrip(ft, image.Rect(0, 0, 30, 10), 1, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 15, 10)),
+					&widgetapi.Meta{Focused: true},
+					focusedOpts,
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyTab},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(15, 1, 30, 10)),
+					&widgetapi.Meta{},
+					focusedOpts,
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("tc.container => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestTabsUpdateHiddenContainer(t *testing.T) {
+	ft, err := faketerm.New(image.Point{20, 10})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	c, err := New(
+		ft,
+		SplitTabs(
+			Tab("a"),
+			Tab("b", ID("hidden")),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	widgetOpts := widgetapi.Options{}
+	if err := c.Update("hidden", PlaceWidget(fakewidget.New(widgetOpts))); err != nil {
+		t.Fatalf("Update => unexpected error: %v", err)
+	}
+	c.setActiveTab(1)
+	if err := c.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	want := faketerm.MustNew(ft.Size())
+	mustDrawTabStrip(want, image.Rect(0, 0, 20, 10), 1, "a", "b")
+	fakewidget.MustDraw(
+		want,
+		testcanvas.MustNew(image.Rect(0, 1, 20, 10)),
+		&widgetapi.Meta{},
+		widgetOpts,
+	)
+	if diff := faketerm.Diff(want, ft); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
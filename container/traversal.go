@@ -35,8 +35,9 @@ func rootCont(c *Container) *Container {
 type visitFunc func(*Container) error
 
 // preOrder performs pre-order DFS traversal on the container tree.
+// Hidden containers and their sub containers aren't visited.
 func preOrder(c *Container, errStr *string, visit visitFunc) {
-	if c == nil || *errStr != "" {
+	if c == nil || c.isHidden() || *errStr != "" {
 		return
 	}
 
@@ -49,8 +50,9 @@ func preOrder(c *Container, errStr *string, visit visitFunc) {
 }
 
 // postOrder performs post-order DFS traversal on the container tree.
+// Hidden containers and their sub containers aren't visited.
 func postOrder(c *Container, errStr *string, visit visitFunc) {
-	if c == nil || *errStr != "" {
+	if c == nil || c.isHidden() || *errStr != "" {
 		return
 	}
 
@@ -62,6 +64,28 @@ func postOrder(c *Container, errStr *string, visit visitFunc) {
 	}
 }
 
+// preOrderAll performs pre-order DFS traversal on the container tree like
+// preOrder, but also visits hidden containers and the hidden tabs of
+// containers split into tabs.
+func preOrderAll(c *Container, errStr *string, visit visitFunc) {
+	if c == nil || *errStr != "" {
+		return
+	}
+
+	if err := visit(c); err != nil {
+		*errStr = err.Error()
+		return
+	}
+	if c.hasTabs() {
+		for _, t := range c.tabs {
+			preOrderAll(t.cont, errStr, visit)
+		}
+		return
+	}
+	preOrderAll(c.first, errStr, visit)
+	preOrderAll(c.second, errStr, visit)
+}
+
 // findID finds container with the provided ID.
 // Returns an error of there is no container with the specified ID.
 func findID(root *Container, id string) (*Container, error) {
@@ -73,7 +97,7 @@ func findID(root *Container, id string) (*Container, error) {
 		errStr string
 		cont   *Container
 	)
-	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+	preOrderAll(root, &errStr, visitFunc(func(c *Container) error {
 		if c.opts.id == id {
 			cont = c
 		}
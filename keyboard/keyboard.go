@@ -90,6 +90,11 @@ var buttonNames = map[Key]string{
 	KeyCtrl7:      "KeyCtrl7",
 	KeySpace:      "KeySpace",
 	KeyBackspace2: "KeyBackspace2",
+
+	KeyShiftArrowUp:    "KeyShiftArrowUp",
+	KeyShiftArrowDown:  "KeyShiftArrowDown",
+	KeyShiftArrowLeft:  "KeyShiftArrowLeft",
+	KeyShiftArrowRight: "KeyShiftArrowRight",
 }
 
 // Printable characters, but worth having constants for them.
@@ -155,6 +160,16 @@ const (
 	KeyCtrl6
 	KeyCtrl7
 	KeyBackspace2
+
+	// The arrow keys pressed together with the shift key. Only the tcell
+	// terminal reports these, it reports the arrow keys pressed with shift
+	// as these keys instead of KeyArrowUp, KeyArrowDown, KeyArrowLeft and
+	// KeyArrowRight. The termbox terminal never reports these, it reports
+	// the plain arrow keys regardless of the shift key.
+	KeyShiftArrowUp
+	KeyShiftArrowDown
+	KeyShiftArrowLeft
+	KeyShiftArrowRight
 )
 
 // Keys declared as duplicates by termbox.
@@ -32,6 +32,7 @@ var lineStyleNames = map[LineStyle]string{
 	Light:  "LineStyleLight",
 	Double: "LineStyleDouble",
 	Round:  "LineStyleRound",
+	Dotted: "LineStyleDotted",
 }
 
 // Supported line styles.
@@ -48,4 +49,7 @@ const (
 
 	// Round is line style using the rounded corners '╭' characters.
 	Round
+
+	// Dotted is line style using the '┄' characters.
+	Dotted
 )
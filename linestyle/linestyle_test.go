@@ -47,6 +47,11 @@ func TestLineStyleName(t *testing.T) {
 			ls:   Round,
 			want: "LineStyleRound",
 		},
+		{
+			desc: "dotted",
+			ls:   Dotted,
+			want: "LineStyleDotted",
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,139 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package offscreen provides an off-screen canvas that custom widgets can use
+// to compose their output before drawing it onto the canvas provided to
+// the widget's Draw method.
+//
+// Unlike the canvas passed to widgets, the API of this package is stable.
+package offscreen
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/runewidth"
+)
+
+// Canvas is a zero-based two dimensional grid of cells that isn't displayed
+// on the terminal. The axes increase right and down.
+// Use New to create an instance.
+type Canvas struct {
+	// cvs stores the cells.
+	cvs *canvas.Canvas
+}
+
+// New returns a new off-screen canvas of the specified size.
+// All the cells are initially empty and have the default cell options.
+func New(size image.Point) (*Canvas, error) {
+	if size.X <= 0 || size.Y <= 0 {
+		return nil, fmt.Errorf("invalid canvas size %v, both dimensions must be positive", size)
+	}
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return nil, err
+	}
+	return &Canvas{cvs: cvs}, nil
+}
+
+// Size returns the size of the canvas.
+func (c *Canvas) Size() image.Point {
+	return c.cvs.Size()
+}
+
+// Area returns the area of the canvas, i.e. a zero-based rectangle of its
+// size.
+func (c *Canvas) Area() image.Rectangle {
+	return c.cvs.Area()
+}
+
+// Clear clears all the content on the canvas.
+func (c *Canvas) Clear() error {
+	return c.cvs.Clear()
+}
+
+// SetCell sets the rune and the options of the cell at the specified point.
+// Returns the number of cells the rune occupies, wide runes occupy two cells
+// when printed on the terminal. See http://www.unicode.org/reports/tr11/.
+// Options that aren't specified retain their previous value.
+func (c *Canvas) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error) {
+	return c.cvs.SetCell(p, r, opts...)
+}
+
+// Cell returns the rune and a copy of the options of the cell at the
+// specified point. The returned options can be passed to SetCell.
+func (c *Canvas) Cell(p image.Point) (rune, *cell.Options, error) {
+	cl, err := c.cvs.Cell(p)
+	if err != nil {
+		return 0, nil, err
+	}
+	return cl.Rune, cl.Opts, nil
+}
+
+// CopyRegion copies the cells in the region of this canvas onto the
+// destination canvas, placing the top left corner of the region at the
+// specified point.
+// The copy is clipped, cells of the region that fall outside of this canvas or
+// outside of the destination canvas are skipped. So are wide runes that
+// wouldn't fully fit onto the destination canvas.
+func (c *Canvas) CopyRegion(dst *Canvas, region image.Rectangle, at image.Point) error {
+	return c.copyRegion(dst.cvs, region, at)
+}
+
+// DrawTo is like CopyRegion, but copies the region onto the canvas provided
+// to the Draw method of a widget.
+func (c *Canvas) DrawTo(dst *canvas.Canvas, region image.Rectangle, at image.Point) error {
+	return c.copyRegion(dst, region, at)
+}
+
+// copyRegion implements CopyRegion and DrawTo.
+func (c *Canvas) copyRegion(dst *canvas.Canvas, region image.Rectangle, at image.Point) error {
+	src := region.Intersect(c.Area())
+	dstAr := dst.Area()
+	for row := src.Min.Y; row < src.Max.Y; row++ {
+		for col := src.Min.X; col < src.Max.X; col++ {
+			p := image.Point{col, row}
+			if col > 0 {
+				// Skip over partial cells, i.e. cells that follow a cell
+				// containing a full-width rune.
+				prev, err := c.cvs.Cell(image.Point{col - 1, row})
+				if err != nil {
+					return err
+				}
+				if runewidth.RuneWidth(prev.Rune) == 2 {
+					continue
+				}
+			}
+
+			cl, err := c.cvs.Cell(p)
+			if err != nil {
+				return err
+			}
+			target := p.Sub(region.Min).Add(at)
+			last := target
+			if runewidth.RuneWidth(cl.Rune) == 2 {
+				last.X++
+			}
+			if !target.In(dstAr) || !last.In(dstAr) {
+				continue
+			}
+			if _, err := dst.SetCell(target, cl.Rune, cl.Opts); err != nil {
+				return fmt.Errorf("dst.SetCell(%v) => %v", target, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offscreen
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/faketerm"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		size    image.Point
+		wantErr bool
+	}{
+		{
+			desc:    "fails on zero width",
+			size:    image.Point{0, 1},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative height",
+			size:    image.Point{1, -1},
+			wantErr: true,
+		},
+		{
+			desc: "creates the canvas",
+			size: image.Point{3, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := New(tc.size)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.Size(); got != tc.size {
+				t.Errorf("Size => %v, want %v", got, tc.size)
+			}
+			if got, want := c.Area(), image.Rect(0, 0, tc.size.X, tc.size.Y); got != want {
+				t.Errorf("Area => %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSetCellAndCell(t *testing.T) {
+	c, err := New(image.Point{2, 2})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if _, err := c.SetCell(image.Point{2, 0}, 'x'); err == nil {
+		t.Errorf("SetCell outside of the canvas => got nil error, want an error")
+	}
+	if _, err := c.SetCell(image.Point{1, 1}, 'x', cell.FgColor(cell.ColorRed)); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if _, _, err := c.Cell(image.Point{0, 2}); err == nil {
+		t.Errorf("Cell outside of the canvas => got nil error, want an error")
+	}
+
+	r, opts, err := c.Cell(image.Point{1, 1})
+	if err != nil {
+		t.Fatalf("Cell => unexpected error: %v", err)
+	}
+	if r != 'x' {
+		t.Errorf("Cell => rune %q, want %q", r, 'x')
+	}
+	wantOpts := cell.NewOptions(cell.FgColor(cell.ColorRed))
+	if diff := pretty.Compare(wantOpts, opts); diff != "" {
+		t.Errorf("Cell => unexpected options, diff (-want, +got):\n%s", diff)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+	if r, _, _ := c.Cell(image.Point{1, 1}); r != 0 {
+		t.Errorf("Cell after Clear => rune %q, want the zero rune", r)
+	}
+}
+
+// fill sets the rows of text onto the canvas, starting at its top left corner.
+func fill(t *testing.T, c *Canvas, rows []string, opts ...cell.Option) {
+	t.Helper()
+	for y, row := range rows {
+		x := 0
+		for _, r := range row {
+			cells, err := c.SetCell(image.Point{x, y}, r, opts...)
+			if err != nil {
+				t.Fatalf("SetCell => unexpected error: %v", err)
+			}
+			x += cells
+		}
+	}
+}
+
+func TestCopyRegion(t *testing.T) {
+	tests := []struct {
+		desc    string
+		src     []string
+		srcSize image.Point
+		dstSize image.Point
+		region  image.Rectangle
+		at      image.Point
+		want    func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:    "copies a region to the same position",
+			src:     []string{"abc", "def", "ghi"},
+			srcSize: image.Point{3, 3},
+			dstSize: image.Point{3, 3},
+			region:  image.Rect(1, 1, 3, 3),
+			at:      image.Point{1, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{1, 1}, 'e', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustSetCell(cvs, image.Point{2, 1}, 'f', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustSetCell(cvs, image.Point{1, 2}, 'h', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustSetCell(cvs, image.Point{2, 2}, 'i', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "moves the region",
+			src:     []string{"abc", "def", "ghi"},
+			srcSize: image.Point{3, 3},
+			dstSize: image.Point{4, 2},
+			region:  image.Rect(0, 2, 3, 3),
+			at:      image.Point{1, 0},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{1, 0}, 'g', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustSetCell(cvs, image.Point{2, 0}, 'h', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustSetCell(cvs, image.Point{3, 0}, 'i', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "clips the region at the edges of the source canvas",
+			src:     []string{"ab", "cd"},
+			srcSize: image.Point{2, 2},
+			dstSize: image.Point{3, 3},
+			region:  image.Rect(-1, -1, 1, 1),
+			at:      image.Point{0, 0},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{1, 1}, 'a', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "clips the region at the right and bottom edges of the destination canvas",
+			src:     []string{"ab", "cd"},
+			srcSize: image.Point{2, 2},
+			dstSize: image.Point{2, 2},
+			region:  image.Rect(0, 0, 2, 2),
+			at:      image.Point{1, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{1, 1}, 'a', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "clips the region at the left and top edges of the destination canvas",
+			src:     []string{"ab", "cd"},
+			srcSize: image.Point{2, 2},
+			dstSize: image.Point{2, 2},
+			region:  image.Rect(0, 0, 2, 2),
+			at:      image.Point{-1, -1},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{0, 0}, 'd', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "copies full-width runes",
+			src:     []string{"世x"},
+			srcSize: image.Point{3, 1},
+			dstSize: image.Point{3, 1},
+			region:  image.Rect(0, 0, 3, 1),
+			at:      image.Point{0, 0},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{0, 0}, '世', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustSetCell(cvs, image.Point{2, 0}, 'x', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "skips full-width runes that don't fit onto the destination",
+			src:     []string{"x世"},
+			srcSize: image.Point{3, 1},
+			dstSize: image.Point{2, 1},
+			region:  image.Rect(0, 0, 3, 1),
+			at:      image.Point{0, 0},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{0, 0}, 'x', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			src, err := New(tc.srcSize)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			fill(t, src, tc.src, cell.FgColor(cell.ColorBlue))
+
+			dst, err := New(tc.dstSize)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := src.CopyRegion(dst, tc.region, tc.at); err != nil {
+				t.Fatalf("CopyRegion => unexpected error: %v", err)
+			}
+
+			got := faketerm.MustNew(tc.dstSize)
+			testcanvas.MustApply(dst.cvs, got)
+			if diff := faketerm.Diff(tc.want(tc.dstSize), got); diff != "" {
+				t.Errorf("CopyRegion => %v", diff)
+			}
+		})
+	}
+}
+
+func TestDrawTo(t *testing.T) {
+	src, err := New(image.Point{3, 1})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	fill(t, src, []string{"abc"}, cell.BgColor(cell.ColorRed))
+
+	// The widget canvas doesn't have to be zero-based on the terminal.
+	ft := faketerm.MustNew(image.Point{5, 2})
+	cvs, err := canvas.New(image.Rect(1, 1, 5, 2))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := src.DrawTo(cvs, src.Area(), image.Point{2, 0}); err != nil {
+		t.Fatalf("DrawTo => unexpected error: %v", err)
+	}
+	testcanvas.MustApply(cvs, ft)
+
+	want := faketerm.MustNew(ft.Size())
+	wantCvs := testcanvas.MustNew(image.Rect(1, 1, 5, 2))
+	testcanvas.MustSetCell(wantCvs, image.Point{2, 0}, 'a', cell.BgColor(cell.ColorRed))
+	testcanvas.MustSetCell(wantCvs, image.Point{3, 0}, 'b', cell.BgColor(cell.ColorRed))
+	testcanvas.MustApply(wantCvs, want)
+	if diff := faketerm.Diff(want, ft); diff != "" {
+		t.Errorf("DrawTo => %v", diff)
+	}
+}
@@ -31,9 +31,9 @@ func hAlign(rect image.Rectangle, ar image.Rectangle, h align.Horizontal) (image
 	switch h {
 	case align.HorizontalRight:
 		// Use gap from above.
-	case align.HorizontalCenter:
+	case align.HorizontalCenter, align.HorizontalDistributed:
 		gap /= 2
-	case align.HorizontalLeft:
+	case align.HorizontalLeft, align.HorizontalJustified:
 		gap = 0
 	default:
 		return image.ZR, fmt.Errorf("unsupported horizontal alignment %v", h)
@@ -57,6 +57,13 @@ func vAlign(rect image.Rectangle, ar image.Rectangle, v align.Vertical) (image.R
 		gap /= 2
 	case align.VerticalTop:
 		gap = 0
+	case align.VerticalBaseline:
+		// The last line of the area goes onto the middle line of the
+		// rectangle, unless the area is too tall.
+		gap = (rect.Dy()-1)/2 - (ar.Dy() - 1)
+		if gap < 0 {
+			gap = 0
+		}
 	default:
 		return image.ZR, fmt.Errorf("unsupported vertical alignment %v", v)
 	}
@@ -69,6 +76,67 @@ func vAlign(rect image.Rectangle, ar image.Rectangle, v align.Vertical) (image.R
 	), nil
 }
 
+// Row aligns items of the specified widths placed next to each other in
+// the rectangle horizontally. Returns the X coordinates where the items
+// start. Items aligned left, center or right are placed without spaces
+// between them. Justified and distributed items are spread evenly, cells
+// that cannot be divided evenly go to the leftmost spaces.
+// The items must fit into the rectangle.
+func Row(rect image.Rectangle, widths []int, h align.Horizontal) ([]int, error) {
+	var total int
+	for i, w := range widths {
+		if w < 0 {
+			return nil, fmt.Errorf("item %d has a negative width %d", i, w)
+		}
+		total += w
+	}
+	if total > rect.Dx() {
+		return nil, fmt.Errorf("cannot align items with total width %d inside rectangle %v, the rectangle is too narrow", total, rect)
+	}
+	if len(widths) == 0 {
+		return nil, nil
+	}
+
+	free := rect.Dx() - total
+	var (
+		lead   int // Cells before the first item.
+		spaces int // Number of spaces between items that share the free cells.
+	)
+	switch h {
+	case align.HorizontalLeft:
+	case align.HorizontalCenter:
+		lead = free / 2
+	case align.HorizontalRight:
+		lead = free
+	case align.HorizontalJustified:
+		spaces = len(widths) - 1
+	case align.HorizontalDistributed:
+		spaces = len(widths) + 1
+		lead = free / spaces
+		if free%spaces > 0 {
+			lead++
+		}
+		free -= lead
+		spaces--
+	default:
+		return nil, fmt.Errorf("unsupported horizontal alignment %v", h)
+	}
+
+	res := make([]int, len(widths))
+	x := rect.Min.X + lead
+	for i, w := range widths {
+		res[i] = x
+		x += w
+		if i < spaces {
+			x += free / spaces
+			if i < free%spaces {
+				x++
+			}
+		}
+	}
+	return res, nil
+}
+
 // Rectangle aligns the area within the rectangle returning the
 // aligned area. The area must fall within the rectangle.
 func Rectangle(rect image.Rectangle, ar image.Rectangle, h align.Horizontal, v align.Vertical) (image.Rectangle, error) {
@@ -208,6 +208,46 @@ func TestRectangle(t *testing.T) {
 			vAlign: align.VerticalBottom,
 			want:   image.Rect(2, 2, 3, 3),
 		},
+		{
+			desc:   "justified single area is aligned left",
+			rect:   image.Rect(0, 0, 3, 3),
+			area:   image.Rect(1, 1, 2, 2),
+			hAlign: align.HorizontalJustified,
+			vAlign: align.VerticalTop,
+			want:   image.Rect(0, 0, 1, 1),
+		},
+		{
+			desc:   "distributed single area is aligned center",
+			rect:   image.Rect(0, 0, 3, 3),
+			area:   image.Rect(1, 1, 2, 2),
+			hAlign: align.HorizontalDistributed,
+			vAlign: align.VerticalTop,
+			want:   image.Rect(1, 0, 2, 1),
+		},
+		{
+			desc:   "aligns single line onto the baseline",
+			rect:   image.Rect(0, 0, 3, 5),
+			area:   image.Rect(0, 0, 1, 1),
+			hAlign: align.HorizontalLeft,
+			vAlign: align.VerticalBaseline,
+			want:   image.Rect(0, 2, 1, 3),
+		},
+		{
+			desc:   "aligns last line of a taller area onto the baseline",
+			rect:   image.Rect(0, 0, 3, 5),
+			area:   image.Rect(0, 0, 1, 2),
+			hAlign: align.HorizontalLeft,
+			vAlign: align.VerticalBaseline,
+			want:   image.Rect(0, 1, 1, 3),
+		},
+		{
+			desc:   "area too tall for the baseline is aligned top",
+			rect:   image.Rect(0, 0, 3, 5),
+			area:   image.Rect(0, 0, 1, 4),
+			hAlign: align.HorizontalLeft,
+			vAlign: align.VerticalBaseline,
+			want:   image.Rect(0, 0, 1, 4),
+		},
 	}
 
 	for _, tc := range tests {
@@ -355,3 +395,134 @@ func TestText(t *testing.T) {
 		})
 	}
 }
+
+func TestRow(t *testing.T) {
+	tests := []struct {
+		desc    string
+		rect    image.Rectangle
+		widths  []int
+		hAlign  align.Horizontal
+		want    []int
+		wantErr bool
+	}{
+		{
+			desc:    "fails on negative width",
+			rect:    image.Rect(0, 0, 10, 1),
+			widths:  []int{1, -1},
+			hAlign:  align.HorizontalLeft,
+			wantErr: true,
+		},
+		{
+			desc:    "fails when items don't fit",
+			rect:    image.Rect(0, 0, 5, 1),
+			widths:  []int{3, 3},
+			hAlign:  align.HorizontalLeft,
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported horizontal alignment",
+			rect:    image.Rect(0, 0, 10, 1),
+			widths:  []int{1},
+			hAlign:  align.Horizontal(-1),
+			wantErr: true,
+		},
+		{
+			desc:   "no items",
+			rect:   image.Rect(0, 0, 10, 1),
+			hAlign: align.HorizontalDistributed,
+		},
+		{
+			desc:   "aligns items left",
+			rect:   image.Rect(0, 0, 10, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalLeft,
+			want:   []int{0, 2, 4},
+		},
+		{
+			desc:   "aligns items center",
+			rect:   image.Rect(0, 0, 10, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalCenter,
+			want:   []int{2, 4, 6},
+		},
+		{
+			desc:   "aligns items right",
+			rect:   image.Rect(0, 0, 10, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalRight,
+			want:   []int{4, 6, 8},
+		},
+		{
+			desc:   "justifies items",
+			rect:   image.Rect(0, 0, 10, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalJustified,
+			want:   []int{0, 4, 8},
+		},
+		{
+			desc:   "justifies items, leftmost space gets the remainder",
+			rect:   image.Rect(0, 0, 11, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalJustified,
+			want:   []int{0, 5, 9},
+		},
+		{
+			desc:   "justifies a single item to the left",
+			rect:   image.Rect(0, 0, 10, 1),
+			widths: []int{2},
+			hAlign: align.HorizontalJustified,
+			want:   []int{0},
+		},
+		{
+			desc:   "distributes items",
+			rect:   image.Rect(0, 0, 14, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalDistributed,
+			want:   []int{2, 6, 10},
+		},
+		{
+			desc:   "distributes items, leftmost spaces get the remainder",
+			rect:   image.Rect(0, 0, 12, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalDistributed,
+			want:   []int{2, 6, 9},
+		},
+		{
+			desc:   "distributes items of different widths",
+			rect:   image.Rect(0, 0, 16, 1),
+			widths: []int{1, 3, 4},
+			hAlign: align.HorizontalDistributed,
+			want:   []int{2, 5, 10},
+		},
+		{
+			desc:   "distributes items, rectangle isn't zero based",
+			rect:   image.Rect(5, 1, 19, 2),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalDistributed,
+			want:   []int{7, 11, 15},
+		},
+		{
+			desc:   "distributes items that fill the rectangle",
+			rect:   image.Rect(0, 0, 6, 1),
+			widths: []int{2, 2, 2},
+			hAlign: align.HorizontalDistributed,
+			want:   []int{0, 2, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := Row(tc.rect, tc.widths, tc.hAlign)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Row => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Row => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -155,6 +155,32 @@ func TestBorder(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "draws dotted border around the canvas",
+			canvas: image.Rect(0, 0, 4, 4),
+			border: image.Rect(0, 0, 4, 4),
+			opts: []BorderOption{
+				BorderLineStyle(linestyle.Dotted),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for x, r := range []rune("┌┄┄┐") {
+					testcanvas.MustSetCell(c, image.Point{x, 0}, r)
+				}
+				for y := 1; y <= 2; y++ {
+					testcanvas.MustSetCell(c, image.Point{0, y}, '┆')
+					testcanvas.MustSetCell(c, image.Point{3, y}, '┆')
+				}
+				for x, r := range []rune("└┄┄┘") {
+					testcanvas.MustSetCell(c, image.Point{x, 3}, r)
+				}
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "draws border in the canvas",
 			canvas: image.Rect(0, 0, 4, 4),
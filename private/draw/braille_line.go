@@ -19,6 +19,7 @@ package draw
 import (
 	"fmt"
 	"image"
+	"math"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/canvas/braille"
@@ -59,6 +60,48 @@ type BrailleLineOption interface {
 type brailleLineOptions struct {
 	cellOpts    []cell.Option
 	pixelChange braillePixelChange
+	dashPattern []int
+	dashOffset  int
+}
+
+// validate validates the provided options.
+func (o *brailleLineOptions) validate() error {
+	if len(o.dashPattern) == 0 {
+		return nil
+	}
+	if len(o.dashPattern)%2 != 0 {
+		return fmt.Errorf("the dash pattern must have an even number of lengths, got %v", o.dashPattern)
+	}
+	for _, l := range o.dashPattern {
+		if l <= 0 {
+			return fmt.Errorf("the lengths in the dash pattern must be positive, got %v", o.dashPattern)
+		}
+	}
+	if o.dashOffset < 0 {
+		return fmt.Errorf("the dash offset cannot be negative, got %d", o.dashOffset)
+	}
+	return nil
+}
+
+// dashOn determines if the pixel at the position along the line is drawn
+// according to the dash pattern.
+func (o *brailleLineOptions) dashOn(pos int) bool {
+	if len(o.dashPattern) == 0 {
+		return true
+	}
+
+	var period int
+	for _, l := range o.dashPattern {
+		period += l
+	}
+	pos = (pos + o.dashOffset) % period
+	for i, l := range o.dashPattern {
+		if pos < l {
+			return i%2 == 0
+		}
+		pos -= l
+	}
+	return true
 }
 
 // newBrailleLineOptions returns a new brailleLineOptions instance.
@@ -94,6 +137,19 @@ func BrailleLineClearPixels() BrailleLineOption {
 	})
 }
 
+// BrailleLineDash draws a dashed line. The pattern contains lengths in pixels
+// of the alternating drawn and skipped parts of the line, starting with a
+// drawn part. The pattern must contain an even number of positive lengths.
+// The offset is the number of pixels of the pattern that are consumed before
+// the start of the line, which allows continuing the pattern across connected
+// line segments.
+func BrailleLineDash(offset int, pattern ...int) BrailleLineOption {
+	return brailleLineOption(func(opts *brailleLineOptions) {
+		opts.dashOffset = offset
+		opts.dashPattern = pattern
+	})
+}
+
 // BrailleLine draws an approximated line segment on the braille canvas between
 // the two provided points.
 // Both start and end must be valid points within the canvas. Start and end can
@@ -112,9 +168,21 @@ func BrailleLine(bc *braille.Canvas, start, end image.Point, opts ...BrailleLine
 	for _, o := range opts {
 		o.set(opt)
 	}
+	if err := opt.validate(); err != nil {
+		return err
+	}
 
 	points := brailleLinePoints(start, end)
-	for _, p := range points {
+	if len(points) > 0 && points[0] != start {
+		// The dash pattern starts at the start point.
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+	for i, p := range points {
+		if !opt.dashOn(i) {
+			continue
+		}
 		switch opt.pixelChange {
 		case braillePixelChangeSet:
 			if err := bc.SetPixel(p, opt.cellOpts...); err != nil {
@@ -129,6 +197,55 @@ func BrailleLine(bc *braille.Canvas, start, end image.Point, opts ...BrailleLine
 	return nil
 }
 
+// BrailleLineFill fills the area between the line segment and the horizontal
+// line at the baseY pixel row on the braille canvas. The filled area includes
+// the pixels of the line segment itself and of the baseline. The line segment
+// can be on either side of the baseline or cross it.
+// Both start and end must be valid points within the canvas, so must be the
+// baseline.
+// Accepts the same options as BrailleLine, e.g. BrailleLineCellOpts sets
+// options on the cells that contain the filled area.
+func BrailleLineFill(bc *braille.Canvas, start, end image.Point, baseY int, opts ...BrailleLineOption) error {
+	if baseY < 0 {
+		return fmt.Errorf("the baseline cannot be negative, got: %d", baseY)
+	}
+
+	for _, p := range brailleLinePoints(start, end) {
+		if err := BrailleLine(bc, p, image.Point{p.X, baseY}, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BrailleBandFill fills the area between the line segment and the base line
+// segment that spans the same pixel columns and starts at the baseStartY and
+// ends at the baseEndY pixel rows on the braille canvas. The filled area
+// includes the pixels of both line segments. The line segment can be on
+// either side of the base line segment or cross it.
+// Both start and end must be valid points within the canvas, so must be the
+// base line segment.
+// Accepts the same options as BrailleLine.
+func BrailleBandFill(bc *braille.Canvas, start, end image.Point, baseStartY, baseEndY int, opts ...BrailleLineOption) error {
+	if baseStartY < 0 || baseEndY < 0 {
+		return fmt.Errorf("the base line cannot be negative, got: %d and %d", baseStartY, baseEndY)
+	}
+
+	for _, p := range brailleLinePoints(start, end) {
+		baseYs := []int{baseStartY, baseEndY}
+		if dx := end.X - start.X; dx != 0 {
+			fraction := float64(p.X-start.X) / float64(dx)
+			baseYs = []int{baseStartY + int(math.Round(fraction*float64(baseEndY-baseStartY)))}
+		}
+		for _, baseY := range baseYs {
+			if err := BrailleLine(bc, p, image.Point{p.X, baseY}, opts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // brailleLinePoints returns the points to set when drawing the line.
 func brailleLinePoints(start, end image.Point) []image.Point {
 	// Implements Bresenham's line algorithm.
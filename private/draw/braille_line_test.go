@@ -408,6 +408,100 @@ func TestBrailleLine(t *testing.T) {
 				testbraille.MustSetPixel(bc, image.Point{0, 0})
 				testbraille.MustSetPixel(bc, image.Point{0, 1})
 
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "fails on dash pattern with odd number of lengths",
+			canvas: image.Rect(0, 0, 4, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{7, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDash(0, 2, 1, 1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails on dash pattern with zero length",
+			canvas: image.Rect(0, 0, 4, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{7, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDash(0, 2, 0),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails on negative dash offset",
+			canvas: image.Rect(0, 0, 4, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{7, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDash(-1, 2, 1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws dashed line",
+			canvas: image.Rect(0, 0, 4, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{7, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDash(0, 2, 1),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustSetPixel(bc, image.Point{1, 0})
+				testbraille.MustSetPixel(bc, image.Point{3, 0})
+				testbraille.MustSetPixel(bc, image.Point{4, 0})
+				testbraille.MustSetPixel(bc, image.Point{6, 0})
+				testbraille.MustSetPixel(bc, image.Point{7, 0})
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "dash pattern starts at the start point of a reversed line",
+			canvas: image.Rect(0, 0, 4, 1),
+			start:  image.Point{7, 0},
+			end:    image.Point{0, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDash(0, 1, 2),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testbraille.MustSetPixel(bc, image.Point{7, 0})
+				testbraille.MustSetPixel(bc, image.Point{4, 0})
+				testbraille.MustSetPixel(bc, image.Point{1, 0})
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "dash pattern continues from the offset",
+			canvas: image.Rect(0, 0, 4, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{7, 0},
+			opts: []BrailleLineOption{
+				BrailleLineDash(2, 2, 2),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testbraille.MustSetPixel(bc, image.Point{2, 0})
+				testbraille.MustSetPixel(bc, image.Point{3, 0})
+				testbraille.MustSetPixel(bc, image.Point{6, 0})
+				testbraille.MustSetPixel(bc, image.Point{7, 0})
+
 				testbraille.MustApply(bc, ft)
 				return ft
 			},
@@ -455,3 +549,250 @@ func TestBrailleLine(t *testing.T) {
 		})
 	}
 }
+
+func TestBrailleLineFill(t *testing.T) {
+	tests := []struct {
+		desc    string
+		canvas  image.Rectangle
+		start   image.Point
+		end     image.Point
+		baseY   int
+		opts    []BrailleLineOption
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:    "fails on negative baseline",
+			canvas:  image.Rect(0, 0, 1, 1),
+			start:   image.Point{0, 0},
+			end:     image.Point{1, 1},
+			baseY:   -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on baseline outside of the canvas",
+			canvas:  image.Rect(0, 0, 1, 1),
+			start:   image.Point{0, 0},
+			end:     image.Point{1, 1},
+			baseY:   4,
+			wantErr: true,
+		},
+		{
+			desc:   "fills the area under the line",
+			canvas: image.Rect(0, 0, 2, 1),
+			start:  image.Point{0, 3},
+			end:    image.Point{3, 0},
+			baseY:  3,
+			opts: []BrailleLineOption{
+				BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				opts := []cell.Option{cell.FgColor(cell.ColorRed)}
+				testbraille.MustSetPixel(bc, image.Point{0, 3}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{1, 2}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{1, 3}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{2, 1}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{2, 2}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{2, 3}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 0}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 1}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 2}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 3}, opts...)
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "fills toward the baseline from both sides",
+			canvas: image.Rect(0, 0, 2, 1),
+			start:  image.Point{0, 0},
+			end:    image.Point{3, 3},
+			baseY:  1,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustSetPixel(bc, image.Point{0, 1})
+				testbraille.MustSetPixel(bc, image.Point{1, 1})
+				testbraille.MustSetPixel(bc, image.Point{2, 1})
+				testbraille.MustSetPixel(bc, image.Point{2, 2})
+				testbraille.MustSetPixel(bc, image.Point{3, 1})
+				testbraille.MustSetPixel(bc, image.Point{3, 2})
+				testbraille.MustSetPixel(bc, image.Point{3, 3})
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			bc, err := braille.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("braille.New => unexpected error: %v", err)
+			}
+
+			err = BrailleLineFill(bc, tc.start, tc.end, tc.baseY, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("BrailleLineFill => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			size := area.Size(tc.canvas)
+			got, err := faketerm.New(size)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := bc.Apply(got); err != nil {
+				t.Fatalf("bc.Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(size), got); diff != "" {
+				t.Fatalf("BrailleLineFill => %v", diff)
+			}
+		})
+	}
+}
+
+func TestBrailleBandFill(t *testing.T) {
+	tests := []struct {
+		desc       string
+		canvas     image.Rectangle
+		start      image.Point
+		end        image.Point
+		baseStartY int
+		baseEndY   int
+		opts       []BrailleLineOption
+		want       func(size image.Point) *faketerm.Terminal
+		wantErr    bool
+	}{
+		{
+			desc:       "fails on negative base line",
+			canvas:     image.Rect(0, 0, 1, 1),
+			start:      image.Point{0, 0},
+			end:        image.Point{1, 1},
+			baseStartY: 3,
+			baseEndY:   -1,
+			wantErr:    true,
+		},
+		{
+			desc:       "fails on base line outside of the canvas",
+			canvas:     image.Rect(0, 0, 1, 1),
+			start:      image.Point{0, 0},
+			end:        image.Point{1, 1},
+			baseStartY: 4,
+			baseEndY:   3,
+			wantErr:    true,
+		},
+		{
+			desc:       "fills the band between two lines",
+			canvas:     image.Rect(0, 0, 2, 1),
+			start:      image.Point{0, 0},
+			end:        image.Point{3, 0},
+			baseStartY: 0,
+			baseEndY:   3,
+			opts: []BrailleLineOption{
+				BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				opts := []cell.Option{cell.FgColor(cell.ColorRed)}
+				testbraille.MustSetPixel(bc, image.Point{0, 0}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{1, 0}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{1, 1}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{2, 0}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{2, 1}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{2, 2}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 0}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 1}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 2}, opts...)
+				testbraille.MustSetPixel(bc, image.Point{3, 3}, opts...)
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:       "fills toward a horizontal base line",
+			canvas:     image.Rect(0, 0, 2, 1),
+			start:      image.Point{0, 3},
+			end:        image.Point{3, 0},
+			baseStartY: 3,
+			baseEndY:   3,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+				testbraille.MustSetPixel(bc, image.Point{0, 3})
+				testbraille.MustSetPixel(bc, image.Point{1, 2})
+				testbraille.MustSetPixel(bc, image.Point{1, 3})
+				testbraille.MustSetPixel(bc, image.Point{2, 1})
+				testbraille.MustSetPixel(bc, image.Point{2, 2})
+				testbraille.MustSetPixel(bc, image.Point{2, 3})
+				testbraille.MustSetPixel(bc, image.Point{3, 0})
+				testbraille.MustSetPixel(bc, image.Point{3, 1})
+				testbraille.MustSetPixel(bc, image.Point{3, 2})
+				testbraille.MustSetPixel(bc, image.Point{3, 3})
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:       "fills toward both ends of a vertical base line",
+			canvas:     image.Rect(0, 0, 1, 1),
+			start:      image.Point{0, 1},
+			end:        image.Point{0, 2},
+			baseStartY: 0,
+			baseEndY:   3,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustSetPixel(bc, image.Point{0, 1})
+				testbraille.MustSetPixel(bc, image.Point{0, 2})
+				testbraille.MustSetPixel(bc, image.Point{0, 3})
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			bc, err := braille.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("braille.New => unexpected error: %v", err)
+			}
+
+			err = BrailleBandFill(bc, tc.start, tc.end, tc.baseStartY, tc.baseEndY, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("BrailleBandFill => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			size := area.Size(tc.canvas)
+			got, err := faketerm.New(size)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := bc.Apply(got); err != nil {
+				t.Fatalf("bc.Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(size), got); diff != "" {
+				t.Fatalf("BrailleBandFill => %v", diff)
+			}
+		})
+	}
+}
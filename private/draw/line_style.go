@@ -66,6 +66,19 @@ var lineStyleChars = map[linestyle.LineStyle]map[linePart]rune{
 		vAndRight:         '├',
 		vAndH:             '┼',
 	},
+	linestyle.Dotted: {
+		hLine:             '┄',
+		vLine:             '┆',
+		topLeftCorner:     '┌',
+		topRightCorner:    '┐',
+		bottomLeftCorner:  '└',
+		bottomRightCorner: '┘',
+		hAndUp:            '┴',
+		hAndDown:          '┬',
+		vAndLeft:          '┤',
+		vAndRight:         '├',
+		vAndH:             '┼',
+	},
 }
 
 // init verifies that all line parts are half-width runes (occupy only one
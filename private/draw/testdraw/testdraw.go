@@ -66,6 +66,20 @@ func MustBrailleLine(bc *braille.Canvas, start, end image.Point, opts ...draw.Br
 	}
 }
 
+// MustBrailleBandFill fills the area between two braille lines or panics.
+func MustBrailleBandFill(bc *braille.Canvas, start, end image.Point, baseStartY, baseEndY int, opts ...draw.BrailleLineOption) {
+	if err := draw.BrailleBandFill(bc, start, end, baseStartY, baseEndY, opts...); err != nil {
+		panic(fmt.Sprintf("draw.BrailleBandFill => unexpected error: %v", err))
+	}
+}
+
+// MustBrailleLineFill fills the area under the braille line or panics.
+func MustBrailleLineFill(bc *braille.Canvas, start, end image.Point, baseY int, opts ...draw.BrailleLineOption) {
+	if err := draw.BrailleLineFill(bc, start, end, baseY, opts...); err != nil {
+		panic(fmt.Sprintf("draw.BrailleLineFill => unexpected error: %v", err))
+	}
+}
+
 // MustBrailleCircle draws the braille circle or panics.
 func MustBrailleCircle(bc *braille.Canvas, mid image.Point, radius int, opts ...draw.BrailleCircleOption) {
 	if err := draw.BrailleCircle(bc, mid, radius, opts...); err != nil {
@@ -137,6 +137,20 @@ func (t *Terminal) String() string {
 	return b.String()
 }
 
+// Capabilities implements terminalapi.CapabilityReporter.Capabilities.
+func (t *Terminal) Capabilities() terminalapi.Capabilities {
+	return terminalapi.Capabilities{
+		ColorMode:     terminalapi.ColorModeTrueColor,
+		Bold:          true,
+		Italic:        true,
+		Underline:     true,
+		Strikethrough: true,
+		Inverse:       true,
+		Blink:         true,
+		Dim:           true,
+	}
+}
+
 // Size implements terminalapi.Terminal.Size.
 func (t *Terminal) Size() image.Point {
 	t.mu.Lock()
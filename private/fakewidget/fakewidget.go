@@ -31,13 +31,14 @@ import (
 )
 
 // outputLines are the number of lines written by this plugin.
-const outputLines = 4
+const outputLines = 5
 
 const (
 	sizeLine = iota
 	keyboardLine
 	mouseLine
 	focusLine
+	resizeLine
 )
 
 // MinimumSize is the minimum size required to draw this widget.
@@ -55,13 +56,14 @@ type Event struct {
 // canvas and writes the size of its assigned canvas on the first line of the
 // canvas.
 //
-// It writes the last received keyboard event onto the second line. It
+// It writes the last received keyboard or paste event onto the second line. It
 // writes the last received mouse event onto the third line. If the widget was
 // focused at the time of the event, the event will be prepended with a "F:".
 //
 // If a non-empty string is provided via the Text() method, that text will be
 // written right after the canvas size on the first line. If the widget's
-// container is focused it writes "focus" onto the fourth line.
+// container is focused it writes "focus" onto the fourth line. It writes the
+// terminal size from the last received resize event onto the fifth line.
 //
 // The widget requests the same options that are provided to the constructor.
 // If the options or canvas size don't allow for the lines mentioned above, the
@@ -175,6 +177,31 @@ func (mi *Mirror) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
 	return nil
 }
 
+// Paste draws the pasted text on the canvas in place of the last received
+// keyboard event, prefixed with "P:".
+// Paste implements widgetapi.Paster.Paste.
+func (mi *Mirror) Paste(p *terminalapi.Paste, meta *widgetapi.EventMeta) error {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	if meta.Focused {
+		mi.lines[keyboardLine] = fmt.Sprintf("F:P:%s", p.Text)
+	} else {
+		mi.lines[keyboardLine] = fmt.Sprintf("P:%s", p.Text)
+	}
+	return nil
+}
+
+// Resize draws the terminal size from the resize event on the canvas.
+// Resize implements widgetapi.Resizer.Resize.
+func (mi *Mirror) Resize(r *terminalapi.Resize, meta *widgetapi.EventMeta) error {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	mi.lines[resizeLine] = fmt.Sprintf("R:%v", r.Size)
+	return nil
+}
+
 // Options implements widgetapi.Widget.Options.
 func (mi *Mirror) Options() widgetapi.Options {
 	return mi.opts
@@ -212,6 +239,20 @@ func DrawWithMirror(mirror *Mirror, t terminalapi.Terminal, cvs *canvas.Canvas,
 			if err := mirror.Keyboard(e, ev.Meta); err != nil {
 				return err
 			}
+		case *terminalapi.Paste:
+			if mirror.opts.WantKeyboard == widgetapi.KeyScopeNone {
+				continue
+			}
+			if err := mirror.Paste(e, ev.Meta); err != nil {
+				return err
+			}
+		case *terminalapi.Resize:
+			if !mirror.opts.WantResize {
+				continue
+			}
+			if err := mirror.Resize(e, ev.Meta); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unsupported event type %T", e)
 		}
@@ -196,6 +196,23 @@ func TestMirror(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "draws the last paste event",
+			apiEvents: func(mi *Mirror) {
+				mi.Paste(&terminalapi.Paste{Text: "abc"}, &widgetapi.EventMeta{Focused: true})
+			},
+			cvs:  testcanvas.MustNew(image.Rect(0, 0, 12, 4)),
+			meta: &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, cvs.Area())
+				testdraw.MustText(cvs, "(12,4)", image.Point{1, 1})
+				testdraw.MustText(cvs, "F:P:abc", image.Point{1, 2})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc: "draws the last mouse event",
 			mouseEvents: []mouseEvents{
@@ -0,0 +1,123 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package palette finds the terminal palette colors nearest to true colors.
+package palette
+
+import (
+	"github.com/mum4k/termdash/cell"
+)
+
+// cubeLevels are the intensities of the components in the 6x6x6 color cube.
+var cubeLevels = func() [6]int {
+	var levels [6]int
+	for i := range levels {
+		levels[i], _, _ = cell.ColorRGB6(i, 0, 0).RGB()
+	}
+	return levels
+}()
+
+// distance returns the squared euclidean distance between two RGB colors.
+func distance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+// nearestCubeLevel returns the index of the cube level closest to v.
+func nearestCubeLevel(v int) int {
+	best := 0
+	for i, l := range cubeLevels {
+		if abs(l-v) < abs(cubeLevels[best]-v) {
+			best = i
+		}
+	}
+	return best
+}
+
+// abs returns the absolute value of v.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// NearestCube returns the color from the 6x6x6 color cube that is nearest to
+// the provided true color. Colors that aren't true colors are returned
+// unchanged.
+func NearestCube(c cell.Color) cell.Color {
+	if !c.IsRGB24() {
+		return c
+	}
+	r, g, b := c.RGB()
+	return cell.ColorRGB6(nearestCubeLevel(r), nearestCubeLevel(g), nearestCubeLevel(b))
+}
+
+// NearestGrayscale returns the color from the 24 shades of grey that is
+// nearest to the provided true color. Colors that aren't true colors are
+// returned unchanged.
+func NearestGrayscale(c cell.Color) cell.Color {
+	if !c.IsRGB24() {
+		return c
+	}
+	r, g, b := c.RGB()
+	// The shades of grey have values 8, 18, ..., 238.
+	gray := ((r+g+b)/3 - 8 + 5) / 10
+	if gray < 0 {
+		gray = 0
+	}
+	if gray > 23 {
+		gray = 23
+	}
+	return cell.ColorNumber(232 + gray)
+}
+
+// Nearest256 returns the color from the 6x6x6 color cube or from the 24 shades
+// of grey that is nearest to the provided true color. The 16 base colors
+// aren't considered, since terminals commonly redefine them. Colors that
+// aren't true colors are returned unchanged.
+func Nearest256(c cell.Color) cell.Color {
+	if !c.IsRGB24() {
+		return c
+	}
+	r, g, b := c.RGB()
+	cube := NearestCube(c)
+	gray := NearestGrayscale(c)
+
+	cr, cg, cb := cube.RGB()
+	gr, gg, gb := gray.RGB()
+	if distance(gr, gg, gb, r, g, b) < distance(cr, cg, cb, r, g, b) {
+		return gray
+	}
+	return cube
+}
+
+// Nearest16 returns the one of the 16 base Xterm colors that is nearest to the
+// provided true color. Colors that aren't true colors are returned unchanged.
+func Nearest16(c cell.Color) cell.Color {
+	if !c.IsRGB24() {
+		return c
+	}
+	r, g, b := c.RGB()
+	best := cell.ColorNumber(0)
+	bestDist := -1
+	for n := 0; n < 16; n++ {
+		cand := cell.ColorNumber(n)
+		cr, cg, cb := cand.RGB()
+		if d := distance(cr, cg, cb, r, g, b); bestDist < 0 || d < bestDist {
+			best, bestDist = cand, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package palette
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		desc          string
+		color         cell.Color
+		want16        cell.Color
+		want256       cell.Color
+		wantCube      cell.Color
+		wantGrayscale cell.Color
+	}{
+		{
+			desc:          "palette colors are returned unchanged",
+			color:         cell.ColorNumber(100),
+			want16:        cell.ColorNumber(100),
+			want256:       cell.ColorNumber(100),
+			wantCube:      cell.ColorNumber(100),
+			wantGrayscale: cell.ColorNumber(100),
+		},
+		{
+			desc:          "black",
+			color:         cell.ColorRGB24(0, 0, 0),
+			want16:        cell.ColorBlack,
+			want256:       cell.ColorRGB6(0, 0, 0),
+			wantCube:      cell.ColorRGB6(0, 0, 0),
+			wantGrayscale: cell.ColorNumber(232),
+		},
+		{
+			desc:          "white",
+			color:         cell.ColorRGB24(255, 255, 255),
+			want16:        cell.ColorWhite,
+			want256:       cell.ColorRGB6(5, 5, 5),
+			wantCube:      cell.ColorRGB6(5, 5, 5),
+			wantGrayscale: cell.ColorNumber(255),
+		},
+		{
+			desc:          "exact cube color",
+			color:         cell.ColorRGB24(95, 135, 255),
+			want16:        cell.ColorSilver,
+			want256:       cell.ColorRGB6(1, 2, 5),
+			wantCube:      cell.ColorRGB6(1, 2, 5),
+			wantGrayscale: cell.ColorNumber(247),
+		},
+		{
+			desc:          "grey prefers the shades of grey",
+			color:         cell.ColorRGB24(50, 50, 50),
+			want16:        cell.ColorBlack,
+			want256:       cell.ColorNumber(236),
+			wantCube:      cell.ColorRGB6(1, 1, 1),
+			wantGrayscale: cell.ColorNumber(236),
+		},
+		{
+			desc:          "nearest color",
+			color:         cell.ColorRGB24(200, 10, 20),
+			want16:        cell.ColorRed,
+			want256:       cell.ColorRGB6(4, 0, 0),
+			wantCube:      cell.ColorRGB6(4, 0, 0),
+			wantGrayscale: cell.ColorNumber(239),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := Nearest16(tc.color); got != tc.want16 {
+				t.Errorf("Nearest16(%v) => %v, want %v", tc.color, got, tc.want16)
+			}
+			if got := Nearest256(tc.color); got != tc.want256 {
+				t.Errorf("Nearest256(%v) => %v, want %v", tc.color, got, tc.want256)
+			}
+			if got := NearestCube(tc.color); got != tc.wantCube {
+				t.Errorf("NearestCube(%v) => %v, want %v", tc.color, got, tc.wantCube)
+			}
+			if got := NearestGrayscale(tc.color); got != tc.wantGrayscale {
+				t.Errorf("NearestGrayscale(%v) => %v, want %v", tc.color, got, tc.wantGrayscale)
+			}
+		})
+	}
+}
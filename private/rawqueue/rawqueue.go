@@ -0,0 +1,45 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rawqueue provides a queue of raw strings the terminals write on the
+// next flush.
+package rawqueue
+
+import "sync"
+
+// Queue holds the strings queued by WriteRaw of a terminal.
+// The zero value is an empty queue ready to use. Queue must not be copied,
+// pass it by reference only.
+// This implementation is thread-safe.
+type Queue struct {
+	mu      sync.Mutex
+	pending []string
+}
+
+// Add queues the string.
+func (q *Queue) Add(s string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, s)
+}
+
+// Take returns and removes all the queued strings in the order they were
+// added. Returns nil if the queue is empty.
+func (q *Queue) Take() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pending := q.pending
+	q.pending = nil
+	return pending
+}
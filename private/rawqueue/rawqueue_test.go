@@ -0,0 +1,62 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawqueue
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		desc string
+		// add are the strings added before each call to Take.
+		add  [][]string
+		want [][]string
+	}{
+		{
+			desc: "empty queue",
+			add:  [][]string{nil},
+			want: [][]string{nil},
+		},
+		{
+			desc: "takes the strings in the order they were added",
+			add:  [][]string{{"a", "b", "c"}},
+			want: [][]string{{"a", "b", "c"}},
+		},
+		{
+			desc: "take empties the queue",
+			add:  [][]string{{"a", "b"}, nil, {"c"}},
+			want: [][]string{{"a", "b"}, nil, {"c"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var q Queue
+			var got [][]string
+			for _, add := range tc.add {
+				for _, s := range add {
+					q.Add(s)
+				}
+				got = append(got, q.Take())
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Take => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -95,6 +95,9 @@ type Attributes struct {
 	// VertBotY is the Y coordinate where the area of the segment vertically
 	// at the bottom starts, i.e. Y coordinate of D1 and D2.
 	VertBotY int
+
+	// width is the width of the pixel area.
+	width int
 }
 
 // NewAttributes calculates attributes needed to place the segments for the
@@ -161,6 +164,8 @@ func NewAttributes(bcAr image.Rectangle) *Attributes {
 		horizRightX: horizRightX,
 		vertCenY:    vertCenY,
 		VertBotY:    vertBotY,
+
+		width: bcAr.Dx(),
 	}
 }
 
@@ -283,3 +288,20 @@ func (a *Attributes) diaBetween(top, left, right, bottom Segment) image.Rectangl
 	endY := int(math.Round(float64(rightAr.Max.Y) - a.segPeakDist + a.diaLeg - hvToDiaGap))
 	return image.Rect(startX, startY, endX, endY)
 }
+
+// dpArea returns the area for the decimal point segment.
+// The decimal point is a square placed right of the C segment and vertically
+// aligned with the D1 and D2 segments. It shrinks if there isn't enough space
+// right of the C segment, the returned area is empty if there is no space at
+// all.
+func (a *Attributes) dpArea() image.Rectangle {
+	c := a.hvSegArea(C)
+	size := a.segSize
+	if avail := a.width - c.Max.X; avail < size {
+		size = avail
+	}
+	if size <= 0 {
+		return image.ZR
+	}
+	return image.Rect(c.Max.X, a.VertBotY, c.Max.X+size, a.VertBotY+size)
+}
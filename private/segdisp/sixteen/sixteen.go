@@ -34,8 +34,11 @@ The following outlines segments in the display and their names.
 	E |   N   M   L   | C
 	  |  /    |    \  |
 	  | /     |     \ |
-	   ------- -------
-	     D1      D2
+	   ------- ------- o
+	     D1      D2    DP
+
+The decimal point DP is an additional segment placed right of the bottom
+segments, it isn't used by any of the characters.
 */
 package sixteen
 
@@ -78,6 +81,7 @@ var segmentNames = map[Segment]string{
 	L:  "L",
 	M:  "M",
 	N:  "N",
+	DP: "DP",
 }
 
 const (
@@ -115,6 +119,8 @@ const (
 	M
 	// N is a segment, see the diagram above.
 	N
+	// DP is the decimal point segment, see the diagram above.
+	DP
 
 	segmentMax // Used for validation.
 )
@@ -228,7 +234,8 @@ var characterSegments = map[rune][]Segment{
 
 // SupportsChars asserts whether the display supports all runes in the
 // provided string.
-// The display only supports a subset of ASCII characters.
+// The display supports all the printable ASCII characters, i.e. the range
+// from ' ' to '~'.
 // Returns any unsupported runes found in the string in an unspecified order.
 func SupportsChars(s string) (bool, []rune) {
 	unsupp := map[rune]bool{}
@@ -248,10 +255,16 @@ func SupportsChars(s string) (bool, []rune) {
 // Sanitize returns a copy of the string, replacing all unsupported characters
 // with a space character.
 func Sanitize(s string) string {
+	return SanitizeWith(s, ' ')
+}
+
+// SanitizeWith returns a copy of the string, replacing all unsupported
+// characters with the fallback character.
+func SanitizeWith(s string, fallback rune) string {
 	var b strings.Builder
 	for _, r := range s {
 		if _, ok := characterSegments[r]; !ok {
-			b.WriteRune(' ')
+			b.WriteRune(fallback)
 			continue
 		}
 		b.WriteRune(r)
@@ -260,9 +273,13 @@ func Sanitize(s string) string {
 }
 
 // AllSegments returns all 16 segments in an undefined order.
+// The decimal point DP isn't included.
 func AllSegments() []Segment {
 	var res []Segment
 	for s := range segmentNames {
+		if s == DP {
+			continue
+		}
 		res = append(res, s)
 	}
 	return res
@@ -356,8 +373,8 @@ func (d *Display) ToggleSegment(s Segment) error {
 
 // SetCharacter sets all the segments that are needed to display the provided
 // character.
-// The display only supports a subset of ASCII characters, use SupportsChars()
-// or Sanitize() to ensure the provided character is supported.
+// The display only supports the printable ASCII characters, use
+// SupportsChars() or Sanitize() to ensure the provided character is supported.
 // Doesn't clear the display of segments set previously.
 func (d *Display) SetCharacter(c rune) error {
 	seg, ok := characterSegments[c]
@@ -436,5 +453,13 @@ func (d *Display) Draw(cvs *canvas.Canvas, opts ...Option) error {
 			return fmt.Errorf("failed to draw segment %v, segment.Diagonal => %v", seg, err)
 		}
 	}
+
+	if d.segments[DP] {
+		if ar := attr.dpArea(); !ar.Empty() {
+			if err := segment.HV(bc, ar, segment.Vertical, sOpts...); err != nil {
+				return fmt.Errorf("failed to draw segment %v, segment.HV => %v", DP, err)
+			}
+		}
+	}
 	return bc.CopyTo(cvs)
 }
@@ -286,6 +286,36 @@ func TestDraw(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:       "smallest valid display 6x5, DP",
+			cellCanvas: image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows),
+			update: func(d *Display) error {
+				return d.SetSegment(DP)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testsegment.MustHV(bc, image.Rect(9, 16, 10, 17), segment.Vertical) // DP
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:       "segment width of two, DP",
+			cellCanvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows*2),
+			update: func(d *Display) error {
+				return d.SetSegment(DP)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testsegment.MustHV(bc, image.Rect(22, 36, 24, 38), segment.Vertical) // DP
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
 		{
 			desc:       "smallest valid display 6x5, H",
 			cellCanvas: image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows),
@@ -1674,6 +1704,14 @@ func TestSupportsChars(t *testing.T) {
 	}
 }
 
+func TestSupportsAllPrintableASCII(t *testing.T) {
+	for r := ' '; r <= '~'; r++ {
+		if ok, _ := SupportsChars(string(r)); !ok {
+			t.Errorf("SupportsChars(%q) => false, want true for all printable ASCII characters", r)
+		}
+	}
+}
+
 func TestSanitize(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1709,3 +1747,38 @@ func TestSanitize(t *testing.T) {
 		})
 	}
 }
+
+func TestSanitizeWith(t *testing.T) {
+	tests := []struct {
+		desc     string
+		str      string
+		fallback rune
+		want     string
+	}{
+		{
+			desc:     "no alternation to empty string",
+			fallback: '?',
+		},
+		{
+			desc:     "all characters are supported",
+			str:      " wW",
+			fallback: '?',
+			want:     " wW",
+		},
+		{
+			desc:     "replaces unsupported characters with the fallback",
+			str:      " w←W:\t",
+			fallback: '?',
+			want:     " w?W:?",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := SanitizeWith(tc.str, tc.fallback)
+			if got != tc.want {
+				t.Errorf("SanitizeWith => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -53,6 +53,8 @@ func (o option) set(td *termdash) {
 }
 
 // RedrawInterval sets how often termdash redraws the container and all the widgets.
+// Widgets can request more frequent redraws, see
+// widgetapi.Options.RedrawInterval.
 // Defaults to DefaultRedrawInterval. Use the controller to disable the
 // periodic redraw.
 func RedrawInterval(t time.Duration) Option {
@@ -73,6 +75,8 @@ func ErrorHandler(f func(error)) Option {
 
 // KeyboardSubscriber registers a subscriber for Keyboard events. Each
 // keyboard event is forwarded to the container and the registered subscriber.
+// Events that report key releases aren't forwarded to the subscriber, see
+// terminalapi.Keyboard.Release.
 // The provided function must be thread-safe.
 func KeyboardSubscriber(f func(*terminalapi.Keyboard)) Option {
 	return option(func(td *termdash) {
@@ -220,12 +224,13 @@ func (td *termdash) subscribers() {
 		td.setClearNeeded()
 	})
 
-	// Redraws the screen on Keyboard and Mouse events.
+	// Redraws the screen on Keyboard, Mouse and Paste events.
 	// These events very likely change the content of the widgets (e.g. zooming
 	// a LineChart) so a redraw is needed to make that visible.
 	td.eds.Subscribe([]terminalapi.Event{
 		&terminalapi.Keyboard{},
 		&terminalapi.Mouse{},
+		&terminalapi.Paste{},
 	}, func(terminalapi.Event) {
 		td.evRedraw()
 	}, event.MaxRepetitive(0)) // No repetitive events that cause terminal redraw.
@@ -233,7 +238,9 @@ func (td *termdash) subscribers() {
 	// Keyboard and Mouse subscribers specified via options.
 	if td.keyboardSubscriber != nil {
 		td.eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(ev terminalapi.Event) {
-			td.keyboardSubscriber(ev.(*terminalapi.Keyboard))
+			if k := ev.(*terminalapi.Keyboard); !k.Release {
+				td.keyboardSubscriber(k)
+			}
 		})
 	}
 	if td.mouseSubscriber != nil {
@@ -301,6 +308,15 @@ func (td *termdash) periodicRedraw() error {
 	return td.redraw()
 }
 
+// nextRedraw returns the time until the next periodic redraw. This is the
+// RedrawInterval unless any of the widgets requests a shorter interval.
+func (td *termdash) nextRedraw() time.Duration {
+	if d := td.container.RedrawInterval(); d > 0 && d < td.redrawInterval {
+		return d
+	}
+	return td.redrawInterval
+}
+
 // processEvents processes terminal input events.
 // This is the body of the event collecting goroutine.
 func (td *termdash) processEvents(ctx context.Context) {
@@ -329,7 +345,7 @@ func (td *termdash) start(ctx context.Context) error {
 		return err
 	}
 
-	redrawTimer := time.NewTicker(td.redrawInterval)
+	redrawTimer := time.NewTimer(td.nextRedraw())
 	defer redrawTimer.Stop()
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -344,6 +360,7 @@ func (td *termdash) start(ctx context.Context) error {
 			if err := td.periodicRedraw(); err != nil {
 				return err
 			}
+			redrawTimer.Reset(td.nextRedraw())
 
 		case <-ctx.Done():
 			return nil
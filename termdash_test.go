@@ -26,6 +26,7 @@ import (
 	"github.com/mum4k/termdash/container"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/testcanvas"
 	"github.com/mum4k/termdash/private/event"
 	"github.com/mum4k/termdash/private/event/eventqueue"
@@ -361,6 +362,45 @@ func TestRun(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "doesn't forward key releases to the subscriber",
+			size: image.Point{60, 10},
+			opts: func(eh *eventHandlers) []Option {
+				return []Option{
+					RedrawInterval(1),
+					KeyboardSubscriber(eh.keySub.receive),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyF1},
+				&terminalapi.Keyboard{Key: keyboard.KeyF2, Release: true},
+			},
+			wantProcessed: 6,
+			after: func(eh *eventHandlers) error {
+				want := terminalapi.Keyboard{Key: keyboard.KeyF1}
+				if diff := pretty.Compare(want, eh.keySub.get()); diff != "" {
+					return fmt.Errorf("keySubscriber got unexpected value, diff (-want, +got):\n%s", diff)
+				}
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{
+						WantKeyboard: widgetapi.KeyScopeFocused,
+					},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyF1},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
 		{
 			desc: "forwards mouse events to the subscriber",
 			size: image.Point{60, 10},
@@ -469,6 +509,89 @@ func TestRun(t *testing.T) {
 	}
 }
 
+// drawCounter is a widget that counts how many times it was drawn.
+type drawCounter struct {
+	opts  widgetapi.Options
+	mu    sync.Mutex
+	draws int
+}
+
+func (dc *drawCounter) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.draws++
+	return nil
+}
+
+func (dc *drawCounter) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	return nil
+}
+
+func (dc *drawCounter) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	return nil
+}
+
+func (dc *drawCounter) Options() widgetapi.Options {
+	return dc.opts
+}
+
+func (dc *drawCounter) get() int {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	return dc.draws
+}
+
+func TestRunWidgetRedrawInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc string
+		opts widgetapi.Options
+		// wantMin and wantMax bound the number of draws.
+		wantMin int
+		wantMax int
+	}{
+		{
+			desc:    "widget without a redraw interval is drawn only once",
+			wantMin: 1,
+			wantMax: 1,
+		},
+		{
+			desc:    "widget requesting redraw interval is drawn repeatedly",
+			opts:    widgetapi.Options{RedrawInterval: 100 * time.Millisecond},
+			wantMin: 3,
+			wantMax: 10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc := tc
+			t.Parallel()
+
+			ft, err := faketerm.New(image.Point{10, 10}, faketerm.WithEventQueue(eventqueue.New()))
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			dc := &drawCounter{opts: tc.opts}
+			cont, err := container.New(ft, container.PlaceWidget(dc))
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 550*time.Millisecond)
+			defer cancel()
+			if err := Run(ctx, ft, cont, RedrawInterval(time.Hour)); err != nil {
+				t.Fatalf("Run => unexpected error: %v", err)
+			}
+
+			if got := dc.get(); got < tc.wantMin || got > tc.wantMax {
+				t.Errorf("widget drawn %d times, want %d <= draws <= %d", got, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
+
 func TestController(t *testing.T) {
 	t.Parallel()
 
@@ -594,7 +717,8 @@ func TestController(t *testing.T) {
 			events: []terminalapi.Event{
 				&terminalapi.Resize{Size: image.Point{70, 10}},
 			},
-			wantProcessed: 1,
+			// Processed by termdash and the container.
+			wantProcessed: 2,
 			controls: func(ctrl *Controller) error {
 				return ctrl.Redraw()
 			},
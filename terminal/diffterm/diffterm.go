@@ -0,0 +1,225 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package diffterm implements a terminal that only writes the changed cells.
+
+The diffterm Terminal wraps another terminal. It keeps the cells set since
+the last flush in its own buffer and when flushed, writes to the wrapped
+terminal only the cells that differ from the previously flushed frame.
+Redrawing a frame that didn't change writes no cells at all. This reduces
+the amount of data sent to terminals that don't do this themselves and the
+flicker on slow connections, e.g. on SSH sessions with a high latency.
+
+Use the diffterm Terminal in place of the wrapped terminal, i.e. provide it
+to both the container and termdash:
+
+	t, err := tcell.New()
+	...
+	dt := diffterm.New(t)
+	c, err := container.New(dt, ...)
+	...
+	err = termdash.Run(ctx, dt, c)
+*/
+package diffterm
+
+import (
+	"context"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Terminal wraps a terminal and writes only the cells that changed since the
+// previous flush.
+// This implementation is thread-safe.
+// Implements terminalapi.Terminal.
+type Terminal struct {
+	// term is the wrapped terminal.
+	term terminalapi.Terminal
+
+	// back holds the cells set since the last flush, the cells that weren't
+	// set retain their previous content.
+	back buffer.Buffer
+	// front holds the cells written to the wrapped terminal on the last
+	// flush. Nil if the content of the wrapped terminal isn't known, in which
+	// case all the cells are written on the next flush.
+	front buffer.Buffer
+
+	// mu protects the fields above.
+	mu sync.Mutex
+}
+
+// New returns a new Terminal that wraps the provided terminal.
+// Closing the returned Terminal closes the wrapped terminal.
+func New(t terminalapi.Terminal) *Terminal {
+	return &Terminal{term: t}
+}
+
+// resetIfResized replaces the back buffer with an empty one and forgets the
+// flushed content if the wrapped terminal was resized.
+// The caller must hold the lock.
+func (t *Terminal) resetIfResized() error {
+	size := t.term.Size()
+	if t.back != nil && t.back.Size() == size {
+		return nil
+	}
+
+	b, err := buffer.New(size)
+	if err != nil {
+		return err
+	}
+	t.back = b
+	t.front = nil
+	return nil
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	return t.term.Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+// Only clears the internal buffer, the wrapped terminal receives the cleared
+// cells on the next flush if they differ from its content.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.resetIfResized(); err != nil {
+		return err
+	}
+	for col := range t.back {
+		for row := range t.back[col] {
+			t.back[col][row] = buffer.NewCell(0, opts...)
+		}
+	}
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+// Writes the cells that changed since the last flush to the wrapped terminal
+// and flushes it.
+func (t *Terminal) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.back == nil {
+		if err := t.resetIfResized(); err != nil {
+			return err
+		}
+	}
+
+	// The wrapped terminal might have been resized since the last clear. Its
+	// content is no longer known, so all the cells that fit are written until
+	// the next clear resizes the buffer.
+	size := t.term.Size()
+	resized := t.back.Size() != size
+	if resized {
+		t.front = nil
+	}
+
+	for col := range t.back {
+		for row, c := range t.back[col] {
+			if col >= size.X || row >= size.Y {
+				continue
+			}
+			if t.front != nil {
+				if f := t.front[col][row]; f.Rune == c.Rune && *f.Opts == *c.Opts {
+					continue
+				}
+			}
+			if err := t.term.SetCell(image.Point{col, row}, c.Rune, c.Opts); err != nil {
+				return err
+			}
+		}
+	}
+	if err := t.term.Flush(); err != nil {
+		return err
+	}
+
+	if resized {
+		return nil
+	}
+	if t.front == nil {
+		f, err := buffer.New(size)
+		if err != nil {
+			return err
+		}
+		t.front = f
+	}
+	for col := range t.back {
+		for row, c := range t.back[col] {
+			f := t.front[col][row]
+			f.Rune = c.Rune
+			*f.Opts = *c.Opts
+		}
+	}
+	return nil
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+func (t *Terminal) SetCursor(p image.Point) {
+	t.term.SetCursor(p)
+}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+func (t *Terminal) HideCursor() {
+	t.term.HideCursor()
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+// Like the terminal implementations, ignores cells that fall outside of the
+// terminal, which can happen when the terminal gets resized while drawing.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.back == nil {
+		if err := t.resetIfResized(); err != nil {
+			return err
+		}
+	}
+	if s := t.back.Size(); p.X < 0 || p.Y < 0 || p.X >= s.X || p.Y >= s.Y {
+		return nil
+	}
+
+	c := t.back[p.X][p.Y]
+	c.Rune = r
+	c.Apply(opts...)
+	return nil
+}
+
+// Event implements terminalapi.Terminal.Event.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	return t.term.Event(ctx)
+}
+
+// Capabilities implements terminalapi.CapabilityReporter.Capabilities.
+// Reports no capabilities if the wrapped terminal doesn't implement
+// terminalapi.CapabilityReporter.
+func (t *Terminal) Capabilities() terminalapi.Capabilities {
+	if cr, ok := t.term.(terminalapi.CapabilityReporter); ok {
+		return cr.Capabilities()
+	}
+	return terminalapi.Capabilities{}
+}
+
+// Close implements terminalapi.Terminal.Close.
+func (t *Terminal) Close() {
+	t.term.Close()
+}
@@ -0,0 +1,307 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffterm
+
+import (
+	"context"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/headless"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// countingTerm is a headless terminal that counts the cells set on it.
+type countingTerm struct {
+	*headless.Terminal
+
+	// writes is the number of cells set since the last call to take.
+	writes int
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (ct *countingTerm) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	ct.writes++
+	return ct.Terminal.SetCell(p, r, opts...)
+}
+
+// take returns the number of cells set since the last call and resets it.
+func (ct *countingTerm) take() int {
+	w := ct.writes
+	ct.writes = 0
+	return w
+}
+
+// text sets the runes of the text on the terminal starting at the point.
+func text(t terminalapi.Terminal, start image.Point, s string, opts ...cell.Option) error {
+	for i, r := range s {
+		if err := t.SetCell(start.Add(image.Point{i, 0}), r, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFlush(t *testing.T) {
+	tests := []struct {
+		desc string
+		// frames are drawn and flushed one after another.
+		frames []func(t *Terminal) error
+		// wantWrites are the numbers of cells written to the wrapped terminal
+		// on each flush.
+		wantWrites []int
+		want       string
+	}{
+		{
+			desc: "first flush writes all the cells",
+			frames: []func(t *Terminal) error{
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 0}, "abc")
+				},
+			},
+			wantWrites: []int{6},
+			want:       "abc\n   \n",
+		},
+		{
+			desc: "redrawing an unchanged frame writes no cells",
+			frames: []func(t *Terminal) error{
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 0}, "abc")
+				},
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 0}, "abc")
+				},
+				func(t *Terminal) error {
+					return nil
+				},
+			},
+			wantWrites: []int{6, 0, 0},
+			want:       "abc\n   \n",
+		},
+		{
+			desc: "clearing and redrawing an unchanged frame writes no cells",
+			frames: []func(t *Terminal) error{
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 1}, "abc")
+				},
+				func(t *Terminal) error {
+					if err := t.Clear(); err != nil {
+						return err
+					}
+					return text(t, image.Point{0, 1}, "abc")
+				},
+			},
+			wantWrites: []int{6, 0},
+			want:       "   \nabc\n",
+		},
+		{
+			desc: "writes only the changed cells",
+			frames: []func(t *Terminal) error{
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 0}, "abc")
+				},
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 0}, "axc")
+				},
+			},
+			wantWrites: []int{6, 1},
+			want:       "axc\n   \n",
+		},
+		{
+			desc: "writes cells whose options changed",
+			frames: []func(t *Terminal) error{
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 0}, "abc")
+				},
+				func(t *Terminal) error {
+					return text(t, image.Point{1, 0}, "b", cell.FgColor(cell.ColorRed))
+				},
+			},
+			wantWrites: []int{6, 1},
+			want:       "abc\n   \n",
+		},
+		{
+			desc: "clearing writes the cells that had content",
+			frames: []func(t *Terminal) error{
+				func(t *Terminal) error {
+					return text(t, image.Point{0, 0}, "ab")
+				},
+				func(t *Terminal) error {
+					return t.Clear()
+				},
+			},
+			wantWrites: []int{6, 2},
+			want:       "   \n   \n",
+		},
+		{
+			desc: "ignores cells outside of the terminal",
+			frames: []func(t *Terminal) error{
+				func(t *Terminal) error {
+					return text(t, image.Point{1, 0}, "abcd")
+				},
+			},
+			wantWrites: []int{6},
+			want:       " ab\n   \n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ht, err := headless.New(headless.Size(image.Point{3, 2}))
+			if err != nil {
+				t.Fatalf("headless.New => unexpected error: %v", err)
+			}
+			ct := &countingTerm{Terminal: ht}
+			dt := New(ct)
+			defer dt.Close()
+
+			var gotWrites []int
+			for i, frame := range tc.frames {
+				if err := frame(dt); err != nil {
+					t.Fatalf("frame[%d] => unexpected error: %v", i, err)
+				}
+				if err := dt.Flush(); err != nil {
+					t.Fatalf("Flush => unexpected error: %v", err)
+				}
+				gotWrites = append(gotWrites, ct.take())
+			}
+
+			if diff := pretty.Compare(tc.wantWrites, gotWrites); diff != "" {
+				t.Errorf("writes => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if got := ht.String(); got != tc.want {
+				t.Errorf("String => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFlushKeepsOptions(t *testing.T) {
+	ht, err := headless.New(headless.Size(image.Point{2, 1}))
+	if err != nil {
+		t.Fatalf("headless.New => unexpected error: %v", err)
+	}
+	dt := New(ht)
+	defer dt.Close()
+
+	if err := dt.SetCell(image.Point{1, 0}, 'x', cell.FgColor(cell.ColorRed), cell.Bold()); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := dt.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+
+	want := [][]headless.Cell{
+		{{}},
+		{{Rune: 'x', Opts: cell.Options{FgColor: cell.ColorRed, Bold: true}}},
+	}
+	if diff := pretty.Compare(want, ht.Cells()); diff != "" {
+		t.Errorf("Cells => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFlushAfterResize(t *testing.T) {
+	ht, err := headless.New(headless.Size(image.Point{3, 2}))
+	if err != nil {
+		t.Fatalf("headless.New => unexpected error: %v", err)
+	}
+	ct := &countingTerm{Terminal: ht}
+	dt := New(ct)
+	defer dt.Close()
+
+	if err := text(dt, image.Point{0, 0}, "abc"); err != nil {
+		t.Fatalf("text => unexpected error: %v", err)
+	}
+	if err := dt.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	ct.take()
+
+	// Resizing clears the wrapped terminal, the next flush writes all the
+	// cells that fit even though they didn't change.
+	ht.Inject(&terminalapi.Resize{Size: image.Point{2, 2}})
+	if ev := dt.Event(context.Background()); ev == nil {
+		t.Fatalf("Event => nil, want the resize event")
+	}
+	if err := dt.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	if got, want := ct.take(), 4; got != want {
+		t.Errorf("Flush after resize => wrote %d cells, want %d", got, want)
+	}
+	if got, want := ht.String(), "ab\n  \n"; got != want {
+		t.Errorf("String => %q, want %q", got, want)
+	}
+
+	// The clear after the resize starts diffing again.
+	if err := dt.Clear(); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+	if err := text(dt, image.Point{0, 1}, "xy"); err != nil {
+		t.Fatalf("text => unexpected error: %v", err)
+	}
+	for _, want := range []int{4, 0} {
+		if err := dt.Flush(); err != nil {
+			t.Fatalf("Flush => unexpected error: %v", err)
+		}
+		if got := ct.take(); got != want {
+			t.Errorf("Flush => wrote %d cells, want %d", got, want)
+		}
+	}
+	if got, want := ht.String(), "  \nxy\n"; got != want {
+		t.Errorf("String => %q, want %q", got, want)
+	}
+}
+
+// plainTerm is a terminal that doesn't implement
+// terminalapi.CapabilityReporter.
+type plainTerm struct {
+	terminalapi.Terminal
+}
+
+func TestCapabilities(t *testing.T) {
+	ht, err := headless.New(headless.Size(image.Point{2, 1}))
+	if err != nil {
+		t.Fatalf("headless.New => unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc string
+		term terminalapi.Terminal
+		want terminalapi.Capabilities
+	}{
+		{
+			desc: "reports the capabilities of the wrapped terminal",
+			term: ht,
+			want: ht.Capabilities(),
+		},
+		{
+			desc: "reports no capabilities when the wrapped terminal doesn't",
+			term: plainTerm{ht},
+			want: terminalapi.Capabilities{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := New(tc.term).Capabilities()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Capabilities => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headless
+
+// ansi.go contains code that exports the rendered content as text with ANSI
+// escape sequences.
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// ANSI returns the flushed content of the terminal, one line per row, with
+// the colors and font modifiers of the cells encoded as ANSI SGR escape
+// sequences. The result can be saved to a file and displayed by running e.g.
+// "cat" in a terminal that supports the used colors.
+//
+// An escape sequence is emitted only when the options change from the
+// previous cell and each row that doesn't end with the default options ends
+// with a reset. Hyperlinks set by cell.Link aren't exported.
+func (t *Terminal) ANSI() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b strings.Builder
+	for row := 0; row < t.size.Y; row++ {
+		var cur cell.Options
+		for col := 0; col < t.size.X; col++ {
+			c := t.front[col][row]
+			partial, err := t.front.IsPartial(image.Point{col, row})
+			if err != nil {
+				panic(fmt.Sprintf("unable to determine if cell %v is partial: %v", image.Point{col, row}, err))
+			}
+			if partial {
+				continue
+			}
+
+			opts := *c.Opts
+			opts.Link = ""
+			if opts != cur {
+				b.WriteString(sgr(&opts))
+				cur = opts
+			}
+
+			r := c.Rune
+			if r == 0 {
+				r = ' '
+			}
+			b.WriteRune(r)
+		}
+		if cur != (cell.Options{}) {
+			b.WriteString(sgr(&cell.Options{}))
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
+
+// sgr returns the SGR escape sequence that resets the terminal and then
+// applies the provided options.
+func sgr(opts *cell.Options) string {
+	params := []string{"0"}
+	for _, attr := range []struct {
+		set   bool
+		param string
+	}{
+		{opts.Bold, "1"},
+		{opts.Dim, "2"},
+		{opts.Italic, "3"},
+		{opts.Underline, "4"},
+		{opts.Blink, "5"},
+		{opts.Inverse, "7"},
+		{opts.Strikethrough, "9"},
+	} {
+		if attr.set {
+			params = append(params, attr.param)
+		}
+	}
+	params = append(params, colorParams(opts.FgColor, 30, 90, 38)...)
+	params = append(params, colorParams(opts.BgColor, 40, 100, 48)...)
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// colorParams returns the SGR parameters that select the color.
+// The base and bright arguments are the parameters of the first normal and
+// the first bright color among the 16 base colors, extended is the parameter
+// that selects a color from the 256 color palette or a true color.
+// Returns no parameters for the default color.
+func colorParams(c cell.Color, base, bright, extended int) []string {
+	n := int(c) - 1 // Colors are off-by-one due to ColorDefault being zero.
+	switch {
+	case c.IsRGB24():
+		r, g, b := c.RGB()
+		return []string{strconv.Itoa(extended), "2", strconv.Itoa(r), strconv.Itoa(g), strconv.Itoa(b)}
+	case n < 0 || n > 255:
+		return nil
+	case n < 8:
+		return []string{strconv.Itoa(base + n)}
+	case n < 16:
+		return []string{strconv.Itoa(bright + n - 8)}
+	default:
+		return []string{strconv.Itoa(extended), "5", strconv.Itoa(n)}
+	}
+}
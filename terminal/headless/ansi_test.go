@@ -0,0 +1,114 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headless
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestANSI(t *testing.T) {
+	tests := []struct {
+		desc string
+		size image.Point
+		// cells are the cells set on the terminal before it is flushed.
+		cells map[image.Point]struct {
+			r    rune
+			opts []cell.Option
+		}
+		want string
+	}{
+		{
+			desc: "empty terminal has no escape sequences",
+			size: image.Point{2, 2},
+			want: "  \n  \n",
+		},
+		{
+			desc: "base colors and font modifiers",
+			size: image.Point{3, 2},
+			cells: map[image.Point]struct {
+				r    rune
+				opts []cell.Option
+			}{
+				{0, 0}: {'a', []cell.Option{cell.FgColor(cell.ColorMaroon), cell.Bold()}},
+				{1, 0}: {'b', []cell.Option{cell.FgColor(cell.ColorMaroon), cell.Bold()}},
+				{2, 0}: {'c', nil},
+				{0, 1}: {'d', []cell.Option{cell.BgColor(cell.ColorBlue), cell.Underline(), cell.Italic()}},
+				{1, 1}: {'e', []cell.Option{cell.Inverse(), cell.Strikethrough(), cell.Blink(), cell.Dim()}},
+			},
+			want: "\x1b[0;1;31mab\x1b[0mc\n" +
+				"\x1b[0;3;4;104md\x1b[0;2;5;7;9me\x1b[0m \n",
+		},
+		{
+			desc: "palette and true colors",
+			size: image.Point{2, 1},
+			cells: map[image.Point]struct {
+				r    rune
+				opts []cell.Option
+			}{
+				{0, 0}: {'a', []cell.Option{cell.FgColor(cell.ColorNumber(100))}},
+				{1, 0}: {'b', []cell.Option{cell.BgColor(cell.ColorRGB24(1, 2, 3))}},
+			},
+			want: "\x1b[0;38;5;100ma\x1b[0;48;2;1;2;3mb\x1b[0m\n",
+		},
+		{
+			desc: "wide runes occupy two cells",
+			size: image.Point{3, 1},
+			cells: map[image.Point]struct {
+				r    rune
+				opts []cell.Option
+			}{
+				{0, 0}: {'世', []cell.Option{cell.FgColor(cell.ColorGreen)}},
+			},
+			want: "\x1b[0;32m世\x1b[0m \n",
+		},
+		{
+			desc: "links aren't exported",
+			size: image.Point{1, 1},
+			cells: map[image.Point]struct {
+				r    rune
+				opts []cell.Option
+			}{
+				{0, 0}: {'a', []cell.Option{cell.Link("https://example.com")}},
+			},
+			want: "a\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			term, err := New(Size(tc.size))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			defer term.Close()
+
+			for p, c := range tc.cells {
+				if err := term.SetCell(p, c.r, c.opts...); err != nil {
+					t.Fatalf("SetCell => unexpected error: %v", err)
+				}
+			}
+			if err := term.Flush(); err != nil {
+				t.Fatalf("Flush => unexpected error: %v", err)
+			}
+
+			if got := term.ANSI(); got != tc.want {
+				t.Errorf("ANSI => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package headless implements a terminal that renders into an in-memory buffer.
+
+The headless terminal doesn't require a TTY. It can be used to render
+dashboards on servers, e.g. to produce snapshots, or to test whole
+applications by injecting synthetic keyboard and mouse events and comparing
+the rendered content.
+*/
+package headless
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/event/eventqueue"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*Terminal)
+}
+
+// option implements Option.
+type option func(*Terminal)
+
+// set implements Option.set.
+func (o option) set(t *Terminal) {
+	o(t)
+}
+
+// DefaultSize is the default value for the Size option.
+var DefaultSize = image.Point{80, 24}
+
+// Size sets the size of the terminal in cells.
+// Both dimensions must be positive numbers.
+// Defaults to DefaultSize.
+func Size(size image.Point) Option {
+	return option(func(t *Terminal) {
+		t.size = size
+	})
+}
+
+// Cell is a single cell of the rendered content.
+type Cell struct {
+	// Rune is the rune in the cell.
+	// The zero value indicates an empty cell or a cell that is occupied by
+	// the wide rune in the previous cell.
+	Rune rune
+
+	// Opts are the options of the cell.
+	Opts cell.Options
+}
+
+// Terminal is a terminal that renders into an in-memory buffer.
+// Content set on the terminal becomes visible in the output of Cells and
+// String after a call to Flush.
+// This implementation is thread-safe.
+// Implements terminalapi.Terminal.
+type Terminal struct {
+	// back is the buffer modified by SetCell and Clear.
+	back buffer.Buffer
+	// front is the buffer with the flushed content.
+	front buffer.Buffer
+
+	// cursor is the position of the cursor.
+	cursor image.Point
+	// cursorVisible indicates if the cursor is visible.
+	cursorVisible bool
+
+	// events is a queue of input events.
+	events *eventqueue.Unbound
+
+	// size is the size of the terminal.
+	size image.Point
+
+	// mu protects the fields above.
+	mu sync.Mutex
+}
+
+// New returns a new headless Terminal.
+// Call Close() when the terminal isn't required anymore.
+func New(opts ...Option) (*Terminal, error) {
+	t := &Terminal{
+		events: eventqueue.New(),
+		size:   DefaultSize,
+	}
+	for _, opt := range opts {
+		opt.set(t)
+	}
+
+	if err := t.resize(t.size); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// resize replaces both buffers with empty buffers of the provided size.
+// The caller must hold the lock unless the terminal isn't shared yet.
+func (t *Terminal) resize(size image.Point) error {
+	back, err := buffer.New(size)
+	if err != nil {
+		return fmt.Errorf("invalid terminal size: %v", err)
+	}
+	front, err := buffer.New(size)
+	if err != nil {
+		return fmt.Errorf("invalid terminal size: %v", err)
+	}
+	t.back = back
+	t.front = front
+	t.size = size
+	return nil
+}
+
+// Inject injects the provided event, it will be returned by a subsequent call
+// to Event. Can be used to simulate keyboard and mouse input.
+// Injecting a terminalapi.Resize event resizes the terminal once the event is
+// returned by Event, this clears the content of the terminal.
+func (t *Terminal) Inject(ev terminalapi.Event) {
+	t.events.Push(ev)
+}
+
+// Cells returns a copy of the flushed content of the terminal.
+// The returned cells are indexed by the column and the row, i.e. cells[x][y].
+func (t *Terminal) Cells() [][]Cell {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cells := make([][]Cell, len(t.front))
+	for col := range t.front {
+		cells[col] = make([]Cell, len(t.front[col]))
+		for row, c := range t.front[col] {
+			cells[col][row] = Cell{
+				Rune: c.Rune,
+				Opts: *c.Opts,
+			}
+		}
+	}
+	return cells
+}
+
+// Cursor returns the position of the cursor and whether it is visible.
+func (t *Terminal) Cursor() (image.Point, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.cursor, t.cursorVisible
+}
+
+// String returns the runes of the flushed content of the terminal, one line
+// per row. Cell options are ignored.
+// Implements fmt.Stringer.
+func (t *Terminal) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b strings.Builder
+	for row := 0; row < t.size.Y; row++ {
+		for col := 0; col < t.size.X; col++ {
+			r := t.front[col][row].Rune
+			partial, err := t.front.IsPartial(image.Point{col, row})
+			if err != nil {
+				panic(fmt.Sprintf("unable to determine if cell %v is partial: %v", image.Point{col, row}, err))
+			}
+			switch {
+			case partial:
+				continue
+			case r == 0:
+				r = ' '
+			}
+			b.WriteRune(r)
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
+
+// Capabilities implements terminalapi.CapabilityReporter.Capabilities.
+// The headless terminal stores the cell options unchanged, so it supports all
+// of them.
+func (t *Terminal) Capabilities() terminalapi.Capabilities {
+	return terminalapi.Capabilities{
+		ColorMode:     terminalapi.ColorModeTrueColor,
+		Bold:          true,
+		Italic:        true,
+		Underline:     true,
+		Strikethrough: true,
+		Inverse:       true,
+		Blink:         true,
+		Dim:           true,
+	}
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.size
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for col := range t.back {
+		for row := range t.back[col] {
+			t.back[col][row] = buffer.NewCell(0, opts...)
+		}
+	}
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (t *Terminal) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for col := range t.back {
+		for row, c := range t.back[col] {
+			t.front[col][row] = c.Copy()
+		}
+	}
+	return nil
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+func (t *Terminal) SetCursor(p image.Point) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cursor = p
+	t.cursorVisible = true
+}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+func (t *Terminal) HideCursor() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cursorVisible = false
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.back.SetCell(p, r, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Event implements terminalapi.Terminal.Event.
+// Returns the events provided via Inject in the order they were injected.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	ev := t.events.Pull(ctx)
+	if ev == nil {
+		return nil
+	}
+
+	if res, ok := ev.(*terminalapi.Resize); ok {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if err := t.resize(res.Size); err != nil {
+			return terminalapi.NewErrorf("unable to resize the terminal: %v", err)
+		}
+	}
+	return ev
+}
+
+// Close implements terminalapi.Terminal.Close.
+func (t *Terminal) Close() {
+	t.events.Close()
+}
@@ -0,0 +1,249 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headless
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []Option
+		wantSize image.Point
+		wantErr  bool
+	}{
+		{
+			desc:     "uses the default size",
+			wantSize: DefaultSize,
+		},
+		{
+			desc:     "uses the provided size",
+			opts:     []Option{Size(image.Point{3, 2})},
+			wantSize: image.Point{3, 2},
+		},
+		{
+			desc:    "fails on zero width",
+			opts:    []Option{Size(image.Point{0, 2})},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative height",
+			opts:    []Option{Size(image.Point{3, -1})},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			term, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer term.Close()
+
+			if got := term.Size(); got != tc.wantSize {
+				t.Errorf("Size => %v, want %v", got, tc.wantSize)
+			}
+		})
+	}
+}
+
+func TestRendering(t *testing.T) {
+	tests := []struct {
+		desc string
+		size image.Point
+		// draw draws on the terminal.
+		draw      func(t *Terminal) error
+		wantStr   string
+		wantCells func(size image.Point) [][]Cell
+		wantErr   bool
+	}{
+		{
+			desc:    "empty terminal",
+			size:    image.Point{2, 2},
+			draw:    func(t *Terminal) error { return nil },
+			wantStr: "  \n  \n",
+		},
+		{
+			desc: "cells aren't visible until flushed",
+			size: image.Point{2, 1},
+			draw: func(t *Terminal) error {
+				return t.SetCell(image.Point{0, 0}, 'a')
+			},
+			wantStr: "  \n",
+		},
+		{
+			desc: "flushed cells honor the cell options",
+			size: image.Point{2, 1},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{1, 0}, 'a', cell.FgColor(cell.ColorRed), cell.Bold()); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantStr: " a\n",
+			wantCells: func(size image.Point) [][]Cell {
+				return [][]Cell{
+					{{}},
+					{{Rune: 'a', Opts: cell.Options{FgColor: cell.ColorRed, Bold: true}}},
+				}
+			},
+		},
+		{
+			desc: "wide runes occupy two cells",
+			size: image.Point{3, 1},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{0, 0}, '世'); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantStr: "世 \n",
+		},
+		{
+			desc: "clear sets the options on all the cells",
+			size: image.Point{1, 2},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				if err := t.Clear(cell.BgColor(cell.ColorBlue)); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantStr: " \n \n",
+			wantCells: func(size image.Point) [][]Cell {
+				return [][]Cell{
+					{
+						{Opts: cell.Options{BgColor: cell.ColorBlue}},
+						{Opts: cell.Options{BgColor: cell.ColorBlue}},
+					},
+				}
+			},
+		},
+		{
+			desc: "fails on cell outside of the terminal",
+			size: image.Point{1, 1},
+			draw: func(t *Terminal) error {
+				return t.SetCell(image.Point{1, 0}, 'a')
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			term, err := New(Size(tc.size))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			defer term.Close()
+
+			{
+				err := tc.draw(term)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("draw => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			if got := term.String(); got != tc.wantStr {
+				t.Errorf("String => %q, want %q", got, tc.wantStr)
+			}
+			if tc.wantCells != nil {
+				if diff := pretty.Compare(tc.wantCells(tc.size), term.Cells()); diff != "" {
+					t.Errorf("Cells => unexpected diff (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	term, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	defer term.Close()
+
+	if _, visible := term.Cursor(); visible {
+		t.Errorf("Cursor => visible, want hidden on a new terminal")
+	}
+
+	want := image.Point{2, 3}
+	term.SetCursor(want)
+	if got, visible := term.Cursor(); got != want || !visible {
+		t.Errorf("Cursor after SetCursor => %v, %v, want %v, true", got, visible, want)
+	}
+
+	term.HideCursor()
+	if _, visible := term.Cursor(); visible {
+		t.Errorf("Cursor after HideCursor => visible, want hidden")
+	}
+}
+
+func TestEvent(t *testing.T) {
+	term, err := New(Size(image.Point{2, 2}))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	defer term.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	injected := []terminalapi.Event{
+		&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+		&terminalapi.Resize{Size: image.Point{3, 1}},
+	}
+	for _, ev := range injected {
+		term.Inject(ev)
+	}
+
+	for _, want := range injected {
+		got := term.Event(ctx)
+		if diff := pretty.Compare(want, got); diff != "" {
+			t.Errorf("Event => unexpected diff (-want, +got):\n%s", diff)
+		}
+	}
+
+	if got, want := term.Size(), (image.Point{3, 1}); got != want {
+		t.Errorf("Size after the resize event => %v, want %v", got, want)
+	}
+	if got, want := term.String(), "   \n"; got != want {
+		t.Errorf("String after the resize event => %q, want %q", got, want)
+	}
+
+	cctx, ccancel := context.WithCancel(context.Background())
+	ccancel()
+	if got := term.Event(cctx); got != nil {
+		t.Errorf("Event on canceled context => %v, want nil", got)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+// background.go contains code that queries the background color of the
+// terminal.
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	tcell "github.com/gdamore/tcell/v2"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// backgroundQuery asks the terminal for its background color (OSC 11) and
+// then for its primary device attributes (DA1). Virtually all terminals
+// answer the latter, so its answer marks the end of the answers even if the
+// terminal doesn't support OSC 11.
+const backgroundQuery = "\x1b]11;?\x1b\\\x1b[c"
+
+var (
+	// backgroundAnswer matches the answer to the OSC 11 query. Each color
+	// component has one to four hexadecimal digits.
+	backgroundAnswer = regexp.MustCompile(`\x1b\]11;rgba?:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})`)
+	// da1Answer matches the answer to the DA1 query.
+	da1Answer = regexp.MustCompile(`\x1b\[\?[0-9;]*c`)
+)
+
+// background is the background of the terminal.
+type background struct {
+	// kind indicates whether the background is light or dark.
+	kind terminalapi.Background
+	// color is the reported color, cell.ColorDefault if unknown.
+	color cell.Color
+}
+
+// tcellNewTty opens the tty used to query the background color. Can be
+// overridden from tests.
+var tcellNewTty = tcell.NewDevTty
+
+// queryBackground writes the query to the tty and waits for the answers up to
+// the timeout. Returns an unknown background if the terminal didn't report
+// its background color.
+func queryBackground(tty tcell.Tty, timeout time.Duration) (*background, error) {
+	if err := tty.Start(); err != nil {
+		return nil, fmt.Errorf("tty.Start => %v", err)
+	}
+	defer tty.Stop()
+
+	if _, err := io.WriteString(tty, backgroundQuery); err != nil {
+		return nil, fmt.Errorf("failed to write the background query to the tty: %v", err)
+	}
+
+	answers := make(chan string, 1)
+	go func() {
+		var (
+			read []byte
+			buf  = make([]byte, 64)
+		)
+		for {
+			n, err := tty.Read(buf)
+			read = append(read, buf[:n]...)
+			if err != nil || n == 0 || da1Answer.Match(read) {
+				answers <- string(read)
+				return
+			}
+		}
+	}()
+
+	var got string
+	select {
+	case got = <-answers:
+	case <-time.After(timeout):
+		// Unblocks the pending read.
+		if err := tty.Drain(); err != nil {
+			return nil, fmt.Errorf("tty.Drain => %v", err)
+		}
+		got = <-answers
+	}
+	return parseBackground(got), nil
+}
+
+// parseBackground parses the answer to the OSC 11 query from the answers the
+// terminal sent. The background is light if its relative luminance is above
+// one half.
+func parseBackground(answers string) *background {
+	m := backgroundAnswer.FindStringSubmatch(answers)
+	if m == nil {
+		return &background{
+			kind:  terminalapi.BackgroundUnknown,
+			color: cell.ColorDefault,
+		}
+	}
+
+	var rgb [3]float64
+	for i, comp := range m[1:] {
+		// The regular expression only matches valid hexadecimal numbers.
+		v, _ := strconv.ParseUint(comp, 16, 16)
+		// Scale to 0.0-1.0, the number of digits determines the maximum.
+		rgb[i] = float64(v) / float64(uint64(1)<<(4*len(comp))-1)
+	}
+
+	kind := terminalapi.BackgroundDark
+	if 0.299*rgb[0]+0.587*rgb[1]+0.114*rgb[2] > 0.5 {
+		kind = terminalapi.BackgroundLight
+	}
+	return &background{
+		kind: kind,
+		color: cell.ColorRGB24(
+			int(rgb[0]*255+0.5),
+			int(rgb[1]*255+0.5),
+			int(rgb[2]*255+0.5),
+		),
+	}
+}
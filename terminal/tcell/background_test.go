@@ -0,0 +1,156 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"testing"
+	"time"
+
+	tcell "github.com/gdamore/tcell/v2"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+func TestParseBackground(t *testing.T) {
+	tests := []struct {
+		desc    string
+		answers string
+		want    *background
+	}{
+		{
+			desc:    "unknown without an answer",
+			answers: "\x1b[?62;22c",
+			want:    &background{kind: terminalapi.BackgroundUnknown, color: cell.ColorDefault},
+		},
+		{
+			desc:    "dark background with four digit components terminated by BEL",
+			answers: "\x1b]11;rgb:0000/0000/0000\x07\x1b[?62;22c",
+			want:    &background{kind: terminalapi.BackgroundDark, color: cell.ColorRGB24(0, 0, 0)},
+		},
+		{
+			desc:    "light background with four digit components terminated by ST",
+			answers: "\x1b]11;rgb:ffff/ffff/dddd\x1b\\\x1b[?62;22c",
+			want:    &background{kind: terminalapi.BackgroundLight, color: cell.ColorRGB24(255, 255, 221)},
+		},
+		{
+			desc:    "two digit components",
+			answers: "\x1b]11;rgb:28/2c/34\x07",
+			want:    &background{kind: terminalapi.BackgroundDark, color: cell.ColorRGB24(40, 44, 52)},
+		},
+		{
+			desc:    "one digit components with alpha",
+			answers: "\x1b]11;rgba:f/f/f/f\x07",
+			want:    &background{kind: terminalapi.BackgroundLight, color: cell.ColorRGB24(255, 255, 255)},
+		},
+		{
+			desc:    "mid grey is dark",
+			answers: "\x1b]11;rgb:7f7f/7f7f/7f7f\x07",
+			want:    &background{kind: terminalapi.BackgroundDark, color: cell.ColorRGB24(127, 127, 127)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := parseBackground(tc.answers)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("parseBackground => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQueryBackground(t *testing.T) {
+	tests := []struct {
+		desc    string
+		answers []string
+		want    terminalapi.Background
+	}{
+		{
+			desc:    "terminal reports its background",
+			answers: []string{"\x1b]11;rgb:ffff/ffff/ffff\x07", "\x1b[?62;22c"},
+			want:    terminalapi.BackgroundLight,
+		},
+		{
+			desc:    "answer split across reads",
+			answers: []string{"\x1b]11;rgb:00", "00/0000/0000\x07\x1b[?6", "2;22c"},
+			want:    terminalapi.BackgroundDark,
+		},
+		{
+			desc:    "terminal only answers the device attributes query",
+			answers: []string{"\x1b[?62;22c"},
+			want:    terminalapi.BackgroundUnknown,
+		},
+		{
+			desc: "terminal doesn't answer at all",
+			want: terminalapi.BackgroundUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tty := newFakeTty()
+			go func() {
+				for _, a := range tc.answers {
+					tty.input <- []byte(a)
+				}
+			}()
+
+			// Only the terminal that doesn't answer waits for the timeout.
+			got, err := queryBackground(tty, 50*time.Millisecond)
+			if err != nil {
+				t.Fatalf("queryBackground => unexpected error: %v", err)
+			}
+			if got.kind != tc.want {
+				t.Errorf("queryBackground => %v, want %v", got.kind, tc.want)
+			}
+			if got, want := tty.output(), backgroundQuery; got != want {
+				t.Errorf("queryBackground wrote %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesBackground(t *testing.T) {
+	defer func(orig func() (tcell.Screen, error)) { tcellNewScreen = orig }(tcellNewScreen)
+	defer func(orig func() (tcell.Tty, error)) { tcellNewTty = orig }(tcellNewTty)
+	tcellNewScreen = func() (tcell.Screen, error) { return nil, nil }
+	tcellNewTty = func() (tcell.Tty, error) {
+		tty := newFakeTty()
+		go func() { tty.input <- []byte("\x1b]11;rgb:0000/0000/0000\x07\x1b[?62;22c") }()
+		return tty, nil
+	}
+
+	got, err := newTerminal(QueryBackground(time.Second))
+	if err != nil {
+		t.Fatalf("newTerminal => unexpected error: %v", err)
+	}
+	caps := got.Capabilities()
+	if caps.Background != terminalapi.BackgroundDark {
+		t.Errorf("Capabilities().Background => %v, want %v", caps.Background, terminalapi.BackgroundDark)
+	}
+	if want := cell.ColorRGB24(0, 0, 0); caps.BackgroundColor != want {
+		t.Errorf("Capabilities().BackgroundColor => %v, want %v", caps.BackgroundColor, want)
+	}
+
+	// The background isn't queried without the option.
+	got, err = newTerminal()
+	if err != nil {
+		t.Fatalf("newTerminal => unexpected error: %v", err)
+	}
+	if caps := got.Capabilities(); caps.Background != terminalapi.BackgroundUnknown {
+		t.Errorf("Capabilities().Background => %v, want %v", caps.Background, terminalapi.BackgroundUnknown)
+	}
+}
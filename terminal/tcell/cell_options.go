@@ -17,6 +17,7 @@ package tcell
 import (
 	tcell "github.com/gdamore/tcell/v2"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/palette"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
 
@@ -25,6 +26,10 @@ func cellColor(c cell.Color) tcell.Color {
 	if c == cell.ColorDefault {
 		return tcell.ColorDefault
 	}
+	if c.IsRGB24() {
+		r, g, b := c.RGB()
+		return tcell.NewRGBColor(int32(r), int32(g), int32(b))
+	}
 	// Subtract one, because cell.ColorBlack has value one instead of zero.
 	// Zero is used for cell.ColorDefault instead.
 	return tcell.Color(c-1) + tcell.ColorValid
@@ -35,10 +40,27 @@ func colorToMode(c cell.Color, colorMode terminalapi.ColorMode) cell.Color {
 	if c == cell.ColorDefault {
 		return c
 	}
+	if c.IsRGB24() {
+		switch colorMode {
+		case terminalapi.ColorModeNormal:
+			return palette.Nearest16(c)
+		case terminalapi.ColorMode256:
+			return palette.Nearest256(c)
+		case terminalapi.ColorMode216:
+			return palette.NearestCube(c)
+		case terminalapi.ColorModeGrayscale:
+			return palette.NearestGrayscale(c)
+		case terminalapi.ColorModeTrueColor:
+			return c
+		default:
+			return cell.ColorDefault
+		}
+	}
+
 	switch colorMode {
 	case terminalapi.ColorModeNormal:
 		c %= 16 + 1 // Add one for cell.ColorDefault.
-	case terminalapi.ColorMode256:
+	case terminalapi.ColorMode256, terminalapi.ColorModeTrueColor:
 		c %= 256 + 1 // Add one for cell.ColorDefault.
 	case terminalapi.ColorMode216:
 		if c <= 216 { // Add one for cell.ColorDefault.
@@ -56,12 +78,23 @@ func colorToMode(c cell.Color, colorMode terminalapi.ColorMode) cell.Color {
 	return c
 }
 
+// mapColor replaces the color with its override from the color map if there
+// is one.
+func mapColor(c cell.Color, colorMap map[cell.Color]cell.Color) cell.Color {
+	if mapped, ok := colorMap[c]; ok {
+		return mapped
+	}
+	return c
+}
+
 // cellOptsToStyle converts termdash cell color to the tcell format.
-func cellOptsToStyle(opts *cell.Options, colorMode terminalapi.ColorMode) tcell.Style {
+// Colors present in the color map are replaced before they are adjusted to the
+// color mode.
+func cellOptsToStyle(opts *cell.Options, colorMode terminalapi.ColorMode, colorMap map[cell.Color]cell.Color) tcell.Style {
 	st := tcell.StyleDefault
 
-	fg := cellColor(colorToMode(opts.FgColor, colorMode))
-	bg := cellColor(colorToMode(opts.BgColor, colorMode))
+	fg := cellColor(colorToMode(mapColor(opts.FgColor, colorMap), colorMode))
+	bg := cellColor(colorToMode(mapColor(opts.BgColor, colorMap), colorMode))
 
 	st = st.Foreground(fg).
 		Background(bg).
@@ -71,6 +104,7 @@ func cellOptsToStyle(opts *cell.Options, colorMode terminalapi.ColorMode) tcell.
 		StrikeThrough(opts.Strikethrough).
 		Reverse(opts.Inverse).
 		Blink(opts.Blink).
-		Dim(opts.Dim)
+		Dim(opts.Dim).
+		Url(opts.Link)
 	return st
 }
@@ -28,6 +28,7 @@ func TestCellOptsToStyle(t *testing.T) {
 	tests := []struct {
 		desc      string
 		colorMode terminalapi.ColorMode
+		colorMap  map[cell.Color]cell.Color
 		opts      cell.Options
 		want      tcell.Style
 	}{
@@ -284,6 +285,72 @@ func TestCellOptsToStyle(t *testing.T) {
 				Foreground(tcell.Color232).
 				Background(tcell.Color233),
 		},
+		{
+			desc:      "ColorModeTrueColor: true colors are kept",
+			colorMode: terminalapi.ColorModeTrueColor,
+			opts: cell.Options{
+				FgColor: cell.ColorRGB24(1, 2, 3),
+				BgColor: cell.ColorRGB24(255, 128, 0),
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.NewRGBColor(1, 2, 3)).
+				Background(tcell.NewRGBColor(255, 128, 0)),
+		},
+		{
+			desc:      "ColorModeTrueColor: palette colors same as in ColorMode256",
+			colorMode: terminalapi.ColorModeTrueColor,
+			opts: cell.Options{
+				FgColor: cell.ColorMaroon,
+				BgColor: cell.ColorNumber(200),
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.ColorMaroon).
+				Background(tcell.Color200),
+		},
+		{
+			desc:      "ColorMode256: true colors downsample to the nearest color",
+			colorMode: terminalapi.ColorMode256,
+			opts: cell.Options{
+				FgColor: cell.ColorRGB24(95, 135, 255),
+				BgColor: cell.ColorRGB24(50, 50, 50),
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.Color69).
+				Background(tcell.Color236),
+		},
+		{
+			desc:      "ColorModeNormal: true colors downsample to the nearest color",
+			colorMode: terminalapi.ColorModeNormal,
+			opts: cell.Options{
+				FgColor: cell.ColorRGB24(250, 5, 5),
+				BgColor: cell.ColorRGB24(0, 0, 120),
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.ColorRed).
+				Background(tcell.ColorNavy),
+		},
+		{
+			desc:      "ColorMode216: true colors downsample to the nearest color",
+			colorMode: terminalapi.ColorMode216,
+			opts: cell.Options{
+				FgColor: cell.ColorRGB24(0, 0, 0),
+				BgColor: cell.ColorRGB24(50, 50, 50),
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.Color16).
+				Background(tcell.Color59),
+		},
+		{
+			desc:      "ColorModeGrayscale: true colors downsample to the nearest color",
+			colorMode: terminalapi.ColorModeGrayscale,
+			opts: cell.Options{
+				FgColor: cell.ColorRGB24(0, 0, 0),
+				BgColor: cell.ColorRGB24(255, 255, 255),
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.Color232).
+				Background(tcell.Color255),
+		},
 		{
 			desc:      "Unknown color mode converts to default color",
 			colorMode: terminalapi.ColorMode(-1),
@@ -330,11 +397,82 @@ func TestCellOptsToStyle(t *testing.T) {
 			opts:      cell.Options{Dim: true},
 			want:      tcell.StyleDefault.Dim(true),
 		},
+		{
+			colorMode: terminalapi.ColorModeNormal,
+			opts:      cell.Options{Link: "https://example.com"},
+			want:      tcell.StyleDefault.Url("https://example.com"),
+		},
+		{
+			desc:      "ColorMode256: color map overrides colors",
+			colorMode: terminalapi.ColorMode256,
+			colorMap: map[cell.Color]cell.Color{
+				cell.ColorRed:  cell.ColorNumber(196),
+				cell.ColorBlue: cell.ColorNumber(27),
+			},
+			opts: cell.Options{
+				FgColor: cell.ColorRed,
+				BgColor: cell.ColorBlue,
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.Color196).
+				Background(tcell.Color27),
+		},
+		{
+			desc:      "ColorMode256: colors not in the color map are unchanged",
+			colorMode: terminalapi.ColorMode256,
+			colorMap: map[cell.Color]cell.Color{
+				cell.ColorRed: cell.ColorNumber(196),
+			},
+			opts: cell.Options{
+				FgColor: cell.ColorYellow,
+				BgColor: cell.ColorDefault,
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.ColorYellow).
+				Background(tcell.ColorDefault),
+		},
+		{
+			desc:      "ColorMode256: color map can override the default color",
+			colorMode: terminalapi.ColorMode256,
+			colorMap: map[cell.Color]cell.Color{
+				cell.ColorDefault: cell.ColorNumber(17),
+			},
+			opts: cell.Options{},
+			want: tcell.StyleDefault.
+				Foreground(tcell.Color17).
+				Background(tcell.Color17),
+		},
+		{
+			desc:      "ColorMode256: mapped colors are adjusted to the color mode",
+			colorMode: terminalapi.ColorMode256,
+			colorMap: map[cell.Color]cell.Color{
+				cell.ColorRed: cell.ColorRGB24(255, 0, 0),
+			},
+			opts: cell.Options{
+				FgColor: cell.ColorRed,
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.Color196).
+				Background(tcell.ColorDefault),
+		},
+		{
+			desc:      "ColorModeTrueColor: color map overrides with true colors",
+			colorMode: terminalapi.ColorModeTrueColor,
+			colorMap: map[cell.Color]cell.Color{
+				cell.ColorRed: cell.ColorRGB24(200, 10, 20),
+			},
+			opts: cell.Options{
+				FgColor: cell.ColorRed,
+			},
+			want: tcell.StyleDefault.
+				Foreground(tcell.NewRGBColor(200, 10, 20)).
+				Background(tcell.ColorDefault),
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			got := cellOptsToStyle(&tc.opts, tc.colorMode)
+			got := cellOptsToStyle(&tc.opts, tc.colorMode, tc.colorMap)
 			if !reflect.DeepEqual(got, tc.want) {
 				diff := pretty.Compare(tc.want, got)
 				t.Logf("opts: %+v\nstyle:%+v", tc.opts, got)
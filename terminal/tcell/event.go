@@ -16,6 +16,7 @@ package tcell
 
 import (
 	"image"
+	"strings"
 
 	tcell "github.com/gdamore/tcell/v2"
 	"github.com/mum4k/termdash/keyboard"
@@ -86,9 +87,25 @@ var tcellToTd = map[tcell.Key]keyboard.Key{
 	tcell.KeyCtrlSpace:      keyboard.KeyCtrlSpace,
 }
 
+// tcellShiftToTd maps tcell key values pressed together with the shift key
+// to the termdash format. Keys not listed here ignore the shift key.
+var tcellShiftToTd = map[tcell.Key]keyboard.Key{
+	tcell.KeyUp:    keyboard.KeyShiftArrowUp,
+	tcell.KeyDown:  keyboard.KeyShiftArrowDown,
+	tcell.KeyLeft:  keyboard.KeyShiftArrowLeft,
+	tcell.KeyRight: keyboard.KeyShiftArrowRight,
+}
+
 // convKey converts a tcell keyboard event to the termdash format.
 func convKey(event *tcell.EventKey) terminalapi.Event {
 	tcellKey := event.Key()
+	if event.Modifiers()&tcell.ModShift != 0 {
+		if k, ok := tcellShiftToTd[tcellKey]; ok {
+			return &terminalapi.Keyboard{
+				Key: k,
+			}
+		}
+	}
 
 	if tcellKey == tcell.KeyRune {
 		ch := event.Rune()
@@ -168,6 +185,52 @@ func convResize(event *tcell.EventResize) terminalapi.Event {
 	}
 }
 
+// pasteTracker assembles the keys tcell reports between the start and the end
+// of a bracketed paste into a single termdash Paste event.
+// This is not thread-safe.
+type pasteTracker struct {
+	// active is true between the start and the end of a paste.
+	active bool
+	// text is the text pasted so far.
+	text strings.Builder
+}
+
+// events converts a tcell event to the termdash event format while tracking
+// bracketed pastes. Returns no events while a paste is in progress and a
+// single Paste event once it ends.
+func (pt *pasteTracker) events(event tcell.Event) []terminalapi.Event {
+	switch event := event.(type) {
+	case *tcell.EventPaste:
+		if event.Start() {
+			pt.active = true
+			pt.text.Reset()
+			return nil
+		}
+		if !pt.active {
+			return nil
+		}
+		pt.active = false
+		return []terminalapi.Event{
+			&terminalapi.Paste{Text: pt.text.String()},
+		}
+
+	case *tcell.EventKey:
+		if !pt.active {
+			break
+		}
+		switch event.Key() {
+		case tcell.KeyRune:
+			pt.text.WriteRune(event.Rune())
+		case tcell.KeyEnter, tcell.KeyLF:
+			pt.text.WriteRune('\n')
+		case tcell.KeyTab:
+			pt.text.WriteRune('\t')
+		}
+		return nil
+	}
+	return toTermdashEvents(event)
+}
+
 // toTermdashEvents converts a tcell event to the termdash event format.
 // This function returns nil if the event is unsupported by termdash.
 func toTermdashEvents(event tcell.Event) []terminalapi.Event {
@@ -109,6 +109,82 @@ func TestToTermdashEvents(t *testing.T) {
 	}
 }
 
+func TestPasteTracker(t *testing.T) {
+	tests := []struct {
+		desc   string
+		events []tcell.Event
+		want   []terminalapi.Event
+	}{
+		{
+			desc: "events outside of a paste are converted as usual",
+			events: []tcell.Event{
+				tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+		},
+		{
+			desc: "keys between the start and the end of a paste become a single event",
+			events: []tcell.Event{
+				tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone),
+				tcell.NewEventPaste(true),
+				tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyRune, '世', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModNone),
+				tcell.NewEventPaste(false),
+				tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone),
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'x'},
+				&terminalapi.Paste{Text: "ab\n\t世"},
+				&terminalapi.Keyboard{Key: 'y'},
+			},
+		},
+		{
+			desc: "consecutive pastes",
+			events: []tcell.Event{
+				tcell.NewEventPaste(true),
+				tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+				tcell.NewEventPaste(false),
+				tcell.NewEventPaste(true),
+				tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone),
+				tcell.NewEventPaste(false),
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Paste{Text: "a"},
+				&terminalapi.Paste{Text: "b"},
+			},
+		},
+		{
+			desc: "ignores the end of a paste that never started",
+			events: []tcell.Event{
+				tcell.NewEventPaste(false),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var (
+				pt  pasteTracker
+				got []terminalapi.Event
+			)
+			for _, ev := range tc.events {
+				got = append(got, pt.events(ev)...)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("pasteTracker.events => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestMouseButtons(t *testing.T) {
 	tests := []struct {
 		btnMask tcell.ButtonMask
@@ -280,3 +356,65 @@ func TestKeyboardKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestShiftKeys(t *testing.T) {
+	tests := []struct {
+		desc string
+		key  tcell.Key
+		mod  tcell.ModMask
+		want keyboard.Key
+	}{
+		{
+			desc: "shift with arrow up",
+			key:  tcell.KeyUp,
+			mod:  tcell.ModShift,
+			want: keyboard.KeyShiftArrowUp,
+		},
+		{
+			desc: "shift with arrow down",
+			key:  tcell.KeyDown,
+			mod:  tcell.ModShift,
+			want: keyboard.KeyShiftArrowDown,
+		},
+		{
+			desc: "shift with arrow left",
+			key:  tcell.KeyLeft,
+			mod:  tcell.ModShift,
+			want: keyboard.KeyShiftArrowLeft,
+		},
+		{
+			desc: "shift with arrow right",
+			key:  tcell.KeyRight,
+			mod:  tcell.ModShift,
+			want: keyboard.KeyShiftArrowRight,
+		},
+		{
+			desc: "arrow together with another modifier",
+			key:  tcell.KeyUp,
+			mod:  tcell.ModCtrl,
+			want: keyboard.KeyArrowUp,
+		},
+		{
+			desc: "shift is ignored for other keys",
+			key:  tcell.KeyHome,
+			mod:  tcell.ModShift,
+			want: keyboard.KeyHome,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			evs := toTermdashEvents(tcell.NewEventKey(tc.key, 0, tc.mod))
+			if len(evs) != 1 {
+				t.Fatalf("toTermdashEvents => got %d events, want 1, events were:\n%v", len(evs), pretty.Sprint(evs))
+			}
+			k, ok := evs[0].(*terminalapi.Keyboard)
+			if !ok {
+				t.Fatalf("toTermdashEvents => unexpected event type %T", evs[0])
+			}
+			if k.Key != tc.want {
+				t.Errorf("toTermdashEvents => got key %v, want %v", k.Key, tc.want)
+			}
+		})
+	}
+}
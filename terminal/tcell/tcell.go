@@ -16,13 +16,19 @@ package tcell
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image"
+	"io"
+	"os"
+	"time"
 
 	tcell "github.com/gdamore/tcell/v2"
 	"github.com/gdamore/tcell/v2/encoding"
+	"github.com/gdamore/tcell/v2/terminfo"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/event/eventqueue"
+	"github.com/mum4k/termdash/private/rawqueue"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
 
@@ -62,6 +68,122 @@ func ClearStyle(fg, bg cell.Color) Option {
 	})
 }
 
+// ColorMap sets overrides for the colors used by the widgets. Whenever a cell
+// uses a color that is a key in the map, the terminal displays the mapped
+// color instead. This allows e.g. matching a theme or adjusting the named
+// colors to the palette of the terminal without changes to the widgets.
+// The mapped colors are adjusted to the color mode like any other color.
+// Defaults to no overrides.
+func ColorMap(m map[cell.Color]cell.Color) Option {
+	return option(func(t *Terminal) {
+		// Copy to avoid external modifications.
+		t.colorMap = make(map[cell.Color]cell.Color, len(m))
+		for k, v := range m {
+			t.colorMap[k] = v
+		}
+	})
+}
+
+// DisableAltScreen configures the terminal to draw on the main screen buffer
+// instead of switching to the alternate screen buffer. The last frame drawn
+// then remains in the terminal and its scrollback after Close.
+//
+// The dashboard still occupies the entire visible screen and overwrites any
+// content previously displayed there. When the terminal resizes, the
+// dashboard is redrawn in full from the top left corner of the visible
+// screen, content the terminal reflows into the view during the resize is
+// overwritten.
+//
+// Only supported on terminals described by terminfo, New returns an error if
+// the terminal described by the TERM environment variable isn't known.
+// Defaults to switching to the alternate screen buffer.
+func DisableAltScreen() Option {
+	return option(func(t *Terminal) {
+		t.disableAltScreen = true
+	})
+}
+
+// MouseMode determines which mouse events the terminal reports.
+type MouseMode int
+
+// String implements fmt.Stringer()
+func (mm MouseMode) String() string {
+	if n, ok := mouseModeNames[mm]; ok {
+		return n
+	}
+	return "MouseModeUnknown"
+}
+
+// mouseModeNames maps MouseMode values to human readable names.
+var mouseModeNames = map[MouseMode]string{
+	MouseModeMotion:   "MouseModeMotion",
+	MouseModeDrag:     "MouseModeDrag",
+	MouseModeButtons:  "MouseModeButtons",
+	MouseModeDisabled: "MouseModeDisabled",
+}
+
+const (
+	// MouseModeMotion reports button presses, releases and all mouse
+	// movement, even when no button is pressed.
+	MouseModeMotion MouseMode = iota
+
+	// MouseModeDrag reports button presses, releases and mouse movement
+	// while a button is pressed.
+	MouseModeDrag
+
+	// MouseModeButtons reports only button presses and releases.
+	MouseModeButtons
+
+	// MouseModeDisabled disables mouse reporting, the terminal handles the
+	// mouse itself, e.g. to select text.
+	MouseModeDisabled
+)
+
+// DefaultMouseMode is the default value for the MouseReporting option.
+const DefaultMouseMode = MouseModeMotion
+
+// MouseReporting selects which mouse events the terminal reports.
+// The mouse events are always requested in the SGR extended format, so the
+// reported positions aren't limited to the first 223 columns and rows.
+// Pixel level mouse reporting isn't supported by tcell, the positions are
+// always reported in cells.
+// Defaults to DefaultMouseMode.
+func MouseReporting(mm MouseMode) Option {
+	return option(func(t *Terminal) {
+		t.mouseMode = mm
+	})
+}
+
+// mouseFlags returns the tcell mouse flags that enable the reporting of the
+// mouse events selected by the mouse mode. Returns false if the mouse
+// reporting should be disabled.
+func mouseFlags(mm MouseMode) (tcell.MouseFlags, bool) {
+	switch mm {
+	case MouseModeDrag:
+		return tcell.MouseButtonEvents | tcell.MouseDragEvents, true
+	case MouseModeButtons:
+		return tcell.MouseButtonEvents, true
+	case MouseModeDisabled:
+		return 0, false
+	default:
+		return tcell.MouseButtonEvents | tcell.MouseDragEvents | tcell.MouseMotionEvents, true
+	}
+}
+
+// QueryBackground asks the terminal for its background color when the
+// Terminal is created and waits up to the timeout for the answer. Capabilities
+// then reports whether the background is light or dark. The background is
+// terminalapi.BackgroundUnknown if the terminal doesn't support the query
+// (OSC 11) or didn't answer in time. Terminals usually answer within
+// milliseconds, but the timeout delays the start on terminals that don't
+// answer at all.
+// By default the background isn't queried.
+func QueryBackground(timeout time.Duration) Option {
+	return option(func(t *Terminal) {
+		t.bgTimeout = timeout
+	})
+}
+
 // Terminal provides input and output to a real terminal. Wraps the
 // gdamore/tcell terminal implementation. This object is not thread-safe.
 // Implements terminalapi.Terminal.
@@ -75,35 +197,85 @@ type Terminal struct {
 	// the tcell terminal window
 	screen tcell.Screen
 
+	// paste assembles bracketed pastes into single events.
+	paste pasteTracker
+
+	// raw are the raw strings queued by WriteRaw, written on the next Flush.
+	raw rawqueue.Queue
+
 	// Options.
-	colorMode  terminalapi.ColorMode
-	clearStyle *cell.Options
+	colorMode        terminalapi.ColorMode
+	clearStyle       *cell.Options
+	colorMap         map[cell.Color]cell.Color
+	disableAltScreen bool
+	mouseMode        MouseMode
+	bgTimeout        time.Duration
+
+	// bg is the background of the terminal if it was queried.
+	bg *background
 }
 
 // tcellNewScreen can be overridden from tests.
 var tcellNewScreen = tcell.NewScreen
 
-// newTerminal creates the terminal and applies the options.
-func newTerminal(opts ...Option) (*Terminal, error) {
-	screen, err := tcellNewScreen()
+// tcellNewInlineScreen creates the screen used with the DisableAltScreen
+// option. Can be overridden from tests.
+var tcellNewInlineScreen = func() (tcell.Screen, error) {
+	ti, err := tcell.LookupTerminfo(os.Getenv("TERM"))
 	if err != nil {
-		return nil, fmt.Errorf("tcell.NewScreen => %v", err)
+		return nil, err
 	}
+	return newInlineScreen(nil, ti)
+}
 
+// newInlineScreen returns a screen that draws on the main screen buffer of
+// the terminal described by the terminfo. A nil tty means the controlling
+// terminal.
+func newInlineScreen(tty tcell.Tty, ti *terminfo.Terminfo) (tcell.Screen, error) {
+	inline := *ti
+	// Don't switch the screen buffers. Also don't clear the screen, tcell
+	// clears it when exiting which would erase the last frame.
+	inline.EnterCA = ""
+	inline.ExitCA = ""
+	inline.Clear = ""
+	return tcell.NewTerminfoScreenFromTtyTerminfo(tty, &inline)
+}
+
+// newTerminal creates the terminal and applies the options.
+func newTerminal(opts ...Option) (*Terminal, error) {
 	t := &Terminal{
 		events:    eventqueue.New(),
 		done:      make(chan struct{}),
 		colorMode: DefaultColorMode,
+		mouseMode: DefaultMouseMode,
 		clearStyle: &cell.Options{
 			FgColor: cell.ColorDefault,
 			BgColor: cell.ColorDefault,
 		},
-		screen: screen,
 	}
 	for _, opt := range opts {
 		opt.set(t)
 	}
 
+	newScreen := tcellNewScreen
+	if t.disableAltScreen {
+		newScreen = tcellNewInlineScreen
+	}
+	if t.bgTimeout > 0 {
+		// The query must be answered before tcell starts processing the
+		// input, otherwise it would report the answer as key presses.
+		// Terminals that cannot be queried have an unknown background.
+		if tty, err := tcellNewTty(); err == nil {
+			t.bg, _ = queryBackground(tty, t.bgTimeout)
+			tty.Close()
+		}
+	}
+
+	screen, err := newScreen()
+	if err != nil {
+		return nil, fmt.Errorf("tcell.NewScreen => %v", err)
+	}
+	t.screen = screen
 	return t, nil
 }
 
@@ -121,14 +293,36 @@ func New(opts ...Option) (*Terminal, error) {
 		return nil, err
 	}
 
-	clearStyle := cellOptsToStyle(t.clearStyle, t.colorMode)
-	t.screen.EnableMouse()
+	clearStyle := cellOptsToStyle(t.clearStyle, t.colorMode, t.colorMap)
+	if flags, ok := mouseFlags(t.mouseMode); ok {
+		t.screen.EnableMouse(flags)
+	}
+	t.screen.EnablePaste()
 	t.screen.SetStyle(clearStyle)
 
 	go t.pollEvents() // Stops when Close() is called.
 	return t, nil
 }
 
+// Capabilities implements terminalapi.CapabilityReporter.Capabilities.
+func (t *Terminal) Capabilities() terminalapi.Capabilities {
+	c := terminalapi.Capabilities{
+		ColorMode:     t.colorMode,
+		Bold:          true,
+		Italic:        true,
+		Underline:     true,
+		Strikethrough: true,
+		Inverse:       true,
+		Blink:         true,
+		Dim:           true,
+	}
+	if t.bg != nil {
+		c.Background = t.bg.kind
+		c.BackgroundColor = t.bg.color
+	}
+	return c
+}
+
 // Size implements terminalapi.Terminal.Size.
 func (t *Terminal) Size() image.Point {
 	w, h := t.screen.Size()
@@ -141,17 +335,44 @@ func (t *Terminal) Size() image.Point {
 // Clear implements terminalapi.Terminal.Clear.
 func (t *Terminal) Clear(opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	st := cellOptsToStyle(o, t.colorMode)
+	st := cellOptsToStyle(o, t.colorMode, t.colorMap)
 	t.screen.Fill(' ', st)
 	return nil
 }
 
 // Flush implements terminalapi.Terminal.Flush.
+// Any strings queued by WriteRaw are written after the cells.
 func (t *Terminal) Flush() error {
 	t.screen.Show()
+
+	raw := t.raw.Take()
+	if len(raw) == 0 {
+		return nil
+	}
+	tty, ok := t.screen.Tty()
+	if !ok {
+		return errors.New("the tcell screen doesn't provide access to its tty, unable to write the raw strings")
+	}
+	for _, r := range raw {
+		if _, err := io.WriteString(tty, r); err != nil {
+			return fmt.Errorf("failed to write a raw string to the tty: %v", err)
+		}
+	}
 	return nil
 }
 
+// WriteRaw queues a string that is written to the terminal unmodified on the
+// next call to Flush, after the cells were written. This is an escape hatch
+// that allows emitting escape sequences termdash doesn't model, e.g. an OSC
+// sequence that sets the title of the window.
+// The string must not move the cursor or change the content of the cells,
+// since the terminal would then no longer match the cells tcell believes are
+// displayed.
+// Unlike other methods of the Terminal, this method is thread-safe.
+func (t *Terminal) WriteRaw(s string) {
+	t.raw.Add(s)
+}
+
 // SetCursor implements terminalapi.Terminal.SetCursor.
 func (t *Terminal) SetCursor(p image.Point) {
 	t.screen.ShowCursor(p.X, p.Y)
@@ -165,7 +386,7 @@ func (t *Terminal) HideCursor() {
 // SetCell implements terminalapi.Terminal.SetCell.
 func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	st := cellOptsToStyle(o, t.colorMode)
+	st := cellOptsToStyle(o, t.colorMode, t.colorMap)
 	t.screen.SetContent(p.X, p.Y, r, nil, st)
 	return nil
 }
@@ -179,7 +400,7 @@ func (t *Terminal) pollEvents() {
 		default:
 		}
 
-		events := toTermdashEvents(t.screen.PollEvent())
+		events := t.paste.events(t.screen.PollEvent())
 		for _, ev := range events {
 			t.events.Push(ev)
 		}
@@ -15,11 +15,20 @@
 package tcell
 
 import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	tcell "github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/terminfo"
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
 
@@ -119,3 +128,475 @@ func TestNewTerminalClearStyle(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTerminalColorMap(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want *Terminal
+	}{
+		{
+			desc: "default options",
+			want: &Terminal{
+				colorMode: terminalapi.ColorMode256,
+			},
+		},
+		{
+			desc: "sets color map",
+			opts: []Option{
+				ColorMap(map[cell.Color]cell.Color{
+					cell.ColorRed: cell.ColorNumber(196),
+				}),
+			},
+			want: &Terminal{
+				colorMode: terminalapi.ColorMode256,
+				colorMap: map[cell.Color]cell.Color{
+					cell.ColorRed: cell.ColorNumber(196),
+				},
+			},
+		},
+	}
+
+	tcellNewScreen = func() (tcell.Screen, error) { return nil, nil }
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := newTerminal(tc.opts...)
+			if err != nil {
+				t.Errorf("newTerminal => unexpected error:\n%v", err)
+				return
+			}
+
+			// Ignore these fields.
+			got.screen = nil
+			got.events = nil
+			got.done = nil
+			got.clearStyle = nil
+
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("newTerminal => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetCellColorMap(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		cOpts  []cell.Option
+		wantFg tcell.Color
+		wantBg tcell.Color
+	}{
+		{
+			desc: "colors without overrides",
+			cOpts: []cell.Option{
+				cell.FgColor(cell.ColorRed),
+				cell.BgColor(cell.ColorBlue),
+			},
+			wantFg: tcell.ColorRed,
+			wantBg: tcell.ColorBlue,
+		},
+		{
+			desc: "overridden colors",
+			opts: []Option{
+				ColorMap(map[cell.Color]cell.Color{
+					cell.ColorRed:  cell.ColorNumber(196),
+					cell.ColorBlue: cell.ColorNumber(27),
+				}),
+			},
+			cOpts: []cell.Option{
+				cell.FgColor(cell.ColorRed),
+				cell.BgColor(cell.ColorBlue),
+			},
+			wantFg: tcell.Color196,
+			wantBg: tcell.Color27,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			screen := tcell.NewSimulationScreen("")
+			if err := screen.Init(); err != nil {
+				t.Fatalf("screen.Init => unexpected error: %v", err)
+			}
+			defer screen.Fini()
+			tcellNewScreen = func() (tcell.Screen, error) { return screen, nil }
+
+			term, err := newTerminal(tc.opts...)
+			if err != nil {
+				t.Fatalf("newTerminal => unexpected error: %v", err)
+			}
+			if err := term.SetCell(image.Point{1, 1}, 'x', tc.cOpts...); err != nil {
+				t.Fatalf("SetCell => unexpected error: %v", err)
+			}
+			if err := term.Flush(); err != nil {
+				t.Fatalf("Flush => unexpected error: %v", err)
+			}
+
+			r, _, st, _ := screen.GetContent(1, 1)
+			if r != 'x' {
+				t.Errorf("GetContent => got rune %q, want %q", r, 'x')
+			}
+			fg, bg, _ := st.Decompose()
+			if fg != tc.wantFg || bg != tc.wantBg {
+				t.Errorf("GetContent => got fg %v and bg %v, want fg %v and bg %v", fg, bg, tc.wantFg, tc.wantBg)
+			}
+		})
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	tcellNewScreen = func() (tcell.Screen, error) { return nil, nil }
+	got, err := newTerminal(ColorMode(terminalapi.ColorModeTrueColor))
+	if err != nil {
+		t.Fatalf("newTerminal => unexpected error:\n%v", err)
+	}
+
+	want := terminalapi.Capabilities{
+		ColorMode:     terminalapi.ColorModeTrueColor,
+		Bold:          true,
+		Italic:        true,
+		Underline:     true,
+		Strikethrough: true,
+		Inverse:       true,
+		Blink:         true,
+		Dim:           true,
+	}
+	if diff := pretty.Compare(want, got.Capabilities()); diff != "" {
+		t.Errorf("Capabilities => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestNewTerminalDisableAltScreen(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		wantInline bool
+	}{
+		{
+			desc: "uses the alternate screen buffer by default",
+		},
+		{
+			desc:       "draws on the main screen buffer when disabled",
+			opts:       []Option{DisableAltScreen()},
+			wantInline: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotInline bool
+			tcellNewScreen = func() (tcell.Screen, error) { return nil, nil }
+			tcellNewInlineScreen = func() (tcell.Screen, error) {
+				gotInline = true
+				return nil, nil
+			}
+
+			if _, err := newTerminal(tc.opts...); err != nil {
+				t.Fatalf("newTerminal => unexpected error:\n%v", err)
+			}
+			if gotInline != tc.wantInline {
+				t.Errorf("newTerminal => created the inline screen: %v, want %v", gotInline, tc.wantInline)
+			}
+		})
+	}
+}
+
+// fakeTty implements tcell.Tty and records everything written to it.
+// Data sent to input is returned by Read.
+type fakeTty struct {
+	mu      sync.Mutex
+	out     bytes.Buffer
+	drained chan struct{}
+	input   chan []byte
+	size    tcell.WindowSize
+}
+
+func newFakeTty() *fakeTty {
+	return &fakeTty{
+		drained: make(chan struct{}),
+		input:   make(chan []byte),
+		size:    tcell.WindowSize{Width: 80, Height: 24},
+	}
+}
+
+func (*fakeTty) Start() error { return nil }
+func (*fakeTty) Stop() error  { return nil }
+func (ft *fakeTty) Drain() error {
+	close(ft.drained)
+	return nil
+}
+func (*fakeTty) NotifyResize(func()) {}
+func (ft *fakeTty) WindowSize() (tcell.WindowSize, error) {
+	return ft.size, nil
+}
+func (ft *fakeTty) Read(p []byte) (int, error) {
+	select {
+	case in := <-ft.input:
+		return copy(p, in), nil
+	case <-ft.drained:
+		return 0, io.EOF
+	}
+}
+func (ft *fakeTty) Write(p []byte) (int, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return ft.out.Write(p)
+}
+func (*fakeTty) Close() error { return nil }
+
+// output returns everything written to the tty.
+func (ft *fakeTty) output() string {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return ft.out.String()
+}
+
+func TestInlineScreenDoesNotSwitchBuffers(t *testing.T) {
+	const (
+		enterCA = "\x1b[?1049h"
+		exitCA  = "\x1b[?1049l"
+		clear   = "\x1b[H\x1b[2J"
+	)
+	ti := &terminfo.Terminfo{
+		Name:      "termdash-test",
+		Columns:   80,
+		Lines:     24,
+		Colors:    256,
+		EnterCA:   enterCA,
+		ExitCA:    exitCA,
+		Clear:     clear,
+		AttrOff:   "\x1b[m",
+		SetFg:     "\x1b[38;5;%p1%dm",
+		SetBg:     "\x1b[48;5;%p1%dm",
+		SetCursor: "\x1b[%i%p1%d;%p2%dH",
+	}
+
+	tests := []struct {
+		desc       string
+		newScreen  func(tcell.Tty, *terminfo.Terminfo) (tcell.Screen, error)
+		wantSwitch bool
+	}{
+		{
+			desc:       "tcell switches the buffers by default",
+			newScreen:  tcell.NewTerminfoScreenFromTtyTerminfo,
+			wantSwitch: true,
+		},
+		{
+			desc:      "inline screen stays on the main buffer",
+			newScreen: newInlineScreen,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tty := newFakeTty()
+			screen, err := tc.newScreen(tty, ti)
+			if err != nil {
+				t.Fatalf("newScreen => unexpected error: %v", err)
+			}
+			if err := screen.Init(); err != nil {
+				t.Fatalf("screen.Init => unexpected error: %v", err)
+			}
+			screen.SetContent(0, 0, 'x', nil, tcell.StyleDefault)
+			screen.Show()
+			screen.Fini()
+
+			out := tty.output()
+			for _, seq := range []string{enterCA, exitCA, clear} {
+				if got := strings.Contains(out, seq); got != tc.wantSwitch {
+					t.Errorf("output contains %q: %v, want %v, output:\n%q", seq, got, tc.wantSwitch, out)
+				}
+			}
+			if !strings.Contains(out, "x") {
+				t.Errorf("output doesn't contain the drawn content, output:\n%q", out)
+			}
+		})
+	}
+}
+
+func TestMouseReporting(t *testing.T) {
+	const (
+		buttons = "\x1b[?1000h"
+		drag    = "\x1b[?1002h"
+		motion  = "\x1b[?1003h"
+		sgr     = "\x1b[?1006h"
+	)
+	ti := &terminfo.Terminfo{
+		Name:      "termdash-test",
+		Columns:   400,
+		Lines:     50,
+		Colors:    256,
+		AttrOff:   "\x1b[m",
+		SetFg:     "\x1b[38;5;%p1%dm",
+		SetBg:     "\x1b[48;5;%p1%dm",
+		SetCursor: "\x1b[%i%p1%d;%p2%dH",
+		Mouse:     "\x1b[M",
+	}
+
+	tests := []struct {
+		desc       string
+		opts       []Option
+		wantSeqs   []string
+		noSeqs     []string
+		wantEvents bool
+	}{
+		{
+			desc:       "reports all mouse events by default",
+			wantSeqs:   []string{buttons, drag, motion, sgr},
+			wantEvents: true,
+		},
+		{
+			desc:       "reports drag events",
+			opts:       []Option{MouseReporting(MouseModeDrag)},
+			wantSeqs:   []string{buttons, drag, sgr},
+			noSeqs:     []string{motion},
+			wantEvents: true,
+		},
+		{
+			desc:       "reports button events only",
+			opts:       []Option{MouseReporting(MouseModeButtons)},
+			wantSeqs:   []string{buttons, sgr},
+			noSeqs:     []string{drag, motion},
+			wantEvents: true,
+		},
+		{
+			desc:   "mouse reporting disabled",
+			opts:   []Option{MouseReporting(MouseModeDisabled)},
+			noSeqs: []string{buttons, drag, motion, sgr},
+		},
+	}
+
+	defer func(orig func() (tcell.Screen, error)) { tcellNewScreen = orig }(tcellNewScreen)
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tty := newFakeTty()
+			tty.size = tcell.WindowSize{Width: 400, Height: 50}
+			tcellNewScreen = func() (tcell.Screen, error) {
+				return tcell.NewTerminfoScreenFromTtyTerminfo(tty, ti)
+			}
+
+			term, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			defer term.Close()
+
+			out := tty.output()
+			for _, seq := range tc.wantSeqs {
+				if !strings.Contains(out, seq) {
+					t.Errorf("output doesn't contain %q, output:\n%q", seq, out)
+				}
+			}
+			for _, seq := range tc.noSeqs {
+				if strings.Contains(out, seq) {
+					t.Errorf("output contains %q, output:\n%q", seq, out)
+				}
+			}
+			if !tc.wantEvents {
+				return
+			}
+
+			// A left button press at the 301st column and the 5th row in
+			// the SGR extended format.
+			tty.input <- []byte("\x1b[<0;301;5M")
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			want := &terminalapi.Mouse{
+				Position: image.Point{300, 4},
+				Button:   mouse.ButtonLeft,
+			}
+			for {
+				ev := term.Event(ctx)
+				if ev == nil {
+					t.Fatalf("Event => timed out waiting for %v", want)
+				}
+				if _, ok := ev.(*terminalapi.Resize); ok {
+					continue
+				}
+				if diff := pretty.Compare(want, ev); diff != "" {
+					t.Errorf("Event => unexpected diff (-want, +got):\n%s", diff)
+				}
+				break
+			}
+		})
+	}
+}
+
+func TestFlushWritesRaw(t *testing.T) {
+	const title = "\x1b]0;title\x07"
+	ti := &terminfo.Terminfo{
+		Name:      "termdash-test",
+		Columns:   80,
+		Lines:     24,
+		Colors:    256,
+		AttrOff:   "\x1b[m",
+		SetFg:     "\x1b[38;5;%p1%dm",
+		SetBg:     "\x1b[48;5;%p1%dm",
+		SetCursor: "\x1b[%i%p1%d;%p2%dH",
+	}
+
+	defer func(orig func() (tcell.Screen, error)) { tcellNewScreen = orig }(tcellNewScreen)
+	tty := newFakeTty()
+	tcellNewScreen = func() (tcell.Screen, error) {
+		return tcell.NewTerminfoScreenFromTtyTerminfo(tty, ti)
+	}
+
+	term, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	defer term.Close()
+
+	if err := term.SetCell(image.Point{0, 0}, 'x'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	term.WriteRaw(title)
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	out := tty.output()
+	cellIdx := strings.LastIndex(out, "x")
+	rawIdx := strings.Index(out, title)
+	if cellIdx == -1 || rawIdx == -1 || rawIdx < cellIdx {
+		t.Errorf("Flush => expected the cell followed by the raw string %q, output:\n%q", title, out)
+	}
+
+	// The raw string is only written once, the cells are still flushed.
+	if err := term.SetCell(image.Point{1, 0}, 'y'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	out = tty.output()
+	if got := strings.Count(out, title); got != 1 {
+		t.Errorf("output contains the raw string %d times, want once, output:\n%q", got, out)
+	}
+	if !strings.Contains(out[rawIdx:], "y") {
+		t.Errorf("output doesn't contain the cell drawn after the raw string, output:\n%q", out)
+	}
+}
+
+func TestFlushRawWithoutTty(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen.Init => unexpected error: %v", err)
+	}
+	defer screen.Fini()
+
+	defer func(orig func() (tcell.Screen, error)) { tcellNewScreen = orig }(tcellNewScreen)
+	tcellNewScreen = func() (tcell.Screen, error) { return screen, nil }
+	term, err := newTerminal()
+	if err != nil {
+		t.Fatalf("newTerminal => unexpected error: %v", err)
+	}
+
+	if err := term.Flush(); err != nil {
+		t.Errorf("Flush => unexpected error without raw strings: %v", err)
+	}
+	term.WriteRaw("raw")
+	if err := term.Flush(); err == nil {
+		t.Errorf("Flush => got nil error, want an error when the screen has no tty")
+	}
+}
@@ -20,11 +20,31 @@ import (
 	"errors"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/palette"
+	"github.com/mum4k/termdash/terminal/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
 
 // cellColor converts termdash cell color to the termbox format.
-func cellColor(c cell.Color) tbx.Attribute {
+// True colors are replaced with the nearest color supported by the color
+// mode, since termbox cannot mix true colors with the terminal palette.
+func cellColor(c cell.Color, cm terminalapi.ColorMode) tbx.Attribute {
+	if c.IsRGB24() {
+		switch cm {
+		case terminalapi.ColorModeNormal:
+			return tbx.Attribute(palette.Nearest16(c))
+		case terminalapi.ColorMode216:
+			// Termbox expects the cube colors to start at one in this mode.
+			return tbx.Attribute(palette.NearestCube(c) - 16)
+		case terminalapi.ColorModeGrayscale:
+			// Termbox expects the shades of grey to start at two in this
+			// mode, one is reserved for black.
+			return tbx.Attribute(palette.NearestGrayscale(c) - 231)
+		default:
+			return tbx.Attribute(palette.Nearest256(c))
+		}
+	}
+
 	// Special cases for backward compatibility after we have aligned the
 	// definition of the first 16 colors with Xterm and tcell.
 	// This ensures that users that run with termbox-go don't experience any
@@ -44,8 +64,8 @@ func cellColor(c cell.Color) tbx.Attribute {
 }
 
 // cellOptsToFg converts the cell options to the termbox foreground attribute.
-func cellOptsToFg(opts *cell.Options) (tbx.Attribute, error) {
-	a := cellColor(opts.FgColor)
+func cellOptsToFg(opts *cell.Options, cm terminalapi.ColorMode) (tbx.Attribute, error) {
+	a := cellColor(opts.FgColor, cm)
 	if opts.Bold {
 		a |= tbx.AttrBold
 	}
@@ -76,6 +96,6 @@ func cellOptsToFg(opts *cell.Options) (tbx.Attribute, error) {
 }
 
 // cellOptsToBg converts the cell options to the termbox background attribute.
-func cellOptsToBg(opts *cell.Options) tbx.Attribute {
-	return cellColor(opts.BgColor)
+func cellOptsToBg(opts *cell.Options, cm terminalapi.ColorMode) tbx.Attribute {
+	return cellColor(opts.BgColor, cm)
 }
@@ -19,31 +19,38 @@ import (
 	"testing"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
 
 func TestCellColor(t *testing.T) {
 	tests := []struct {
-		color cell.Color
-		want  tbx.Attribute
+		color     cell.Color
+		colorMode terminalapi.ColorMode
+		want      tbx.Attribute
 	}{
-		{cell.ColorDefault, tbx.ColorDefault},
-		{cell.ColorBlack, tbx.ColorBlack},
-		{cell.ColorRed, tbx.ColorRed},
-		{cell.ColorGreen, tbx.ColorGreen},
-		{cell.ColorYellow, tbx.ColorYellow},
-		{cell.ColorBlue, tbx.ColorBlue},
-		{cell.ColorMagenta, tbx.ColorMagenta},
-		{cell.ColorCyan, tbx.ColorCyan},
-		{cell.ColorWhite, tbx.ColorWhite},
-		{cell.Color(42), tbx.Attribute(42)},
+		{cell.ColorDefault, terminalapi.ColorMode256, tbx.ColorDefault},
+		{cell.ColorBlack, terminalapi.ColorMode256, tbx.ColorBlack},
+		{cell.ColorRed, terminalapi.ColorMode256, tbx.ColorRed},
+		{cell.ColorGreen, terminalapi.ColorMode256, tbx.ColorGreen},
+		{cell.ColorYellow, terminalapi.ColorMode256, tbx.ColorYellow},
+		{cell.ColorBlue, terminalapi.ColorMode256, tbx.ColorBlue},
+		{cell.ColorMagenta, terminalapi.ColorMode256, tbx.ColorMagenta},
+		{cell.ColorCyan, terminalapi.ColorMode256, tbx.ColorCyan},
+		{cell.ColorWhite, terminalapi.ColorMode256, tbx.ColorWhite},
+		{cell.Color(42), terminalapi.ColorMode256, tbx.Attribute(42)},
+		{cell.ColorRGB24(255, 0, 0), terminalapi.ColorModeNormal, tbx.Attribute(cell.ColorRed)},
+		{cell.ColorRGB24(95, 135, 255), terminalapi.ColorMode256, tbx.Attribute(cell.ColorRGB6(1, 2, 5))},
+		{cell.ColorRGB24(0, 0, 0), terminalapi.ColorMode216, tbx.Attribute(1)},
+		{cell.ColorRGB24(8, 8, 8), terminalapi.ColorModeGrayscale, tbx.Attribute(2)},
+		{cell.ColorRGB24(238, 238, 238), terminalapi.ColorModeGrayscale, tbx.Attribute(25)},
 	}
 
 	for _, tc := range tests {
-		t.Run(tc.color.String(), func(t *testing.T) {
-			got := cellColor(tc.color)
+		t.Run(fmt.Sprintf("%v in %v", tc.color, tc.colorMode), func(t *testing.T) {
+			got := cellColor(tc.color, tc.colorMode)
 			if got != tc.want {
-				t.Errorf("cellColor(%v) => got %v, want %v", tc.color, got, tc.want)
+				t.Errorf("cellColor(%v, %v) => got %v, want %v", tc.color, tc.colorMode, got, tc.want)
 			}
 
 		})
@@ -67,7 +74,7 @@ func TestCellFontModifier(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(fmt.Sprintf("%v", tc.opt), func(t *testing.T) {
-			got, err := cellOptsToFg(&tc.opt)
+			got, err := cellOptsToFg(&tc.opt, terminalapi.ColorMode256)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("cellOptsToFg(%v) => unexpected error: %v, wantErr: %v", tc.opt, err, tc.wantErr)
 			}
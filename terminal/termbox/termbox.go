@@ -18,10 +18,14 @@ package termbox
 
 import (
 	"context"
+	"fmt"
 	"image"
+	"io"
+	"os"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/event/eventqueue"
+	"github.com/mum4k/termdash/private/rawqueue"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
@@ -44,6 +48,8 @@ func (o option) set(t *Terminal) {
 const DefaultColorMode = terminalapi.ColorMode256
 
 // ColorMode sets the terminal color mode.
+// The terminalapi.ColorModeTrueColor mode isn't supported by termbox, true
+// colors are replaced with the nearest color of the selected mode.
 // Defaults to DefaultColorMode.
 func ColorMode(cm terminalapi.ColorMode) Option {
 	return option(func(t *Terminal) {
@@ -64,10 +70,21 @@ type Terminal struct {
 	// done gets closed when Close() is called.
 	done chan struct{}
 
+	// raw are the raw strings queued by WriteRaw, written on the next Flush.
+	raw rawqueue.Queue
+
 	// Options.
 	colorMode terminalapi.ColorMode
 }
 
+// tbxFlush flushes the termbox back buffer to the terminal.
+// Can be overridden from tests.
+var tbxFlush = tbx.Flush
+
+// rawOut is where the strings queued by WriteRaw are written.
+// Can be overridden from tests.
+var rawOut io.Writer = os.Stdout
+
 // newTerminal creates the terminal and applies the options.
 func newTerminal(opts ...Option) *Terminal {
 	t := &Terminal{
@@ -83,6 +100,14 @@ func newTerminal(opts ...Option) *Terminal {
 
 // New returns a new termbox based Terminal.
 // Call Close() when the terminal isn't required anymore.
+//
+// The termbox library always switches to the alternate screen buffer, use the
+// tcell based Terminal with its DisableAltScreen option to draw on the main
+// screen buffer instead.
+//
+// The termbox library doesn't support bracketed paste, pasted text is
+// delivered as a keyboard event for every character. Use the tcell based
+// Terminal to receive terminalapi.Paste events.
 func New(opts ...Option) (*Terminal, error) {
 	if err := tbx.Init(); err != nil {
 		return nil, err
@@ -100,6 +125,18 @@ func New(opts ...Option) (*Terminal, error) {
 	return t, nil
 }
 
+// Capabilities implements terminalapi.CapabilityReporter.Capabilities.
+// Termbox doesn't support the italic, strikethrough, blink and dim font
+// modifiers.
+func (t *Terminal) Capabilities() terminalapi.Capabilities {
+	return terminalapi.Capabilities{
+		ColorMode: t.colorMode,
+		Bold:      true,
+		Underline: true,
+		Inverse:   true,
+	}
+}
+
 // Size implements terminalapi.Terminal.Size.
 func (t *Terminal) Size() image.Point {
 	w, h := tbx.Size()
@@ -109,16 +146,38 @@ func (t *Terminal) Size() image.Point {
 // Clear implements terminalapi.Terminal.Clear.
 func (t *Terminal) Clear(opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	fg, err := cellOptsToFg(o)
+	fg, err := cellOptsToFg(o, t.colorMode)
 	if err != nil {
 		return err
 	}
-	return tbx.Clear(fg, cellOptsToBg(o))
+	return tbx.Clear(fg, cellOptsToBg(o, t.colorMode))
 }
 
 // Flush implements terminalapi.Terminal.Flush.
+// Any strings queued by WriteRaw are written after the cells.
 func (t *Terminal) Flush() error {
-	return tbx.Flush()
+	if err := tbxFlush(); err != nil {
+		return err
+	}
+	for _, r := range t.raw.Take() {
+		if _, err := io.WriteString(rawOut, r); err != nil {
+			return fmt.Errorf("failed to write a raw string to the standard output: %v", err)
+		}
+	}
+	return nil
+}
+
+// WriteRaw queues a string that is written to the terminal unmodified on the
+// next call to Flush, after the cells were written. This is an escape hatch
+// that allows emitting escape sequences termdash doesn't model, e.g. an OSC
+// sequence that sets the title of the window.
+// The string is written to the standard output, which must be connected to
+// the terminal. The string must not move the cursor or change the content of
+// the cells, since the terminal would then no longer match the cells termbox
+// believes are displayed.
+// Unlike other methods of the Terminal, this method is thread-safe.
+func (t *Terminal) WriteRaw(s string) {
+	t.raw.Add(s)
 }
 
 // SetCursor implements terminalapi.Terminal.SetCursor.
@@ -134,11 +193,11 @@ func (t *Terminal) HideCursor() {
 // SetCell implements terminalapi.Terminal.SetCell.
 func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	fg, err := cellOptsToFg(o)
+	fg, err := cellOptsToFg(o, t.colorMode)
 	if err != nil {
 		return err
 	}
-	tbx.SetCell(p.X, p.Y, r, fg, cellOptsToBg(o))
+	tbx.SetCell(p.X, p.Y, r, fg, cellOptsToBg(o, t.colorMode))
 	return nil
 }
 
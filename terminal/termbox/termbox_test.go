@@ -15,6 +15,8 @@
 package termbox
 
 import (
+	"bytes"
+	"io"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -58,3 +60,76 @@ func TestNewTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want terminalapi.Capabilities
+	}{
+		{
+			desc: "default color mode",
+			want: terminalapi.Capabilities{
+				ColorMode: terminalapi.ColorMode256,
+				Bold:      true,
+				Underline: true,
+				Inverse:   true,
+			},
+		},
+		{
+			desc: "reports the selected color mode",
+			opts: []Option{
+				ColorMode(terminalapi.ColorModeGrayscale),
+			},
+			want: terminalapi.Capabilities{
+				ColorMode: terminalapi.ColorModeGrayscale,
+				Bold:      true,
+				Underline: true,
+				Inverse:   true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := newTerminal(tc.opts...).Capabilities()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Capabilities => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if got.Italic || got.Blink {
+				t.Errorf("Capabilities => %+v, termbox doesn't support italic and blink", got)
+			}
+		})
+	}
+}
+
+func TestFlushWritesRaw(t *testing.T) {
+	const title = "\x1b]0;title\x07"
+
+	defer func(orig func() error) { tbxFlush = orig }(tbxFlush)
+	defer func(orig io.Writer) { rawOut = orig }(rawOut)
+	var out bytes.Buffer
+	tbxFlush = func() error {
+		out.WriteString("cells")
+		return nil
+	}
+	rawOut = &out
+
+	term := newTerminal()
+	term.WriteRaw(title)
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	if got, want := out.String(), "cells"+title; got != want {
+		t.Errorf("Flush wrote %q, want %q", got, want)
+	}
+
+	// The raw string is only written once, the cells are still flushed.
+	out.Reset()
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	if got, want := out.String(), "cells"; got != want {
+		t.Errorf("Flush wrote %q, want %q", got, want)
+	}
+}
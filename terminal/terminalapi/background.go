@@ -0,0 +1,50 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminalapi
+
+// background.go defines the kinds of the background of a terminal.
+
+// Background indicates whether the background of a terminal is light or
+// dark.
+type Background int
+
+// String implements fmt.Stringer()
+func (b Background) String() string {
+	if n, ok := backgroundNames[b]; ok {
+		return n
+	}
+	return "BackgroundUnknown"
+}
+
+// backgroundNames maps Background values to human readable names.
+var backgroundNames = map[Background]string{
+	BackgroundUnknown: "BackgroundUnknown",
+	BackgroundDark:    "BackgroundDark",
+	BackgroundLight:   "BackgroundLight",
+}
+
+const (
+	// BackgroundUnknown is used when the terminal didn't report its
+	// background color.
+	BackgroundUnknown Background = iota
+
+	// BackgroundDark is used when the terminal has a dark background, i.e.
+	// light text is readable on it.
+	BackgroundDark
+
+	// BackgroundLight is used when the terminal has a light background, i.e.
+	// dark text is readable on it.
+	BackgroundLight
+)
@@ -0,0 +1,61 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminalapi
+
+// capabilities.go defines the report of features supported by a terminal.
+
+import "github.com/mum4k/termdash/cell"
+
+// Capabilities reports the features supported by a terminal implementation.
+// Applications and widgets can use it to avoid cell options the terminal
+// cannot display.
+type Capabilities struct {
+	// ColorMode is the color mode the terminal operates in.
+	ColorMode ColorMode
+
+	// The font modifiers, true if the terminal supports the modifier.
+	Bold          bool
+	Italic        bool
+	Underline     bool
+	Strikethrough bool
+	Inverse       bool
+	Blink         bool
+	Dim           bool
+
+	// Background indicates whether the terminal reported a light or a dark
+	// background color. Applications can use it to choose readable colors,
+	// e.g. to pick a theme.
+	Background Background
+	// BackgroundColor is the background color the terminal reported or
+	// cell.ColorDefault if it didn't report one.
+	BackgroundColor cell.Color
+}
+
+// Supports determines if the terminal supports all the font modifiers set in
+// the provided cell options.
+func (c Capabilities) Supports(opts *cell.Options) bool {
+	switch {
+	case opts.Bold && !c.Bold,
+		opts.Italic && !c.Italic,
+		opts.Underline && !c.Underline,
+		opts.Strikethrough && !c.Strikethrough,
+		opts.Inverse && !c.Inverse,
+		opts.Blink && !c.Blink,
+		opts.Dim && !c.Dim:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminalapi
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestCapabilitiesSupports(t *testing.T) {
+	tests := []struct {
+		desc string
+		caps Capabilities
+		opts []cell.Option
+		want bool
+	}{
+		{
+			desc: "no modifiers are always supported",
+			want: true,
+		},
+		{
+			desc: "colors don't affect support",
+			opts: []cell.Option{
+				cell.FgColor(cell.ColorRGB24(1, 2, 3)),
+				cell.BgColor(cell.ColorRed),
+			},
+			want: true,
+		},
+		{
+			desc: "supported modifiers",
+			caps: Capabilities{
+				Bold:      true,
+				Underline: true,
+			},
+			opts: []cell.Option{
+				cell.Bold(),
+				cell.Underline(),
+			},
+			want: true,
+		},
+		{
+			desc: "one unsupported modifier",
+			caps: Capabilities{
+				Bold:      true,
+				Underline: true,
+			},
+			opts: []cell.Option{
+				cell.Bold(),
+				cell.Italic(),
+			},
+			want: false,
+		},
+		{
+			desc: "all modifiers supported",
+			caps: Capabilities{
+				Bold:          true,
+				Italic:        true,
+				Underline:     true,
+				Strikethrough: true,
+				Inverse:       true,
+				Blink:         true,
+				Dim:           true,
+			},
+			opts: []cell.Option{
+				cell.Bold(),
+				cell.Italic(),
+				cell.Underline(),
+				cell.Strikethrough(),
+				cell.Inverse(),
+				cell.Blink(),
+				cell.Dim(),
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.caps.Supports(cell.NewOptions(tc.opts...))
+			if got != tc.want {
+				t.Errorf("Supports => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ var colorModeNames = map[ColorMode]string{
 	ColorMode256:       "ColorMode256",
 	ColorMode216:       "ColorMode216",
 	ColorModeGrayscale: "ColorModeGrayscale",
+	ColorModeTrueColor: "ColorModeTrueColor",
 }
 
 // Supported color modes.
@@ -57,4 +58,10 @@ const (
 	// i.e the 24 different shades of grey. However in this mode the colors are
 	// zero based, so the caller doesn't need to provide an offset.
 	ColorModeGrayscale
+
+	// ColorModeTrueColor supports the 256 terminal colors same as
+	// ColorMode256 and additionally the 24 bit true colors created by
+	// cell.ColorRGB24. In all the other modes, true colors are replaced with
+	// the nearest color supported by the mode.
+	ColorModeTrueColor
 )
@@ -30,20 +30,50 @@ type Event interface {
 	isEvent()
 }
 
-// Keyboard is the event used when a key is pressed.
+// Keyboard is the event used when a key is pressed or released.
 // Implements terminalapi.Event.
 type Keyboard struct {
-	// Key is the pressed key.
+	// Key is the pressed or released key.
 	Key keyboard.Key
+
+	// Release is true if the key was released rather than pressed.
+	// Only terminals that implement a keyboard protocol which reports key
+	// releases, e.g. the kitty keyboard protocol, send these events. Other
+	// terminals only report key presses. Neither the tcell nor the termbox
+	// library currently expose key releases, the headless terminal sends
+	// the injected events as they are.
+	//
+	// Releases are only delivered to widgets that set
+	// widgetapi.Options.WantKeyRelease.
+	Release bool
 }
 
 func (*Keyboard) isEvent() {}
 
 // String implements fmt.Stringer.
 func (k Keyboard) String() string {
+	if k.Release {
+		return fmt.Sprintf("Keyboard{Key: %v, Release: true}", k.Key)
+	}
 	return fmt.Sprintf("Keyboard{Key: %v}", k.Key)
 }
 
+// Paste is the event used when text is pasted into the terminal. Terminals
+// that support bracketed paste deliver the entire pasted text in a single
+// event instead of a keyboard event for every character.
+// Implements terminalapi.Event.
+type Paste struct {
+	// Text is the pasted text. Lines are separated by the newline character.
+	Text string
+}
+
+func (*Paste) isEvent() {}
+
+// String implements fmt.Stringer.
+func (p Paste) String() string {
+	return fmt.Sprintf("Paste{Text: %q}", p.Text)
+}
+
 // Resize is the event used when the terminal was resized.
 // Implements terminalapi.Event.
 type Resize struct {
@@ -54,3 +54,11 @@ type Terminal interface {
 	// the terminal isn't required anymore to return the screen to a sane state.
 	Close()
 }
+
+// CapabilityReporter is implemented by terminals that report the features
+// they support. Use a type assertion to check if a Terminal implements it.
+type CapabilityReporter interface {
+	// Capabilities reports the features supported by the terminal, e.g. the
+	// font modifiers it can display.
+	Capabilities() Capabilities
+}
@@ -17,7 +17,10 @@ package widgetapi
 
 import (
 	"image"
+	"time"
 
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
@@ -137,6 +140,21 @@ type Options struct {
 	// KeyScopeGlobal.
 	ExclusiveKeyboardOnFocus bool
 
+	// CaptureKeysOnFocus allows a widget to capture the specified keys while
+	// its container is focused. A captured key is delivered only to this
+	// widget and the container doesn't act on its own key bindings for it,
+	// e.g. it doesn't move the keyboard focus even if the key is set with the
+	// container.KeyFocusNext option. Has no effect if WantKeyboard is set to
+	// KeyScopeNone.
+	CaptureKeysOnFocus []keyboard.Key
+
+	// WantKeyRelease allows a widget to request keyboard events that report
+	// the release of a key, see terminalapi.Keyboard.Release. The releases
+	// are delivered within the scope set by WantKeyboard. Other widgets, the
+	// container and the keyboard subscribers only see key presses.
+	// Only some terminals report key releases.
+	WantKeyRelease bool
+
 	// WantMouse allows a widget to request mouse events and specify their
 	// desired scope. If set to MouseScopeNone, no mouse events are forwarded
 	// to the widget.
@@ -144,12 +162,33 @@ type Options struct {
 	// if it falls onto its canvas. See the documentation next to individual
 	// MouseScope values for details.
 	WantMouse MouseScope
+
+	// WantResize allows a widget to request terminal resize events.
+	// The widget must also implement the Resizer interface, otherwise the
+	// events aren't forwarded to it.
+	WantResize bool
+
+	// RedrawInterval allows a widget to request to be redrawn at least this
+	// often, e.g. while it is animating. When any visible widget requests
+	// an interval shorter than termdash.RedrawInterval, termdash redraws the
+	// terminal at the shortest requested interval instead. The widget can
+	// reset this to zero once it doesn't need the frequent redraws, the
+	// options are checked after each redraw.
+	// The zero value indicates no request. Ignored when termdash is driven
+	// by the termdash.Controller.
+	RedrawInterval time.Duration
 }
 
 // Meta provide additional metadata to widgets.
 type Meta struct {
 	// Focused asserts whether the widget's container is focused.
 	Focused bool
+
+	// Theme is the theme applied to the widget's container or inherited from
+	// one of its parents, nil if there is no theme. Widgets that support
+	// themes use its colors in place of their defaults for the color options
+	// that weren't set.
+	Theme *cell.Theme
 }
 
 // EventMeta provides additional metadata about events to widgets.
@@ -200,3 +239,43 @@ type Widget interface {
 	// Draw.
 	Options() Options
 }
+
+// Paster is implemented by widgets that accept pasted text in one step, e.g.
+// to insert it at once instead of character by character.
+// Paste events are forwarded to the same widgets that would receive keyboard
+// events according to their WantKeyboard option. Widgets that don't implement
+// this interface receive the pasted text as a keyboard event for every
+// character instead, with newlines delivered as keyboard.KeyEnter and tabs
+// as keyboard.KeyTab.
+type Paster interface {
+	// Paste is called with every paste event.
+	//
+	// The argument meta is guaranteed to be valid (i.e. non-nil).
+	Paste(p *terminalapi.Paste, meta *EventMeta) error
+}
+
+// Resizer is implemented by widgets that react to terminal resize events, e.g.
+// to recompute offsets of scrolled content. The widgets still learn about the
+// size of their canvas when Draw is called, the new size of the terminal
+// doesn't determine the size of the canvas.
+// The events are only forwarded to widgets that set the WantResize option.
+type Resizer interface {
+	// Resize is called with every terminal resize event.
+	//
+	// The argument meta is guaranteed to be valid (i.e. non-nil).
+	Resize(r *terminalapi.Resize, meta *EventMeta) error
+}
+
+// PreferredSizer is implemented by widgets that know the size their content
+// needs, e.g. a short text. A container configured with the ShrinkToFit
+// option sizes itself to the preferred size of its widget and gives the
+// remaining space to its sibling.
+type PreferredSizer interface {
+	// PreferredSize returns the size of the canvas in cells that fits the
+	// current content of the widget. A zero coordinate indicates that the
+	// widget has no preference along that axis.
+	//
+	// The size is queried whenever the layout is determined, so it can
+	// change with the content of the widget.
+	PreferredSize() image.Point
+}
@@ -25,10 +25,12 @@ import (
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/area"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
@@ -36,7 +38,10 @@ import (
 // BarChart displays multiple bars showing relative ratios of values.
 //
 // Each bar can have a text label under it explaining the meaning of the value
-// and can display the value itself inside the bar.
+// and can display the value itself inside the bar. The bars are vertical by
+// default, see the Horizontal option for bars laid out in rows. Bars can also
+// be composed of multiple stacked segments, see StackedValues, or shown in
+// groups of adjacent bars, see GroupedValues.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type BarChart struct {
@@ -44,10 +49,23 @@ type BarChart struct {
 	// individual bars that will be drawn.
 	values []int
 	// max is the maximum value of a bar. A bar having this value takes all the
-	// vertical space.
+	// vertical space, or all the horizontal space in the horizontal mode.
+	// Zero for stacked bars that are each scaled to their own total.
 	max int
 
-	// lastWidth is the width of the canvas as of the last time when Draw was called.
+	// segments are the values of the segments of stacked bars provided on a
+	// call to StackedValues(). Nil unless the bars are stacked, the values
+	// then hold the totals of each of the bars.
+	segments [][]int
+
+	// groups are the values of the bars of each group of grouped bars
+	// provided on a call to GroupedValues(). Nil unless the bars are grouped,
+	// the values then hold the largest value of each group.
+	groups [][]int
+
+	// lastWidth is the width of the canvas as of the last time when Draw was
+	// called. This is the height of the canvas in the horizontal mode, i.e.
+	// always the size along the axis where the bars are laid out.
 	lastWidth int
 
 	// mu protects the BarChart.
@@ -77,7 +95,7 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
-	bc.lastWidth = cvs.Area().Dx()
+	bc.lastWidth = bc.barAxis(cvs)
 	needAr, err := area.FromSize(bc.minSize())
 	if err != nil {
 		return err
@@ -86,23 +104,42 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		return draw.ResizeNeeded(cvs)
 	}
 
-	for i, v := range bc.values {
-		r, err := bc.barRect(cvs, i, v)
-		if err != nil {
+	if bc.opts.baseline {
+		if err := bc.drawBaseline(cvs); err != nil {
 			return err
 		}
-
-		if r.Dy() > 0 { // Value might be so small so that the rectangle is zero.
-			if err := draw.Rectangle(cvs, r,
-				draw.RectCellOpts(cell.BgColor(bc.barColor(i))),
-				draw.RectChar(bc.opts.barChar),
-			); err != nil {
+	}
+	for i, v := range bc.values {
+		switch {
+		case bc.segments != nil:
+			if err := bc.drawSegments(cvs, i); err != nil {
 				return err
 			}
+		case bc.groups != nil:
+			if err := bc.drawGroup(cvs, i); err != nil {
+				return err
+			}
+		default:
+			r, err := bc.barRect(cvs, i, v)
+			if err != nil {
+				return err
+			}
+			color := bc.barColor(i)
+			if v < 0 {
+				color = bc.opts.negColor
+			}
+			if err := bc.drawBar(cvs, r, color); err != nil {
+				return err
+			}
+			if bc.opts.partial && v > 0 {
+				if err := bc.drawPartial(cvs, i, v, r); err != nil {
+					return err
+				}
+			}
 		}
 
-		if bc.opts.showValues {
-			if err := bc.drawText(cvs, i, fmt.Sprint(bc.values[i]), bc.valColor(i), insideBar); err != nil {
+		if bc.opts.showValues && bc.groups == nil {
+			if err := bc.drawValue(cvs, i); err != nil {
 				return err
 			}
 		}
@@ -117,35 +154,212 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	return nil
 }
 
+// drawBar draws a bar or a segment of a stacked bar in the rectangle.
+func (bc *BarChart) drawBar(cvs *canvas.Canvas, r image.Rectangle, color cell.Color) error {
+	if r.Empty() { // Value might be so small so that the rectangle is zero.
+		return nil
+	}
+	return draw.Rectangle(cvs, r,
+		draw.RectCellOpts(cell.BgColor(color)),
+		draw.RectChar(bc.opts.barChar),
+	)
+}
+
+// Block elements that fill one to seven eighths of a cell. Used for the
+// partial cells at the top of the vertical bars and at the right end of the
+// horizontal bars.
+var (
+	lowerEighths = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇'}
+	leftEighths  = []rune{'▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+)
+
+// drawPartial draws the partial cell of the i-th bar that displays the value
+// right above the rectangle of its full cells, or right of it in the
+// horizontal mode.
+func (bc *BarChart) drawPartial(cvs *canvas.Canvas, i, value int, r image.Rectangle) error {
+	part := bc.barEighths(cvs, i, value) - 8*bc.barHeight(cvs, i, value)
+	if part <= 0 || part >= 8 {
+		return nil
+	}
+
+	pos, _ := bc.sideCells(cvs)
+	full := bc.rectOfHeight(cvs, i, pos)
+	var (
+		pr image.Rectangle
+		ch rune
+	)
+	if bc.opts.horizontal {
+		pr = image.Rect(r.Max.X, r.Min.Y, r.Max.X+1, r.Max.Y)
+		ch = leftEighths[part-1]
+	} else {
+		pr = image.Rect(r.Min.X, r.Min.Y-1, r.Max.X, r.Min.Y)
+		ch = lowerEighths[part-1]
+	}
+	if !pr.In(full) {
+		return nil
+	}
+	return draw.Rectangle(cvs, pr,
+		draw.RectCellOpts(cell.FgColor(bc.barColor(i))),
+		draw.RectChar(ch),
+	)
+}
+
+// drawSegments draws the segments of the i-th stacked bar. The segments are
+// stacked from the bottom up, or from the left to the right in the horizontal
+// mode.
+func (bc *BarChart) drawSegments(cvs *canvas.Canvas, i int) error {
+	var sum int
+	for j, v := range bc.segments[i] {
+		// Rectangles of the bar up to the start and up to the end of the
+		// segment. Calculating the segment from the cumulative sums prevents
+		// rounding errors from accumulating.
+		from, err := bc.barRect(cvs, i, sum)
+		if err != nil {
+			return err
+		}
+		sum += v
+		to, err := bc.barRect(cvs, i, sum)
+		if err != nil {
+			return err
+		}
+
+		var r image.Rectangle
+		if bc.opts.horizontal {
+			r = image.Rect(from.Max.X, to.Min.Y, to.Max.X, to.Max.Y)
+		} else {
+			r = image.Rect(to.Min.X, to.Min.Y, to.Max.X, from.Min.Y)
+		}
+		if err := bc.drawBar(cvs, r, bc.segColor(i, j)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawGroup draws the bars of the i-th group of grouped bars side by side
+// within the space of the group.
+func (bc *BarChart) drawGroup(cvs *canvas.Canvas, i int) error {
+	for j, v := range bc.groups[i] {
+		r, err := bc.barRect(cvs, i, v)
+		if err != nil {
+			return err
+		}
+		color := bc.seriesColor(i, j)
+		if v < 0 {
+			color = bc.opts.negColor
+		}
+		if err := bc.drawBar(cvs, bc.memberRect(cvs, r, j), color); err != nil {
+			return err
+		}
+
+		if bc.opts.showValues {
+			pos, neg := bc.sideCells(cvs)
+			full := bc.rectOfHeight(cvs, i, pos)
+			if v < 0 {
+				full = bc.rectOfHeight(cvs, i, -neg)
+			}
+			if err := bc.drawValueIn(cvs, bc.memberRect(cvs, full, j), v, bc.valColor(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// memberRect returns the part of the rectangle of a group of grouped bars
+// occupied by the j-th bar of the group. The space of the group is divided
+// evenly among the bars, the cells that remain after the division stay empty
+// at the end of the group.
+func (bc *BarChart) memberRect(cvs *canvas.Canvas, r image.Rectangle, j int) image.Rectangle {
+	n := bc.groupSize()
+	slot := bc.barWidth(cvs)
+	w := (slot - (n-1)*bc.opts.groupGap) / n
+	start := j * (w + bc.opts.groupGap)
+	if bc.opts.horizontal {
+		return image.Rect(r.Min.X, r.Min.Y+start, r.Max.X, r.Min.Y+start+w)
+	}
+	return image.Rect(r.Min.X+start, r.Min.Y, r.Min.X+start+w, r.Max.Y)
+}
+
+// groupSize returns the number of bars in the largest group of grouped bars.
+func (bc *BarChart) groupSize() int {
+	var n int
+	for _, g := range bc.groups {
+		if len(g) > n {
+			n = len(g)
+		}
+	}
+	return n
+}
+
+// drawValue draws the value of the i-th bar inside the bar, unless the
+// formatted value doesn't fit into the width of the bar.
+func (bc *BarChart) drawValue(cvs *canvas.Canvas, i int) error {
+	return bc.drawValueIn(cvs, bc.fullRect(cvs, i), bc.values[i], bc.valColor(i))
+}
+
+// drawValueIn draws the value inside the rectangle of a bar that takes all
+// the cells available on its side of the baseline, unless the formatted value
+// doesn't fit into the width of the bar.
+func (bc *BarChart) drawValueIn(cvs *canvas.Canvas, r image.Rectangle, value int, color cell.Color) error {
+	text := fmt.Sprint(value)
+	if bc.opts.valueFormat != nil {
+		text = bc.opts.valueFormat(value)
+	}
+
+	if text == "" || runewidth.StringWidth(text) > r.Dx() {
+		return nil
+	}
+	return bc.drawTextIn(cvs, r, value < 0, text, color, insideBar)
+}
+
 // textLoc represents the location of the drawn text.
 type textLoc int
 
 const (
 	insideBar textLoc = iota
+	// underBar is to the left of the bar in the horizontal mode.
 	underBar
 )
 
 // drawText draws the provided text inside or under the i-th bar.
 func (bc *BarChart) drawText(cvs *canvas.Canvas, i int, text string, color cell.Color, loc textLoc) error {
+	return bc.drawTextIn(cvs, bc.fullRect(cvs, i), bc.values[i] < 0, text, color, loc)
+}
+
+// drawTextIn draws the provided text inside or under the full rectangle of a
+// bar, see fullRect. The neg argument indicates if the bar displays a
+// negative value.
+func (bc *BarChart) drawTextIn(cvs *canvas.Canvas, r image.Rectangle, neg bool, text string, color cell.Color, loc textLoc) error {
 	// Rectangle representing area in which the text will be aligned.
 	var barCol image.Rectangle
 
-	r, err := bc.barRect(cvs, i, bc.max)
-	if err != nil {
-		return err
+	hAlign, vAlign := align.HorizontalCenter, align.VerticalBottom
+	if bc.opts.horizontal {
+		hAlign, vAlign = align.HorizontalLeft, align.VerticalMiddle
 	}
-
-	switch loc {
-	case insideBar:
-		// Align the text within the bar itself.
+	switch {
+	case loc == insideBar:
+		// Align the text within the bar itself, next to the baseline for
+		// bars that grow downward or to the left.
 		barCol = r
-	case underBar:
+		if neg {
+			hAlign, vAlign = align.HorizontalCenter, align.VerticalTop
+			if bc.opts.horizontal {
+				hAlign, vAlign = align.HorizontalRight, align.VerticalMiddle
+			}
+		}
+	case bc.opts.horizontal:
+		// Align the text within the label column left of the bar.
+		minX := cvs.Area().Min.X
+		barCol = image.Rect(minX, r.Min.Y, minX+bc.labelWidth(), r.Max.Y)
+	default:
 		// Align the text within the entire column where the bar is, this
 		// includes the space for any label under the bar.
 		barCol = image.Rect(r.Min.X, cvs.Area().Min.Y, r.Max.X, cvs.Area().Max.Y)
 	}
 
-	start, err := alignfor.Text(barCol, text, align.HorizontalCenter, align.VerticalBottom)
+	start, err := alignfor.Text(barCol, text, hAlign, vAlign)
 	if err != nil {
 		return err
 	}
@@ -157,53 +371,259 @@ func (bc *BarChart) drawText(cvs *canvas.Canvas, i int, text string, color cell.
 	)
 }
 
+// barAxis returns the size of the canvas along the axis where the bars are
+// laid out, i.e. its width or its height in the horizontal mode.
+func (bc *BarChart) barAxis(cvs *canvas.Canvas) int {
+	if bc.opts.horizontal {
+		return cvs.Area().Dy()
+	}
+	return cvs.Area().Dx()
+}
+
+// labelWidth returns the width of the column with labels in the horizontal
+// mode. This is the width of the longest label.
+func (bc *BarChart) labelWidth() int {
+	if !bc.opts.horizontal {
+		return 0
+	}
+	var w int
+	for _, l := range bc.opts.labels {
+		if lw := runewidth.StringWidth(l); lw > w {
+			w = lw
+		}
+	}
+	return w
+}
+
 // barWidth determines the width of a single bar based on options and the canvas.
+// In the horizontal mode, this is the height of the bar.
 func (bc *BarChart) barWidth(cvs *canvas.Canvas) int {
+	return bc.barWidthAlong(bc.barAxis(cvs))
+}
+
+// barWidthAlong determines the width of a single bar when the size of the
+// canvas along the axis where the bars are laid out is axis. This is the width
+// of the whole group for grouped bars.
+func (bc *BarChart) barWidthAlong(axis int) int {
 	if len(bc.values) == 0 {
 		return 0 // No width when we have no values.
 	}
 
 	if bc.opts.barWidth >= 1 {
 		// Prefer width set via the options.
-		return bc.opts.barWidth
+		return bc.minSlotWidth()
 	}
 
 	gaps := len(bc.values) - 1
 	gapW := gaps * bc.opts.barGap
-	rem := cvs.Area().Dx() - gapW
+	rem := axis - gapW
 	return rem / len(bc.values)
 }
 
+// available returns the height available to a bar that displays the maximum
+// value. In the horizontal mode, this is the width.
+func (bc *BarChart) available(cvs *canvas.Canvas) int {
+	switch {
+	case bc.opts.horizontal:
+		return cvs.Area().Dx() - bc.labelWidth()
+	case len(bc.opts.labels) > 0:
+		// One line for the bar labels.
+		return cvs.Area().Dy() - 1
+	default:
+		return cvs.Area().Dy()
+	}
+}
+
+// sideCells returns the number of cells available to bars that display
+// positive values and to bars that display negative values. With the Baseline
+// option, the cells remaining after the baseline are divided in proportion to
+// the maximum value and to the magnitude of the smallest value. Otherwise all
+// the available cells belong to the positive values.
+func (bc *BarChart) sideCells(cvs *canvas.Canvas) (int, int) {
+	avail := bc.available(cvs)
+	if !bc.opts.baseline {
+		return avail, 0
+	}
+
+	rest := avail - 1 // One cell for the baseline.
+	neg := -bc.clamp(bc.minValue())
+	if rest <= 0 || neg <= 0 {
+		return rest, 0
+	}
+	pos := int(math.Round(float64(rest) * float64(bc.max) / float64(bc.max+neg)))
+	return pos, rest - pos
+}
+
+// minValue returns the smallest of the values or zero if all of them are
+// positive.
+func (bc *BarChart) minValue() int {
+	var min int
+	for _, v := range bc.values {
+		if v < min {
+			min = v
+		}
+	}
+	for _, g := range bc.groups {
+		for _, v := range g {
+			if v < min {
+				min = v
+			}
+		}
+	}
+	return min
+}
+
+// baseOffset returns the number of cells between the bottom of the bars and
+// the bottom of the bars that display positive values. These are the cells of
+// the bars that display negative values and the baseline. In the horizontal
+// mode, the offset is from the left edge of the bars.
+func (bc *BarChart) baseOffset(cvs *canvas.Canvas) int {
+	if !bc.opts.baseline {
+		return 0
+	}
+	_, neg := bc.sideCells(cvs)
+	return neg + 1
+}
+
+// clamp clamps the value into the range -max <= value <= max. Only values
+// provided together with the Max option can be out of this range.
+// Stacked bars that are scaled to their own totals aren't clamped.
+func (bc *BarChart) clamp(value int) int {
+	switch {
+	case bc.max == 0:
+		return value
+	case value > bc.max:
+		return bc.max
+	case value < -bc.max:
+		return -bc.max
+	}
+	return value
+}
+
+// barMax returns the value at which the i-th bar takes all the available
+// space. This is the total of the bar for stacked bars that are scaled to
+// their own totals.
+func (bc *BarChart) barMax(i int) int {
+	if bc.max == 0 {
+		return bc.values[i]
+	}
+	return bc.max
+}
+
 // barHeight determines the height of the i-th bar based on the value it is displaying.
+// In the horizontal mode, this is the width of the bar.
+// Bars that display negative values have a negative height.
 func (bc *BarChart) barHeight(cvs *canvas.Canvas, i, value int) int {
-	available := cvs.Area().Dy()
-	if len(bc.opts.labels) > 0 {
-		// One line for the bar labels.
-		available--
+	pos, neg := bc.sideCells(cvs)
+	value = bc.clamp(value)
+	if value < 0 {
+		return -bc.scaled(neg, -value, -bc.clamp(bc.minValue()))
 	}
 
-	ratio := float32(value) / float32(bc.max)
-	return int(float32(available) * ratio)
+	max := bc.barMax(i)
+	if max == 0 {
+		return 0
+	}
+	return bc.scaled(pos, value, max)
+}
+
+// scaled returns the number of cells out of the available cells that
+// represent the value when the max value takes all of them.
+func (bc *BarChart) scaled(cells, value, max int) int {
+	ratio := float32(value) / float32(max)
+	if bc.opts.scale == ScaleLog {
+		ratio = float32(math.Log10(float64(value)+1) / math.Log10(float64(max)+1))
+	}
+	return int(float32(cells) * ratio)
+}
+
+// barEighths determines the height of the i-th bar in eighths of a cell.
+// In the horizontal mode, this is the width of the bar.
+func (bc *BarChart) barEighths(cvs *canvas.Canvas, i, value int) int {
+	max := bc.barMax(i)
+	if max == 0 {
+		return 0
+	}
+	pos, _ := bc.sideCells(cvs)
+	eighths := pos * 8
+	value = bc.clamp(value)
+	if bc.opts.scale == ScaleLog {
+		return int(float64(eighths) * math.Log10(float64(value)+1) / math.Log10(float64(max)+1))
+	}
+	return eighths * value / max
 }
 
 // barRect returns a rectangle that represents the i-th bar on the canvas that
 // displays the specified value.
 func (bc *BarChart) barRect(cvs *canvas.Canvas, i, value int) (image.Rectangle, error) {
+	return bc.rectOfHeight(cvs, i, bc.barHeight(cvs, i, value)), nil
+}
+
+// rectOfHeight returns a rectangle that represents the i-th bar on the canvas
+// that has the specified height, i.e. width in the horizontal mode.
+// Negative heights are bars that grow downward from the baseline, or to the
+// left in the horizontal mode.
+func (bc *BarChart) rectOfHeight(cvs *canvas.Canvas, i, bh int) image.Rectangle {
 	bw := bc.barWidth(cvs)
-	minX := bw * i
+	// The start of the bar on the axis where the bars are laid out.
+	start := bw * i
 	if i > 0 {
-		minX += bc.opts.barGap * i
+		start += bc.opts.barGap * i
+	}
+
+	if bc.opts.horizontal {
+		minX := bc.labelWidth() + bc.baseOffset(cvs)
+		if bh < 0 {
+			// Left of the baseline.
+			return image.Rect(minX-1+bh, start, minX-1, start+bw)
+		}
+		return image.Rect(minX, start, minX+bh, start+bw)
 	}
-	maxX := minX + bw
 
-	bh := bc.barHeight(cvs, i, value)
 	maxY := cvs.Area().Max.Y
 	if len(bc.opts.labels) > 0 {
 		// One line for the bar labels.
 		maxY--
 	}
+	maxY -= bc.baseOffset(cvs)
+	if bh < 0 {
+		// Under the baseline.
+		return image.Rect(start, maxY+1, start+bw, maxY+1-bh)
+	}
 	minY := maxY - bh
-	return image.Rect(minX, minY, maxX, maxY), nil
+	return image.Rect(start, minY, start+bw, maxY)
+}
+
+// fullRect returns a rectangle that represents the i-th bar if it took all
+// the cells available on its side of the baseline.
+func (bc *BarChart) fullRect(cvs *canvas.Canvas, i int) image.Rectangle {
+	pos, neg := bc.sideCells(cvs)
+	if bc.values[i] < 0 {
+		return bc.rectOfHeight(cvs, i, -neg)
+	}
+	return bc.rectOfHeight(cvs, i, pos)
+}
+
+// drawBaseline draws the line that represents zero, see the Baseline option.
+func (bc *BarChart) drawBaseline(cvs *canvas.Canvas) error {
+	ar := cvs.Area()
+	off := bc.baseOffset(cvs)
+	if bc.opts.horizontal {
+		x := ar.Min.X + bc.labelWidth() + off - 1
+		return draw.Rectangle(cvs, image.Rect(x, ar.Min.Y, x+1, ar.Max.Y),
+			draw.RectChar('│'),
+		)
+	}
+
+	maxY := ar.Max.Y
+	if len(bc.opts.labels) > 0 {
+		// One line for the bar labels.
+		maxY--
+	}
+	y := maxY - off
+	return draw.Rectangle(cvs, image.Rect(ar.Min.X, y, ar.Max.X, y+1),
+		draw.RectChar('─'),
+	)
 }
 
 // barColor safely determines the color for the i-th bar.
@@ -215,6 +635,26 @@ func (bc *BarChart) barColor(i int) cell.Color {
 	return DefaultBarColor
 }
 
+// seriesColor safely determines the color for the j-th bar of the i-th group
+// of grouped bars. Bars that don't have a color specified use the color of
+// the group.
+func (bc *BarChart) seriesColor(i, j int) cell.Color {
+	if len(bc.opts.seriesColors) > j {
+		return bc.opts.seriesColors[j]
+	}
+	return bc.barColor(i)
+}
+
+// segColor safely determines the color for the j-th segment of the i-th
+// stacked bar. Segments that don't have a color specified use the color of the
+// bar.
+func (bc *BarChart) segColor(i, j int) cell.Color {
+	if len(bc.opts.segColors) > j {
+		return bc.opts.segColors[j]
+	}
+	return bc.barColor(i)
+}
+
 // valColor safely determines the color for the i-th value.
 // Colors are optional and don't have to be specified for all the values.
 func (bc *BarChart) valColor(i int) cell.Color {
@@ -241,6 +681,7 @@ func (bc *BarChart) label(i int) (string, cell.Color) {
 // ValueCapacity returns the number of values that can fit into the canvas.
 // This is essentially the number of available cells on the canvas as observed
 // on the last call to draw. Returns zero if draw wasn't called.
+// In the horizontal mode, this is based on the height of the canvas.
 //
 // Note that this capacity changes each time the terminal resizes, so there is
 // no guarantee this remains the same next time Draw is called.
@@ -249,16 +690,17 @@ func (bc *BarChart) ValueCapacity() int {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
-	barWidth := float64(bc.minBarWidth())
+	barWidth := float64(bc.minSlotWidth())
 	gapWidth := float64(bc.opts.barGap)
 	lastWidth := float64(bc.lastWidth)
 	return valueCapacity(barWidth, gapWidth, lastWidth)
 }
 
 // Values sets the values to be displayed by the BarChart.
-// Each value ends up in its own bar. The values must not be negative and must
-// be less or equal the maximum value. A bar displaying the maximum value is a
-// full bar, taking all available vertical space.
+// Each value ends up in its own bar. The values must not be negative, unless
+// the Baseline option is set, and must be less or equal the maximum value. A
+// bar displaying the maximum value is a full bar, taking all available
+// vertical space.
 // Provided options override values set when New() was called.
 func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
 	bc.mu.Lock()
@@ -267,15 +709,124 @@ func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
 	// Copy to avoid external modifications. See #174.
 	v := make([]int, len(values))
 	copy(v, values)
-	if err := validateValues(v, max); err != nil {
+
+	// The provided options decide which values are valid, but must not take
+	// effect when the values are rejected.
+	newOpts := *bc.opts
+	for _, opt := range opts {
+		opt.set(&newOpts)
+	}
+	if newOpts.max > 0 {
+		max = newOpts.max
+	}
+	if err := validateValues(clampedTo(v, newOpts.max), max, newOpts.baseline); err != nil {
 		return err
 	}
+	bc.opts = &newOpts
+	bc.values = v
+	bc.max = max
+	bc.segments = nil
+	bc.groups = nil
+	return nil
+}
+
+// StackedValues sets the values to be displayed by the BarChart as stacked
+// bars. Each of the values is a slice of the segments of one bar, the segments
+// are stacked from the bottom up (or from the left to the right when the
+// Horizontal option is set) and colored according to the SegmentColors option.
+// The segments must not be negative.
+//
+// If max is positive, it is the maximum value of a bar and the sum of the
+// segments of each bar must be less or equal to it. If max is zero, each bar is
+// scaled to its own total, i.e. each non-empty bar takes all the available
+// space and the segments display the ratios within the bar.
+//
+// The ShowValues option displays the total of each bar.
+// Provided options override values set when New() was called.
+func (bc *BarChart) StackedValues(values [][]int, max int, opts ...Option) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
 
+	// Copy to avoid external modifications. See #174.
+	segs := make([][]int, len(values))
+	totals := make([]int, len(values))
+	for i, bar := range values {
+		segs[i] = make([]int, len(bar))
+		copy(segs[i], bar)
+		for _, v := range bar {
+			totals[i] += v
+		}
+	}
+	// The provided options decide which values are valid, but must not take
+	// effect when the values are rejected.
+	newOpts := *bc.opts
 	for _, opt := range opts {
-		opt.set(bc.opts)
+		opt.set(&newOpts)
 	}
-	bc.values = v
+	if newOpts.max > 0 {
+		max = newOpts.max
+	}
+	if err := validateStackedValues(segs, clampedTo(totals, newOpts.max), max); err != nil {
+		return err
+	}
+	bc.opts = &newOpts
+	bc.values = totals
+	bc.max = max
+	bc.segments = segs
+	bc.groups = nil
+	return nil
+}
+
+// GroupedValues sets the values to be displayed by the BarChart as groups of
+// bars. Each of the values is a slice of the values of the bars in one group,
+// the bars of a group are drawn side by side and colored according to the
+// SeriesColors option. The space of each group is divided evenly among the
+// bars of the largest group, the GroupGap option sets the space between the
+// bars of a group and the BarGap option the space between the groups. When
+// the BarWidth option is set, it is the width of each bar of a group. The
+// labels are displayed under each group.
+//
+// The values must not be negative, unless the Baseline option is set, and
+// must be less or equal the maximum value.
+// Provided options override values set when New() was called.
+func (bc *BarChart) GroupedValues(values [][]int, max int, opts ...Option) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	// Copy to avoid external modifications. See #174.
+	groups := make([][]int, len(values))
+	largest := make([]int, len(values))
+	for i, g := range values {
+		groups[i] = make([]int, len(g))
+		copy(groups[i], g)
+		for j, v := range g {
+			if j == 0 || v > largest[i] {
+				largest[i] = v
+			}
+		}
+	}
+
+	// The provided options decide which values are valid, but must not take
+	// effect when the values are rejected.
+	newOpts := *bc.opts
+	for _, opt := range opts {
+		opt.set(&newOpts)
+	}
+	if newOpts.max > 0 {
+		max = newOpts.max
+	}
+	clamped := make([][]int, len(groups))
+	for i, g := range groups {
+		clamped[i] = clampedTo(g, newOpts.max)
+	}
+	if err := validateGroupedValues(clamped, max, newOpts.baseline); err != nil {
+		return err
+	}
+	bc.opts = &newOpts
+	bc.values = largest
 	bc.max = max
+	bc.segments = nil
+	bc.groups = groups
 	return nil
 }
 
@@ -284,9 +835,51 @@ func (*BarChart) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) er
 	return errors.New("the BarChart widget doesn't support keyboard events")
 }
 
-// Mouse input isn't supported on the BarChart widget.
-func (*BarChart) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
-	return errors.New("the BarChart widget doesn't support mouse events")
+// Mouse calls the function provided via the OnClick option when the user
+// clicks on a bar. Mouse input isn't supported without the OnClick option.
+// Implements widgetapi.Widget.Mouse.
+func (bc *BarChart) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	bc.mu.Lock()
+	onClick := bc.opts.onClick
+	if onClick == nil {
+		bc.mu.Unlock()
+		return errors.New("the BarChart widget doesn't support mouse events without the OnClick option")
+	}
+	if m.Button != mouse.ButtonLeft {
+		bc.mu.Unlock()
+		return nil
+	}
+	i, ok := bc.barAt(m.Position)
+	// Mutex must be released when calling the callback.
+	// Users might call container methods from the callback like the
+	// Container.Update, see #205.
+	bc.mu.Unlock()
+
+	if ok {
+		onClick(i)
+	}
+	return nil
+}
+
+// barAt returns the index of the bar laid out at the point on the canvas as of
+// the last call to Draw. The bool return value is false if the point falls
+// into a gap between the bars or beyond the last bar.
+func (bc *BarChart) barAt(p image.Point) (int, bool) {
+	pos := p.X
+	if bc.opts.horizontal {
+		pos = p.Y
+	}
+
+	bw := bc.barWidthAlong(bc.lastWidth)
+	if bw <= 0 || pos < 0 {
+		return 0, false
+	}
+	step := bw + bc.opts.barGap
+	i := pos / step
+	if i >= len(bc.values) || pos%step >= bw {
+		return 0, false
+	}
+	return i, true
 }
 
 // Options implements widgetapi.Widget.Options.
@@ -300,12 +893,20 @@ func (bc *BarChart) Options() widgetapi.Options {
 	// never update bc.lastWidth and the result of ValueCapacity().
 	// Draw will stil refuse to draw if the canvas is too small, but the user
 	// will have an option to send less values.
-	min.X = bc.minBarWidth()
+	if bc.opts.horizontal {
+		min.Y = bc.minSlotWidth()
+	} else {
+		min.X = bc.minSlotWidth()
+	}
 
+	wantMouse := widgetapi.MouseScopeNone
+	if bc.opts.onClick != nil {
+		wantMouse = widgetapi.MouseScopeWidget
+	}
 	return widgetapi.Options{
 		MinimumSize:  min,
 		WantKeyboard: widgetapi.KeyScopeNone,
-		WantMouse:    widgetapi.MouseScopeNone,
+		WantMouse:    wantMouse,
 	}
 }
 
@@ -321,6 +922,16 @@ func (bc *BarChart) minBarWidth() int {
 	return minBarWidth
 }
 
+// minSlotWidth determines the minimum possible width of the space of one bar,
+// or of one group of bars when the bars are grouped.
+func (bc *BarChart) minSlotWidth() int {
+	n := bc.groupSize()
+	if n == 0 {
+		return bc.minBarWidth()
+	}
+	return n*bc.minBarWidth() + (n-1)*bc.opts.groupGap
+}
+
 // minSize determines the minimum required size of the canvas.
 func (bc *BarChart) minSize() image.Point {
 	bars := len(bc.values)
@@ -328,24 +939,97 @@ func (bc *BarChart) minSize() image.Point {
 		return image.Point{1, 1}
 	}
 
-	minHeight := 1 // At least one character vertically to display the bar.
+	minLayout := bars*bc.minSlotWidth() + (bars-1)*bc.opts.barGap
+	minBar := 1 // At least one character to display the bar.
+	if bc.opts.baseline {
+		minBar++ // One more for the baseline.
+	}
+	if bc.opts.horizontal {
+		return image.Point{bc.labelWidth() + minBar, minLayout}
+	}
+
+	minHeight := minBar
 	if len(bc.opts.labels) > 0 {
 		minHeight++ // One line for the labels.
 	}
+	return image.Point{minLayout, minHeight}
+}
 
-	minWidth := bars*bc.minBarWidth() + (bars-1)*bc.opts.barGap
-	return image.Point{minWidth, minHeight}
+// clampedTo returns a copy of the values clamped into the range
+// -max <= value <= max. Returns the values unchanged if max is zero, i.e.
+// when the Max option isn't set.
+func clampedTo(values []int, max int) []int {
+	if max == 0 {
+		return values
+	}
+	res := make([]int, len(values))
+	for i, v := range values {
+		switch {
+		case v > max:
+			v = max
+		case v < -max:
+			v = -max
+		}
+		res[i] = v
+	}
+	return res
 }
 
 // validateValues validates the provided values and maximum.
-func validateValues(values []int, max int) error {
+// Negative values are only valid with a baseline.
+func validateValues(values []int, max int, baseline bool) error {
 	if max < 1 {
 		return fmt.Errorf("invalid maximum value %d, must be at least 1", max)
 	}
 
+	min, minStr := 0, "0"
+	if baseline {
+		min, minStr = -max, "-max"
+	}
 	for i, v := range values {
-		if v < 0 || v > max {
-			return fmt.Errorf("invalid values[%d]: %d, each value must be 0 <= value <= max", i, v)
+		if v < min || v > max {
+			return fmt.Errorf("invalid values[%d]: %d, each value must be %s <= value <= max", i, v, minStr)
+		}
+	}
+	return nil
+}
+
+// validateGroupedValues validates the provided values of grouped bars and the
+// maximum. Negative values are only valid with a baseline.
+func validateGroupedValues(groups [][]int, max int, baseline bool) error {
+	if max < 1 {
+		return fmt.Errorf("invalid maximum value %d, must be at least 1", max)
+	}
+
+	min, minStr := 0, "0"
+	if baseline {
+		min, minStr = -max, "-max"
+	}
+	for i, g := range groups {
+		for j, v := range g {
+			if v < min || v > max {
+				return fmt.Errorf("invalid values[%d][%d]: %d, each value must be %s <= value <= max", i, j, v, minStr)
+			}
+		}
+	}
+	return nil
+}
+
+// validateStackedValues validates the provided segments of stacked bars, their
+// totals and the maximum.
+func validateStackedValues(segments [][]int, totals []int, max int) error {
+	if max < 0 {
+		return fmt.Errorf("invalid maximum value %d, must be zero or a positive integer", max)
+	}
+
+	for i, bar := range segments {
+		for j, v := range bar {
+			if v < 0 {
+				return fmt.Errorf("invalid values[%d][%d]: %d, each segment must be 0 <= value", i, j, v)
+			}
+		}
+		if max > 0 && totals[i] > max {
+			return fmt.Errorf("invalid values[%d], the sum of its segments %d must be <= max %d", i, totals[i], max)
 		}
 	}
 	return nil
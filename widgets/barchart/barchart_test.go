@@ -15,16 +15,19 @@
 package barchart
 
 import (
+	"fmt"
 	"image"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/testcanvas"
 	"github.com/mum4k/termdash/private/draw"
 	"github.com/mum4k/termdash/private/draw/testdraw"
 	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -189,27 +192,33 @@ func TestBarChart(t *testing.T) {
 			wantCapacity: 4,
 		},
 		{
-			desc: "displays bars with labels",
+			desc: "fails on negative Max",
+			opts: []Option{
+				Max(-1),
+			},
+			update: func(bc *BarChart) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "displays bars relative to the Max instead of the max argument",
 			opts: []Option{
 				Char('o'),
-				Labels([]string{
-					"1",
-					"2",
-					"3",
-				}),
+				Max(20),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2, 5, 10}, 10)
+				return bc.Values([]int{0, 4, 10, 20}, 1)
 			},
-			canvas: image.Rect(0, 0, 7, 11),
+			canvas: image.Rect(0, 0, 7, 10),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
-				)
 				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
@@ -222,45 +231,122 @@ func TestBarChart(t *testing.T) {
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 4,
+		},
+		{
+			desc: "Max clamps values above it to full bars",
+			opts: []Option{
+				Char('o'),
+				Max(10),
+				ShowValues(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 15}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-				// Labels.
-				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
-				))
-				testdraw.MustText(c, "2", image.Point{2, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
+				testdraw.MustRectangle(c, image.Rect(0, 5, 2, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 0, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				// The value of the clamped bar is displayed as is.
+				testdraw.MustText(c, "5", image.Point{0, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
 				))
-				testdraw.MustText(c, "3", image.Point{4, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
+				testdraw.MustText(c, "15", image.Point{3, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
 				))
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
-			wantCapacity: 4,
+			wantCapacity: 3,
 		},
 		{
-			desc: "trims too long labels",
+			desc: "Max clamps stacked bars",
 			opts: []Option{
 				Char('o'),
-				Labels([]string{
-					"1",
-					"22",
-					"3",
+				Max(10),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
 				}),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2, 5, 10}, 10)
+				return bc.StackedValues([][]int{{6, 8}}, 0)
 			},
-			canvas: image.Rect(0, 0, 7, 11),
+			canvas: image.Rect(0, 0, 3, 10),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 4, 3, 10),
 					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
 				)
-				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "Max still rejects negative values without a baseline",
+			opts: []Option{
+				Max(10),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, -1}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails on an unsupported value scale",
+			opts: []Option{
+				ValueScale(Scale(-1)),
+			},
+			update: func(bc *BarChart) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "displays bars on the logarithmic scale",
+			opts: []Option{
+				Char('o'),
+				ValueScale(ScaleLog),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{0, 1, 10, 100}, 100)
+			},
+			canvas: image.Rect(0, 0, 7, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(2, 9, 3, 10),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
@@ -272,46 +358,32 @@ func TestBarChart(t *testing.T) {
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
-
-				// Labels.
-				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
-				))
-				testdraw.MustText(c, "…", image.Point{2, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
-				))
-				testdraw.MustText(c, "3", image.Point{4, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
-				))
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 			wantCapacity: 4,
 		},
 		{
-			desc: "displays bars with labels and values",
+			desc: "draws partial blocks at the top of the bars",
 			opts: []Option{
 				Char('o'),
-				Labels([]string{
-					"1",
-					"2",
-					"3",
-				}),
-				ShowValues(),
+				PartialBlocks(),
+				BarColors([]cell.Color{cell.ColorBlue}),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2, 5, 10}, 10)
+				return bc.Values([]int{45, 100, 50}, 100)
 			},
-			canvas: image.Rect(0, 0, 7, 11),
+			canvas: image.Rect(0, 0, 5, 10),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 6, 1, 10),
 					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
 				)
-				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+				testcanvas.MustSetCell(c, image.Point{0, 5}, '▄', cell.FgColor(cell.ColorBlue))
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 10),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
@@ -319,266 +391,1304 @@ func TestBarChart(t *testing.T) {
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
-				testdraw.MustRectangle(c, image.Rect(6, 0, 7, 10),
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "draws partial blocks at the right end of horizontal bars",
+			opts: []Option{
+				Char('o'),
+				PartialBlocks(),
+				Horizontal(),
+				BarGap(0),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{15, 99}, 100)
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 1),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
-				// Labels.
-				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
-				))
-				testdraw.MustText(c, "2", image.Point{2, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
-				))
-				testdraw.MustText(c, "3", image.Point{4, 10}, draw.TextCellOpts(
-					cell.FgColor(DefaultLabelColor),
-				))
-				// Values.
-				testdraw.MustText(c, "1", image.Point{0, 9}, draw.TextCellOpts(
-					cell.FgColor(DefaultValueColor),
-					cell.BgColor(DefaultBarColor),
-				))
-				testdraw.MustText(c, "2", image.Point{2, 9}, draw.TextCellOpts(
-					cell.FgColor(DefaultValueColor),
-					cell.BgColor(DefaultBarColor),
-				))
-				testdraw.MustText(c, "5", image.Point{4, 9}, draw.TextCellOpts(
-					cell.FgColor(DefaultValueColor),
-					cell.BgColor(DefaultBarColor),
-				))
-				testdraw.MustText(c, "…", image.Point{6, 9}, draw.TextCellOpts(
-					cell.FgColor(DefaultValueColor),
-					cell.BgColor(DefaultBarColor),
-				))
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '▌', cell.FgColor(DefaultBarColor))
+				testdraw.MustRectangle(c, image.Rect(0, 1, 9, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustSetCell(c, image.Point{9, 1}, '▉', cell.FgColor(DefaultBarColor))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "fails on negative values without the baseline",
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{-1}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails on values below minus max with the baseline",
+			opts: []Option{
+				Baseline(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{-11}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "baseline option provided with the values allows negative values",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{-2}, 2, Baseline())
+			},
+			canvas: image.Rect(0, 0, 1, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// The maximum keeps the space for positive values.
+				testdraw.MustRectangle(c, image.Rect(0, 1, 1, 2),
+					draw.RectChar('─'),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 2, 1, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultNegativeColor)),
+				)
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
-			wantCapacity: 4,
+			wantCapacity: 1,
+		},
+		{
+			desc: "draws mixed positive and negative values around the baseline",
+			opts: []Option{
+				Char('o'),
+				Baseline(),
+				Labels([]string{"a", "b", "c"}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{10, -5, 5}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Eight rows remain after the baseline and the labels, five
+				// of them for the positive values.
+				testdraw.MustRectangle(c, image.Rect(0, 5, 5, 6),
+					draw.RectChar('─'),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 6, 3, 9),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultNegativeColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 3, 5, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				for i, l := range []string{"a", "b", "c"} {
+					testdraw.MustText(c, l, image.Point{i * 2, 9}, draw.TextCellOpts(
+						cell.FgColor(DefaultLabelColor),
+					))
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "baseline is at the bottom when all values are positive",
+			opts: []Option{
+				Char('o'),
+				Baseline(),
+				NegativeColor(cell.ColorMagenta),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{4, 2}, 4)
+			},
+			canvas: image.Rect(0, 0, 3, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 4, 3, 5),
+					draw.RectChar('─'),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 2, 3, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "draws values of negative bars next to the baseline",
+			opts: []Option{
+				Char('o'),
+				Baseline(),
+				NegativeColor(cell.ColorMagenta),
+				ShowValues(),
+				BarWidth(2),
+				BarGap(0),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{4, -4}, 4)
+			},
+			canvas: image.Rect(0, 0, 4, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 2, 4, 3),
+					draw.RectChar('─'),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "4", image.Point{0, 1},
+					draw.TextCellOpts(
+						cell.FgColor(DefaultValueColor),
+						cell.BgColor(DefaultBarColor),
+					),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 3, 4, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustText(c, "-4", image.Point{2, 3},
+					draw.TextCellOpts(
+						cell.FgColor(DefaultValueColor),
+						cell.BgColor(cell.ColorMagenta),
+					),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "draws negative values left of the baseline in the horizontal mode",
+			opts: []Option{
+				Char('o'),
+				Baseline(),
+				Horizontal(),
+				BarGap(0),
+				Labels([]string{"a", "b"}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{-4, 8}, 8)
+			},
+			canvas: image.Rect(0, 0, 11, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Nine columns remain after the labels and the baseline, six
+				// of them for the positive values.
+				testdraw.MustRectangle(c, image.Rect(4, 0, 5, 2),
+					draw.RectChar('│'),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 0, 4, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultNegativeColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(5, 1, 11, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "b", image.Point{0, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "displays real values on the logarithmic scale",
+			opts: []Option{
+				Char('o'),
+				ValueScale(ScaleLog),
+				ShowValues(),
+				BarWidth(3),
+				BarGap(0),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{10, 100}, 100)
+			},
+			canvas: image.Rect(0, 0, 6, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 5, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "10", image.Point{0, 9},
+					draw.TextCellOpts(
+						cell.FgColor(DefaultValueColor),
+						cell.BgColor(DefaultBarColor),
+					),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 0, 6, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "100", image.Point{3, 9},
+					draw.TextCellOpts(
+						cell.FgColor(DefaultValueColor),
+						cell.BgColor(DefaultBarColor),
+					),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "displays bars with labels",
+			opts: []Option{
+				Char('o'),
+				Labels([]string{
+					"1",
+					"2",
+					"3",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2, 5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 11),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 5, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(6, 0, 7, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+
+				// Labels.
+				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "2", image.Point{2, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "3", image.Point{4, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 4,
+		},
+		{
+			desc: "trims too long labels",
+			opts: []Option{
+				Char('o'),
+				Labels([]string{
+					"1",
+					"22",
+					"3",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2, 5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 11),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 5, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(6, 0, 7, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+
+				// Labels.
+				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "…", image.Point{2, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "3", image.Point{4, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 4,
+		},
+		{
+			desc: "displays bars with labels and values",
+			opts: []Option{
+				Char('o'),
+				Labels([]string{
+					"1",
+					"2",
+					"3",
+				}),
+				ShowValues(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2, 5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 11),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 5, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(6, 0, 7, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				// Labels.
+				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "2", image.Point{2, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "3", image.Point{4, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				// Values.
+				testdraw.MustText(c, "1", image.Point{0, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testdraw.MustText(c, "2", image.Point{2, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testdraw.MustText(c, "5", image.Point{4, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				// The value "10" doesn't fit into the last bar.
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 4,
+		},
+		{
+			desc: "displays formatted values in wide bars",
+			opts: []Option{
+				Char('o'),
+				BarWidth(4),
+				ShowValues(),
+				ValueFormatter(func(v int) string {
+					return fmt.Sprintf("%d%%", v*10)
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 9, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 2, 4, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(5, 0, 9, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "50%", image.Point{0, 3}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testdraw.MustText(c, "100%", image.Point{5, 3}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "skips formatted values that don't fit narrow bars",
+			opts: []Option{
+				Char('o'),
+				BarWidth(2),
+				ShowValues(),
+				ValueFormatter(func(v int) string {
+					return fmt.Sprintf("%dms", v)
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 2, 2, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 0, 5, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "bars take as much width as available",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 2, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 8, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "respects set bar width",
+			opts: []Option{
+				Char('o'),
+				BarWidth(1),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "options can be set on a call to Values",
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2}, 10, Char('o'), BarWidth(1))
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "respects set bar gap",
+			opts: []Option{
+				Char('o'),
+				BarGap(2),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 8, 4, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "respects both width and gap",
+			opts: []Option{
+				Char('o'),
+				BarGap(2),
+				BarWidth(2),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 3}, 10)
+			},
+			canvas: image.Rect(0, 0, 6, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 5, 2, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 7, 6, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "respects bar and label colors",
+			opts: []Option{
+				Char('o'),
+				BarColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorYellow,
+				}),
+				LabelColors([]cell.Color{
+					cell.ColorCyan,
+					cell.ColorMagenta,
+				}),
+				Labels([]string{
+					"1",
+					"2",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 2, 3}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 11),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorCyan),
+				))
+
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testdraw.MustText(c, "2", image.Point{2, 10}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorMagenta),
+				))
+
+				testdraw.MustRectangle(c, image.Rect(4, 7, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "respects value colors",
+			opts: []Option{
+				Char('o'),
+				ValueColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorBlack,
+				}),
+				ShowValues(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{0, 2, 3}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "0", image.Point{0, 9}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "2", image.Point{2, 9}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlack),
+					cell.BgColor(DefaultBarColor),
+				))
+
+				testdraw.MustRectangle(c, image.Rect(4, 7, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "3", image.Point{4, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "fails for negative max of stacked values",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 2}}, -1)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails for negative segment of stacked values",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, -2}}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails when the sum of segments exceeds the max",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 2}, {5, 6}}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "displays stacked bars with three segments and labels",
+			opts: []Option{
+				Char('o'),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+					cell.ColorYellow,
+				}),
+				Labels([]string{
+					"a",
+					"b",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{2, 3, 5}, {1, 1, 2}}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 11),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 8, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 5, 1, 8),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 9, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 9),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 6, 3, 8),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+
+				// Labels.
+				testdraw.MustText(c, "a", image.Point{0, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "b", image.Point{2, 10}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "stacked bars scaled to their own totals",
+			opts: []Option{
+				Char('o'),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+					cell.ColorYellow,
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 1, 2}, {3, 1}, {}}, 0)
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 3, 1, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 2, 1, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 1, 3, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "segments without color use the bar color",
+			opts: []Option{
+				Char('o'),
+				BarColors([]cell.Color{cell.ColorMagenta}),
+				SegmentColors([]cell.Color{cell.ColorBlue}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 3}}, 4)
+			},
+			canvas: image.Rect(0, 0, 1, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 3, 1, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
+		{
+			desc: "displays horizontal stacked bars",
+			opts: []Option{
+				Char('o'),
+				Horizontal(),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+					cell.ColorYellow,
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.StackedValues([][]int{{1, 3}}, 4)
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 0, 4, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
+		{
+			desc: "Values replaces stacked bars",
+			opts: []Option{
+				Char('o'),
+				SegmentColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+					cell.ColorYellow,
+				}),
+			},
+			update: func(bc *BarChart) error {
+				if err := bc.StackedValues([][]int{{1, 3}}, 4); err != nil {
+					return err
+				}
+				return bc.Values([]int{2}, 4)
+			},
+			canvas: image.Rect(0, 0, 1, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 2, 1, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
+		{
+			desc: "fails on negative group gap",
+			opts: []Option{
+				GroupGap(-1),
+			},
+			update: func(bc *BarChart) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails for negative grouped values without a baseline",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.GroupedValues([][]int{{1, -2}}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails for grouped values above the max",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.GroupedValues([][]int{{1, 2}, {3, 11}}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
 		},
 		{
-			desc: "bars take as much width as available",
+			desc: "displays two series across three categories with labels",
 			opts: []Option{
 				Char('o'),
+				SeriesColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+				}),
+				Labels([]string{
+					"a",
+					"b",
+					"c",
+				}),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2}, 10)
+				return bc.GroupedValues([][]int{{1, 2}, {3, 4}, {5, 0}}, 5)
 			},
-			canvas: image.Rect(0, 0, 5, 10),
+			canvas: image.Rect(0, 0, 8, 6),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 2, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
-				)
-				testdraw.MustRectangle(c, image.Rect(3, 8, 5, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
-				)
+				for _, b := range []struct {
+					x, value int
+					color    cell.Color
+				}{
+					{0, 1, cell.ColorBlue},
+					{1, 2, cell.ColorGreen},
+					{3, 3, cell.ColorBlue},
+					{4, 4, cell.ColorGreen},
+					{6, 5, cell.ColorBlue},
+				} {
+					testdraw.MustRectangle(c, image.Rect(b.x, 5-b.value, b.x+1, 5),
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(b.color)),
+					)
+				}
+
+				// Labels under the groups.
+				for i, l := range []string{"a", "b", "c"} {
+					testdraw.MustText(c, l, image.Point{i * 3, 5}, draw.TextCellOpts(
+						cell.FgColor(DefaultLabelColor),
+					))
+				}
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 			wantCapacity: 3,
 		},
 		{
-			desc: "respects set bar width",
+			desc: "displays grouped bars with a gap within the groups",
 			opts: []Option{
 				Char('o'),
 				BarWidth(1),
+				GroupGap(1),
+				SeriesColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+				}),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2}, 10)
+				return bc.GroupedValues([][]int{{1, 2}, {3, 4}, {2, 1}}, 4)
 			},
-			canvas: image.Rect(0, 0, 5, 10),
+			canvas: image.Rect(0, 0, 12, 4),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
-				)
-				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
-				)
+				for _, b := range []struct {
+					x, value int
+					color    cell.Color
+				}{
+					{0, 1, cell.ColorBlue},
+					{2, 2, cell.ColorGreen},
+					{4, 3, cell.ColorBlue},
+					{6, 4, cell.ColorGreen},
+					{8, 2, cell.ColorBlue},
+					{10, 1, cell.ColorGreen},
+				} {
+					testdraw.MustRectangle(c, image.Rect(b.x, 4-b.value, b.x+1, 4),
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(b.color)),
+					)
+				}
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 			wantCapacity: 3,
 		},
 		{
-			desc: "options can be set on a call to Values",
+			desc: "displays horizontal grouped bars with values",
+			opts: []Option{
+				Char('o'),
+				Horizontal(),
+				ShowValues(),
+				BarGap(0),
+				SeriesColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorGreen,
+				}),
+			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2}, 10, Char('o'), BarWidth(1))
+				return bc.GroupedValues([][]int{{2, 4}, {3}}, 4)
 			},
-			canvas: image.Rect(0, 0, 5, 10),
+			canvas: image.Rect(0, 0, 4, 4),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 1),
 					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
 				)
-				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 1, 4, 2),
 					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 2, 3, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
 				)
+
+				// Values.
+				for y, v := range []string{"2", "4", "3"} {
+					testdraw.MustText(c, v, image.Point{0, y}, draw.TextCellOpts(
+						cell.FgColor(DefaultValueColor),
+						cell.BgColor(map[int]cell.Color{
+							0: cell.ColorBlue,
+							1: cell.ColorGreen,
+							2: cell.ColorBlue,
+						}[y]),
+					))
+				}
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
-			wantCapacity: 3,
+			wantCapacity: 2,
 		},
 		{
-			desc: "respects set bar gap",
+			desc: "displays horizontal bars",
 			opts: []Option{
 				Char('o'),
-				BarGap(2),
+				Horizontal(),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2}, 10)
+				return bc.Values([]int{0, 2, 5, 10}, 10)
 			},
-			canvas: image.Rect(0, 0, 5, 10),
+			canvas: image.Rect(0, 0, 10, 7),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 2, 2, 3),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
-				testdraw.MustRectangle(c, image.Rect(3, 8, 4, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 4, 5, 5),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 6, 10, 7),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
-			wantCapacity: 2,
+			wantCapacity: 4,
 		},
 		{
-			desc: "respects both width and gap",
+			desc: "displays horizontal bars with some labels and values",
 			opts: []Option{
 				Char('o'),
-				BarGap(2),
-				BarWidth(2),
+				Horizontal(),
+				Labels([]string{
+					"a",
+					"bcd",
+				}),
+				ShowValues(),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{5, 3}, 10)
+				return bc.Values([]int{1, 5, 10}, 10)
 			},
-			canvas: image.Rect(0, 0, 6, 10),
+			canvas: image.Rect(0, 0, 13, 5),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 5, 2, 10),
+				testdraw.MustRectangle(c, image.Rect(3, 0, 4, 1),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
-				testdraw.MustRectangle(c, image.Rect(4, 7, 6, 10),
+				testdraw.MustRectangle(c, image.Rect(3, 2, 8, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 4, 13, 5),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
+				// Labels.
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustText(c, "bcd", image.Point{0, 2}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				// Values.
+				testdraw.MustText(c, "1", image.Point{3, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testdraw.MustText(c, "5", image.Point{3, 2}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testdraw.MustText(c, "10", image.Point{3, 4}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
-			wantCapacity: 2,
+			wantCapacity: 3,
 		},
 		{
-			desc: "respects bar and label colors",
+			desc: "horizontal bars respect bar width and gap",
 			opts: []Option{
 				Char('o'),
-				BarColors([]cell.Color{
-					cell.ColorBlue,
-					cell.ColorYellow,
-				}),
-				LabelColors([]cell.Color{
-					cell.ColorCyan,
-					cell.ColorMagenta,
-				}),
-				Labels([]string{
-					"1",
-					"2",
-				}),
+				Horizontal(),
+				BarWidth(2),
+				BarGap(0),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{1, 2, 3}, 10)
+				return bc.Values([]int{5, 10}, 10)
 			},
-			canvas: image.Rect(0, 0, 5, 11),
+			canvas: image.Rect(0, 0, 4, 5),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
-				)
-				testdraw.MustText(c, "1", image.Point{0, 10}, draw.TextCellOpts(
-					cell.FgColor(cell.ColorCyan),
-				))
-
-				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 2),
 					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
-				testdraw.MustText(c, "2", image.Point{2, 10}, draw.TextCellOpts(
-					cell.FgColor(cell.ColorMagenta),
-				))
-
-				testdraw.MustRectangle(c, image.Rect(4, 7, 5, 10),
+				testdraw.MustRectangle(c, image.Rect(0, 2, 4, 4),
 					draw.RectChar('o'),
 					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
 				)
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
-			wantCapacity: 3,
+			wantCapacity: 2,
 		},
 		{
-			desc: "respects value colors",
+			desc: "draws resize needed character when labels don't fit horizontally",
 			opts: []Option{
 				Char('o'),
-				ValueColors([]cell.Color{
-					cell.ColorBlue,
-					cell.ColorBlack,
-				}),
-				ShowValues(),
+				Horizontal(),
+				Labels([]string{"abc"}),
 			},
 			update: func(bc *BarChart) error {
-				return bc.Values([]int{0, 2, 3}, 10)
+				return bc.Values([]int{1}, 10)
 			},
-			canvas: image.Rect(0, 0, 5, 10),
+			canvas: image.Rect(0, 0, 3, 1),
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "0", image.Point{0, 9}, draw.TextCellOpts(
-					cell.FgColor(cell.ColorBlue),
-				))
-
-				testdraw.MustRectangle(c, image.Rect(2, 8, 3, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
-				)
-				testdraw.MustText(c, "2", image.Point{2, 9}, draw.TextCellOpts(
-					cell.FgColor(cell.ColorBlack),
-					cell.BgColor(DefaultBarColor),
-				))
-
-				testdraw.MustRectangle(c, image.Rect(4, 7, 5, 10),
-					draw.RectChar('o'),
-					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
-				)
-				testdraw.MustText(c, "3", image.Point{4, 9}, draw.TextCellOpts(
-					cell.FgColor(DefaultValueColor),
-					cell.BgColor(DefaultBarColor),
-				))
+				testdraw.MustResizeNeeded(c)
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
-			wantCapacity: 3,
+			wantCapacity: 1,
 		},
 		{
 			desc: "regression for #174, protects against external data mutation",
@@ -706,6 +1816,19 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "wants mouse events with the OnClick option",
+			create: func() (*BarChart, error) {
+				return New(
+					OnClick(func(int) {}),
+				)
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
 		{
 			desc: "minimum size for no bars, but have labels",
 			create: func() (*BarChart, error) {
@@ -719,6 +1842,24 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "minimum size for one bar with the baseline",
+			create: func() (*BarChart, error) {
+				bc, err := New(Baseline())
+				if err != nil {
+					return nil, err
+				}
+				if err := bc.Values([]int{-1}, 3); err != nil {
+					return nil, err
+				}
+				return bc, nil
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 2},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
 		{
 			desc: "minimum size for one bar, default width, gap and no labels",
 			create: func() (*BarChart, error) {
@@ -775,6 +1916,28 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "minimum size for horizontal bars accounts for labels, width and gap",
+			create: func() (*BarChart, error) {
+				bc, err := New(
+					Horizontal(),
+					BarWidth(2),
+					Labels([]string{"foo", "a"}),
+				)
+				if err != nil {
+					return nil, err
+				}
+				if err := bc.Values([]int{1, 2}, 3); err != nil {
+					return nil, err
+				}
+				return bc, nil
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{4, 2},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -793,6 +1956,139 @@ func TestOptions(t *testing.T) {
 	}
 }
 
+func TestMouse(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		noClick bool
+		canvas  image.Rectangle
+		mouse   *terminalapi.Mouse
+		want    int
+		wantOK  bool
+		wantErr bool
+	}{
+		{
+			desc:    "fails without the OnClick option",
+			noClick: true,
+			canvas:  image.Rect(0, 0, 10, 3),
+			mouse:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			wantErr: true,
+		},
+		{
+			desc:   "click on the first column of the first bar",
+			opts:   []Option{BarWidth(2)},
+			canvas: image.Rect(0, 0, 10, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{0, 2}, Button: mouse.ButtonLeft},
+			want:   0,
+			wantOK: true,
+		},
+		{
+			desc:   "click on the last column of the first bar",
+			opts:   []Option{BarWidth(2)},
+			canvas: image.Rect(0, 0, 10, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+			want:   0,
+			wantOK: true,
+		},
+		{
+			desc:   "click in the gap after the first bar",
+			opts:   []Option{BarWidth(2)},
+			canvas: image.Rect(0, 0, 10, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{2, 2}, Button: mouse.ButtonLeft},
+		},
+		{
+			desc:   "click on the second bar",
+			opts:   []Option{BarWidth(2)},
+			canvas: image.Rect(0, 0, 10, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{4, 2}, Button: mouse.ButtonLeft},
+			want:   1,
+			wantOK: true,
+		},
+		{
+			desc:   "click on the third bar with a wider gap",
+			opts:   []Option{BarWidth(2), BarGap(2)},
+			canvas: image.Rect(0, 0, 12, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{8, 2}, Button: mouse.ButtonLeft},
+			want:   2,
+			wantOK: true,
+		},
+		{
+			desc:   "click in the wider gap",
+			opts:   []Option{BarWidth(2), BarGap(2)},
+			canvas: image.Rect(0, 0, 12, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{7, 2}, Button: mouse.ButtonLeft},
+		},
+		{
+			desc:   "click beyond the last bar",
+			opts:   []Option{BarWidth(2)},
+			canvas: image.Rect(0, 0, 10, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{9, 2}, Button: mouse.ButtonLeft},
+		},
+		{
+			desc:   "bars without BarWidth use all the width",
+			canvas: image.Rect(0, 0, 11, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{6, 2}, Button: mouse.ButtonLeft},
+			want:   1,
+			wantOK: true,
+		},
+		{
+			desc:   "gaps between bars that use all the width",
+			canvas: image.Rect(0, 0, 11, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{7, 2}, Button: mouse.ButtonLeft},
+		},
+		{
+			desc:   "horizontal bars are hit-tested along the rows",
+			opts:   []Option{Horizontal(), BarWidth(2)},
+			canvas: image.Rect(0, 0, 3, 10),
+			mouse:  &terminalapi.Mouse{Position: image.Point{0, 7}, Button: mouse.ButtonLeft},
+			want:   2,
+			wantOK: true,
+		},
+		{
+			desc:   "ignores other buttons",
+			opts:   []Option{BarWidth(2)},
+			canvas: image.Rect(0, 0, 10, 3),
+			mouse:  &terminalapi.Mouse{Position: image.Point{0, 2}, Button: mouse.ButtonRight},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var (
+				got   int
+				gotOK bool
+				opts  = tc.opts
+			)
+			if !tc.noClick {
+				opts = append(opts, OnClick(func(i int) {
+					got, gotOK = i, true
+				}))
+			}
+			bc, err := New(opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := bc.Values([]int{1, 2, 3}, 3); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+			if err := bc.Draw(testcanvas.MustNew(tc.canvas), &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			err = bc.Mouse(tc.mouse, &widgetapi.EventMeta{})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Mouse => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want || gotOK != tc.wantOK {
+				t.Errorf("Mouse => OnClick called with (%d, %v), want (%d, %v)", got, gotOK, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
 func TestValueCapacity(t *testing.T) {
 	tests := []struct {
 		desc                         string
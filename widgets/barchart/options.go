@@ -39,14 +39,25 @@ func (o option) set(opts *options) {
 
 // options holds the provided options.
 type options struct {
-	barChar     rune
-	barWidth    int
-	barGap      int
-	showValues  bool
-	barColors   []cell.Color
-	labelColors []cell.Color
-	valueColors []cell.Color
-	labels      []string
+	barChar      rune
+	barWidth     int
+	barGap       int
+	showValues   bool
+	valueFormat  func(int) string
+	barColors    []cell.Color
+	segColors    []cell.Color
+	seriesColors []cell.Color
+	groupGap     int
+	labelColors  []cell.Color
+	valueColors  []cell.Color
+	labels       []string
+	horizontal   bool
+	onClick      func(index int)
+	scale        Scale
+	partial      bool
+	baseline     bool
+	negColor     cell.Color
+	max          int
 }
 
 // validate validates the provided options.
@@ -57,14 +68,25 @@ func (o *options) validate() error {
 	if got, min := o.barGap, 0; got < min {
 		return fmt.Errorf("invalid BarGap %d, must be %d <= BarGap", got, min)
 	}
+	if got, min := o.groupGap, 0; got < min {
+		return fmt.Errorf("invalid GroupGap %d, must be %d <= GroupGap", got, min)
+	}
+	if got, min := o.max, 0; got < min {
+		return fmt.Errorf("invalid Max %d, must be %d <= Max", got, min)
+	}
+	if _, ok := scaleNames[o.scale]; !ok {
+		return fmt.Errorf("unsupported ValueScale %v", o.scale)
+	}
 	return nil
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
-		barChar: DefaultChar,
-		barGap:  DefaultBarGap,
+		barChar:  DefaultChar,
+		barGap:   DefaultBarGap,
+		groupGap: DefaultGroupGap,
+		negColor: DefaultNegativeColor,
 	}
 }
 
@@ -82,6 +104,7 @@ func Char(ch rune) Option {
 // BarWidth sets the width of the bars. If not set, or set to zero, the bars
 // use all the space available to the widget. Must be a positive or zero
 // integer.
+// When the Horizontal option is set, this is the height of the bars.
 func BarWidth(width int) Option {
 	return option(func(opts *options) {
 		opts.barWidth = width
@@ -92,6 +115,7 @@ func BarWidth(width int) Option {
 const DefaultBarGap = 1
 
 // BarGap sets the width of the space between the bars.
+// When the Horizontal option is set, this is the height of the space.
 // Must be a positive or zero integer.
 // Defaults to DefaultBarGap.
 func BarGap(width int) Option {
@@ -101,12 +125,24 @@ func BarGap(width int) Option {
 }
 
 // ShowValues tells the bar chart to display the actual values inside each of the bars.
+// Values that don't fit into the width of their bar aren't displayed.
 func ShowValues() Option {
 	return option(func(opts *options) {
 		opts.showValues = true
 	})
 }
 
+// ValueFormatter sets a function that formats the values displayed inside the
+// bars when the ShowValues option is set, e.g. to display percentages or to
+// add units. The function receives the value of the bar, or the total of the
+// bar for stacked bars.
+// Defaults to formatting the values with fmt.Sprint.
+func ValueFormatter(formatter func(int) string) Option {
+	return option(func(opts *options) {
+		opts.valueFormat = formatter
+	})
+}
+
 // DefaultBarColor is the default color of a bar, unless specified otherwise
 // via the BarColors option.
 const DefaultBarColor = cell.ColorRed
@@ -136,7 +172,8 @@ func LabelColors(colors []cell.Color) Option {
 	})
 }
 
-// Labels sets the labels displayed under each bar,
+// Labels sets the labels displayed under each bar, or to the left of each bar
+// when the Horizontal option is set.
 // Bars are created on a call to Values(), each value ends up in its own Bar.
 // The first supplied label applies to the bar displaying the first value.
 // If not specified, the corresponding bar (or all the bars) don't have a
@@ -162,3 +199,160 @@ func ValueColors(colors []cell.Color) Option {
 		opts.valueColors = colors
 	})
 }
+
+// Horizontal makes the bar chart draw horizontal bars that are laid out in
+// rows and grow from the left to the right. The labels are displayed to the
+// left of the bars.
+// Bars are vertical, growing upwards, if this option isn't set.
+func Horizontal() Option {
+	return option(func(opts *options) {
+		opts.horizontal = true
+	})
+}
+
+// SegmentColors sets the colors of the segments of stacked bars, see
+// StackedValues. The first supplied color applies to the first (bottom or
+// leftmost) segment of every bar. Any segments that don't have a color
+// specified use the color of the bar, see BarColors.
+func SegmentColors(colors []cell.Color) Option {
+	return option(func(opts *options) {
+		opts.segColors = colors
+	})
+}
+
+// SeriesColors sets the colors of the series of grouped bars, see
+// GroupedValues. The first supplied color applies to the first bar of every
+// group. Any bars that don't have a color specified use the color of the
+// group, see BarColors.
+func SeriesColors(colors []cell.Color) Option {
+	return option(func(opts *options) {
+		opts.seriesColors = colors
+	})
+}
+
+// DefaultGroupGap is the default value for the GroupGap option.
+const DefaultGroupGap = 0
+
+// GroupGap sets the width of the space between the bars within a group of
+// grouped bars, see GroupedValues. The space between the groups is set by
+// the BarGap option.
+// When the Horizontal option is set, this is the height of the space.
+// Must be a positive or zero integer.
+// Defaults to DefaultGroupGap, i.e. the bars of a group are adjacent.
+func GroupGap(width int) Option {
+	return option(func(opts *options) {
+		opts.groupGap = width
+	})
+}
+
+// OnClick sets a function that is called with the index of the bar when the
+// user clicks on it with the left mouse button. Bars are indexed in the order
+// of the values provided to Values or StackedValues, grouped bars are indexed
+// by their group. Clicks that fall into the gaps between bars are ignored.
+//
+// The BarChart only requests mouse events when this option is set.
+// The function is called synchronously from the goroutine that processes
+// mouse events, without holding the BarChart's lock, so it is safe to call
+// methods of the BarChart or the container from it.
+func OnClick(fn func(index int)) Option {
+	return option(func(opts *options) {
+		opts.onClick = fn
+	})
+}
+
+// Scale determines how the values are mapped onto the heights of the bars.
+type Scale int
+
+// String implements fmt.Stringer()
+func (s Scale) String() string {
+	if n, ok := scaleNames[s]; ok {
+		return n
+	}
+	return "ScaleUnknown"
+}
+
+// scaleNames maps Scale values to human readable names.
+var scaleNames = map[Scale]string{
+	ScaleLinear: "ScaleLinear",
+	ScaleLog:    "ScaleLog",
+}
+
+const (
+	// ScaleLinear is the default scale where the height of a bar is
+	// proportional to its value.
+	ScaleLinear Scale = iota
+
+	// ScaleLog is a scale where the height of a bar is proportional to the
+	// log10 of its value plus one. This makes values that span several orders
+	// of magnitude comparable on a single chart. The max value still takes
+	// all the available height and a zero value renders as an empty bar.
+	// Values displayed by ShowValues aren't affected by the scale.
+	ScaleLog
+)
+
+// ValueScale sets the scale used to compute the heights of the bars.
+// Defaults to ScaleLinear.
+func ValueScale(s Scale) Option {
+	return option(func(opts *options) {
+		opts.scale = s
+	})
+}
+
+// PartialBlocks draws the topmost cell of each bar with one of the Unicode
+// block elements that fill one to seven eighths of a cell. This gives the
+// bars a resolution of one eighth of a cell, so bars that display values that
+// don't land on a cell boundary are drawn more accurately. The partial cell is
+// drawn to the right of the bar in the horizontal mode.
+// The partial cells use the color of the bar as their foreground color and
+// ignore the Char option. Doesn't apply to stacked bars, see StackedValues,
+// or to grouped bars, see GroupedValues.
+func PartialBlocks() Option {
+	return option(func(opts *options) {
+		opts.partial = true
+	})
+}
+
+// Baseline allows the BarChart to display negative values.
+// The BarChart draws a baseline that represents zero, bars that display
+// values above zero grow upward from the baseline and bars that display
+// values below zero grow downward. In the horizontal mode the baseline is a
+// column, the bars grow to the right and to the left of it. The space on
+// either side of the baseline is proportional to the maximum value and to the
+// magnitude of the smallest value, so both sides share the same scale. The
+// labels stay under the bars, or left of the bars in the horizontal mode.
+//
+// With this option, the values provided to Values must be in the range
+// -max <= value <= max. Stacked bars don't support negative values.
+// The PartialBlocks option only applies to bars that display positive values.
+func Baseline() Option {
+	return option(func(opts *options) {
+		opts.baseline = true
+	})
+}
+
+// DefaultNegativeColor is the default value for the NegativeColor option.
+const DefaultNegativeColor = cell.ColorBlue
+
+// NegativeColor sets the color of the bars that display negative values.
+// Only has effect together with the Baseline option.
+// Defaults to DefaultNegativeColor if not set.
+func NegativeColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.negColor = c
+	})
+}
+
+// Max sets a fixed maximum value that overrides the max argument of Values,
+// GroupedValues and StackedValues. A bar displaying the maximum value is a
+// full bar, so the bars reflect the magnitude of the values relative to a
+// fixed reference, e.g. 100 for percentages, instead of to the max argument.
+// Values above the maximum are accepted and displayed as full bars, values
+// below -max are displayed as full bars below the baseline. The ShowValues
+// option still displays the actual values.
+// Defaults to zero which means the max argument is used. Must not be
+// negative.
+func Max(v int) Option {
+	return option(func(opts *options) {
+		opts.max = v
+	})
+}
@@ -90,6 +90,9 @@ type Button struct {
 	// callback gets called on each button press.
 	callback CallbackFn
 
+	// disabled indicates that the button is disabled and cannot be pressed.
+	disabled bool
+
 	// mu protects the widget.
 	mu sync.Mutex
 
@@ -141,6 +144,7 @@ func NewFromChunks(chunks []*TextChunk, cFn CallbackFn, opts ...Option) (*Button
 
 	for _, tOpts := range givenTOpts {
 		tOpts.setDefaultFgColor(opt.textColor)
+		tOpts.setDefaultDisabledFgColor(opt.disabledTextColor)
 	}
 	return &Button{
 		text:         text,
@@ -148,6 +152,7 @@ func NewFromChunks(chunks []*TextChunk, cFn CallbackFn, opts ...Option) (*Button
 		tOptsTracker: tOptsTracker,
 		mouseFSM:     button.NewFSM(mouse.ButtonLeft, image.ZR),
 		callback:     cFn,
+		disabled:     opt.disabled,
 		opts:         opt,
 	}, nil
 }
@@ -159,6 +164,25 @@ func (b *Button) SetCallback(cFn CallbackFn) {
 	b.callback = cFn
 }
 
+// SetDisabled disables or enables the button. A disabled button is drawn with
+// the disabled style, ignores mouse clicks and keyboard keys and doesn't
+// invoke its callback. See the Disabled option.
+func (b *Button) SetDisabled(disabled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if disabled == b.disabled {
+		return
+	}
+	b.disabled = disabled
+	if disabled {
+		// Forget any press in progress, so that a release after the button
+		// gets enabled again doesn't count as a click.
+		b.state = button.Up
+		b.keyTriggerTime = nil
+		b.mouseFSM = button.NewFSM(mouse.ButtonLeft, image.ZR)
+	}
+}
+
 // Vars to be replaced from tests.
 var (
 	// Runes to use in cells that contain the button.
@@ -203,6 +227,8 @@ func (b *Button) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 
 	var fillColor cell.Color
 	switch {
+	case b.disabled:
+		fillColor = b.opts.disabledFillColor
 	case b.state == button.Down && b.opts.pressedFillColor != nil:
 		fillColor = *b.opts.pressedFillColor
 	case meta.Focused && b.opts.focusedFillColor != nil:
@@ -250,6 +276,8 @@ func (b *Button) drawText(cvs *canvas.Canvas, meta *widgetapi.Meta, buttonAr ima
 		tOpts := b.givenTOpts[optRange.AttrIdx]
 		var cellOpts []cell.Option
 		switch {
+		case b.disabled:
+			cellOpts = tOpts.disabledCellOpts
 		case b.state == button.Down && len(tOpts.pressedCellOpts) > 0:
 			cellOpts = tOpts.pressedCellOpts
 		case meta.Focused && len(tOpts.focusedCellOpts) > 0:
@@ -271,6 +299,9 @@ func (b *Button) keyActivated(k *terminalapi.Keyboard, meta *widgetapi.EventMeta
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.disabled {
+		return false
+	}
 	if b.opts.globalKeys[k.Key] || (b.opts.focusedKeys[k.Key] && meta.Focused) {
 		b.state = button.Down
 		now := time.Now().UTC()
@@ -301,6 +332,9 @@ func (b *Button) mouseActivated(m *terminalapi.Mouse) bool {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.disabled {
+		return false
+	}
 	clicked, state := b.mouseFSM.Event(m)
 	b.state = state
 	b.keyTriggerTime = nil
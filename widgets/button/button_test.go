@@ -635,6 +635,88 @@ func TestButton(t *testing.T) {
 				count:  1,
 			},
 		},
+		{
+			desc:     "draws button in down state due to a key combination when multiple keys are specified",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Keys(keyboard.KeyEnter, 's', keyboard.KeyCtrlS),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			events: []*event{
+				{
+					ev:   &terminalapi.Keyboard{Key: keyboard.KeyCtrlS},
+					meta: &widgetapi.EventMeta{Focused: true},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 'x', cell.BgColor(cell.ColorNumber(117)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{2, 2},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorBlack),
+						cell.BgColor(cell.ColorNumber(117))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{
+				called: true,
+				count:  1,
+			},
+		},
+		{
+			desc:     "each of multiple keys triggers the callback",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Keys(keyboard.KeyEnter, 's'),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			events: []*event{
+				{
+					ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+					meta: &widgetapi.EventMeta{Focused: true},
+				},
+				{
+					ev:   &terminalapi.Keyboard{Key: 's'},
+					meta: &widgetapi.EventMeta{Focused: true},
+				},
+				{
+					ev:   &terminalapi.Keyboard{Key: 'x'},
+					meta: &widgetapi.EventMeta{Focused: true},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 'x', cell.BgColor(cell.ColorNumber(117)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{2, 2},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorBlack),
+						cell.BgColor(cell.ColorNumber(117))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{
+				called: true,
+				count:  2,
+			},
+		},
 		{
 			desc:     "draws button in down state due to a keyboard event when single global key is specified",
 			callback: &callbackTracker{},
@@ -1462,6 +1544,191 @@ func TestButton(t *testing.T) {
 			},
 			wantCallback: &callbackTracker{},
 		},
+		{
+			desc:     "draws a disabled button",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Disabled(true),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Shadow.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 's', cell.BgColor(cell.ColorNumber(240)))
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 7, 3), 'x', cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{1, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorNumber(DefaultDisabledTextColorNumber)),
+						cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
+		{
+			desc:     "draws a disabled button with custom colors, disabled style takes precedence over focus",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Disabled(true),
+				FocusedFillColor(cell.ColorBlue),
+				DisabledFillColor(cell.ColorYellow),
+				DisabledTextColor(cell.ColorRed),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: true},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Shadow.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 's', cell.BgColor(cell.ColorNumber(240)))
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 7, 3), 'x', cell.BgColor(cell.ColorYellow))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{1, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorRed),
+						cell.BgColor(cell.ColorYellow)),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
+		{
+			desc:     "draws a disabled button with text chunks that specify disabled cell options",
+			callback: &callbackTracker{},
+			textChunks: []*TextChunk{
+				NewChunk(
+					"hello",
+					DisabledTextCellOpts(cell.FgColor(cell.ColorMagenta)),
+				),
+			},
+			opts: []Option{
+				Disabled(true),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Shadow.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 's', cell.BgColor(cell.ColorNumber(240)))
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 7, 3), 'x', cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{1, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorMagenta),
+						cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
+		{
+			desc:     "disabled button ignores its trigger key",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Disabled(true),
+				Key(keyboard.KeyEnter),
+				GlobalKey('x'),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			events: []*event{
+				{
+					ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+					meta: &widgetapi.EventMeta{Focused: true},
+				},
+				{
+					ev:   &terminalapi.Keyboard{Key: 'x'},
+					meta: &widgetapi.EventMeta{},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Shadow.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 's', cell.BgColor(cell.ColorNumber(240)))
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 7, 3), 'x', cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{1, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorNumber(DefaultDisabledTextColorNumber)),
+						cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
+		{
+			desc:     "disabled button ignores mouse clicks",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Disabled(true),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			events: []*event{
+				{
+					ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+					meta: &widgetapi.EventMeta{},
+				},
+				{
+					ev:   &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+					meta: &widgetapi.EventMeta{},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Shadow.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 's', cell.BgColor(cell.ColorNumber(240)))
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 7, 3), 'x', cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{1, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorNumber(DefaultDisabledTextColorNumber)),
+						cell.BgColor(cell.ColorNumber(DefaultDisabledFillColorNumber))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
 	}
 
 	buttonRune = 'x'
@@ -1609,6 +1876,61 @@ func TestButton(t *testing.T) {
 	}
 }
 
+func TestSetDisabled(t *testing.T) {
+	ct := &callbackTracker{}
+	b, err := New("hello", ct.callback, Key(keyboard.KeyEnter))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	cvs := testcanvas.MustNew(image.Rect(0, 0, 8, 4))
+	if err := b.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	press := func() {
+		t.Helper()
+		if err := b.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}, &widgetapi.EventMeta{Focused: true}); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+	mouseEv := func(btn mouse.Button) {
+		t.Helper()
+		if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{0, 0}, Button: btn}, &widgetapi.EventMeta{}); err != nil {
+			t.Fatalf("Mouse => unexpected error: %v", err)
+		}
+	}
+
+	press()
+	if got, want := ct.count, 1; got != want {
+		t.Fatalf("enabled button => callback called %d times, want %d", got, want)
+	}
+
+	b.SetDisabled(true)
+	press()
+	if got, want := ct.count, 1; got != want {
+		t.Fatalf("disabled button => callback called %d times, want %d", got, want)
+	}
+
+	// A press that started before the button got disabled doesn't click on
+	// release after the button is enabled again.
+	b.SetDisabled(false)
+	mouseEv(mouse.ButtonLeft)
+	b.SetDisabled(true)
+	b.SetDisabled(false)
+	if err := b.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	mouseEv(mouse.ButtonRelease)
+	if got, want := ct.count, 1; got != want {
+		t.Fatalf("release after re-enabling => callback called %d times, want %d", got, want)
+	}
+
+	press()
+	if got, want := ct.count, 2; got != want {
+		t.Fatalf("re-enabled button => callback called %d times, want %d", got, want)
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
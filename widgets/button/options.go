@@ -54,6 +54,9 @@ type options struct {
 	focusedKeys           map[keyboard.Key]bool
 	globalKeys            map[keyboard.Key]bool
 	keyUpDelay            time.Duration
+	disabled              bool
+	disabledFillColor     cell.Color
+	disabledTextColor     cell.Color
 }
 
 // validate validates the provided options.
@@ -97,6 +100,8 @@ func newOptions(text string) *options {
 		keyUpDelay:            DefaultKeyUpDelay,
 		focusedKeys:           map[keyboard.Key]bool{},
 		globalKeys:            map[keyboard.Key]bool{},
+		disabledFillColor:     cell.ColorNumber(DefaultDisabledFillColorNumber),
+		disabledTextColor:     cell.ColorNumber(DefaultDisabledTextColorNumber),
 	}
 }
 
@@ -192,7 +197,12 @@ func GlobalKey(k keyboard.Key) Option {
 	})
 }
 
-// Keys is like Key, but allows to configure multiple keys.
+// Keys is like Key, but allows to configure multiple keys. The button is
+// pressed when any of the keys is pressed, e.g. both keyboard.KeyEnter and a
+// letter shortcut can trigger the same button.
+//
+// Keyboard events don't carry modifiers separately, key combinations are
+// represented by their own keys, e.g. keyboard.KeyCtrlS for Ctrl+S.
 //
 // Clears all keys set by Key() or Keys() previously.
 func Keys(keys ...keyboard.Key) Option {
@@ -240,6 +250,43 @@ func DisableShadow() Option {
 	})
 }
 
+// Disabled sets the initial state of the button. A disabled button is drawn
+// with the DisabledFillColor and DisabledTextColor, ignores mouse clicks and
+// keyboard keys and doesn't invoke its callback.
+// The state can be changed later by calling Button.SetDisabled.
+// Defaults to an enabled button.
+func Disabled(disabled bool) Option {
+	return option(func(opts *options) {
+		opts.disabled = disabled
+	})
+}
+
+// DefaultDisabledFillColorNumber is the default color number for the
+// DisabledFillColor option.
+const DefaultDisabledFillColorNumber = 245
+
+// DisabledFillColor sets the fill color of the button when it is disabled.
+// Defaults to DefaultDisabledFillColorNumber.
+func DisabledFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.disabledFillColor = c
+	})
+}
+
+// DefaultDisabledTextColorNumber is the default color number for the
+// DisabledTextColor option.
+const DefaultDisabledTextColorNumber = 238
+
+// DisabledTextColor sets the color of the text label in the button when it is
+// disabled. Applies only to text chunks that don't specify
+// DisabledTextCellOpts.
+// Defaults to DefaultDisabledTextColorNumber.
+func DisabledTextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.disabledTextColor = c
+	})
+}
+
 // DefaultTextHorizontalPadding is the default value for the HorizontalPadding option.
 const DefaultTextHorizontalPadding = 1
 
@@ -26,9 +26,10 @@ type TextOption interface {
 
 // textOptions stores the provided options.
 type textOptions struct {
-	cellOpts        []cell.Option
-	focusedCellOpts []cell.Option
-	pressedCellOpts []cell.Option
+	cellOpts         []cell.Option
+	focusedCellOpts  []cell.Option
+	pressedCellOpts  []cell.Option
+	disabledCellOpts []cell.Option
 }
 
 // setDefaultFgColor configures a default color for text if one isn't specified
@@ -40,6 +41,14 @@ func (to *textOptions) setDefaultFgColor(c cell.Color) {
 	)
 }
 
+// setDefaultDisabledFgColor configures the color for text when the button is
+// disabled if the disabled cell options aren't specified.
+func (to *textOptions) setDefaultDisabledFgColor(c cell.Color) {
+	if len(to.disabledCellOpts) == 0 {
+		to.disabledCellOpts = []cell.Option{cell.FgColor(c)}
+	}
+}
+
 // newTextOptions returns new textOptions instance.
 func newTextOptions(tOpts ...TextOption) *textOptions {
 	to := &textOptions{}
@@ -83,3 +92,13 @@ func PressedTextCellOpts(opts ...cell.Option) TextOption {
 		tOpts.pressedCellOpts = opts
 	})
 }
+
+// DisabledTextCellOpts sets options on the cells that contain the button text
+// when the button is disabled.
+// If not specified, the text will have its foreground color set to the value
+// of DisabledTextColor().
+func DisabledTextCellOpts(opts ...cell.Option) TextOption {
+	return textOption(func(tOpts *textOptions) {
+		tOpts.disabledCellOpts = opts
+	})
+}
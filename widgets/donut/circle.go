@@ -95,6 +95,50 @@ func midAndRadius(ar image.Rectangle) (image.Point, int) {
 	return mid, radius
 }
 
+// ringRadius is the radius of one ring of the donut in pixels.
+type ringRadius struct {
+	// outer is the radius of the outer edge of the ring.
+	outer int
+	// inner is the radius of the circle inside the ring that remains empty.
+	// Zero if nothing should be cleared inside the ring.
+	inner int
+}
+
+// ringRadii splits the space between the radius of the donut and the radius
+// of its hole among n concentric rings. The rings are ordered from the
+// outermost one and separated from each other by a gap of one pixel. The
+// innermost ring also gets any pixels that remain after the split.
+// Returns false if the rings don't fit.
+func ringRadii(radius, holeR, n int) ([]ringRadius, bool) {
+	if n <= 1 {
+		return []ringRadius{{outer: radius, inner: holeR}}, true
+	}
+
+	gaps := n - 1
+	band := (radius - holeR - gaps) / n
+	minBand := 1
+	if holeR == 0 {
+		// Without a hole the innermost ring is a full circle which must have
+		// the smallest valid circle radius.
+		minBand = 2
+	}
+	if band < minBand {
+		return nil, false
+	}
+
+	var rings []ringRadius
+	outer := radius
+	for i := 0; i < n; i++ {
+		inner := outer - band
+		if i == n-1 {
+			inner = holeR
+		}
+		rings = append(rings, ringRadius{outer: outer, inner: inner})
+		outer = inner - 1 // One pixel for the gap.
+	}
+	return rings, true
+}
+
 // availableCells given a radius returns the number of cells that are available
 // within the circle and the coordinates of the first cell.
 // These coordinates are for a normal (non-braille) canvas.
@@ -17,6 +17,8 @@ package donut
 import (
 	"image"
 	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
 )
 
 func TestStartEndAngles(t *testing.T) {
@@ -467,3 +469,88 @@ func TestAvailableCells(t *testing.T) {
 		})
 	}
 }
+
+func TestRingRadii(t *testing.T) {
+	tests := []struct {
+		desc   string
+		radius int
+		holeR  int
+		n      int
+		want   []ringRadius
+		wantOK bool
+	}{
+		{
+			desc:   "single ring spans from the hole to the radius",
+			radius: 9,
+			holeR:  3,
+			n:      1,
+			want:   []ringRadius{{outer: 9, inner: 3}},
+			wantOK: true,
+		},
+		{
+			desc:   "two rings on a small canvas",
+			radius: 5,
+			holeR:  2,
+			n:      2,
+			want: []ringRadius{
+				{outer: 5, inner: 4},
+				{outer: 3, inner: 2},
+			},
+			wantOK: true,
+		},
+		{
+			desc:   "two rings, the innermost ring gets the remainder",
+			radius: 9,
+			holeR:  3,
+			n:      2,
+			want: []ringRadius{
+				{outer: 9, inner: 7},
+				{outer: 6, inner: 3},
+			},
+			wantOK: true,
+		},
+		{
+			desc:   "three rings",
+			radius: 9,
+			holeR:  3,
+			n:      3,
+			want: []ringRadius{
+				{outer: 9, inner: 8},
+				{outer: 7, inner: 6},
+				{outer: 5, inner: 3},
+			},
+			wantOK: true,
+		},
+		{
+			desc:   "three rings without a hole",
+			radius: 9,
+			holeR:  0,
+			n:      3,
+			want: []ringRadius{
+				{outer: 9, inner: 7},
+				{outer: 6, inner: 4},
+				{outer: 3, inner: 0},
+			},
+			wantOK: true,
+		},
+		{
+			desc:   "three rings don't fit on a small canvas",
+			radius: 5,
+			holeR:  2,
+			n:      3,
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotOK := ringRadii(tc.radius, tc.holeR, tc.n)
+			if gotOK != tc.wantOK {
+				t.Errorf("ringRadii => ok %v, want %v", gotOK, tc.wantOK)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("ringRadii => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,8 +22,10 @@ import (
 	"image"
 	"math"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/area"
 	"github.com/mum4k/termdash/private/canvas"
@@ -56,9 +58,14 @@ const (
 	progressTypeAbsolute
 )
 
+// animationRedrawInterval is how often the donut requests to be redrawn while
+// it transitions between progress values.
+const animationRedrawInterval = 50 * time.Millisecond
+
 // Donut displays the progress of an operation by filling a partial circle and
 // eventually by completing a full circle. The circle can have a "hole" in the
-// middle, which is where the name comes from.
+// middle, which is where the name comes from. Multiple related progress values
+// can be displayed as concentric rings.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type Donut struct {
@@ -70,6 +77,19 @@ type Donut struct {
 	// For progressTypePercent, this is 100, for progressTypeAbsolute this is
 	// the total provided by the caller.
 	total int
+	// rings are the rings set by a call to Rings.
+	// Empty when the progress was set by Percent or Absolute.
+	rings []RingData
+
+	// from is the progress the animation towards current started at.
+	from int
+	// animStart is the time the animation towards current started.
+	animStart time.Time
+	// displayed is the progress displayed by the ongoing call to Draw.
+	displayed int
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+
 	// mu protects the Donut.
 	mu sync.Mutex
 
@@ -88,6 +108,7 @@ func New(opts ...Option) (*Donut, error) {
 	}
 	return &Donut{
 		opts: opt,
+		now:  time.Now,
 	}, nil
 }
 
@@ -111,9 +132,7 @@ func (d *Donut) Absolute(done, total int, opts ...Option) error {
 		return err
 	}
 
-	d.pt = progressTypeAbsolute
-	d.current = done
-	d.total = total
+	d.setProgress(progressTypeAbsolute, done, total)
 	return nil
 }
 
@@ -135,19 +154,140 @@ func (d *Donut) Percent(p int, opts ...Option) error {
 		return err
 	}
 
+	d.setProgress(progressTypePercent, p, 100)
+	return nil
+}
+
+// setProgress sets the progress. The change is animated if the
+// AnimationDuration option is set and neither the progress type nor the total
+// changed.
+func (d *Donut) setProgress(pt progressType, current, total int) {
+	if d.opts.animation > 0 && len(d.rings) == 0 && d.pt == pt && d.total == total {
+		d.from = d.shown()
+		d.animStart = d.now()
+	} else {
+		d.from = current
+	}
+	d.pt = pt
+	d.current = current
+	d.total = total
+	d.rings = nil
+}
+
+// animating asserts whether the displayed progress is still transitioning
+// towards the current progress.
+func (d *Donut) animating() bool {
+	return d.from != d.current && d.now().Sub(d.animStart) < d.opts.animation
+}
+
+// shown returns the progress that should be displayed, i.e. the current
+// progress or a value interpolated between from and current when animating.
+func (d *Donut) shown() int {
+	if !d.animating() {
+		return d.current
+	}
+	frac := float64(d.now().Sub(d.animStart)) / float64(d.opts.animation)
+	return d.from + int(math.Round(float64(d.current-d.from)*frac))
+}
+
+// Animating asserts whether the donut is transitioning between progress
+// values as configured by the AnimationDuration option. The transition only
+// advances when the donut is drawn, so when redrawing manually via the
+// termdash controller, keep redrawing while this returns true.
+func (d *Donut) Animating() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.animating()
+}
+
+// RingData is the progress displayed in one of the concentric rings of the
+// Donut.
+type RingData struct {
+	// Percent is the progress in percentage.
+	// Must be between 0 and 100.
+	Percent int
+
+	// CellOpts are the cell options on cells that contain the ring.
+	// E.g. cell.FgColor sets the color of the ring.
+	CellOpts []cell.Option
+}
+
+// Rings displays multiple concentric rings, each showing its own progress in
+// percentage. The first ring is the outermost one. The space between the
+// outer edge of the donut and its hole is split evenly among the rings, so
+// the HolePercent option still determines the size of the hole inside the
+// innermost ring. The text progress displays the percentage of the first
+// ring.
+// At least one ring must be provided.
+// Provided options override values set when New() was called.
+func (d *Donut) Rings(rings []RingData, opts ...Option) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(rings) == 0 {
+		return errors.New("at least one ring must be provided")
+	}
+	for i, r := range rings {
+		if r.Percent < 0 || r.Percent > 100 {
+			return fmt.Errorf("invalid percentage in ring[%d], p(%d) must be 0 <= p <= 100", i, r.Percent)
+		}
+	}
+
+	for _, opt := range opts {
+		opt.set(d.opts)
+	}
+	if err := d.opts.validate(); err != nil {
+		return err
+	}
+
 	d.pt = progressTypePercent
-	d.current = p
+	d.current = rings[0].Percent
+	d.from = d.current
 	d.total = 100
+	d.rings = make([]RingData, len(rings))
+	copy(d.rings, rings)
 	return nil
 }
 
+// ringsToDraw returns the rings that should be drawn.
+// Progress set by Percent or Absolute is displayed as a single ring.
+func (d *Donut) ringsToDraw() []RingData {
+	if len(d.rings) > 0 {
+		return d.rings
+	}
+	// The angles of the single ring are determined by ringAngles.
+	return []RingData{{CellOpts: d.opts.cellOpts}}
+}
+
+// ringAngles returns the start and end angles of the partial circle that
+// represents the progress of the i-th ring.
+func (d *Donut) ringAngles(i int) (start, end int) {
+	if len(d.rings) == 0 {
+		return startEndAngles(d.displayed, d.total, d.opts.startAngle, d.opts.direction)
+	}
+	return startEndAngles(d.rings[i].Percent, 100, d.opts.startAngle, d.opts.direction)
+}
+
+// hasProgress asserts whether any of the rings has progress to display.
+func (d *Donut) hasProgress() bool {
+	for i := range d.ringsToDraw() {
+		if startA, endA := d.ringAngles(i); startA != endA {
+			return true
+		}
+	}
+	return false
+}
+
 // progressText returns the textual representation of the current progress.
 func (d *Donut) progressText() string {
+	if f := d.opts.textFormatter; f != nil {
+		return f(d.displayed, d.total)
+	}
 	switch d.pt {
 	case progressTypePercent:
-		return fmt.Sprintf("%d%%", d.current)
+		return fmt.Sprintf("%d%%", d.displayed)
 	case progressTypeAbsolute:
-		return fmt.Sprintf("%d/%d", d.current, d.total)
+		return fmt.Sprintf("%d/%d", d.displayed, d.total)
 	default:
 		return ""
 	}
@@ -163,6 +303,11 @@ func (d *Donut) holeRadius(donutRadius int) int {
 	return r
 }
 
+// minTruncatedCells is the minimum number of cells required to display
+// formatted text progress that doesn't fit into the hole. These are two
+// characters of the text followed by an ellipsis.
+const minTruncatedCells = 3
+
 // drawText draws the text label showing the progress.
 // The text is only drawn if the radius of the donut "hole" is large enough to
 // accommodate it.
@@ -172,7 +317,15 @@ func (d *Donut) drawText(cvs *canvas.Canvas, mid image.Point, holeR int) error {
 	t := d.progressText()
 	needCells := runewidth.StringWidth(t)
 	if cells < needCells {
-		return nil
+		if d.opts.textFormatter == nil || cells < minTruncatedCells {
+			return nil
+		}
+		trimmed, err := draw.TrimText(t, cells, draw.OverrunModeThreeDot)
+		if err != nil {
+			return fmt.Errorf("draw.TrimText => %v", err)
+		}
+		t = trimmed
+		needCells = runewidth.StringWidth(t)
 	}
 
 	ar := image.Rect(first.X, first.Y, first.X+cells+2, first.Y+1)
@@ -206,8 +359,8 @@ func (d *Donut) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	startA, endA := startEndAngles(d.current, d.total, d.opts.startAngle, d.opts.direction)
-	if startA == endA {
+	d.displayed = d.shown()
+	if !d.hasProgress() {
 		// No progress recorded, so nothing to do.
 		return nil
 	}
@@ -237,21 +390,32 @@ func (d *Donut) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	mid, r := midAndRadius(bc.Area())
-	if err := draw.BrailleCircle(bc, mid, r,
-		draw.BrailleCircleFilled(),
-		draw.BrailleCircleArcOnly(startA, endA),
-		draw.BrailleCircleCellOpts(d.opts.cellOpts...),
-	); err != nil {
-		return fmt.Errorf("failed to draw the outer circle: %v", err)
+	holeR := d.holeRadius(r)
+	rings := d.ringsToDraw()
+	radii, ok := ringRadii(r, holeR, len(rings))
+	if !ok {
+		return draw.ResizeNeeded(cvs)
 	}
 
-	holeR := d.holeRadius(r)
-	if holeR != 0 {
-		if err := draw.BrailleCircle(bc, mid, holeR,
-			draw.BrailleCircleFilled(),
-			draw.BrailleCircleClearPixels(),
-		); err != nil {
-			return fmt.Errorf("failed to draw the outer circle: %v", err)
+	for i, ring := range rings {
+		rr := radii[i]
+		if startA, endA := d.ringAngles(i); startA != endA {
+			if err := draw.BrailleCircle(bc, mid, rr.outer,
+				draw.BrailleCircleFilled(),
+				draw.BrailleCircleArcOnly(startA, endA),
+				draw.BrailleCircleCellOpts(ring.CellOpts...),
+			); err != nil {
+				return fmt.Errorf("failed to draw the ring[%d]: %v", i, err)
+			}
+		}
+
+		if rr.inner != 0 {
+			if err := draw.BrailleCircle(bc, mid, rr.inner,
+				draw.BrailleCircleFilled(),
+				draw.BrailleCircleClearPixels(),
+			); err != nil {
+				return fmt.Errorf("failed to clear inside the ring[%d]: %v", i, err)
+			}
 		}
 	}
 	if err := bc.CopyTo(cvs); err != nil {
@@ -287,15 +451,23 @@ var minSize = image.Point{3, 3}
 
 // Options implements widgetapi.Widget.Options.
 func (d *Donut) Options() widgetapi.Options {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var redraw time.Duration
+	if d.animating() {
+		redraw = animationRedrawInterval
+	}
 	return widgetapi.Options{
 		// We are drawing a circle, ensure equal ratio of rows and columns.
 		// This is adjusted for the inequality of the braille canvas.
 		Ratio: image.Point{braille.RowMult, braille.ColMult},
 
 		// The smallest circle that "looks" like a circle on the canvas.
-		MinimumSize:  minSize,
-		WantKeyboard: widgetapi.KeyScopeNone,
-		WantMouse:    widgetapi.MouseScopeNone,
+		MinimumSize:    minSize,
+		WantKeyboard:   widgetapi.KeyScopeNone,
+		WantMouse:      widgetapi.MouseScopeNone,
+		RedrawInterval: redraw,
 	}
 }
 
@@ -15,8 +15,10 @@
 package donut
 
 import (
+	"fmt"
 	"image"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
@@ -51,6 +53,14 @@ func TestDonut(t *testing.T) {
 			canvas:     image.Rect(0, 0, 3, 3),
 			wantNewErr: true,
 		},
+		{
+			desc: "New fails on negative animation duration",
+			opts: []Option{
+				AnimationDuration(-1),
+			},
+			canvas:     image.Rect(0, 0, 3, 3),
+			wantNewErr: true,
+		},
 		{
 			desc: "New fails on too large donut hole percent",
 			opts: []Option{
@@ -324,6 +334,120 @@ func TestDonut(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "Rings fails without rings",
+			update: func(d *Donut) error {
+				return d.Rings(nil)
+			},
+			canvas:        image.Rect(0, 0, 3, 3),
+			wantUpdateErr: true,
+		},
+		{
+			desc: "Rings fails on invalid percent",
+			update: func(d *Donut) error {
+				return d.Rings([]RingData{{Percent: 50}, {Percent: 101}})
+			},
+			canvas:        image.Rect(0, 0, 3, 3),
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "draws two rings",
+			canvas: image.Rect(0, 0, 6, 6),
+			update: func(d *Donut) error {
+				return d.Rings([]RingData{
+					{Percent: 100, CellOpts: []cell.Option{cell.FgColor(cell.ColorRed)}},
+					{Percent: 100, CellOpts: []cell.Option{cell.FgColor(cell.ColorBlue)}},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 5,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 4,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 3,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 2,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "rings without progress aren't drawn",
+			canvas: image.Rect(0, 0, 6, 6),
+			update: func(d *Donut) error {
+				return d.Rings([]RingData{
+					{Percent: 0},
+					{Percent: 100},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 3, draw.BrailleCircleFilled())
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 2,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws resize needed when the rings don't fit",
+			canvas: image.Rect(0, 0, 6, 6),
+			update: func(d *Donut) error {
+				return d.Rings([]RingData{
+					{Percent: 100},
+					{Percent: 100},
+					{Percent: 100},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "a call to Percent replaces the rings",
+			canvas: image.Rect(0, 0, 6, 6),
+			update: func(d *Donut) error {
+				if err := d.Rings([]RingData{{Percent: 100}, {Percent: 100}}); err != nil {
+					return err
+				}
+				return d.Percent(100)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 5, draw.BrailleCircleFilled())
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 2,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
 		{
 			desc: "draws hole and label",
 			opts: []Option{
@@ -496,6 +620,82 @@ func TestDonut(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "formats the text progress",
+			canvas: image.Rect(0, 0, 7, 7),
+			update: func(d *Donut) error {
+				return d.Absolute(42, 42, HolePercent(80), TextFormatter(func(value, total int) string {
+					return fmt.Sprintf("%dGB", value)
+				}))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(c.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 6, draw.BrailleCircleFilled())
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 5,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+				testbraille.MustCopyTo(bc, c)
+
+				testdraw.MustText(c, "42GB", image.Point{2, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "truncates formatted text wider than the hole",
+			canvas: image.Rect(0, 0, 7, 7),
+			update: func(d *Donut) error {
+				return d.Absolute(42, 42, HolePercent(80), TextFormatter(func(value, total int) string {
+					return fmt.Sprintf("%d GB", value)
+				}))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(c.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 6, draw.BrailleCircleFilled())
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 5,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+				testbraille.MustCopyTo(bc, c)
+
+				testdraw.MustText(c, "42 …", image.Point{2, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "omits formatted text when the hole is too small",
+			canvas: image.Rect(0, 0, 7, 7),
+			update: func(d *Donut) error {
+				return d.Absolute(42, 42, HolePercent(50), TextFormatter(func(value, total int) string {
+					return fmt.Sprintf("%d GB", value)
+				}))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(c.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 6, draw.BrailleCircleFilled())
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 3,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "displays 1% progress",
 			canvas: image.Rect(0, 0, 7, 7),
@@ -878,6 +1078,167 @@ func TestDonut(t *testing.T) {
 	}
 }
 
+// fakeClock is a clock whose time only changes when advanced.
+type fakeClock struct {
+	t time.Time
+}
+
+// now returns the current time of the clock.
+func (fc *fakeClock) now() time.Time {
+	return fc.t
+}
+
+// drawDonut draws the donut on a canvas of the provided size and returns the
+// resulting terminal.
+func drawDonut(t *testing.T, d *Donut, size image.Point) *faketerm.Terminal {
+	t.Helper()
+	c, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := d.Draw(c, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	ft := faketerm.MustNew(size)
+	if err := c.Apply(ft); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+	return ft
+}
+
+func TestAnimation(t *testing.T) {
+	tests := []struct {
+		desc string
+		// update is called before the animation starts.
+		update func(*Donut) error
+		// animate is called at the start of the animation.
+		animate func(*Donut) error
+		// elapsed is the time elapsed before each of the draws.
+		elapsed       []time.Duration
+		wantText      []string
+		wantAnimating []bool
+	}{
+		{
+			desc: "interpolates the percentage",
+			update: func(d *Donut) error {
+				return d.Percent(20)
+			},
+			animate: func(d *Donut) error {
+				return d.Percent(60)
+			},
+			elapsed:       []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond, time.Second, 2 * time.Second},
+			wantText:      []string{"20%", "30%", "40%", "50%", "60%", "60%"},
+			wantAnimating: []bool{true, true, true, true, false, false},
+		},
+		{
+			desc: "interpolates decreasing absolute progress",
+			update: func(d *Donut) error {
+				return d.Absolute(10, 10)
+			},
+			animate: func(d *Donut) error {
+				return d.Absolute(2, 10)
+			},
+			elapsed:       []time.Duration{0, 500 * time.Millisecond, time.Second},
+			wantText:      []string{"10/10", "6/10", "2/10"},
+			wantAnimating: []bool{true, true, false},
+		},
+		{
+			desc: "change of the total isn't animated",
+			update: func(d *Donut) error {
+				return d.Absolute(5, 10)
+			},
+			animate: func(d *Donut) error {
+				return d.Absolute(5, 20)
+			},
+			elapsed:       []time.Duration{0},
+			wantText:      []string{"5/20"},
+			wantAnimating: []bool{false},
+		},
+		{
+			desc: "change of the progress type isn't animated",
+			update: func(d *Donut) error {
+				return d.Absolute(5, 100)
+			},
+			animate: func(d *Donut) error {
+				return d.Percent(50)
+			},
+			elapsed:       []time.Duration{0},
+			wantText:      []string{"50%"},
+			wantAnimating: []bool{false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := New(AnimationDuration(time.Second))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			fc := &fakeClock{t: start}
+			d.now = fc.now
+
+			if err := tc.update(d); err != nil {
+				t.Fatalf("update => unexpected error: %v", err)
+			}
+			if d.Animating() {
+				t.Errorf("Animating => true before the progress changed, want false")
+			}
+			if err := tc.animate(d); err != nil {
+				t.Fatalf("animate => unexpected error: %v", err)
+			}
+
+			for i, e := range tc.elapsed {
+				fc.t = start.Add(e)
+				drawDonut(t, d, image.Point{20, 10})
+				if got, want := d.progressText(), tc.wantText[i]; got != want {
+					t.Errorf("after %v progressText => %q, want %q", e, got, want)
+				}
+				if got, want := d.Animating(), tc.wantAnimating[i]; got != want {
+					t.Errorf("after %v Animating => %v, want %v", e, got, want)
+				}
+				var wantRedraw time.Duration
+				if tc.wantAnimating[i] {
+					wantRedraw = animationRedrawInterval
+				}
+				if got := d.Options().RedrawInterval; got != wantRedraw {
+					t.Errorf("after %v Options().RedrawInterval => %v, want %v", e, got, wantRedraw)
+				}
+			}
+		})
+	}
+}
+
+func TestAnimationDrawsInterpolatedValue(t *testing.T) {
+	d, err := New(AnimationDuration(time.Second))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := &fakeClock{t: start}
+	d.now = fc.now
+	if err := d.Percent(0); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	if err := d.Percent(100); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	fc.t = start.Add(350 * time.Millisecond)
+
+	want, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := want.Percent(35); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+
+	size := image.Point{20, 10}
+	if diff := faketerm.Diff(drawDonut(t, want, size), drawDonut(t, d, size)); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
+
 func TestKeyboard(t *testing.T) {
 	d, err := New()
 	if err != nil {
@@ -18,6 +18,7 @@ package donut
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
@@ -42,8 +43,9 @@ type options struct {
 	donutHolePercent int
 	hideTextProgress bool
 
-	textCellOpts []cell.Option
-	cellOpts     []cell.Option
+	textCellOpts  []cell.Option
+	cellOpts      []cell.Option
+	textFormatter func(value, total int) string
 
 	labelCellOpts []cell.Option
 	labelAlign    align.Horizontal
@@ -54,6 +56,9 @@ type options struct {
 	// The direction in which the donut completes as progress increases.
 	// Positive for counter-clockwise, negative for clockwise.
 	direction int
+
+	// animation is the duration of the transition between progress values.
+	animation time.Duration
 }
 
 // validate validates the provided options.
@@ -66,6 +71,10 @@ func (o *options) validate() error {
 		return fmt.Errorf("invalid start angle %d, must be in range %d <= angle < %d", o.startAngle, min, max)
 	}
 
+	if o.animation < 0 {
+		return fmt.Errorf("invalid animation duration %v, must not be negative", o.animation)
+	}
+
 	return nil
 }
 
@@ -128,6 +137,20 @@ func TextCellOpts(cOpts ...cell.Option) Option {
 	})
 }
 
+// TextFormatter sets a function that formats the text progress displayed in
+// the "hole" of the donut instead of the percentage or the "done/total" text,
+// e.g. to display an absolute amount with a unit like "42 GB". The function
+// receives the displayed progress and the total, which is 100 if the progress
+// was set by Percent or Rings.
+// Text that is too wide for the hole is truncated with an ellipsis, it is
+// omitted if the hole doesn't fit at least two of its characters and the
+// ellipsis. The formatter doesn't apply to the text set by the Label option.
+func TextFormatter(f func(value, total int) string) Option {
+	return option(func(opts *options) {
+		opts.textFormatter = f
+	})
+}
+
 // CellOpts sets cell options on cells that contain the donut.
 func CellOpts(cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
@@ -181,3 +204,18 @@ func LabelAlign(la align.Horizontal) Option {
 		opts.labelAlign = la
 	})
 }
+
+// AnimationDuration makes the donut transition smoothly between progress
+// values instead of changing instantly. When the progress set by Percent or
+// Absolute changes, the displayed progress moves from the displayed value to
+// the new one over the provided duration. Each call to Draw displays the
+// value interpolated for the time elapsed since the change. The donut requests
+// frequent redraws while the transition is in progress, see Donut.Animating.
+// Changes of the progress type or of the total aren't animated, neither is
+// progress set by Rings.
+// Defaults to zero, i.e. no animation.
+func AnimationDuration(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.animation = d
+	})
+}
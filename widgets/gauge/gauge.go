@@ -19,8 +19,10 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/linestyle"
@@ -33,6 +35,10 @@ import (
 	"github.com/mum4k/termdash/widgetapi"
 )
 
+// indeterminateRedrawInterval is how often the gauge requests to be redrawn
+// when configured with the Indeterminate option.
+const indeterminateRedrawInterval = 50 * time.Millisecond
+
 // progressType indicates how was the current progress provided by the caller.
 type progressType int
 
@@ -70,6 +76,16 @@ type Gauge struct {
 	// For progressTypePercent, this is 100, for progressTypeAbsolute this is
 	// the total provided by the caller.
 	total int
+	// theme is the theme provided on the last call to Draw.
+	theme *cell.Theme
+	// sweepStart is the time the block of an indeterminate gauge started
+	// moving, zero until the first draw with the Indeterminate option.
+	sweepStart time.Time
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+	// lit are the areas of the lit segments on the last call to Draw, nil
+	// unless the gauge is segmented.
+	lit []image.Rectangle
 	// mu protects the Gauge.
 	mu sync.Mutex
 
@@ -89,6 +105,7 @@ func New(opts ...Option) (*Gauge, error) {
 
 	return &Gauge{
 		opts: opt,
+		now:  time.Now,
 	}, nil
 }
 
@@ -141,9 +158,177 @@ func (g *Gauge) Percent(p int, opts ...Option) error {
 // in order to represent the current progress or to figure out the coordinate
 // for the threshold line.
 func (g *Gauge) width(ar image.Rectangle, w int) int {
-	mult := float32(w) / float32(g.total)
-	width := float32(ar.Dx()) * mult
-	return int(width)
+	return g.length(ar.Dx(), w)
+}
+
+// height is like width, but determines the number of rows of a vertical
+// gauge that represent point h in rectangle ar.
+func (g *Gauge) height(ar image.Rectangle, h int) int {
+	return g.length(ar.Dy(), h)
+}
+
+// length determines the number of cells out of size that represent point p.
+func (g *Gauge) length(size, p int) int {
+	mult := float32(p) / float32(g.total)
+	length := float32(size) * mult
+	return int(length)
+}
+
+// blockPos returns the offset of the block of an indeterminate gauge from the
+// start of the gauge, given the number of cells the block can move over.
+// The block bounces between the two ends of the gauge.
+func (g *Gauge) blockPos(travel int) int {
+	if travel <= 0 {
+		return 0
+	}
+	sweep := g.opts.indeterminateSweep
+	elapsed := g.now().Sub(g.sweepStart) % (2 * sweep)
+	frac := float64(elapsed) / float64(sweep)
+	if frac > 1 {
+		// Moving back towards the start.
+		frac = 2 - frac
+	}
+	return int(math.Round(frac * float64(travel)))
+}
+
+// blockRect returns the area of the block of an indeterminate gauge.
+func (g *Gauge) blockRect(usable image.Rectangle) image.Rectangle {
+	size := usable.Dx()
+	if g.opts.vertical {
+		size = usable.Dy()
+	}
+	block := size / 4
+	if block < 1 {
+		block = 1
+	}
+	pos := g.blockPos(size - block)
+
+	if g.opts.vertical {
+		// Moves from the bottom up like the progress of a vertical gauge.
+		return image.Rect(usable.Min.X, usable.Max.Y-pos-block, usable.Max.X, usable.Max.Y-pos)
+	}
+	return image.Rect(usable.Min.X+pos, usable.Min.Y, usable.Min.X+pos+block, usable.Max.Y)
+}
+
+// progressRect returns the area of the gauge that is filled up to represent
+// the current progress.
+func (g *Gauge) progressRect(usable image.Rectangle) image.Rectangle {
+	if g.opts.indeterminate {
+		return g.blockRect(usable)
+	}
+	if g.opts.vertical {
+		return image.Rect(
+			usable.Min.X,
+			usable.Max.Y-g.height(usable, g.current),
+			usable.Max.X,
+			usable.Max.Y,
+		)
+	}
+	return image.Rect(
+		usable.Min.X,
+		usable.Min.Y,
+		usable.Min.X+g.width(usable, g.current),
+		usable.Max.Y,
+	)
+}
+
+// segmented determines if the gauge is divided into segments.
+func (g *Gauge) segmented() bool {
+	return g.opts.segments > 0 && !g.opts.indeterminate
+}
+
+// segmentsLength returns the number of cells the segments and the gaps
+// between them require along the gauge.
+func (g *Gauge) segmentsLength() int {
+	n := g.opts.segments
+	return n + (n-1)*g.opts.segmentGap
+}
+
+// segmentRects returns the areas of the segments in the order they light up,
+// i.e. from the left or from the bottom if the gauge is vertical. Cells that
+// don't divide evenly among the segments are added to the first segments.
+// Returns nil if the usable area cannot fit all the segments.
+func (g *Gauge) segmentRects(usable image.Rectangle) []image.Rectangle {
+	n, gap := g.opts.segments, g.opts.segmentGap
+	size := usable.Dx()
+	if g.opts.vertical {
+		size = usable.Dy()
+	}
+	avail := size - (n-1)*gap
+	if avail < n {
+		return nil
+	}
+
+	var (
+		rects []image.Rectangle
+		pos   int
+	)
+	for i := 0; i < n; i++ {
+		length := avail / n
+		if i < avail%n {
+			length++
+		}
+		if g.opts.vertical {
+			rects = append(rects, image.Rect(usable.Min.X, usable.Max.Y-pos-length, usable.Max.X, usable.Max.Y-pos))
+		} else {
+			rects = append(rects, image.Rect(usable.Min.X+pos, usable.Min.Y, usable.Min.X+pos+length, usable.Max.Y))
+		}
+		pos += length + gap
+	}
+	return rects
+}
+
+// litSegments returns the number of segments that represent the current
+// progress, rounded to whole segments.
+func (g *Gauge) litSegments() int {
+	if g.total == 0 {
+		return 0
+	}
+	return int(math.Round(float64(g.current) * float64(g.opts.segments) / float64(g.total)))
+}
+
+// drawSegments draws the segments of a segmented gauge and returns the area
+// that spans the lit segments.
+func (g *Gauge) drawSegments(cvs *canvas.Canvas) (image.Rectangle, error) {
+	rects := g.segmentRects(g.usable(cvs))
+	lit := g.litSegments()
+	if lit > len(rects) {
+		lit = len(rects)
+	}
+
+	var progress image.Rectangle
+	for i, r := range rects {
+		color := g.opts.emptySegmentColor
+		if i < lit {
+			color = g.fillColor()
+			progress = progress.Union(r)
+		}
+		if err := draw.Rectangle(cvs, r,
+			draw.RectChar(g.opts.gaugeChar),
+			draw.RectCellOpts(cell.BgColor(color)),
+		); err != nil {
+			return image.ZR, err
+		}
+	}
+	g.lit = rects[:lit]
+	return progress, nil
+}
+
+// filled determines if the point falls into the filled part of the gauge.
+func (g *Gauge) filled(p image.Point, progress image.Rectangle) bool {
+	if !p.In(progress) {
+		return false
+	}
+	if g.lit == nil {
+		return true
+	}
+	// The gaps between the lit segments aren't filled.
+	for _, r := range g.lit {
+		if p.In(r) {
+			return true
+		}
+	}
+	return false
 }
 
 // hasBorder determines of the gauge has a border.
@@ -161,12 +346,56 @@ func (g *Gauge) usable(cvs *canvas.Canvas) image.Rectangle {
 
 // thresholdVisible determines if the threshold line should be drawn.
 func (g *Gauge) thresholdVisible() bool {
-	return g.opts.threshold > 0 && g.opts.threshold < g.total
+	return !g.opts.indeterminate && g.opts.threshold > 0 && g.opts.threshold < g.total
+}
+
+// color returns the color of the gauge.
+func (g *Gauge) color() cell.Color {
+	if !g.opts.colorSet && g.theme != nil {
+		return g.theme.Accent
+	}
+	return g.opts.color
+}
+
+// emptyTextColor returns the color of the text outside of the filled part of
+// the gauge.
+func (g *Gauge) emptyTextColor() cell.Color {
+	if !g.opts.emptyTextColorSet && g.theme != nil {
+		return g.theme.Foreground
+	}
+	return g.opts.emptyTextColor
+}
+
+// borderCellOpts returns the cell options for the border.
+func (g *Gauge) borderCellOpts() []cell.Option {
+	if len(g.opts.borderCellOpts) == 0 && g.theme != nil {
+		return []cell.Option{cell.FgColor(g.theme.Border)}
+	}
+	return g.opts.borderCellOpts
+}
+
+// fillColor returns the color of the filled part of the gauge. This is the
+// fill color of the highest reached threshold marker that has one, or the
+// color of the gauge.
+func (g *Gauge) fillColor() cell.Color {
+	color := g.color()
+	if g.opts.indeterminate {
+		return color
+	}
+	highest := -1
+	for _, tm := range g.opts.thresholdMarkers {
+		if tm.FillColor == cell.ColorDefault || g.current < tm.Value || tm.Value <= highest {
+			continue
+		}
+		color = tm.FillColor
+		highest = tm.Value
+	}
+	return color
 }
 
 // progressText returns the textual representation of the current progress.
 func (g *Gauge) progressText() string {
-	if g.opts.hideTextProgress {
+	if g.opts.hideTextProgress || g.opts.indeterminate {
 		return ""
 	}
 
@@ -190,12 +419,51 @@ func (g *Gauge) gaugeText() string {
 	return b.String()
 }
 
+// textCellOpts returns the cell options for a text rune at the specified
+// point.
+func (g *Gauge) textCellOpts(p image.Point, progress image.Rectangle) []cell.Option {
+	if g.filled(p, progress) {
+		return []cell.Option{cell.FgColor(g.opts.filledTextColor)}
+	}
+	return []cell.Option{cell.FgColor(g.emptyTextColor())}
+}
+
+// drawVerticalText draws the text enumerating the progress and the text label
+// on a vertical gauge, the text flows from top to bottom.
+func (g *Gauge) drawVerticalText(cvs *canvas.Canvas, progress image.Rectangle, text string) error {
+	ar := g.usable(cvs)
+	trimmed, err := draw.TrimText(text, ar.Dy(), draw.OverrunModeThreeDot)
+	if err != nil {
+		return err
+	}
+
+	textAr, err := alignfor.Rectangle(ar, image.Rect(0, 0, 1, len([]rune(trimmed))), g.opts.hTextAlign, g.opts.vTextAlign)
+	if err != nil {
+		return err
+	}
+
+	cur := textAr.Min
+	for _, r := range trimmed {
+		if !cur.In(ar) {
+			break
+		}
+		if _, err := cvs.SetCell(cur, r, g.textCellOpts(cur, progress)...); err != nil {
+			return err
+		}
+		cur = image.Point{cur.X, cur.Y + 1}
+	}
+	return nil
+}
+
 // drawText draws the text enumerating the progress and the text label.
 func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 	text := g.gaugeText()
 	if text == "" {
 		return nil
 	}
+	if g.opts.vertical {
+		return g.drawVerticalText(cvs, progress, text)
+	}
 
 	ar := g.usable(cvs)
 	trimmed, err := draw.TrimText(text, ar.Dx(), draw.OverrunModeThreeDot)
@@ -227,21 +495,14 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 			)
 			if err := draw.Rectangle(cvs, fixup,
 				draw.RectChar(g.opts.gaugeChar),
-				draw.RectCellOpts(cell.BgColor(g.opts.color)),
+				draw.RectCellOpts(cell.BgColor(g.fillColor())),
 			); err != nil {
 				return err
 			}
 
 		}
 
-		var cellOpts []cell.Option
-		if cur.In(progress) {
-			cellOpts = append(cellOpts, cell.FgColor(g.opts.filledTextColor))
-		} else {
-			cellOpts = append(cellOpts, cell.FgColor(g.opts.emptyTextColor))
-		}
-
-		cells, err := cvs.SetCell(cur, r, cellOpts...)
+		cells, err := cvs.SetCell(cur, r, g.textCellOpts(cur, progress)...)
 		if err != nil {
 			return err
 		}
@@ -252,9 +513,23 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 }
 
 // drawThreshold draws the threshold line.
+// The line is vertical on horizontal gauges and horizontal on vertical
+// gauges.
 func (g *Gauge) drawThreshold(cvs *canvas.Canvas) error {
 	ar := g.usable(cvs)
 
+	if g.opts.vertical {
+		y := ar.Max.Y - 1 - g.height(ar, g.opts.threshold)
+		line := draw.HVLine{
+			Start: image.Point{X: cvs.Area().Min.X, Y: y},
+			End:   image.Point{X: cvs.Area().Max.X - 1, Y: y},
+		}
+		return draw.HVLines(cvs, []draw.HVLine{line},
+			draw.HVLineStyle(g.opts.thresholdLineStyle),
+			draw.HVLineCellOpts(g.opts.thresholdCellOpts...),
+		)
+	}
+
 	line := draw.HVLine{
 		Start: image.Point{
 			X: ar.Min.X + g.width(ar, g.opts.threshold),
@@ -271,12 +546,62 @@ func (g *Gauge) drawThreshold(cvs *canvas.Canvas) error {
 	)
 }
 
+// drawThresholdMarkers draws the markers configured with the Thresholds
+// option across the usable area of the gauge. Cells of the markers that fall
+// into the filled part of the gauge keep its color as their background.
+func (g *Gauge) drawThresholdMarkers(cvs *canvas.Canvas, progress image.Rectangle) error {
+	if g.opts.indeterminate {
+		return nil
+	}
+	ar := g.usable(cvs)
+	for _, tm := range g.opts.thresholdMarkers {
+		if tm.Value > g.total {
+			continue
+		}
+
+		var line image.Rectangle
+		r := '│'
+		if g.opts.vertical {
+			y := ar.Max.Y - 1 - g.height(ar, tm.Value)
+			if y < ar.Min.Y {
+				y = ar.Min.Y
+			}
+			line = image.Rect(ar.Min.X, y, ar.Max.X, y+1)
+			r = '─'
+		} else {
+			x := ar.Min.X + g.width(ar, tm.Value)
+			if x >= ar.Max.X {
+				x = ar.Max.X - 1
+			}
+			line = image.Rect(x, ar.Min.Y, x+1, ar.Max.Y)
+		}
+
+		for x := line.Min.X; x < line.Max.X; x++ {
+			for y := line.Min.Y; y < line.Max.Y; y++ {
+				p := image.Point{x, y}
+				cOpts := []cell.Option{cell.FgColor(tm.Color)}
+				if g.filled(p, progress) {
+					cOpts = append(cOpts, cell.BgColor(g.fillColor()))
+				}
+				if _, err := cvs.SetCell(p, r, cOpts...); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // Draw draws the Gauge widget onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (g *Gauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.theme = nil
+	if meta != nil {
+		g.theme = meta.Theme
+	}
 	needAr, err := area.FromSize(g.minSize())
 	if err != nil {
 		return err
@@ -285,28 +610,36 @@ func (g *Gauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		return draw.ResizeNeeded(cvs)
 	}
 
+	if !g.opts.indeterminate {
+		g.sweepStart = time.Time{}
+	} else if g.sweepStart.IsZero() {
+		g.sweepStart = g.now()
+	}
+
 	if g.hasBorder() {
+		bOpts := g.borderCellOpts()
 		if err := draw.Border(cvs, cvs.Area(),
 			draw.BorderLineStyle(g.opts.border),
-			draw.BorderTitle(g.opts.borderTitle, draw.OverrunModeThreeDot, g.opts.borderCellOpts...),
+			draw.BorderTitle(g.opts.borderTitle, draw.OverrunModeThreeDot, bOpts...),
 			draw.BorderTitleAlign(g.opts.borderTitleHAlign),
-			draw.BorderCellOpts(g.opts.borderCellOpts...),
+			draw.BorderCellOpts(bOpts...),
 		); err != nil {
 			return err
 		}
 	}
 
-	usable := g.usable(cvs)
-	progress := image.Rect(
-		usable.Min.X,
-		usable.Min.Y,
-		usable.Min.X+g.width(usable, g.current),
-		usable.Max.Y,
-	)
-	if progress.Dx() > 0 {
+	g.lit = nil
+	var progress image.Rectangle
+	if g.segmented() {
+		p, err := g.drawSegments(cvs)
+		if err != nil {
+			return err
+		}
+		progress = p
+	} else if progress = g.progressRect(g.usable(cvs)); !progress.Empty() {
 		if err := draw.Rectangle(cvs, progress,
 			draw.RectChar(g.opts.gaugeChar),
-			draw.RectCellOpts(cell.BgColor(g.opts.color)),
+			draw.RectCellOpts(cell.BgColor(g.fillColor())),
 		); err != nil {
 			return err
 		}
@@ -316,6 +649,9 @@ func (g *Gauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 			return err
 		}
 	}
+	if err := g.drawThresholdMarkers(cvs, progress); err != nil {
+		return err
+	}
 
 	return g.drawText(cvs, progress)
 }
@@ -332,6 +668,17 @@ func (g *Gauge) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
 
 // maxSize determines the maximum size of the canvas.
 func (g *Gauge) maxSize() image.Point {
+	if g.opts.vertical {
+		// The height option limits the thickness of the gauge, which is its
+		// width when vertical.
+		maxWidth := g.opts.height
+		if g.hasBorder() && maxWidth > 0 {
+			// Add the required space for the border.
+			maxWidth += 2
+		}
+		return image.Point{maxWidth, 0}
+	}
+
 	maxHeight := g.opts.height
 	if g.hasBorder() {
 		// Add the required space for the border.
@@ -344,6 +691,14 @@ func (g *Gauge) maxSize() image.Point {
 func (g *Gauge) minSize() image.Point {
 	minWidth := 1  // Shorter gauge than this cannot display anything.
 	minHeight := 1 // At least one line for the gauge itself.
+	if g.segmented() {
+		// All the segments and the gaps between them must fit.
+		if g.opts.vertical {
+			minHeight = g.segmentsLength()
+		} else {
+			minWidth = g.segmentsLength()
+		}
+	}
 	if g.hasBorder() {
 		// Add the required space for the border.
 		minWidth += 2
@@ -356,10 +711,16 @@ func (g *Gauge) minSize() image.Point {
 func (g *Gauge) Options() widgetapi.Options {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+
+	var redraw time.Duration
+	if g.opts.indeterminate {
+		redraw = indeterminateRedrawInterval
+	}
 	return widgetapi.Options{
-		MaximumSize:  g.maxSize(),
-		MinimumSize:  g.minSize(),
-		WantKeyboard: widgetapi.KeyScopeNone,
-		WantMouse:    widgetapi.MouseScopeNone,
+		MaximumSize:    g.maxSize(),
+		MinimumSize:    g.minSize(),
+		WantKeyboard:   widgetapi.KeyScopeNone,
+		WantMouse:      widgetapi.MouseScopeNone,
+		RedrawInterval: redraw,
 	}
 }
@@ -17,7 +17,9 @@ package gauge
 import (
 	"fmt"
 	"image"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
@@ -80,6 +82,41 @@ func TestGauge(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "fails on zero IndeterminateSweep",
+			opts: []Option{
+				Indeterminate(),
+				IndeterminateSweep(0),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative Segments",
+			opts: []Option{
+				Segments(-1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative SegmentGap",
+			opts: []Option{
+				Segments(2),
+				SegmentGap(-1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
 		{
 			desc: "gauge without progress text",
 			opts: []Option{
@@ -277,6 +314,74 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "uses theme colors for options that aren't set",
+			opts: []Option{
+				Char('o'),
+				Border(linestyle.Light),
+			},
+			percent: &percentCall{p: 35},
+			meta: &widgetapi.Meta{
+				Theme: &cell.Theme{
+					Foreground: cell.ColorWhite,
+					Border:     cell.ColorRed,
+					Accent:     cell.ColorBlue,
+				},
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, image.Rect(0, 0, 10, 3),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 1, 3, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustText(c, "35%", image.Point{3, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorWhite)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "options take precedence over the theme",
+			opts: []Option{
+				Char('o'),
+				Border(linestyle.Light, cell.FgColor(cell.ColorYellow)),
+				Color(cell.ColorMagenta),
+				EmptyTextColor(cell.ColorCyan),
+			},
+			percent: &percentCall{p: 35},
+			meta: &widgetapi.Meta{
+				Theme: &cell.Theme{
+					Foreground: cell.ColorWhite,
+					Border:     cell.ColorRed,
+					Accent:     cell.ColorBlue,
+				},
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, image.Rect(0, 0, 10, 3),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testdraw.MustRectangle(c, image.Rect(1, 1, 3, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustText(c, "35%", image.Point{3, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorCyan)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "respects border options",
 			opts: []Option{
@@ -893,6 +998,81 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "vertical gauge fills from the bottom",
+			opts: []Option{
+				Char('o'),
+				Vertical(),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 5, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "vertical gauge draws centered text top to bottom",
+			opts: []Option{
+				Char('o'),
+				Vertical(),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 5, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustVerticalText(c, "50", image.Point{1, 3},
+					draw.VerticalTextCellOpts(cell.FgColor(cell.ColorDefault)),
+				)
+				testdraw.MustText(c, "%", image.Point{1, 5},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "vertical gauge with border and horizontal threshold",
+			opts: []Option{
+				Char('o'),
+				Vertical(),
+				HideTextProgress(),
+				Border(linestyle.Light),
+				Threshold(25, linestyle.Double),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, c.Area())
+				testdraw.MustRectangle(c, image.Rect(1, 5, 2, 9),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustHVLines(c, []draw.HVLine{{
+					Start: image.Point{X: 0, Y: 6},
+					End:   image.Point{X: 2, Y: 6},
+				}}, draw.HVLineStyle(linestyle.Double))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "threshold outside of bounds (>=max)",
 			opts: []Option{
@@ -992,6 +1172,213 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "fails on negative threshold marker",
+			opts: []Option{
+				Thresholds([]ThresholdMarker{
+					{Value: -1},
+				}),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "threshold markers at 0%, 50% and 100%",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Thresholds([]ThresholdMarker{
+					{Value: 0, Color: cell.ColorBlue},
+					{Value: 50, Color: cell.ColorYellow},
+					{Value: 100, Color: cell.ColorRed},
+				}),
+			},
+			percent: &percentCall{p: 30},
+			canvas:  image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '│', cell.FgColor(cell.ColorBlue), cell.BgColor(cell.ColorGreen))
+				testcanvas.MustSetCell(c, image.Point{0, 1}, '│', cell.FgColor(cell.ColorBlue), cell.BgColor(cell.ColorGreen))
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '│', cell.FgColor(cell.ColorYellow))
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '│', cell.FgColor(cell.ColorYellow))
+				testcanvas.MustSetCell(c, image.Point{9, 0}, '│', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{9, 1}, '│', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "threshold markers with border on absolute progress, markers above total aren't drawn",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Border(linestyle.Light),
+				Thresholds([]ThresholdMarker{
+					{Value: 2, Color: cell.ColorYellow},
+					{Value: 5, Color: cell.ColorRed},
+				}),
+			},
+			absolute: &absoluteCall{done: 1, total: 4},
+			canvas:   image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, c.Area())
+				testdraw.MustRectangle(c, image.Rect(1, 1, 3, 2),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '│', cell.FgColor(cell.ColorYellow))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "threshold markers on a vertical gauge",
+			opts: []Option{
+				Char('o'),
+				Vertical(),
+				HideTextProgress(),
+				Thresholds([]ThresholdMarker{
+					{Value: 0, Color: cell.ColorBlue},
+					{Value: 50, Color: cell.ColorYellow},
+					{Value: 100, Color: cell.ColorRed},
+				}),
+			},
+			percent: &percentCall{p: 30},
+			canvas:  image.Rect(0, 0, 2, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 7, 2, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustSetCell(c, image.Point{0, 9}, '─', cell.FgColor(cell.ColorBlue), cell.BgColor(cell.ColorGreen))
+				testcanvas.MustSetCell(c, image.Point{1, 9}, '─', cell.FgColor(cell.ColorBlue), cell.BgColor(cell.ColorGreen))
+				testcanvas.MustSetCell(c, image.Point{0, 4}, '─', cell.FgColor(cell.ColorYellow))
+				testcanvas.MustSetCell(c, image.Point{1, 4}, '─', cell.FgColor(cell.ColorYellow))
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '─', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '─', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "progress text is drawn over threshold markers",
+			opts: []Option{
+				Char('o'),
+				Thresholds([]ThresholdMarker{
+					{Value: 50, Color: cell.ColorYellow},
+				}),
+			},
+			percent: &percentCall{p: 35},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '│', cell.FgColor(cell.ColorYellow))
+				testcanvas.MustSetCell(c, image.Point{5, 2}, '│', cell.FgColor(cell.ColorYellow))
+				// The progress text replaces the marker cell.
+				testdraw.MustText(c, "35%", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fill color unchanged below the thresholds",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Thresholds([]ThresholdMarker{
+					{Value: 80, Color: cell.ColorRed, FillColor: cell.ColorRed},
+					{Value: 50, Color: cell.ColorYellow, FillColor: cell.ColorYellow},
+				}),
+			},
+			percent: &percentCall{p: 40},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 4, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '│', cell.FgColor(cell.ColorYellow))
+				testcanvas.MustSetCell(c, image.Point{8, 0}, '│', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fill color changes once a threshold is crossed",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Thresholds([]ThresholdMarker{
+					{Value: 80, Color: cell.ColorRed, FillColor: cell.ColorRed},
+					{Value: 50, Color: cell.ColorYellow, FillColor: cell.ColorYellow},
+				}),
+			},
+			percent: &percentCall{p: 60},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 6, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '│', cell.FgColor(cell.ColorYellow), cell.BgColor(cell.ColorYellow))
+				testcanvas.MustSetCell(c, image.Point{8, 0}, '│', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fill color of the highest crossed threshold is used",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Thresholds([]ThresholdMarker{
+					{Value: 80, Color: cell.ColorRed, FillColor: cell.ColorRed},
+					{Value: 50, Color: cell.ColorYellow, FillColor: cell.ColorYellow},
+				}),
+			},
+			percent: &percentCall{p: 90},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 9, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '│', cell.FgColor(cell.ColorYellow), cell.BgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{8, 0}, '│', cell.FgColor(cell.ColorRed), cell.BgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1054,6 +1441,170 @@ func TestGauge(t *testing.T) {
 	}
 }
 
+// fakeClock is a clock whose time only changes when advanced.
+type fakeClock struct {
+	t time.Time
+}
+
+// now returns the current time of the clock.
+func (fc *fakeClock) now() time.Time {
+	return fc.t
+}
+
+// filled returns the cells of the canvas along the gauge that have the
+// provided background color, as a string of '#' for the filled cells and '.'
+// for the others.
+func filled(cvs *canvas.Canvas, vertical bool, color cell.Color) string {
+	var b strings.Builder
+	ar := cvs.Area()
+	if vertical {
+		for y := ar.Max.Y - 1; y >= ar.Min.Y; y-- {
+			if testcanvas.MustCell(cvs, image.Point{0, y}).Opts.BgColor == color {
+				b.WriteRune('#')
+			} else {
+				b.WriteRune('.')
+			}
+		}
+		return b.String()
+	}
+	for x := ar.Min.X; x < ar.Max.X; x++ {
+		if testcanvas.MustCell(cvs, image.Point{x, 0}).Opts.BgColor == color {
+			b.WriteRune('#')
+		} else {
+			b.WriteRune('.')
+		}
+	}
+	return b.String()
+}
+
+func TestIndeterminate(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// want is the expected state of the gauge after each of the steps,
+		// vertical gauges are listed from the bottom up.
+		want []string
+	}{
+		{
+			desc:   "block bounces between the ends of a horizontal gauge",
+			opts:   []Option{Indeterminate(), IndeterminateSweep(2 * time.Second)},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: []string{
+				"##........", // 0s
+				"....##....", // 1s
+				"........##", // 2s, reached the end
+				"....##....", // 3s, moving back
+				"##........", // 4s, back at the start
+				"....##....", // 5s, moving forward again
+			},
+		},
+		{
+			desc:   "faster sweep",
+			opts:   []Option{Indeterminate(), IndeterminateSweep(time.Second)},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: []string{
+				"##........",
+				"........##",
+				"##........",
+				"........##",
+				"##........",
+				"........##",
+			},
+		},
+		{
+			desc:   "block moves up on a vertical gauge",
+			opts:   []Option{Indeterminate(), Vertical()},
+			canvas: image.Rect(0, 0, 1, 8),
+			want: []string{
+				"##......",
+				"...##...",
+				"......##",
+				"...##...",
+				"##......",
+				"...##...",
+			},
+		},
+		{
+			desc:   "ignores the progress",
+			opts:   []Option{Indeterminate(), Threshold(20, linestyle.Light)},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: []string{
+				"##........",
+				"....##....",
+				"........##",
+				"....##....",
+				"##........",
+				"....##....",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := g.Percent(50); err != nil {
+				t.Fatalf("Percent => unexpected error: %v", err)
+			}
+			fc := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+			g.now = fc.now
+
+			for i, want := range tc.want {
+				cvs := testcanvas.MustNew(tc.canvas)
+				if err := g.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+				if got := filled(cvs, g.opts.vertical, DefaultColor); got != want {
+					t.Errorf("Draw after %v => gauge %q, want %q", time.Duration(i)*time.Second, got, want)
+				}
+				fc.t = fc.t.Add(time.Second)
+			}
+		})
+	}
+}
+
+func TestDeterminateResetsTheBlock(t *testing.T) {
+	g, err := New(Indeterminate(), HideTextProgress())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	fc := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g.now = fc.now
+
+	cvs := testcanvas.MustNew(image.Rect(0, 0, 10, 1))
+	if err := g.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	fc.t = fc.t.Add(time.Second)
+
+	if err := g.Percent(30, Determinate()); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	cvs = testcanvas.MustNew(image.Rect(0, 0, 10, 1))
+	if err := g.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got, want := filled(cvs, false, DefaultColor), "###......."; got != want {
+		t.Errorf("Draw with Determinate => gauge %q, want %q", got, want)
+	}
+
+	// The block starts from the beginning when the gauge becomes
+	// indeterminate again.
+	if err := g.Percent(30, Indeterminate()); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	cvs = testcanvas.MustNew(image.Rect(0, 0, 10, 1))
+	if err := g.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got, want := filled(cvs, false, DefaultColor), "##........"; got != want {
+		t.Errorf("Draw with Indeterminate => gauge %q, want %q", got, want)
+	}
+}
+
 func TestKeyboard(t *testing.T) {
 	g, err := New()
 	if err != nil {
@@ -1094,6 +1645,160 @@ func TestProgressTypeString(t *testing.T) {
 	}
 }
 
+func TestSegments(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []Option
+		canvas   image.Rectangle
+		percent  int
+		absolute *absoluteCall // if set, used instead of the percent.
+		// want is the expected state of the gauge, '#' are lit segments, 'o'
+		// are empty segments and ' ' are the gaps. Vertical gauges are listed
+		// from the bottom up.
+		want    string
+		wantLit int
+	}{
+		{
+			desc:    "no segments lit at zero",
+			opts:    []Option{Segments(10)},
+			canvas:  image.Rect(0, 0, 19, 1),
+			percent: 0,
+			want:    "o o o o o o o o o o",
+			wantLit: 0,
+		},
+		{
+			desc:    "rounds down to whole segments",
+			opts:    []Option{Segments(10)},
+			canvas:  image.Rect(0, 0, 19, 1),
+			percent: 34,
+			want:    "# # # o o o o o o o",
+			wantLit: 3,
+		},
+		{
+			desc:    "rounds up to whole segments",
+			opts:    []Option{Segments(10)},
+			canvas:  image.Rect(0, 0, 19, 1),
+			percent: 37,
+			want:    "# # # # o o o o o o",
+			wantLit: 4,
+		},
+		{
+			desc:    "half a segment lights it",
+			opts:    []Option{Segments(10)},
+			canvas:  image.Rect(0, 0, 19, 1),
+			percent: 5,
+			want:    "# o o o o o o o o o",
+			wantLit: 1,
+		},
+		{
+			desc:    "less than half a segment doesn't light it",
+			opts:    []Option{Segments(10)},
+			canvas:  image.Rect(0, 0, 19, 1),
+			percent: 4,
+			want:    "o o o o o o o o o o",
+			wantLit: 0,
+		},
+		{
+			desc:    "all segments lit at 100%",
+			opts:    []Option{Segments(10)},
+			canvas:  image.Rect(0, 0, 19, 1),
+			percent: 100,
+			want:    "# # # # # # # # # #",
+			wantLit: 10,
+		},
+		{
+			desc:    "remaining cells are added to the first segments",
+			opts:    []Option{Segments(3)},
+			canvas:  image.Rect(0, 0, 10, 1),
+			percent: 50,
+			want:    "### ### oo",
+			wantLit: 2,
+		},
+		{
+			desc:    "custom gap",
+			opts:    []Option{Segments(3), SegmentGap(2)},
+			canvas:  image.Rect(0, 0, 10, 1),
+			percent: 33,
+			want:    "##  oo  oo",
+			wantLit: 1,
+		},
+		{
+			desc:    "segments without gaps",
+			opts:    []Option{Segments(5), SegmentGap(0)},
+			canvas:  image.Rect(0, 0, 10, 1),
+			percent: 30,
+			want:    "####oooooo",
+			wantLit: 2,
+		},
+		{
+			desc:     "absolute progress",
+			opts:     []Option{Segments(4)},
+			canvas:   image.Rect(0, 0, 7, 1),
+			absolute: &absoluteCall{done: 5, total: 7},
+			want:     "# # # o",
+			wantLit:  3,
+		},
+		{
+			desc:    "vertical gauge lights segments from the bottom up",
+			opts:    []Option{Segments(3), SegmentGap(0), Vertical()},
+			canvas:  image.Rect(0, 0, 1, 5),
+			percent: 67,
+			want:    "####o",
+			wantLit: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := New(append([]Option{HideTextProgress()}, tc.opts...)...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.absolute != nil {
+				if err := g.Absolute(tc.absolute.done, tc.absolute.total); err != nil {
+					t.Fatalf("Absolute => unexpected error: %v", err)
+				}
+			} else if err := g.Percent(tc.percent); err != nil {
+				t.Fatalf("Percent => unexpected error: %v", err)
+			}
+
+			cvs := testcanvas.MustNew(tc.canvas)
+			if err := g.Draw(cvs, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			var points []image.Point
+			ar := cvs.Area()
+			if g.opts.vertical {
+				for y := ar.Max.Y - 1; y >= ar.Min.Y; y-- {
+					points = append(points, image.Point{0, y})
+				}
+			} else {
+				for x := ar.Min.X; x < ar.Max.X; x++ {
+					points = append(points, image.Point{x, 0})
+				}
+			}
+			var b strings.Builder
+			for _, p := range points {
+				switch testcanvas.MustCell(cvs, p).Opts.BgColor {
+				case DefaultColor:
+					b.WriteRune('#')
+				case cell.ColorNumber(DefaultEmptySegmentColorNumber):
+					b.WriteRune('o')
+				default:
+					b.WriteRune(' ')
+				}
+			}
+			if got := b.String(); got != tc.want {
+				t.Errorf("Draw => gauge %q, want %q", got, tc.want)
+			}
+			if got := g.litSegments(); got != tc.wantLit {
+				t.Errorf("litSegments => %d, want %d", got, tc.wantLit)
+			}
+		})
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1121,6 +1826,46 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "maximum width is limited when height is specified on a vertical gauge",
+			opts: []Option{
+				Height(2),
+				Vertical(),
+			},
+			want: widgetapi.Options{
+				MaximumSize:  image.Point{2, 0},
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "minimum width fits all the segments and gaps",
+			opts: []Option{
+				Segments(10),
+			},
+			want: widgetapi.Options{
+				MaximumSize:  image.Point{0, 0},
+				MinimumSize:  image.Point{19, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "minimum height fits all the segments and gaps on a vertical gauge",
+			opts: []Option{
+				Segments(4),
+				SegmentGap(2),
+				Vertical(),
+				Border(linestyle.Light),
+			},
+			want: widgetapi.Options{
+				MaximumSize:  image.Point{0, 0},
+				MinimumSize:  image.Point{3, 12},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
 		{
 			desc: "border is accounted for in maximum and minimum size",
 			opts: []Option{
@@ -1134,6 +1879,19 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "requests frequent redraws when indeterminate",
+			opts: []Option{
+				Indeterminate(),
+			},
+			want: widgetapi.Options{
+				MaximumSize:    image.Point{0, 0},
+				MinimumSize:    image.Point{1, 1},
+				WantKeyboard:   widgetapi.KeyScopeNone,
+				WantMouse:      widgetapi.MouseScopeNone,
+				RedrawInterval: indeterminateRedrawInterval,
+			},
+		},
 	}
 
 	for _, tc := range tests {
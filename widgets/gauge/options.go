@@ -18,6 +18,7 @@ package gauge
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
@@ -42,15 +43,31 @@ type options struct {
 	color            cell.Color
 	filledTextColor  cell.Color
 	emptyTextColor   cell.Color
+	// Indicate if the colors were set by the options, colors that weren't
+	// set are taken from the theme if there is one.
+	colorSet          bool
+	emptyTextColorSet bool
 	// If set, draws a border around the gauge.
 	border            linestyle.LineStyle
 	borderCellOpts    []cell.Option
 	borderTitle       string
 	borderTitleHAlign align.Horizontal
+	// If set, the gauge fills from the bottom up.
+	vertical bool
 	// If set draws a vertical line representing the threshold.
 	threshold          int
 	thresholdCellOpts  []cell.Option
 	thresholdLineStyle linestyle.LineStyle
+	// thresholdMarkers are drawn as markers across the gauge.
+	thresholdMarkers []ThresholdMarker
+	// If set, the gauge animates a block instead of displaying the progress.
+	indeterminate bool
+	// indeterminateSweep is the time it takes the block to cross the gauge.
+	indeterminateSweep time.Duration
+	// If set, the gauge is divided into this many segments.
+	segments          int
+	segmentGap        int
+	emptySegmentColor cell.Color
 }
 
 // newOptions returns options with the default values set.
@@ -62,6 +79,10 @@ func newOptions() *options {
 		color:           DefaultColor,
 		filledTextColor: DefaultFilledTextColor,
 		emptyTextColor:  DefaultEmptyTextColor,
+
+		indeterminateSweep: DefaultIndeterminateSweep,
+		segmentGap:         DefaultSegmentGap,
+		emptySegmentColor:  cell.ColorNumber(DefaultEmptySegmentColorNumber),
 	}
 }
 
@@ -73,6 +94,20 @@ func (o *options) validate() error {
 	if got, min := o.threshold, 0; got < min {
 		return fmt.Errorf("invalid Threshold %d, must be %d <= Threshold", got, min)
 	}
+	for i, tm := range o.thresholdMarkers {
+		if got, min := tm.Value, 0; got < min {
+			return fmt.Errorf("invalid Thresholds[%d].Value %d, must be %d <= Value", i, got, min)
+		}
+	}
+	if got := o.indeterminateSweep; got <= 0 {
+		return fmt.Errorf("invalid IndeterminateSweep %v, must be positive", got)
+	}
+	if got, min := o.segments, 0; got < min {
+		return fmt.Errorf("invalid Segments %d, must be %d <= Segments", got, min)
+	}
+	if got, min := o.segmentGap, 0; got < min {
+		return fmt.Errorf("invalid SegmentGap %d, must be %d <= SegmentGap", got, min)
+	}
 	return nil
 }
 
@@ -115,6 +150,8 @@ func HideTextProgress() Option {
 
 // Height sets the height of the drawn Gauge. Must be a positive number.
 // Defaults to zero which means the height of the container.
+// If the Vertical option is provided, this sets the width of the Gauge
+// instead.
 func Height(height int) Option {
 	return option(func(opts *options) {
 		opts.height = height
@@ -134,9 +171,12 @@ func TextLabel(text string) Option {
 const DefaultColor = cell.ColorGreen
 
 // Color sets the color of the gauge.
+// Defaults to the Accent color of the theme applied to the container if there
+// is one, or to DefaultColor otherwise.
 func Color(c cell.Color) Option {
 	return option(func(opts *options) {
 		opts.color = c
+		opts.colorSet = true
 	})
 }
 
@@ -158,9 +198,12 @@ const DefaultEmptyTextColor = cell.ColorDefault
 // EmptyTextColor sets color of the text progress and text label for the
 // portion of the text that falls outside the filled up part of the Gauge. I.e.
 // text in the empty area the Gauge didn't fill yet.
+// Defaults to the Foreground color of the theme applied to the container if
+// there is one, or to DefaultEmptyTextColor otherwise.
 func EmptyTextColor(c cell.Color) Option {
 	return option(func(opts *options) {
 		opts.emptyTextColor = c
+		opts.emptyTextColorSet = true
 	})
 }
 
@@ -187,6 +230,8 @@ func VerticalTextAlign(v align.Vertical) Option {
 }
 
 // Border configures the gauge to have a border of the specified style.
+// Without any cell options, the border uses the Border color of the theme
+// applied to the container if there is one.
 func Border(ls linestyle.LineStyle, cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.border = ls
@@ -209,12 +254,23 @@ func BorderTitleAlign(h align.Horizontal) Option {
 	})
 }
 
+// Vertical configures the Gauge to fill from the bottom to the top of its
+// container instead of from the left to the right. The progress text and
+// text label flow from the top to the bottom and the threshold line is drawn
+// horizontally.
+func Vertical() Option {
+	return option(func(opts *options) {
+		opts.vertical = true
+	})
+}
+
 // Threshold configures the Gauge to display a vertical threshold line at value
 // t. If the progress is set by a call to Percent(), t represents a percentage,
 // e.g. "40" means line is displayed at 40%. If the progress is set by a call to
 // Absolute(), the threshold is considered an absolute number.
 // Threshold must be positive to be displayed. If the threshold is zero or
 // greater than total, it won't be displayed. Defaults to zero.
+// The line is horizontal if the Vertical option is provided.
 func Threshold(t int, ls linestyle.LineStyle, cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.threshold = t
@@ -222,3 +278,117 @@ func Threshold(t int, ls linestyle.LineStyle, cOpts ...cell.Option) Option {
 		opts.thresholdCellOpts = cOpts
 	})
 }
+
+// ThresholdMarker is a threshold displayed as a marker on the gauge, see the
+// Thresholds option.
+type ThresholdMarker struct {
+	// Value is the position of the marker. Like the value of the Threshold
+	// option, this is a percentage if the progress is set by a call to
+	// Percent() or an absolute number if the progress is set by a call to
+	// Absolute(). Must be zero or positive, markers with a value greater than
+	// the total aren't displayed.
+	Value int
+
+	// Color is the color of the marker.
+	Color cell.Color
+
+	// FillColor if set replaces the color of the filled part of the gauge
+	// once the progress reaches Value. When the progress reaches multiple
+	// thresholds, the fill color of the one with the highest value is used.
+	// Leave as cell.ColorDefault to keep the color of the gauge.
+	FillColor cell.Color
+}
+
+// Thresholds configures the Gauge to display markers at the provided
+// thresholds, e.g. to indicate warning and critical levels. Each marker is a
+// line across the gauge, vertical on horizontal gauges and horizontal if the
+// Vertical option is provided. The progress text and the text label are drawn
+// over the markers. Markers at the total are drawn on the last cell of the
+// gauge.
+// Defaults to no markers. The markers don't interact with the Threshold
+// option, both can be used at the same time.
+func Thresholds(markers []ThresholdMarker) Option {
+	return option(func(opts *options) {
+		// Copy to avoid external modifications.
+		opts.thresholdMarkers = make([]ThresholdMarker, len(markers))
+		copy(opts.thresholdMarkers, markers)
+	})
+}
+
+// Indeterminate configures the Gauge to indicate activity of an operation
+// whose progress isn't known. Instead of the progress, the gauge displays a
+// block that moves back and forth across it. The block takes a quarter of the
+// gauge, its position is determined by the time elapsed since the gauge was
+// first drawn in this mode. The gauge requests frequent redraws in this mode
+// so that the block moves smoothly.
+//
+// The progress provided via Percent() or Absolute(), its text and the
+// thresholds aren't displayed in this mode, the text label is.
+// See also the IndeterminateSweep and Determinate options.
+func Indeterminate() Option {
+	return option(func(opts *options) {
+		opts.indeterminate = true
+	})
+}
+
+// Determinate configures the Gauge to display the progress provided via
+// Percent() or Absolute(). This is the default behavior, use this option to
+// revert the Indeterminate option once the progress is known.
+func Determinate() Option {
+	return option(func(opts *options) {
+		opts.indeterminate = false
+	})
+}
+
+// DefaultIndeterminateSweep is the default value for the IndeterminateSweep
+// option.
+const DefaultIndeterminateSweep = 2 * time.Second
+
+// IndeterminateSweep sets the speed of the block displayed with the
+// Indeterminate option, this is the time it takes the block to move from one
+// end of the gauge to the other. Must be positive.
+// Defaults to DefaultIndeterminateSweep.
+func IndeterminateSweep(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.indeterminateSweep = d
+	})
+}
+
+// Segments divides the gauge into the specified number of equally sized
+// segments separated by gaps, see SegmentGap. Instead of a continuous fill,
+// the gauge lights whole segments, the number of lit segments is the progress
+// rounded to whole segments. E.g. a gauge with ten segments lights four of
+// them at 37%.
+// The gauge requires enough space to display all the segments and the gaps.
+// Not used with the Indeterminate option.
+// Defaults to zero which means a continuous gauge.
+func Segments(n int) Option {
+	return option(func(opts *options) {
+		opts.segments = n
+	})
+}
+
+// DefaultSegmentGap is the default value for the SegmentGap option.
+const DefaultSegmentGap = 1
+
+// SegmentGap sets the number of cells between the segments of a gauge
+// configured with the Segments option. Must be zero or a positive number.
+// Defaults to DefaultSegmentGap.
+func SegmentGap(cells int) Option {
+	return option(func(opts *options) {
+		opts.segmentGap = cells
+	})
+}
+
+// DefaultEmptySegmentColorNumber is the default color number for the
+// EmptySegmentColor option.
+const DefaultEmptySegmentColorNumber = 236
+
+// EmptySegmentColor sets the color of the segments that aren't lit on a gauge
+// configured with the Segments option.
+// Defaults to DefaultEmptySegmentColorNumber.
+func EmptySegmentColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.emptySegmentColor = c
+	})
+}
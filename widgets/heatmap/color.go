@@ -0,0 +1,59 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+// color.go contains code that interpolates colors of the cells.
+
+import (
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// interpolate returns the value at the relative position frac between from
+// and to.
+func interpolate(from, to int, frac float64) int {
+	return int(math.Round(float64(from) + float64(to-from)*frac))
+}
+
+// gradientColor returns the color at the relative position pos (0 to 1) of
+// the gradient defined by the evenly distributed stops. Colors between the
+// stops are true colors, terminals that don't display true colors replace them
+// with the nearest color supported by their color mode.
+func gradientColor(stops []cell.Color, pos float64) cell.Color {
+	switch {
+	case len(stops) == 0:
+		return cell.ColorDefault
+	case pos <= 0 || len(stops) == 1:
+		return stops[0]
+	case pos >= 1:
+		return stops[len(stops)-1]
+	}
+
+	segs := float64(len(stops) - 1)
+	i := int(pos * segs)
+	frac := pos*segs - float64(i)
+	if frac == 0 {
+		return stops[i]
+	}
+
+	fr, fg, fb := stops[i].RGB()
+	tr, tg, tb := stops[i+1].RGB()
+	return cell.ColorRGB24(
+		interpolate(fr, tr, frac),
+		interpolate(fg, tg, frac),
+		interpolate(fb, tb, frac),
+	)
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/heatmap/internal/axes"
@@ -34,12 +35,16 @@ import (
 // HeatMap draws heat map charts.
 //
 // Heatmap consists of several cells. Each cell represents a value.
-// The larger the value, the darker the color of the cell (from white to black).
+// By default, the larger the value, the darker the color of the cell (from
+// white to black). The colors can be changed with the ColorScale and
+// ColorStops options. Missing values are represented as math.NaN, their cells
+// are drawn in the color set by the MissingColor option.
 //
 // The two dimensions of the values (cells) array are determined by the length of
 // the xLabels and yLabels arrays respectively.
 //
-// HeatMap does not support mouse based zoom.
+// HeatMap does not support mouse based zoom. When the HoverTooltip option is
+// provided, the value under the mouse cursor is displayed in a tooltip.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type HeatMap struct {
@@ -60,6 +65,10 @@ type HeatMap struct {
 	// lastWidth is the height of the canvas as of the last time when Draw was called.
 	lastHeight int
 
+	// hover is the last known position of the mouse cursor on the canvas.
+	// Set to image.Point{-1, -1} when the mouse isn't over the widget.
+	hover image.Point
+
 	// opts are the provided options.
 	opts *options
 
@@ -74,7 +83,8 @@ func New(opts ...Option) (*HeatMap, error) {
 		return nil, err
 	}
 	return &HeatMap{
-		opts: opt,
+		opts:  opt,
+		hover: image.Point{-1, -1},
 	}, nil
 }
 
@@ -84,6 +94,8 @@ func New(opts ...Option) (*HeatMap, error) {
 // len(yLabels) == len(values) and len(xLabels) == len(values[i]).
 // But labels could be empty strings.
 // When no labels are provided, labels will be "0", "1", "2"...
+// Cells without a value should be represented as math.NaN values, these don't
+// affect the min and max values the colors are scaled to.
 //
 // Each call to Values overwrites any previously provided values.
 // Provided options override values set when New() was called.
@@ -161,7 +173,7 @@ func (hp *HeatMap) ValueCapacity() (rows, cols int) {
 		return 0, 0
 	}
 
-	rows = hp.lastHeight - 1
+	rows = (hp.lastHeight - 1) / hp.opts.cellHeight
 	var cw int
 
 	if hp.opts.cellWidth > minCellWidth {
@@ -177,7 +189,7 @@ func (hp *HeatMap) ValueCapacity() (rows, cols int) {
 func (hp *HeatMap) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDetails, error) {
 	hp.cellWidthAdaptive(cvs)
 
-	yd, err := axes.NewYDetails(hp.yLabels)
+	yd, err := axes.NewYDetails(hp.yLabels, hp.opts.cellHeight)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -216,22 +228,29 @@ func (hp *HeatMap) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		return err
 	}
 
-	return hp.drawLabels(cvs, xd, yd)
+	if err := hp.drawLabels(cvs, xd, yd); err != nil {
+		return err
+	}
+	return hp.drawTooltip(cvs, yd)
 }
 
 // drawCells draws m*n cells (rectangles) representing the stored values.
-// The height of each cell is 1 and the default width is 3.
+// The height of each cell is set by the CellHeight option and the minimum
+// width is 3.
 func (hp *HeatMap) drawCells(cvs *canvas.Canvas, yd *axes.YDetails) error {
 	for i := 0; i < len(hp.values); i++ {
 		for j := 0; j < len(hp.values[0]); j++ {
 			startX := yd.Start.X + axes.AxisWidth + j*hp.opts.cellWidth
-			startY := yd.Labels[i].Pos.Y
+			startY := yd.Start.Y + i*hp.opts.cellHeight
 
 			endX := startX + hp.opts.cellWidth
-			endY := startY + 1
+			endY := startY + hp.opts.cellHeight
 
 			rect := image.Rect(startX, startY, endX, endY)
-			color := hp.getCellColor(hp.values[i][j])
+			color := hp.opts.missingColor
+			if v := hp.values[i][j]; !math.IsNaN(v) {
+				color = hp.getCellColor(v)
+			}
 
 			if err := cvs.SetAreaCells(rect, hp.opts.cellChar, cell.BgColor(color)); err != nil {
 				return err
@@ -264,6 +283,91 @@ func (hp *HeatMap) drawLabels(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YD
 	return nil
 }
 
+// cellAt returns the row and the column of the value whose cell contains the
+// provided point on the canvas. Returns false if there is no such cell.
+func (hp *HeatMap) cellAt(p image.Point, yd *axes.YDetails) (row, col int, ok bool) {
+	startX := yd.Start.X + axes.AxisWidth
+	if len(hp.values) == 0 || p.X < startX || hp.opts.cellWidth <= 0 {
+		return 0, 0, false
+	}
+	col = (p.X - startX) / hp.opts.cellWidth
+	if col >= len(hp.values[0]) {
+		return 0, 0, false
+	}
+	if p.Y < yd.Start.Y {
+		return 0, 0, false
+	}
+	row = (p.Y - yd.Start.Y) / hp.opts.cellHeight
+	if row >= len(hp.values) {
+		return 0, 0, false
+	}
+	return row, col, true
+}
+
+// tooltipText returns the text of the tooltip for the specified value.
+func (hp *HeatMap) tooltipText(row, col int) string {
+	var xl, yl string
+	if col < len(hp.xLabels) {
+		xl = hp.xLabels[col]
+	}
+	if row < len(hp.yLabels) {
+		yl = hp.yLabels[row]
+	}
+	if v := hp.values[row][col]; !math.IsNaN(v) {
+		return fmt.Sprintf("%s,%s: %v", xl, yl, v)
+	}
+	return fmt.Sprintf("%s,%s: no data", xl, yl)
+}
+
+// tooltipStart returns the starting point of a tooltip of the specified
+// width displayed for the mouse cursor at point p. The tooltip is placed
+// above and to the right of the cursor, but it is moved to remain within the
+// canvas.
+func tooltipStart(cvsAr image.Rectangle, p image.Point, width int) image.Point {
+	x := p.X + 1
+	if x+width > cvsAr.Max.X {
+		x = cvsAr.Max.X - width
+	}
+	if x < cvsAr.Min.X {
+		x = cvsAr.Min.X
+	}
+
+	y := p.Y - 1
+	if y < cvsAr.Min.Y {
+		y = p.Y + 1
+	}
+	if y >= cvsAr.Max.Y {
+		y = cvsAr.Max.Y - 1
+	}
+	return image.Point{x, y}
+}
+
+// drawTooltip draws the tooltip for the cell under the mouse cursor, if any.
+func (hp *HeatMap) drawTooltip(cvs *canvas.Canvas, yd *axes.YDetails) error {
+	if !hp.opts.hoverTooltip {
+		return nil
+	}
+	row, col, ok := hp.cellAt(hp.hover, yd)
+	if !ok {
+		return nil
+	}
+
+	text := hp.tooltipText(row, col)
+	width := runewidth.StringWidth(text)
+	if max := cvs.Area().Dx(); width > max {
+		width = max
+	}
+	start := tooltipStart(cvs.Area(), hp.hover, width)
+	if err := draw.Text(cvs, text, start,
+		draw.TextCellOpts(hp.opts.tooltipCellOpts...),
+		draw.TextMaxX(start.X+width),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	); err != nil {
+		return fmt.Errorf("failed to draw the tooltip: %v", err)
+	}
+	return nil
+}
+
 // minCellWidth is the minimum width of each cell in the heat map.
 const minCellWidth = 3
 
@@ -295,8 +399,8 @@ func (hp *HeatMap) minSize() image.Point {
 
 	// For the height:
 	// - 1 unit height for labels on the X axis.
-	// - n unit height for the graph.
-	reqHeight := 1 + len(hp.values)
+	// - n times the cell height for the graph.
+	reqHeight := 1 + len(hp.values)*hp.opts.cellHeight
 
 	return image.Point{X: reqWidth, Y: reqHeight}
 }
@@ -306,27 +410,50 @@ func (*HeatMap) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) err
 	return errors.New("the HeatMap widget doesn't support keyboard events")
 }
 
-// Mouse input isn't supported on the HeatMap widget.
-func (*HeatMap) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
-	return errors.New("the HeatMap widget doesn't support mouse events")
+// Mouse tracks the position of the mouse cursor for the HoverTooltip option.
+// Implements widgetapi.Widget.Mouse.
+func (hp *HeatMap) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	if !hp.opts.hoverTooltip {
+		return errors.New("the HeatMap widget doesn't support mouse events without the HoverTooltip option")
+	}
+	hp.hover = m.Position
+	return nil
 }
 
 // Options implements widgetapi.Widget.Options.
 func (hp *HeatMap) Options() widgetapi.Options {
 	hp.mu.Lock()
 	defer hp.mu.Unlock()
-	return widgetapi.Options{}
+
+	var wantMouse widgetapi.MouseScope
+	if hp.opts.hoverTooltip {
+		// Global scope so that the widget learns when the mouse leaves it.
+		wantMouse = widgetapi.MouseScopeGlobal
+	}
+	return widgetapi.Options{
+		WantMouse: wantMouse,
+	}
 }
 
 // getCellColor returns the color of the cell according to its value.
-// The larger the value, the darker the color.
-// The color range is in Xterm color, from 232 to 255.
+// Without the ColorStops option, the larger the value, the darker the color.
+// The default color range is in Xterm color, from 232 to 255.
 // Refer to https://jonasjacek.github.io/colors/.
 func (hp *HeatMap) getCellColor(value float64) cell.Color {
-	const colorNum = 23
 	scale := hp.maxValue - hp.minValue
-	rgb := int(255 - ((value - hp.minValue) / scale * colorNum))
-	return cell.ColorNumber(rgb)
+	var pos float64 // Relative position of the value in the range, 0 to 1.
+	if scale > 0 {
+		pos = (value - hp.minValue) / scale
+	}
+
+	if len(hp.opts.colorStops) == 0 {
+		const colorNum = 23
+		return cell.ColorNumber(int(255 - pos*colorNum))
+	}
+	return gradientColor(hp.opts.colorStops, pos)
 }
 
 // initLabels return initial labels, like '0', '1', '2', ...
@@ -339,12 +466,16 @@ func initLabels(l int) []string {
 }
 
 // minMax returns the min and max values in given integer array.
+// Missing values, i.e. math.NaN, are ignored.
 func minMax(values [][]float64) (min, max float64) {
 	min = math.MaxFloat64
 	max = math.SmallestNonzeroFloat64
 
 	for i := 0; i < len(values); i++ {
 		for j := 0; j < len(values[i]); j++ {
+			if math.IsNaN(values[i][j]) {
+				continue
+			}
 			min = math.Min(min, values[i][j])
 			max = math.Max(max, values[i][j])
 		}
@@ -15,8 +15,18 @@
 package heatmap
 
 import (
+	"image"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
 )
 
 func Test_initLabels(t *testing.T) {
@@ -46,3 +56,396 @@ func Test_initLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestNewValidatesColorStops(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			desc: "no color options",
+		},
+		{
+			desc: "ColorScale",
+			opts: []Option{ColorScale(cell.ColorBlue, cell.ColorRed)},
+		},
+		{
+			desc: "ColorStops with three colors",
+			opts: []Option{ColorStops(cell.ColorBlue, cell.ColorGreen, cell.ColorRed)},
+		},
+		{
+			desc:    "fails on ColorStops with one color",
+			opts:    []Option{ColorStops(cell.ColorBlue)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on ColorStops without colors",
+			opts:    []Option{ColorStops()},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetCellColor(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		values [][]float64
+		value  float64
+		want   cell.Color
+	}{
+		{
+			desc:   "default grayscale, smallest value",
+			values: [][]float64{{0, 10}},
+			value:  0,
+			want:   cell.ColorNumber(255),
+		},
+		{
+			desc:   "default grayscale, largest value",
+			values: [][]float64{{0, 10}},
+			value:  10,
+			want:   cell.ColorNumber(232),
+		},
+		{
+			desc:   "default grayscale, all values equal",
+			values: [][]float64{{5, 5}},
+			value:  5,
+			want:   cell.ColorNumber(255),
+		},
+		{
+			desc:   "ColorScale, smallest value gets the low color",
+			opts:   []Option{ColorScale(cell.ColorBlue, cell.ColorRed)},
+			values: [][]float64{{0, 10}},
+			value:  0,
+			want:   cell.ColorBlue,
+		},
+		{
+			desc:   "ColorScale, largest value gets the high color",
+			opts:   []Option{ColorScale(cell.ColorBlue, cell.ColorRed)},
+			values: [][]float64{{0, 10}},
+			value:  10,
+			want:   cell.ColorRed,
+		},
+		{
+			desc:   "ColorScale, middle value is interpolated",
+			opts:   []Option{ColorScale(cell.ColorRGB6(0, 0, 0), cell.ColorRGB6(0, 0, 5))},
+			values: [][]float64{{0, 10}},
+			value:  5,
+			want:   cell.ColorRGB24(0, 0, 128),
+		},
+		{
+			desc:   "ColorScale, interpolates grays",
+			opts:   []Option{ColorScale(cell.ColorNumber(232), cell.ColorNumber(252))},
+			values: [][]float64{{0, 10}},
+			value:  5,
+			want:   cell.ColorRGB24(108, 108, 108),
+		},
+		{
+			desc:   "ColorScale, all values equal gets the low color",
+			opts:   []Option{ColorScale(cell.ColorBlue, cell.ColorRed)},
+			values: [][]float64{{5, 5}},
+			value:  5,
+			want:   cell.ColorBlue,
+		},
+		{
+			desc:   "ColorStops, value on the middle stop",
+			opts:   []Option{ColorStops(cell.ColorBlue, cell.ColorGreen, cell.ColorRed)},
+			values: [][]float64{{0, 10}},
+			value:  5,
+			want:   cell.ColorGreen,
+		},
+		{
+			desc:   "ColorStops, value between the last two stops",
+			opts:   []Option{ColorStops(cell.ColorRGB6(0, 0, 0), cell.ColorRGB6(0, 0, 5), cell.ColorRGB6(0, 5, 5))},
+			values: [][]float64{{0, 10}},
+			value:  7.5,
+			want:   cell.ColorRGB24(0, 128, 255),
+		},
+		{
+			desc:   "missing values don't affect the smallest value",
+			values: [][]float64{{math.NaN(), 2, 10}},
+			value:  2,
+			want:   cell.ColorNumber(255),
+		},
+		{
+			desc:   "missing values don't affect the largest value",
+			values: [][]float64{{0, 10, math.NaN()}},
+			value:  10,
+			want:   cell.ColorNumber(232),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hp, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := hp.Values(nil, nil, tc.values); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+			if got := hp.getCellColor(tc.value); got != tc.want {
+				t.Errorf("getCellColor(%v) => %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTooltipStart(t *testing.T) {
+	tests := []struct {
+		desc  string
+		cvsAr image.Rectangle
+		p     image.Point
+		width int
+		want  image.Point
+	}{
+		{
+			desc:  "above and to the right of the cursor",
+			cvsAr: image.Rect(0, 0, 10, 5),
+			p:     image.Point{2, 2},
+			width: 3,
+			want:  image.Point{3, 1},
+		},
+		{
+			desc:  "below the cursor on the first row",
+			cvsAr: image.Rect(0, 0, 10, 5),
+			p:     image.Point{2, 0},
+			width: 3,
+			want:  image.Point{3, 1},
+		},
+		{
+			desc:  "clamped to the right edge",
+			cvsAr: image.Rect(0, 0, 10, 5),
+			p:     image.Point{8, 2},
+			width: 4,
+			want:  image.Point{6, 1},
+		},
+		{
+			desc:  "clamped to the left edge when wider than the canvas",
+			cvsAr: image.Rect(0, 0, 10, 5),
+			p:     image.Point{8, 2},
+			width: 12,
+			want:  image.Point{0, 1},
+		},
+		{
+			desc:  "clamped to the bottom edge on a single row canvas",
+			cvsAr: image.Rect(0, 0, 10, 1),
+			p:     image.Point{0, 0},
+			width: 3,
+			want:  image.Point{1, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tooltipStart(tc.cvsAr, tc.p, tc.width); got != tc.want {
+				t.Errorf("tooltipStart => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// readRow returns the text on the specified row of the canvas.
+func readRow(t *testing.T, cvs *canvas.Canvas, y int) string {
+	t.Helper()
+	var b strings.Builder
+	for x := 0; x < cvs.Area().Dx(); x++ {
+		c := testcanvas.MustCell(cvs, image.Point{x, y})
+		if c.Rune == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(c.Rune)
+	}
+	return b.String()
+}
+
+func TestCellHeight(t *testing.T) {
+	hp, err := New(CellHeight(2))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := hp.Values([]string{"a", "b"}, []string{"x", "y"}, [][]float64{{1, 2}, {3, 4}}); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+
+	// One column for the Y labels, one for the axis and two 4-wide cells.
+	cvs := testcanvas.MustNew(image.Rect(0, 0, 10, 5))
+	if err := hp.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	wantRows := []string{
+		"x         ",
+		"          ",
+		"y         ",
+		"          ",
+		"   a   b  ",
+	}
+	for y, want := range wantRows {
+		if got := readRow(t, cvs, y); got != want {
+			t.Errorf("Draw => row %d is %q, want %q", y, got, want)
+		}
+	}
+
+	cells := []struct {
+		ar    image.Rectangle
+		value float64
+	}{
+		{image.Rect(2, 0, 6, 2), 1},
+		{image.Rect(6, 0, 10, 2), 2},
+		{image.Rect(2, 2, 6, 4), 3},
+		{image.Rect(6, 2, 10, 4), 4},
+	}
+	for _, c := range cells {
+		want := hp.getCellColor(c.value)
+		for y := c.ar.Min.Y; y < c.ar.Max.Y; y++ {
+			for x := c.ar.Min.X; x < c.ar.Max.X; x++ {
+				p := image.Point{x, y}
+				if got := testcanvas.MustCell(cvs, p).Opts.BgColor; got != want {
+					t.Errorf("Draw => cell %v has background %v, want %v", p, got, want)
+				}
+			}
+		}
+	}
+
+	if gotRows, gotCols := hp.ValueCapacity(); gotRows != 2 || gotCols != 2 {
+		t.Errorf("ValueCapacity => (%d, %d), want (2, 2)", gotRows, gotCols)
+	}
+
+	small := testcanvas.MustNew(image.Rect(0, 0, 10, 4))
+	if err := hp.Draw(small, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got := readRow(t, small, 0); !strings.Contains(got, "⇄") {
+		t.Errorf("Draw on a canvas shorter than the cells => row 0 is %q, want the resize indicator", got)
+	}
+}
+
+func TestMissingValues(t *testing.T) {
+	hp, err := New(MissingColor(cell.ColorRed))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := hp.Values([]string{"a", "b"}, []string{"x", "y"}, [][]float64{{1, math.NaN()}, {math.NaN(), 4}}); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+
+	cvs := testcanvas.MustNew(image.Rect(0, 0, 10, 3))
+	if err := hp.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	cells := []struct {
+		ar   image.Rectangle
+		want cell.Color
+	}{
+		// The smallest and the largest of the present values.
+		{image.Rect(2, 0, 6, 1), cell.ColorNumber(255)},
+		{image.Rect(6, 1, 10, 2), cell.ColorNumber(232)},
+		// The missing values.
+		{image.Rect(6, 0, 10, 1), cell.ColorRed},
+		{image.Rect(2, 1, 6, 2), cell.ColorRed},
+	}
+	for _, c := range cells {
+		for x := c.ar.Min.X; x < c.ar.Max.X; x++ {
+			p := image.Point{x, c.ar.Min.Y}
+			if got := testcanvas.MustCell(cvs, p).Opts.BgColor; got != c.want {
+				t.Errorf("Draw => cell %v has background %v, want %v", p, got, c.want)
+			}
+		}
+	}
+
+	if got, want := hp.tooltipText(0, 1), "b,x: no data"; got != want {
+		t.Errorf("tooltipText(0, 1) => %q, want %q", got, want)
+	}
+}
+
+func TestNewValidatesCellHeight(t *testing.T) {
+	if _, err := New(CellHeight(0)); err == nil {
+		t.Errorf("New(CellHeight(0)) => got nil error, want an error")
+	}
+}
+
+func TestHoverTooltip(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		mouse   *terminalapi.Mouse
+		wantRow int
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:    "mouse events fail without the HoverTooltip option",
+			mouse:   &terminalapi.Mouse{Position: image.Point{3, 1}},
+			wantErr: true,
+		},
+		{
+			desc:    "draws tooltip above the cell under the cursor",
+			opts:    []Option{HoverTooltip()},
+			mouse:   &terminalapi.Mouse{Position: image.Point{5, 1}, Button: mouse.ButtonRelease},
+			wantRow: 0,
+			want:    "0    a,1: 3",
+		},
+		{
+			desc:    "tooltip is clamped to the right edge",
+			opts:    []Option{HoverTooltip()},
+			mouse:   &terminalapi.Mouse{Position: image.Point{9, 1}, Button: mouse.ButtonRelease},
+			wantRow: 0,
+			want:    "0    b,1: 4",
+		},
+		{
+			desc:    "no tooltip when the mouse leaves the widget",
+			opts:    []Option{HoverTooltip()},
+			mouse:   &terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonRelease},
+			wantRow: 0,
+			want:    "0",
+		},
+		{
+			desc:    "no tooltip when the mouse is over the labels",
+			opts:    []Option{HoverTooltip()},
+			mouse:   &terminalapi.Mouse{Position: image.Point{0, 1}, Button: mouse.ButtonRelease},
+			wantRow: 0,
+			want:    "0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hp, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := hp.Values([]string{"a", "b"}, nil, [][]float64{{1, 2}, {3, 4}}); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+
+			err = hp.Mouse(tc.mouse, &widgetapi.EventMeta{})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Mouse => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			cvs := testcanvas.MustNew(image.Rect(0, 0, 11, 3))
+			if err := hp.Draw(cvs, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if got := strings.TrimRight(readRow(t, cvs, tc.wantRow), " "); got != tc.want {
+				t.Errorf("Draw => row %d is %q, want %q", tc.wantRow, got, tc.want)
+			}
+		})
+	}
+}
@@ -70,6 +70,7 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	hp, err := heatmap.New(
 		heatmap.CellWidth(3),
+		heatmap.HoverTooltip(),
 	)
 	if err != nil {
 		panic(err)
@@ -43,16 +43,17 @@ type YDetails struct {
 
 // NewYDetails retrieves details about the Y axis required
 // to draw it on a canvas of the provided area.
-func NewYDetails(labels []string) (*YDetails, error) {
+// The cellHeight is the number of rows occupied by each row of cells.
+func NewYDetails(labels []string, cellHeight int) (*YDetails, error) {
 	// See how the labels would look like on the entire maxWidth.
 	maxLabelWidth := LongestString(labels)
-	ls, err := yLabels(maxLabelWidth, labels)
+	ls, err := yLabels(maxLabelWidth, labels, cellHeight)
 	if err != nil {
 		return nil, err
 	}
 
 	width := maxLabelWidth + AxisWidth
-	graphHeight := len(labels)
+	graphHeight := len(labels) * cellHeight
 
 	return &YDetails{
 		Width:  width,
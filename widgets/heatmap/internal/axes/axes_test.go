@@ -114,16 +114,50 @@ func TestNewXDetails(t *testing.T) {
 
 func TestNewYDetails(t *testing.T) {
 	tests := []struct {
-		desc    string
-		labels  []string
-		want    *YDetails
-		wantErr bool
+		desc       string
+		labels     []string
+		cellHeight int
+		want       *YDetails
+		wantErr    bool
 	}{
-		// TODO：add test cases
+		{
+			desc:       "fails on zero cell height",
+			labels:     []string{"a"},
+			cellHeight: 0,
+			wantErr:    true,
+		},
+		{
+			desc:       "single row cells",
+			labels:     []string{"a", "bb"},
+			cellHeight: 1,
+			want: &YDetails{
+				Width: 3,
+				Start: image.Point{2, 0},
+				End:   image.Point{2, 2},
+				Labels: []*Label{
+					{Text: "a", Pos: image.Point{1, 0}},
+					{Text: "bb", Pos: image.Point{0, 1}},
+				},
+			},
+		},
+		{
+			desc:       "labels are centered on taller cells",
+			labels:     []string{"a", "bb"},
+			cellHeight: 3,
+			want: &YDetails{
+				Width: 3,
+				Start: image.Point{2, 0},
+				End:   image.Point{2, 6},
+				Labels: []*Label{
+					{Text: "a", Pos: image.Point{1, 1}},
+					{Text: "bb", Pos: image.Point{0, 4}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			got, err := NewYDetails(tt.labels)
+			got, err := NewYDetails(tt.labels, tt.cellHeight)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewYDetails() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -36,16 +36,21 @@ type Label struct {
 // The labelWidth is the width of the area from the left-most side of the
 // canvas until the Y axis (not including the Y axis). This is the area where
 // the labels will be placed and aligned.
+// The cellHeight is the number of rows occupied by each row of cells, labels
+// are vertically centered on their rows.
 // Labels are returned with Y coordinates in ascending order.
 // Y coordinates grow down.
-func yLabels(labelWidth int, labels []string) ([]*Label, error) {
+func yLabels(labelWidth int, labels []string, cellHeight int) ([]*Label, error) {
 	if min := 0; labelWidth < min {
 		return nil, fmt.Errorf("cannot place labels on a canvas with width %d, minimum is %d", labelWidth, min)
 	}
+	if min := 1; cellHeight < min {
+		return nil, fmt.Errorf("cannot place labels next to cells with height %d, minimum is %d", cellHeight, min)
+	}
 
 	var ret []*Label
 	for row, l := range labels {
-		label, err := rowLabel(row, l, labelWidth)
+		label, err := rowLabel(row, l, labelWidth, cellHeight)
 		if err != nil {
 			return nil, err
 		}
@@ -56,10 +61,11 @@ func yLabels(labelWidth int, labels []string) ([]*Label, error) {
 }
 
 // rowLabel returns one Y label for the specified row.
-// The row is the Y coordinate of the row, Y coordinates grow down.
-func rowLabel(row int, label string, labelWidth int) (*Label, error) {
+// The row is the index of the row of cells, Y coordinates grow down.
+func rowLabel(row int, label string, labelWidth, cellHeight int) (*Label, error) {
 	// The area available for the Y label
-	ar := image.Rect(0, row, labelWidth, row+1)
+	startY := row * cellHeight
+	ar := image.Rect(0, startY, labelWidth, startY+cellHeight)
 
 	pos, err := alignfor.Text(ar, label, align.HorizontalRight, align.VerticalMiddle)
 	if err != nil {
@@ -32,10 +32,16 @@ type Option interface {
 type options struct {
 	cellChar       rune
 	cellWidth      int
+	cellHeight     int
 	hideXLabels    bool
 	hideYLabels    bool
 	xLabelCellOpts []cell.Option
 	yLabelCellOpts []cell.Option
+	colorStops     []cell.Color
+	missingColor   cell.Color
+
+	hoverTooltip    bool
+	tooltipCellOpts []cell.Option
 }
 
 // validate validates the provided options.
@@ -43,13 +49,27 @@ func (o *options) validate() error {
 	if got, min := o.cellWidth, 0; got < min {
 		return fmt.Errorf("invalid CellWidth %d, must be %d <= CellWidth", got, min)
 	}
+	if got, min := o.cellHeight, 1; got < min {
+		return fmt.Errorf("invalid CellHeight %d, must be %d <= CellHeight", got, min)
+	}
+	if o.colorStops != nil {
+		if got, min := len(o.colorStops), 2; got < min {
+			return fmt.Errorf("invalid ColorStops, got %d colors, must provide at least %d", got, min)
+		}
+	}
 	return nil
 }
 
 // newOptions returns a new options instance.
 func newOptions(opts ...Option) *options {
 	opt := &options{
-		cellChar: DefaultChar,
+		cellChar:     DefaultChar,
+		cellHeight:   DefaultCellHeight,
+		missingColor: DefaultMissingColor,
+		tooltipCellOpts: []cell.Option{
+			cell.FgColor(cell.ColorBlack),
+			cell.BgColor(cell.ColorWhite),
+		},
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -60,6 +80,9 @@ func newOptions(opts ...Option) *options {
 // DefaultChar is the default value for the Char option.
 const DefaultChar = draw.DefaultRectChar
 
+// DefaultCellHeight is the default value for the CellHeight option.
+const DefaultCellHeight = 1
+
 // option implements Option.
 type option func(*options)
 
@@ -75,6 +98,16 @@ func CellWidth(w int) Option {
 	})
 }
 
+// CellHeight sets the height of cells (or grids) in the heat map, i.e. the
+// number of terminal rows each row of values occupies. The Y labels are
+// vertically centered on their cells.
+// Must be a positive integer, defaults to DefaultCellHeight.
+func CellHeight(h int) Option {
+	return option(func(opts *options) {
+		opts.cellHeight = h
+	})
+}
+
 // ShowXLabels configures the HeatMap so that it displays labels
 // on the X axis. This is the default behavior.
 func ShowXLabels() Option {
@@ -118,3 +151,56 @@ func YLabelCellOpts(co ...cell.Option) Option {
 		opts.yLabelCellOpts = co
 	})
 }
+
+// ColorScale sets the colors used for the cells with the smallest and the
+// largest value. The background color of each cell is interpolated between
+// low and high according to its value relative to the min and max of all the
+// values.
+//
+// The interpolated colors are true colors, terminals that don't display true
+// colors replace them with the nearest color supported by their color mode.
+// Defaults to a grayscale from white (low) to black (high).
+func ColorScale(low, high cell.Color) Option {
+	return ColorStops(low, high)
+}
+
+// ColorStops is like ColorScale, but allows to specify a multi-stop gradient.
+// The stops are evenly distributed across the range of the values, the first
+// color is used for the smallest value and the last color for the largest.
+// At least two colors must be provided.
+func ColorStops(stops ...cell.Color) Option {
+	return option(func(opts *options) {
+		opts.colorStops = make([]cell.Color, len(stops))
+		copy(opts.colorStops, stops)
+	})
+}
+
+// DefaultMissingColor is the default value for the MissingColor option.
+const DefaultMissingColor = cell.ColorDefault
+
+// MissingColor sets the background color of the cells whose values are
+// missing, i.e. math.NaN.
+// Defaults to DefaultMissingColor.
+func MissingColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.missingColor = c
+	})
+}
+
+// HoverTooltip configures the HeatMap to register for mouse events and to
+// display a tooltip with the value and the X and Y labels of the cell under
+// the mouse cursor. The tooltip is removed once the mouse leaves the widget.
+func HoverTooltip() Option {
+	return option(func(opts *options) {
+		opts.hoverTooltip = true
+	})
+}
+
+// TooltipCellOpts sets the cell options for the tooltip displayed when the
+// HoverTooltip option is set.
+// Defaults to black text on white background.
+func TooltipCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.tooltipCellOpts = co
+	})
+}
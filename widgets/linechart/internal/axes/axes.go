@@ -126,6 +126,46 @@ func NewYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
 	}, nil
 }
 
+// NewSecondYDetails retrieves details about a second Y axis that is drawn on
+// the right side of a canvas of the provided area. The reqYWidth is the width
+// required by the first Y axis and its labels on the left side of the canvas.
+// The labels of the second Y axis are placed to the right of the axis.
+func NewSecondYDetails(cvsAr image.Rectangle, reqYWidth int, yp *YProperties) (*YDetails, error) {
+	maxWidth := cvsAr.Dx() - reqYWidth - 1 // Reserve one column for the line chart itself.
+	if req := RequiredWidth(yp.Min, yp.Max); maxWidth < req {
+		return nil, fmt.Errorf("the available maxWidth %d is smaller than the reported required width %d of the second Y axis", maxWidth, req)
+	}
+
+	graphHeight := cvsAr.Dy() - yp.ReqXHeight
+	scale, err := NewYScale(yp.Min, yp.Max, graphHeight, nonZeroDecimals, yp.ScaleMode, yp.ValueFormatter)
+	if err != nil {
+		return nil, err
+	}
+
+	maxLabelWidth := maxWidth - axisWidth
+	labels, err := yLabels(scale, maxLabelWidth)
+	if err != nil {
+		return nil, err
+	}
+	width := longestLabel(labels) + axisWidth
+	if width > maxWidth {
+		width = maxWidth
+	}
+
+	axisX := cvsAr.Max.X - width
+	for _, l := range labels {
+		// The labels are aligned to the left, right next to the axis.
+		l.Pos.X = axisX + axisWidth
+	}
+	return &YDetails{
+		Width:  width,
+		Start:  image.Point{axisX, 0},
+		End:    image.Point{axisX, graphHeight},
+		Scale:  scale,
+		Labels: labels,
+	}, nil
+}
+
 // longestLabel returns the width of the widest label.
 func longestLabel(labels []*Label) int {
 	var widest int
@@ -176,6 +216,22 @@ type XProperties struct {
 	CustomLabels map[int]string
 	// LO is the desired orientation of labels under the X axis.
 	LO LabelOrientation
+	// TimeLabels when not nil indicates that the values on the X axis are
+	// points in time. The labels are then generated from the times instead
+	// of from the CustomLabels.
+	TimeLabels *TimeLabels
+	// LabelEvery when larger than one only keeps every n-th label out of
+	// the labels that fit under the X axis.
+	LabelEvery int
+}
+
+// labels returns the labels for the X axis with the scale in the specified
+// orientation.
+func (xp *XProperties) labels(scale *XScale, graphZero image.Point, lo LabelOrientation) ([]*Label, error) {
+	if xp.TimeLabels != nil {
+		return timeXLabels(scale, graphZero, xp.TimeLabels, lo)
+	}
+	return xLabels(scale, graphZero, xp.CustomLabels, lo)
 }
 
 // NewXDetails retrieves details about the X axis required to draw it on a canvas
@@ -205,10 +261,11 @@ func NewXDetails(cvsAr image.Rectangle, xp *XProperties) (*XDetails, error) {
 		xp.ReqYWidth + 1,
 		cvsAr.Dy() - reqHeight - 1,
 	}
-	labels, err := xLabels(scale, graphZero, xp.CustomLabels, xp.LO)
+	labels, err := xp.labels(scale, graphZero, xp.LO)
 	if err != nil {
 		return nil, err
 	}
+	labels = everyNth(labels, xp.LabelEvery)
 
 	return &XDetails{
 		Start:      image.Point{xp.ReqYWidth, cvsAr.Dy() - reqHeight}, // Space for the labels.
@@ -219,6 +276,49 @@ func NewXDetails(cvsAr image.Rectangle, xp *XProperties) (*XDetails, error) {
 	}, nil
 }
 
+// everyNth returns every n-th label starting with the first one.
+// Returns all the labels if n is less than two.
+func everyNth(labels []*Label, n int) []*Label {
+	if n < 2 {
+		return labels
+	}
+	var res []*Label
+	for i := 0; i < len(labels); i += n {
+		res = append(res, labels[i])
+	}
+	return res
+}
+
+// FittingOrientation returns the orientation of the labels under the X axis
+// drawn on a canvas of the provided area that places the most labels without
+// any of them overlapping. The xp.LO is ignored.
+// Horizontal labels are preferred, vertical labels are only used if more of
+// them fit under the axis and the canvas is tall enough for them and the
+// minGraphHeight rows of the graph.
+func FittingOrientation(cvsAr image.Rectangle, xp *XProperties, minGraphHeight int) (LabelOrientation, error) {
+	if RequiredHeight(xp.Max, xp.CustomLabels, LabelOrientationVertical)+minGraphHeight > cvsAr.Dy() {
+		return LabelOrientationHorizontal, nil
+	}
+
+	graphWidth := cvsAr.Dx() - xp.ReqYWidth - 1
+	scale, err := NewXScale(xp.Min, xp.Max, graphWidth, nonZeroDecimals)
+	if err != nil {
+		return LabelOrientationHorizontal, err
+	}
+	horizontal, err := xp.labels(scale, image.ZP, LabelOrientationHorizontal)
+	if err != nil {
+		return LabelOrientationHorizontal, err
+	}
+	vertical, err := xp.labels(scale, image.ZP, LabelOrientationVertical)
+	if err != nil {
+		return LabelOrientationHorizontal, err
+	}
+	if len(vertical) > len(horizontal) {
+		return LabelOrientationVertical, nil
+	}
+	return LabelOrientationHorizontal, nil
+}
+
 // RequiredHeight calculates the minimum height required in order to draw the X
 // axis and its labels.
 func RequiredHeight(max int, customLabels map[int]string, lo LabelOrientation) int {
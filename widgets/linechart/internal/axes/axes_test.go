@@ -241,6 +241,64 @@ func TestY(t *testing.T) {
 	}
 }
 
+func TestNewSecondYDetails(t *testing.T) {
+	tests := []struct {
+		desc      string
+		yp        *YProperties
+		cvsAr     image.Rectangle
+		reqYWidth int
+		want      *YDetails
+		wantErr   bool
+	}{
+		{
+			desc: "fails when the first Y axis leaves no space",
+			yp: &YProperties{
+				Min:        0,
+				Max:        3,
+				ReqXHeight: 2,
+			},
+			cvsAr:     image.Rect(0, 0, 4, 4),
+			reqYWidth: 2,
+			wantErr:   true,
+		},
+		{
+			desc: "places the axis on the right with labels aligned left",
+			yp: &YProperties{
+				Min:        0,
+				Max:        5,
+				ReqXHeight: 2,
+			},
+			cvsAr:     image.Rect(0, 0, 10, 4),
+			reqYWidth: 2,
+			want: &YDetails{
+				Width: 5,
+				Start: image.Point{5, 0},
+				End:   image.Point{5, 2},
+				Scale: mustNewYScale(0, 5, 2, nonZeroDecimals, YScaleModeAnchored, nil),
+				Labels: []*Label{
+					{NewValue(0, nonZeroDecimals), image.Point{6, 1}},
+					{NewValue(2.88, nonZeroDecimals), image.Point{6, 0}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewSecondYDetails(tc.cvsAr, tc.reqYWidth, tc.yp)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewSecondYDetails => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewSecondYDetails => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNewXDetails(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -526,3 +584,154 @@ func TestRequiredHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestNewXDetailsLabelEvery(t *testing.T) {
+	tests := []struct {
+		desc       string
+		labelEvery int
+		// wantIdx are indexes of the labels without LabelEvery that are
+		// expected to be kept.
+		wantIdx []int
+	}{
+		{
+			desc:    "zero keeps all the labels",
+			wantIdx: []int{0, 1, 2, 3},
+		},
+		{
+			desc:       "one keeps all the labels",
+			labelEvery: 1,
+			wantIdx:    []int{0, 1, 2, 3},
+		},
+		{
+			desc:       "keeps every second label",
+			labelEvery: 2,
+			wantIdx:    []int{0, 2},
+		},
+		{
+			desc:       "keeps every third label",
+			labelEvery: 3,
+			wantIdx:    []int{0, 3},
+		},
+		{
+			desc:       "keeps only the first label when n exceeds the count",
+			labelEvery: 10,
+			wantIdx:    []int{0},
+		},
+	}
+
+	cvsAr := image.Rect(0, 0, 21, 4)
+	all, err := NewXDetails(cvsAr, &XProperties{Max: 100, ReqYWidth: 2})
+	if err != nil {
+		t.Fatalf("NewXDetails => unexpected error: %v", err)
+	}
+	if got, want := len(all.Labels), 4; got != want {
+		t.Fatalf("NewXDetails => got %d labels, want %d", got, want)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewXDetails(cvsAr, &XProperties{Max: 100, ReqYWidth: 2, LabelEvery: tc.labelEvery})
+			if err != nil {
+				t.Fatalf("NewXDetails => unexpected error: %v", err)
+			}
+
+			var want []*Label
+			for _, i := range tc.wantIdx {
+				want = append(want, all.Labels[i])
+			}
+			if diff := pretty.Compare(want, got.Labels); diff != "" {
+				t.Errorf("NewXDetails => unexpected labels, diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFittingOrientation(t *testing.T) {
+	tests := []struct {
+		desc           string
+		cvsAr          image.Rectangle
+		xp             *XProperties
+		minGraphHeight int
+		want           LabelOrientation
+	}{
+		{
+			desc:  "horizontal when all the labels fit horizontally",
+			cvsAr: image.Rect(0, 0, 20, 10),
+			xp:    &XProperties{Max: 3, ReqYWidth: 2},
+			want:  LabelOrientationHorizontal,
+		},
+		{
+			desc:  "vertical when horizontal labels would be skipped",
+			cvsAr: image.Rect(0, 0, 20, 10),
+			xp:    &XProperties{Max: 1000, ReqYWidth: 2},
+			want:  LabelOrientationVertical,
+		},
+		{
+			desc:  "vertical with long custom labels",
+			cvsAr: image.Rect(0, 0, 20, 10),
+			xp: &XProperties{
+				Max:       3,
+				ReqYWidth: 2,
+				CustomLabels: map[int]string{
+					0: "first",
+					1: "second",
+					2: "third",
+					3: "fourth",
+				},
+			},
+			want: LabelOrientationVertical,
+		},
+		{
+			desc:           "horizontal when the canvas isn't tall enough for vertical labels",
+			cvsAr:          image.Rect(0, 0, 20, 6),
+			xp:             &XProperties{Max: 1000, ReqYWidth: 2},
+			minGraphHeight: 2,
+			want:           LabelOrientationHorizontal,
+		},
+		{
+			desc:           "vertical when the canvas is just tall enough",
+			cvsAr:          image.Rect(0, 0, 20, 7),
+			xp:             &XProperties{Max: 1000, ReqYWidth: 2},
+			minGraphHeight: 2,
+			want:           LabelOrientationVertical,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := FittingOrientation(tc.cvsAr, tc.xp, tc.minGraphHeight)
+			if err != nil {
+				t.Fatalf("FittingOrientation => unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("FittingOrientation => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestXLabelsDontOverlap(t *testing.T) {
+	const max = 1000
+	for _, lo := range []LabelOrientation{LabelOrientationHorizontal, LabelOrientationVertical} {
+		for width := 3; width <= 40; width++ {
+			t.Run(fmt.Sprintf("%v width %d", lo, width), func(t *testing.T) {
+				xd, err := NewXDetails(image.Rect(0, 0, width, 10), &XProperties{Max: max, LO: lo})
+				if err != nil {
+					t.Fatalf("NewXDetails => unexpected error: %v", err)
+				}
+				if len(xd.Labels) == 0 || len(xd.Labels) > width {
+					t.Errorf("NewXDetails => got %d labels, want between one and %d", len(xd.Labels), width)
+				}
+				for i := 1; i < len(xd.Labels); i++ {
+					prev, cur := xd.Labels[i-1], xd.Labels[i]
+					if prevEnd := prev.Pos.X + labelLen(prev.Value.Text(), lo); prevEnd >= cur.Pos.X {
+						t.Errorf("label %q at %v overlaps or touches label %q at %v", prev.Value.Text(), prev.Pos, cur.Value.Text(), cur.Pos)
+					}
+				}
+				if last := xd.Labels[len(xd.Labels)-1]; last.Pos.X+labelLen(last.Value.Text(), lo) > width {
+					t.Errorf("label %q at %v doesn't fit the width %d", last.Value.Text(), last.Pos, width)
+				}
+			})
+		}
+	}
+}
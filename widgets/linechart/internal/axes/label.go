@@ -22,6 +22,7 @@ import (
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/canvas/braille"
 )
 
 // LabelOrientation represents the orientation of text labels.
@@ -75,6 +76,10 @@ func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
 		return nil, fmt.Errorf("cannot place labels in label area width %d, minimum is %d", labelWidth, min)
 	}
 
+	if scale.IsLogarithmic() {
+		return logYLabels(scale, labelWidth)
+	}
+
 	var labels []*Label
 	const labelSpacing = 4
 	seen := map[string]bool{}
@@ -102,6 +107,36 @@ func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
 	return labels, nil
 }
 
+// logYLabels returns labels that should be placed next to a Y axis with a
+// logarithmic scale. Labels are placed on the powers of ten, labels that would
+// share a row with an already placed label are skipped.
+func logYLabels(scale *YScale, labelWidth int) ([]*Label, error) {
+	var labels []*Label
+	usedRows := map[int]bool{}
+	for _, v := range scale.Decades() {
+		pixelY, err := scale.ValueToPixel(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine the position of label %v: %v", v, err)
+		}
+		row := pixelY / braille.RowMult
+		if usedRows[row] {
+			continue
+		}
+		usedRows[row] = true
+
+		ar := rowLabelArea(row, labelWidth)
+		pos, err := alignfor.Text(ar, v.Text(), align.HorizontalRight, align.VerticalMiddle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to align the label value: %v", err)
+		}
+		labels = append(labels, &Label{
+			Value: v,
+			Pos:   pos,
+		})
+	}
+	return labels, nil
+}
+
 // rowLabelArea determines the area available for labels on the specified row.
 // The row is the Y coordinate of the row, Y coordinates grow down.
 func rowLabelArea(row int, labelWidth int) image.Rectangle {
@@ -229,6 +264,14 @@ func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string,
 	return res, nil
 }
 
+// labelLen returns the number of cells the label takes along the X axis.
+func labelLen(text string, lo LabelOrientation) int {
+	if lo == LabelOrientationVertical {
+		return 1
+	}
+	return len(text)
+}
+
 // colLabel returns a label placed at the beginning of the space.
 // The space is adjusted according to how much space was taken by the label.
 // Returns nil, nil if the label doesn't fit in the space.
@@ -243,19 +286,13 @@ func colLabel(scale *XScale, space *xSpace, customLabels map[int]string, lo Labe
 		label = NewTextValue(custom)
 	}
 
-	var labelLen int
-	switch lo {
-	case LabelOrientationHorizontal:
-		labelLen = len(label.Text())
-	case LabelOrientationVertical:
-		labelLen = 1
-	}
-	if labelLen > space.Remaining() {
+	length := labelLen(label.Text(), lo)
+	if length > space.Remaining() {
 		return nil, nil
 	}
 
 	abs := space.LabelPos()
-	if err := space.Sub(labelLen); err != nil {
+	if err := space.Sub(length); err != nil {
 		return nil, err
 	}
 
@@ -148,6 +148,64 @@ func TestYLabels(t *testing.T) {
 	}
 }
 
+func TestLogYLabels(t *testing.T) {
+	// Labels on the powers of ten are rounded to a single non-zero decimal.
+	const nonZeroDecimals = 1
+	tests := []struct {
+		desc        string
+		min         float64
+		max         float64
+		graphHeight int
+		labelWidth  int
+		want        []*Label
+	}{
+		{
+			desc:        "labels on all powers of ten",
+			min:         0.01,
+			max:         10000,
+			graphHeight: 12,
+			labelWidth:  5,
+			want: []*Label{
+				{NewValue(0.01, nonZeroDecimals), image.Point{1, 11}},
+				{NewValue(0.1, nonZeroDecimals), image.Point{2, 9}},
+				{NewValue(1, nonZeroDecimals), image.Point{4, 7}},
+				{NewValue(10, nonZeroDecimals), image.Point{3, 5}},
+				{NewValue(100, nonZeroDecimals), image.Point{2, 4}},
+				{NewValue(1000, nonZeroDecimals), image.Point{1, 2}},
+				{NewValue(10000, nonZeroDecimals), image.Point{0, 0}},
+			},
+		},
+		{
+			desc:        "skips labels that would share a row",
+			min:         0.01,
+			max:         10000,
+			graphHeight: 3,
+			labelWidth:  5,
+			want: []*Label{
+				{NewValue(0.01, nonZeroDecimals), image.Point{1, 2}},
+				{NewValue(1, nonZeroDecimals), image.Point{4, 1}},
+				{NewValue(1000, nonZeroDecimals), image.Point{1, 0}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			scale, err := NewYScale(tc.min, tc.max, tc.graphHeight, nonZeroDecimals, YScaleModeLogarithmic, nil)
+			if err != nil {
+				t.Fatalf("NewYScale => unexpected error: %v", err)
+			}
+			got, err := yLabels(scale, tc.labelWidth)
+			if err != nil {
+				t.Fatalf("yLabels => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("yLabels => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestXLabels(t *testing.T) {
 	const nonZeroDecimals = 2
 	tests := []struct {
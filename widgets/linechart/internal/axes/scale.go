@@ -36,8 +36,9 @@ func (ysm YScaleMode) String() string {
 
 // yScaleModeNames maps YScaleMode values to human readable names.
 var yScaleModeNames = map[YScaleMode]string{
-	YScaleModeAnchored: "YScaleModeAnchored",
-	YScaleModeAdaptive: "YScaleModeAdaptive",
+	YScaleModeAnchored:    "YScaleModeAnchored",
+	YScaleModeAdaptive:    "YScaleModeAdaptive",
+	YScaleModeLogarithmic: "YScaleModeLogarithmic",
 }
 
 const (
@@ -50,6 +51,12 @@ const (
 	// I.e. it starts at min for all-positive series and at max for
 	// all-negative series.
 	YScaleModeAdaptive
+
+	// YScaleModeLogarithmic is a mode where the Y scale uses the log10 of the
+	// values. The scale starts and ends on a power of ten that accommodates
+	// the min and max on the series. Only positive values can be placed on
+	// this scale.
+	YScaleModeLogarithmic
 )
 
 // YScale is the scale of the Y axis.
@@ -67,6 +74,12 @@ type YScale struct {
 	// brailleHeight is the height of the braille canvas based on the GraphHeight.
 	brailleHeight int
 
+	// mode is the mode of the scale.
+	mode YScaleMode
+	// logMin and logMax are the exponents of the powers of ten at the
+	// boundaries of the scale in the YScaleModeLogarithmic mode.
+	logMin, logMax int
+
 	// valueFormatter is the value formatter used for the labels
 	// represented by the values on the scale.
 	valueFormatter func(float64) string
@@ -112,6 +125,9 @@ func NewYScale(min, max float64, graphHeight, nonZeroDecimals int, mode YScaleMo
 		if max < 0 && min == max {
 			max = 0
 		}
+	case YScaleModeLogarithmic:
+		return newLogYScale(min, max, graphHeight, brailleHeight, nonZeroDecimals, valueFormatter)
+
 	default:
 		return nil, fmt.Errorf("unsupported mode: %v(%d)", mode, mode)
 	}
@@ -127,6 +143,44 @@ func NewYScale(min, max float64, graphHeight, nonZeroDecimals int, mode YScaleMo
 	}, nil
 }
 
+// newLogYScale calculates the scale of the Y axis in the
+// YScaleModeLogarithmic mode. Non-positive min and max are ignored, if there
+// is no positive value, the scale defaults to the range from one to ten.
+func newLogYScale(min, max float64, graphHeight, brailleHeight, nonZeroDecimals int, valueFormatter func(float64) string) (*YScale, error) {
+	if max <= 0 {
+		min, max = 1, 10
+	}
+	if min <= 0 {
+		min = max
+	}
+
+	logMin := int(math.Floor(math.Log10(min)))
+	logMax := int(math.Ceil(math.Log10(max)))
+	if logMin == logMax {
+		logMax++
+	}
+
+	usablePixels := brailleHeight - 1 // One pixel reserved for the minimum.
+	step := NewValue(float64(logMax-logMin)/float64(usablePixels), nonZeroDecimals)
+	return &YScale{
+		Min:            yScaleNewValue(math.Pow(10, float64(logMin)), nonZeroDecimals, valueFormatter),
+		Max:            yScaleNewValue(math.Pow(10, float64(logMax)), nonZeroDecimals, valueFormatter),
+		Step:           step,
+		GraphHeight:    graphHeight,
+		brailleHeight:  brailleHeight,
+		valueFormatter: valueFormatter,
+		mode:           YScaleModeLogarithmic,
+		logMin:         logMin,
+		logMax:         logMax,
+	}, nil
+}
+
+// IsLogarithmic asserts whether this scale is in the YScaleModeLogarithmic
+// mode.
+func (ys *YScale) IsLogarithmic() bool {
+	return ys.mode == YScaleModeLogarithmic
+}
+
 // PixelToValue given a Y coordinate of the pixel, returns its value according
 // to the scale. The coordinate must be within bounds of the graph height
 // provided to NewYScale. Y coordinates grow down.
@@ -136,6 +190,17 @@ func (ys *YScale) PixelToValue(y int) (float64, error) {
 		return 0, err
 	}
 
+	if ys.IsLogarithmic() {
+		switch {
+		case pos == 0:
+			return ys.Min.Value, nil
+		case pos == ys.brailleHeight-1:
+			return ys.Max.Value, nil
+		default:
+			return math.Pow(10, float64(ys.logMin)+float64(pos)*ys.Step.Value), nil
+		}
+	}
+
 	switch {
 	case pos == 0:
 		return ys.Min.Rounded, nil
@@ -160,6 +225,14 @@ func (ys *YScale) PixelToValue(y int) (float64, error) {
 // The value must be within the bounds provided to NewYScale. Y coordinates
 // grow down.
 func (ys *YScale) ValueToPixel(v float64) (int, error) {
+	if ys.IsLogarithmic() {
+		if v <= 0 {
+			return 0, fmt.Errorf("invalid value %v, only positive values can be placed on a logarithmic scale", v)
+		}
+		pos := int(math.Round((math.Log10(v) - float64(ys.logMin)) / ys.Step.Value))
+		return positionToY(pos, ys.brailleHeight)
+	}
+
 	if ys.Step.Rounded == 0 {
 		return 0, nil
 	}
@@ -196,6 +269,21 @@ func (ys *YScale) CellLabel(y int) (*Value, error) {
 	return yScaleNewValue(v, ys.Min.NonZeroDecimals, ys.valueFormatter), nil
 }
 
+// Decades returns the powers of ten that are on the scale in an increasing
+// order. Returns nil if the scale isn't in the YScaleModeLogarithmic mode.
+func (ys *YScale) Decades() []*Value {
+	if !ys.IsLogarithmic() {
+		return nil
+	}
+	// Powers of ten only have a single non-zero decimal place.
+	const decadeNonZeroDecimals = 1
+	var res []*Value
+	for e := ys.logMin; e <= ys.logMax; e++ {
+		res = append(res, yScaleNewValue(math.Pow(10, float64(e)), decadeNonZeroDecimals, ys.valueFormatter))
+	}
+	return res
+}
+
 // yScaleNewValue is a helper method to get new values for the y scale.
 func yScaleNewValue(value float64, nonZeroDecimals int, valueFormatter func(float64) string) *Value {
 	opts := []ValueOption{}
@@ -763,6 +763,70 @@ func TestYScale(t *testing.T) {
 				{0, NewValue(140, 2), false},
 			},
 		},
+		{
+			desc:            "logarithmic mode, values on powers of ten",
+			min:             1,
+			max:             1000,
+			graphHeight:     4,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLogarithmic,
+			pixelToValueTests: []pixelToValueTest{
+				{0, 1000, false},
+				{5, 100, false},
+				{10, 10, false},
+				{15, 1, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{1, 15, false},
+				{10, 10, false},
+				{100, 5, false},
+				{1000, 0, false},
+				{0, 0, true},
+				{-1, 0, true},
+				{10000, 0, true},
+			},
+		},
+		{
+			desc:            "logarithmic mode, scale extends to the surrounding powers of ten",
+			min:             0.05,
+			max:             5000,
+			graphHeight:     8,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLogarithmic,
+			pixelToValueTests: []pixelToValueTest{
+				{0, 10000, false},
+				{31, 0.01, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{0.01, 31, false},
+				{1, 21, false},
+				{10000, 0, false},
+			},
+		},
+		{
+			desc:            "logarithmic mode, all values equal",
+			min:             10,
+			max:             10,
+			graphHeight:     4,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLogarithmic,
+			valueToPixelTests: []valueToPixelTest{
+				{10, 15, false},
+				{100, 0, false},
+			},
+		},
+		{
+			desc:            "logarithmic mode, defaults without positive values",
+			min:             -10,
+			max:             0,
+			graphHeight:     4,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeLogarithmic,
+			valueToPixelTests: []valueToPixelTest{
+				{1, 15, false},
+				{10, 0, false},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,163 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axes
+
+// time_label.go contains code that calculates the labels of an X axis whose
+// values are points in time.
+
+import (
+	"image"
+	"time"
+)
+
+// TimeLabels are the points in time of the values on the X axis.
+type TimeLabels struct {
+	// Times are the points in time of the values at the positions in the
+	// series. Must be in a non-decreasing order.
+	Times []time.Time
+	// Layout is used to format the times, see time.Time.Format.
+	Layout string
+}
+
+// Formatted returns all the formatted times keyed by their positions.
+func (tl *TimeLabels) Formatted() map[int]string {
+	res := make(map[int]string, len(tl.Times))
+	for i, t := range tl.Times {
+		res[i] = t.Format(tl.Layout)
+	}
+	return res
+}
+
+// day is the duration of one day.
+const day = 24 * time.Hour
+
+// timeIntervals are the intervals between labels on the X axis in an
+// increasing order.
+var timeIntervals = []time.Duration{
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	2 * time.Hour,
+	3 * time.Hour,
+	6 * time.Hour,
+	12 * time.Hour,
+	day,
+	2 * day,
+	7 * day,
+	14 * day,
+	30 * day,
+	90 * day,
+	365 * day,
+}
+
+// tickInterval returns the shortest interval from timeIntervals that results
+// in at most maxTicks labels within the span. Spans that are too long for the
+// longest interval use its multiples.
+func tickInterval(span time.Duration, maxTicks int) time.Duration {
+	if maxTicks < 1 {
+		maxTicks = 1
+	}
+	for _, i := range timeIntervals {
+		if int(span/i) < maxTicks {
+			return i
+		}
+	}
+	longest := timeIntervals[len(timeIntervals)-1]
+	return longest * (span/longest/time.Duration(maxTicks) + 1)
+}
+
+// nextTick returns the earliest time at or after t that falls on the
+// interval counted from the midnight in the location of t. Intervals longer
+// than a day start at the midnight.
+func nextTick(t time.Time, interval time.Duration) time.Time {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	step := interval
+	if step > day {
+		step = day
+	}
+	n := (t.Sub(midnight) + step - 1) / step
+	return midnight.Add(n * step)
+}
+
+// timeXLabels returns labels that should be placed under the X axis whose
+// values are points in time. The interval between the labels is selected so
+// that the labels fit under the width of the axis. Each label is placed at the
+// first value at or after the time it represents.
+// Labels are returned in an increasing value order.
+func timeXLabels(scale *XScale, graphZero image.Point, tl *TimeLabels, lo LabelOrientation) ([]*Label, error) {
+	min, max := int(scale.Min.Value), int(scale.Max.Value)
+	if max >= len(tl.Times) {
+		max = len(tl.Times) - 1
+	}
+	if min < 0 || min > max {
+		return nil, nil
+	}
+
+	const minSpacing = 3
+	width := labelLen(tl.Times[min].Format(tl.Layout), lo)
+	if w := labelLen(tl.Times[max].Format(tl.Layout), lo); w > width {
+		width = w
+	}
+	maxTicks := (scale.GraphWidth + minSpacing) / (width + minSpacing)
+	interval := tickInterval(tl.Times[max].Sub(tl.Times[min]), maxTicks)
+
+	var positions []int
+	pos := min
+	for tick := nextTick(tl.Times[min], interval); !tick.After(tl.Times[max]); tick = tick.Add(interval) {
+		for pos <= max && tl.Times[pos].Before(tick) {
+			pos++
+		}
+		if pos > max {
+			break
+		}
+		if len(positions) == 0 || positions[len(positions)-1] != pos {
+			positions = append(positions, pos)
+		}
+	}
+	if len(positions) == 0 {
+		// The span is shorter than the shortest interval.
+		positions = append(positions, min)
+	}
+
+	var res []*Label
+	free := 0 // The first cell not taken by the previous label.
+	for _, pos := range positions {
+		x, err := scale.ValueToCell(pos)
+		if err != nil {
+			return nil, err
+		}
+		text := tl.Times[pos].Format(tl.Layout)
+		if x < free || x+labelLen(text, lo) > scale.GraphWidth {
+			continue
+		}
+		res = append(res, &Label{
+			Value: NewTextValue(text),
+			// First down is the axis, second the label.
+			Pos: image.Point{graphZero.X + x, graphZero.Y + 2},
+		})
+		free = x + labelLen(text, lo) + minSpacing
+	}
+	return res, nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axes
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// evenTimes returns n points in time separated by the step.
+func evenTimes(n int, step time.Duration) []time.Time {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	var res []time.Time
+	for i := 0; i < n; i++ {
+		res = append(res, start.Add(time.Duration(i)*step))
+	}
+	return res
+}
+
+func TestTickInterval(t *testing.T) {
+	tests := []struct {
+		desc     string
+		span     time.Duration
+		maxTicks int
+		want     time.Duration
+	}{
+		{
+			desc:     "zero span",
+			span:     0,
+			maxTicks: 5,
+			want:     time.Second,
+		},
+		{
+			desc:     "very short span",
+			span:     300 * time.Millisecond,
+			maxTicks: 5,
+			want:     time.Second,
+		},
+		{
+			desc:     "one hour",
+			span:     time.Hour,
+			maxTicks: 5,
+			want:     15 * time.Minute,
+		},
+		{
+			desc:     "one hour with less space",
+			span:     time.Hour,
+			maxTicks: 2,
+			want:     time.Hour,
+		},
+		{
+			desc:     "one week",
+			span:     7 * day,
+			maxTicks: 8,
+			want:     day,
+		},
+		{
+			desc:     "span longer than the longest interval",
+			span:     10 * 365 * day,
+			maxTicks: 3,
+			want:     4 * 365 * day,
+		},
+		{
+			desc:     "no space for ticks",
+			span:     time.Hour,
+			maxTicks: 0,
+			want:     2 * time.Hour,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tickInterval(tc.span, tc.maxTicks)
+			if got != tc.want {
+				t.Errorf("tickInterval(%v, %v) => %v, want %v", tc.span, tc.maxTicks, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNextTick(t *testing.T) {
+	tests := []struct {
+		desc     string
+		t        time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{
+			desc:     "time on the interval",
+			t:        time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+			interval: 15 * time.Minute,
+			want:     time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			desc:     "time between intervals",
+			t:        time.Date(2024, 1, 1, 10, 16, 0, 0, time.UTC),
+			interval: 15 * time.Minute,
+			want:     time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			desc:     "interval counted from the midnight in the location",
+			t:        time.Date(2024, 1, 1, 10, 1, 0, 0, time.FixedZone("UTC+1", 3600)),
+			interval: 3 * time.Hour,
+			want:     time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("UTC+1", 3600)),
+		},
+		{
+			desc:     "interval longer than a day starts at the midnight",
+			t:        time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			interval: 7 * day,
+			want:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := nextTick(tc.t, tc.interval)
+			if !got.Equal(tc.want) {
+				t.Errorf("nextTick(%v, %v) => %v, want %v", tc.t, tc.interval, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeXLabels(t *testing.T) {
+	const nonZeroDecimals = 2
+	tests := []struct {
+		desc             string
+		min              int
+		max              int
+		graphWidth       int
+		graphZero        image.Point
+		timeLabels       *TimeLabels
+		labelOrientation LabelOrientation
+		want             []*Label
+	}{
+		{
+			desc:       "one hour span",
+			min:        0,
+			max:        60,
+			graphWidth: 37,
+			graphZero:  image.Point{0, 1},
+			timeLabels: &TimeLabels{
+				Times:  evenTimes(61, time.Minute),
+				Layout: "15:04",
+			},
+			want: []*Label{
+				{NewTextValue("10:00"), image.Point{0, 3}},
+				{NewTextValue("10:15"), image.Point{9, 3}},
+				{NewTextValue("10:30"), image.Point{18, 3}},
+				{NewTextValue("10:45"), image.Point{27, 3}},
+			},
+		},
+		{
+			desc:       "one week span",
+			min:        0,
+			max:        168,
+			graphWidth: 33,
+			graphZero:  image.Point{0, 1},
+			timeLabels: &TimeLabels{
+				Times:  evenTimes(169, time.Hour),
+				Layout: "Jan 2",
+			},
+			want: []*Label{
+				{NewTextValue("Jan 2"), image.Point{2, 3}},
+				{NewTextValue("Jan 4"), image.Point{12, 3}},
+				{NewTextValue("Jan 6"), image.Point{21, 3}},
+			},
+		},
+		{
+			desc:       "one week span with more space",
+			min:        0,
+			max:        168,
+			graphWidth: 70,
+			graphZero:  image.Point{0, 1},
+			timeLabels: &TimeLabels{
+				Times:  evenTimes(169, time.Hour),
+				Layout: "Jan 2",
+			},
+			want: []*Label{
+				{NewTextValue("Jan 2"), image.Point{6, 3}},
+				{NewTextValue("Jan 3"), image.Point{15, 3}},
+				{NewTextValue("Jan 4"), image.Point{25, 3}},
+				{NewTextValue("Jan 5"), image.Point{35, 3}},
+				{NewTextValue("Jan 6"), image.Point{45, 3}},
+				{NewTextValue("Jan 7"), image.Point{55, 3}},
+				{NewTextValue("Jan 8"), image.Point{65, 3}},
+			},
+		},
+		{
+			desc:       "span shorter than the shortest interval labels the first value",
+			min:        0,
+			max:        4,
+			graphWidth: 20,
+			graphZero:  image.Point{0, 1},
+			timeLabels: &TimeLabels{
+				Times:  evenTimes(5, time.Millisecond),
+				Layout: "15:04:05",
+			},
+			want: []*Label{
+				{NewTextValue("10:00:00"), image.Point{0, 3}},
+			},
+		},
+		{
+			desc:       "vertical labels",
+			min:        0,
+			max:        60,
+			graphWidth: 10,
+			graphZero:  image.Point{0, 1},
+			timeLabels: &TimeLabels{
+				Times:  evenTimes(61, time.Minute),
+				Layout: "15:04",
+			},
+			labelOrientation: LabelOrientationVertical,
+			want: []*Label{
+				{NewTextValue("10:00"), image.Point{0, 3}},
+				{NewTextValue("10:30"), image.Point{4, 3}},
+				{NewTextValue("11:00"), image.Point{9, 3}},
+			},
+		},
+		{
+			desc:       "positions without times aren't labeled",
+			min:        0,
+			max:        10,
+			graphWidth: 20,
+			graphZero:  image.Point{0, 1},
+			timeLabels: &TimeLabels{
+				Layout: "15:04",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			scale, err := NewXScale(tc.min, tc.max, tc.graphWidth, nonZeroDecimals)
+			if err != nil {
+				t.Fatalf("NewXScale => unexpected error: %v", err)
+			}
+			got, err := timeXLabels(scale, tc.graphZero, tc.timeLabels, tc.labelOrientation)
+			if err != nil {
+				t.Fatalf("timeXLabels => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("timeXLabels => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
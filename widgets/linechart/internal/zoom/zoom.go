@@ -224,6 +224,12 @@ func (t *Tracker) Mouse(m *terminalapi.Mouse) error {
 	return nil
 }
 
+// Reset fully unzooms the X axis and cancels any highlight in progress.
+func (t *Tracker) Reset() {
+	t.zoomX = nil
+	t.highlight.reset()
+}
+
 // Range represents a range of values.
 // The range includes all values x such that Start <= x < End.
 type Range struct {
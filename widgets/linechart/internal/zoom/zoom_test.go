@@ -534,6 +534,76 @@ func TestTracker(t *testing.T) {
 				},
 			),
 		},
+		{
+			desc: "reset unzooms the X axis",
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{3, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{6, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{6, 0},
+					Button:   mouse.ButtonRelease,
+				}); err != nil {
+					return err
+				}
+				tr.Reset()
+				return nil
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       5,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "reset cancels highlight in progress",
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{3, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				tr.Reset()
+				return nil
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       5,
+					ReqYWidth: 2,
+				},
+			),
+		},
 		{
 			desc: "highlights and zooms into the X axis twice",
 			xp: &axes.XProperties{
@@ -22,6 +22,7 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/area"
@@ -29,6 +30,7 @@ import (
 	"github.com/mum4k/termdash/private/canvas/braille"
 	"github.com/mum4k/termdash/private/draw"
 	"github.com/mum4k/termdash/private/numbers"
+	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
@@ -45,10 +47,32 @@ type seriesValues struct {
 	max float64
 
 	seriesCellOpts []cell.Option
+	// fill indicates that the area under the series should be filled.
+	fill bool
+	// fillCellOpts are the cell options for the filled area.
+	fillCellOpts []cell.Option
+	// secondYAxis indicates that the series is plotted against the second Y
+	// axis.
+	secondYAxis bool
 	// The custom labels provided on a call to Series and a bool indicating if
 	// the labels were provided. This allows resetting them to nil.
 	xLabelsSet bool
 	xLabels    map[int]string
+	// xTimes are the points in time of the values provided with SeriesXTimes
+	// or nil if they weren't provided.
+	xTimes *axes.TimeLabels
+	// markers is the style of markers drawn at the individual values.
+	markers MarkerStyle
+	// dashPattern is the dash pattern of the line, nil for a solid line.
+	dashPattern []int
+
+	// stacked are the cumulative sums of the values of this and all the
+	// series stacked before it when the StackedAreas option is provided,
+	// otherwise nil.
+	stacked []float64
+	// base are the cumulative sums of the series stacked before this one,
+	// nil for the first stacked series.
+	base []float64
 }
 
 // newSeriesValues returns a new seriesValues instance.
@@ -65,6 +89,14 @@ func newSeriesValues(values []float64) *seriesValues {
 	}
 }
 
+// plotted returns the values of the series that are plotted.
+func (sv *seriesValues) plotted() []float64 {
+	if sv.stacked != nil {
+		return sv.stacked
+	}
+	return sv.values
+}
+
 // LineChart draws line charts.
 //
 // Each line chart has an identifying label and a set of values that are
@@ -78,7 +110,8 @@ func newSeriesValues(values []float64) *seriesValues {
 //
 // LineChart supports mouse based zoom, zooming is achieved by either
 // highlighting an area on the graph (left mouse clicking and dragging) or by
-// using the mouse scroll button.
+// using the mouse scroll button. The zoom can be reset with a key, see the
+// ZoomResetKey option.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type LineChart struct {
@@ -91,6 +124,8 @@ type LineChart struct {
 
 	// yMin are the min and max values for the Y axis.
 	yMin, yMax float64
+	// y2Min and y2Max are the min and max values for the second Y axis.
+	y2Min, y2Max float64
 
 	// capacity is the last observed value capacity in pixels when Draw was
 	// called.
@@ -101,9 +136,15 @@ type LineChart struct {
 
 	// xLabels that were provided on a call to Series.
 	xLabels map[int]string
+	// xTimes that were provided on a call to Series.
+	xTimes *axes.TimeLabels
 
 	// zoom tracks the zooming of the X axis.
 	zoom *zoom.Tracker
+
+	// lastRender are the cells of the visible values of each series as of
+	// the last call to Draw. Keyed by the name of the series.
+	lastRender map[string][]image.Point
 }
 
 // New returns a new line chart widget.
@@ -143,6 +184,32 @@ func SeriesCellOpts(co ...cell.Option) SeriesOption {
 	})
 }
 
+// SeriesFill fills the area between the series and the X axis using the
+// provided cell options. With the StackedAreas option, the band between the
+// series and the series stacked before it is filled instead. When the Y axis
+// contains the zero value, the area is filled toward the zero baseline, so
+// that values below zero are filled upward. Use a lighter shade of the series
+// color to make the fill appear semi-transparent. Series are drawn in
+// alphabetical order based on their name, so that the fill of a later series
+// is drawn over the earlier series.
+func SeriesFill(co ...cell.Option) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.fill = true
+		opts.fillCellOpts = co
+	})
+}
+
+// SeriesSecondYAxis binds the series to a second Y axis drawn on the right
+// side of the LineChart. The minimum and maximum of the second Y axis are
+// determined independently, only from the series bound to it.
+// Use the SecondYAxisCellOpts option to visually distinguish the labels of
+// the second Y axis.
+func SeriesSecondYAxis() SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.secondYAxis = true
+	})
+}
+
 // SeriesXLabels is used to provide custom labels for the X axis.
 // The argument maps the positions in the provided series to the desired label.
 // The labels are only used if they fit under the axis.
@@ -160,18 +227,178 @@ func SeriesXLabels(labels map[int]string) SeriesOption {
 	})
 }
 
-// yMinMax determines the min and max values for the Y axis.
-func (lc *LineChart) yMinMax() (float64, float64) {
+// SeriesXTimes indicates that the values on the X axis are points in time.
+// The argument provides the point in time of the value at each position in
+// the provided series and must be in a non-decreasing order. The layout is
+// used to format the labels, see time.Time.Format.
+// The labels are placed at regular intervals (e.g. every 5 minutes or every
+// day) chosen so that they fit under the axis.
+// Like the labels provided with SeriesXLabels, the times are property of the
+// line chart and replace any previously provided custom labels.
+func SeriesXTimes(times []time.Time, layout string) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		// Copy to avoid external modifications. See #174.
+		t := make([]time.Time, len(times))
+		copy(t, times)
+		opts.xTimes = &axes.TimeLabels{
+			Times:  t,
+			Layout: layout,
+		}
+	})
+}
+
+// MarkerStyle determines the markers drawn at the individual values of a
+// series.
+type MarkerStyle int
+
+// String implements fmt.Stringer()
+func (ms MarkerStyle) String() string {
+	if n, ok := markerStyleNames[ms]; ok {
+		return n
+	}
+	return "MarkerStyleUnknown"
+}
+
+// markerStyleNames maps MarkerStyle values to human readable names.
+var markerStyleNames = map[MarkerStyle]string{
+	MarkerNone:  "MarkerNone",
+	MarkerDot:   "MarkerDot",
+	MarkerCross: "MarkerCross",
+}
+
+// markerStyleRunes maps MarkerStyle values to the runes used to draw them.
+var markerStyleRunes = map[MarkerStyle]rune{
+	MarkerDot:   '•',
+	MarkerCross: '×',
+}
+
+const (
+	// MarkerNone means no markers are drawn.
+	MarkerNone MarkerStyle = iota
+
+	// MarkerDot draws a dot at each value.
+	MarkerDot
+
+	// MarkerCross draws a cross at each value.
+	MarkerCross
+)
+
+// SeriesMarkers draws a marker in the cell of each value of the series.
+// The markers use the cell options of the series and replace the braille
+// pixels in their cells. Markers are also drawn for isolated values
+// surrounded by NaN values, which otherwise aren't visible, since there is no
+// line to draw.
+func SeriesMarkers(ms MarkerStyle) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.markers = ms
+	})
+}
+
+// SeriesLineDash draws the line of the series dashed. The pattern contains
+// lengths in braille pixels of the alternating drawn and skipped parts of the
+// line, starting with a drawn part. The pattern must contain an even number
+// of positive lengths, e.g. SeriesLineDash(2, 1) draws two pixels and skips
+// one. The pattern continues across the consecutive values of the series.
+func SeriesLineDash(pattern ...int) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		// Copy to avoid external modifications. See #174.
+		opts.dashPattern = make([]int, len(pattern))
+		copy(opts.dashPattern, pattern)
+	})
+}
+
+// plotValue returns the value that should be plotted for the provided value
+// of a series. Returns math.NaN if the value cannot be plotted on the Y axis.
+func (lc *LineChart) plotValue(v float64) float64 {
+	if lc.opts.yAxisScale != YAxisLogarithmic {
+		return v
+	}
+	if floor := lc.opts.yAxisLogFloor; floor > 0 && v < floor {
+		return floor
+	}
+	if v <= 0 {
+		return math.NaN()
+	}
+	return v
+}
+
+// logYMinMax determines the min and max values for the Y axis with the
+// YAxisLogarithmic scale, ignoring values that cannot be plotted.
+// If second is true, determines the values for the second Y axis.
+func (lc *LineChart) logYMinMax(second bool) (float64, float64) {
+	var values []float64
+	for _, sv := range lc.series {
+		if sv.secondYAxis != second {
+			continue
+		}
+		for _, v := range sv.plotted() {
+			values = append(values, lc.plotValue(v))
+		}
+	}
+	if lc.opts.yAxisCustomScale != nil && !second {
+		values = append(values,
+			lc.plotValue(lc.opts.yAxisCustomScale.min),
+			lc.plotValue(lc.opts.yAxisCustomScale.max),
+		)
+	}
+	return minMax(values)
+}
+
+// clipValue clips the plotted value to the bounds of the Y axis pinned by the
+// YAxisMin and YAxisMax options. Values on the second Y axis aren't clipped.
+func (lc *LineChart) clipValue(v float64, second bool) float64 {
+	if second || math.IsNaN(v) {
+		return v
+	}
+	if min := lc.opts.yAxisMin; min != nil && v < *min {
+		return *min
+	}
+	if max := lc.opts.yAxisMax; max != nil && v > *max {
+		return *max
+	}
+	return v
+}
+
+// yMinMax determines the min and max values for the Y axis, honoring the
+// bounds pinned by the YAxisMin and YAxisMax options.
+// If second is true, determines the values for the second Y axis.
+func (lc *LineChart) yMinMax(second bool) (float64, float64) {
+	min, max := lc.autoYMinMax(second)
+	if second {
+		return min, max
+	}
+
+	min, max = lc.clipValue(min, second), lc.clipValue(max, second)
+	if pinned := lc.opts.yAxisMin; pinned != nil {
+		min = *pinned
+	}
+	if pinned := lc.opts.yAxisMax; pinned != nil {
+		max = *pinned
+	}
+	return min, max
+}
+
+// autoYMinMax determines the min and max values for the Y axis from the
+// series.
+// If second is true, determines the values for the second Y axis.
+func (lc *LineChart) autoYMinMax(second bool) (float64, float64) {
+	if lc.opts.yAxisScale == YAxisLogarithmic {
+		return lc.logYMinMax(second)
+	}
+
 	var (
 		minimums []float64
 		maximums []float64
 	)
 	for _, sv := range lc.series {
+		if sv.secondYAxis != second {
+			continue
+		}
 		minimums = append(minimums, sv.min)
 		maximums = append(maximums, sv.max)
 	}
 
-	if lc.opts.yAxisCustomScale != nil {
+	if lc.opts.yAxisCustomScale != nil && !second {
 		minimums = append(minimums, lc.opts.yAxisCustomScale.min)
 		maximums = append(maximums, lc.opts.yAxisCustomScale.max)
 	}
@@ -199,7 +426,8 @@ func (lc *LineChart) ValueCapacity() int {
 // Series sets the values that should be displayed as the line chart with the
 // provided label.
 // The values that should not be displayed on the line chart should be represented
-// as math.NaN values on the values slice.
+// as math.NaN values on the values slice. Missing values create gaps, the line
+// isn't drawn across them.
 // Subsequent calls with the same label replace any previously provided values.
 func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption) error {
 	if label == "" {
@@ -222,27 +450,120 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 				return fmt.Errorf("invalid label %d -> %q provided in SeriesXLabels, values cannot be empty", i, t)
 			}
 		}
-		lc.xLabels = series.xLabels
+	}
+	if tl := series.xTimes; tl != nil {
+		if tl.Layout == "" {
+			return errors.New("the layout provided in SeriesXTimes cannot be empty")
+		}
+		for i := 1; i < len(tl.Times); i++ {
+			if tl.Times[i].Before(tl.Times[i-1]) {
+				return fmt.Errorf("the times provided in SeriesXTimes must be in a non-decreasing order, time at position %d is before the previous one", i)
+			}
+		}
+	}
+	if _, ok := markerStyleNames[series.markers]; !ok {
+		return fmt.Errorf("unsupported marker style %v provided in SeriesMarkers", series.markers)
+	}
+	if p := series.dashPattern; p != nil {
+		if len(p) == 0 || len(p)%2 != 0 {
+			return fmt.Errorf("invalid pattern %v provided in SeriesLineDash, must contain an even number of lengths", p)
+		}
+		for _, l := range p {
+			if l <= 0 {
+				return fmt.Errorf("invalid pattern %v provided in SeriesLineDash, lengths must be positive", p)
+			}
+		}
+	}
+
+	if lc.opts.stackedAreas && !series.secondYAxis {
+		for name, sv := range lc.series {
+			if name == label || sv.secondYAxis {
+				continue
+			}
+			if got, want := len(series.values), len(sv.values); got != want {
+				return fmt.Errorf("the stacked series must share the X values, series %q has %d values, but series %q has %d", label, got, name, want)
+			}
+		}
 	}
 
+	// All the options are valid, only now update the chart.
+	if series.xLabelsSet {
+		lc.xLabels = series.xLabels
+		lc.xTimes = nil
+	}
+	if tl := series.xTimes; tl != nil {
+		lc.xTimes = tl
+		lc.xLabels = tl.Formatted()
+	}
 	lc.series[label] = series
-	yMin, yMax := lc.yMinMax()
-	lc.yMin = yMin
-	lc.yMax = yMax
+	lc.stack()
+	lc.yMin, lc.yMax = lc.yMinMax(false)
+	lc.y2Min, lc.y2Max = lc.yMinMax(true)
 	return nil
 }
 
+// stack computes the cumulative sums of the stacked series when the
+// StackedAreas option is provided.
+func (lc *LineChart) stack() {
+	if !lc.opts.stackedAreas {
+		return
+	}
+
+	var names []string
+	for name, sv := range lc.series {
+		if !sv.secondYAxis {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var sums []float64
+	for _, name := range names {
+		sv := lc.series[name]
+		sv.base = sums
+		sums = make([]float64, len(sv.values))
+		sv.stacked = make([]float64, len(sv.values))
+		for i, v := range sv.values {
+			if sv.base != nil {
+				sums[i] = sv.base[i]
+			}
+			if math.IsNaN(v) {
+				sv.stacked[i] = math.NaN()
+				continue
+			}
+			sums[i] += v
+			sv.stacked[i] = sums[i]
+		}
+		sv.min, sv.max = minMax(sv.stacked)
+	}
+}
+
+// hasSecondYAxis asserts whether any of the series is bound to the second Y
+// axis.
+func (lc *LineChart) hasSecondYAxis() bool {
+	for _, sv := range lc.series {
+		if sv.secondYAxis {
+			return true
+		}
+	}
+	return false
+}
+
 // xDetails returns the details for the X axis given the specified minimum and
-// maximum value to display.
-func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axes.XDetails, error) {
+// maximum value to display. The xAr is the area of the canvas available to
+// the X axis, see xArea. The lo is the orientation of the labels, see
+// xLabelOrientation.
+func (lc *LineChart) xDetails(xAr image.Rectangle, reqYWidth, min, max int, lo axes.LabelOrientation) (*axes.XDetails, error) {
 	xp := &axes.XProperties{
 		Min:          min,
 		Max:          max,
 		ReqYWidth:    reqYWidth,
 		CustomLabels: lc.xLabels,
-		LO:           lc.opts.xLabelOrientation,
+		LO:           lo,
+		TimeLabels:   lc.xTimes,
+		LabelEvery:   lc.opts.xLabelEvery,
 	}
-	xd, err := axes.NewXDetails(cvs.Area(), xp)
+	xd, err := axes.NewXDetails(xAr, xp)
 	if err != nil {
 		return nil, fmt.Errorf("NewXDetails => %v", err)
 	}
@@ -254,7 +575,7 @@ func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axe
 // If the capacity cannot accommodate all the values, the starting value of the
 // X axis is adjusted so that it displays the last n values that fit.
 // Returns unadjusted xd if all the values fit.
-func (lc *LineChart) xDetailsForCap(cvs *canvas.Canvas, bc *braille.Canvas, xd *axes.XDetails, yd *axes.YDetails) (*axes.XDetails, error) {
+func (lc *LineChart) xDetailsForCap(xAr image.Rectangle, bc *braille.Canvas, xd *axes.XDetails, yd *axes.YDetails) (*axes.XDetails, error) {
 	lc.capacity = bc.Area().Dx()
 	values := int(xd.Scale.Max.Value) - int(xd.Scale.Min.Value) + 1
 	if !lc.opts.xAxisUnscaled || values <= lc.capacity {
@@ -264,35 +585,96 @@ func (lc *LineChart) xDetailsForCap(cvs *canvas.Canvas, bc *braille.Canvas, xd *
 	diff := values - lc.capacity
 	xMin := int(xd.Scale.Min.Value) + diff
 	xMax := int(xd.Scale.Max.Value)
-	unscaledXD, err := lc.xDetails(cvs, yd.Start.X, xMin, xMax)
+	unscaledXD, err := lc.xDetails(xAr, yd.Start.X, xMin, xMax, xd.Properties.LO)
 	if err != nil {
 		return nil, err
 	}
 	return unscaledXD, nil
 }
 
+// xArea returns the area of the canvas available to the X axis, i.e. the
+// entire canvas or the part of it to the left of the second Y axis.
+func xArea(cvs *canvas.Canvas, yd2 *axes.YDetails) image.Rectangle {
+	ar := cvs.Area()
+	if yd2 != nil {
+		ar.Max.X = yd2.Start.X
+	}
+	return ar
+}
+
+// xLabelOrientation returns the orientation of the labels under the X axis
+// on the canvas. The orientation only depends on the canvas if the labels
+// were requested to fit, see XLabelsFit.
+func (lc *LineChart) xLabelOrientation(cvs *canvas.Canvas) (axes.LabelOrientation, error) {
+	if !lc.opts.xLabelsFit {
+		return lc.opts.xLabelOrientation, nil
+	}
+
+	reqYWidth := axes.RequiredWidth(lc.yMin, lc.yMax)
+	xp := &axes.XProperties{
+		Max:          lc.maxXValue(),
+		ReqYWidth:    reqYWidth,
+		CustomLabels: lc.xLabels,
+		TimeLabels:   lc.xTimes,
+	}
+	xAr := cvs.Area()
+	if lc.hasSecondYAxis() {
+		xAr.Max.X -= axes.RequiredWidth(lc.y2Min, lc.y2Max)
+	}
+	// The same minimum height of the graph as in minSize.
+	lo, err := axes.FittingOrientation(xAr, xp, 2)
+	if err != nil {
+		return 0, fmt.Errorf("FittingOrientation => %v", err)
+	}
+	return lo, nil
+}
+
 // axesDetails determines the details about the X and Y axes.
-func (lc *LineChart) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDetails, error) {
-	reqXHeight := axes.RequiredHeight(lc.maxXValue(), lc.xLabels, lc.opts.xLabelOrientation)
+// The returned details of the second Y axis are nil if no series is bound to
+// it.
+func (lc *LineChart) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDetails, *axes.YDetails, error) {
+	lo, err := lc.xLabelOrientation(cvs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	reqXHeight := axes.RequiredHeight(lc.maxXValue(), lc.xLabels, lo)
+	scaleMode := lc.opts.yAxisMode
+	if lc.opts.yAxisScale == YAxisLogarithmic {
+		scaleMode = axes.YScaleModeLogarithmic
+	}
 	yp := &axes.YProperties{
 		Min:            lc.yMin,
 		Max:            lc.yMax,
 		ReqXHeight:     reqXHeight,
-		ScaleMode:      lc.opts.yAxisMode,
+		ScaleMode:      scaleMode,
 		ValueFormatter: lc.opts.yAxisValueFormatter,
 	}
-	yd, err := axes.NewYDetails(cvs.Area(), yp)
+	var yd2 *axes.YDetails
+	ydAr := cvs.Area()
+	if lc.hasSecondYAxis() {
+		yp2 := *yp // Shallow copy.
+		yp2.Min = lc.y2Min
+		yp2.Max = lc.y2Max
+		d, err := axes.NewSecondYDetails(cvs.Area(), axes.RequiredWidth(lc.yMin, lc.yMax), &yp2)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("NewSecondYDetails => %v", err)
+		}
+		yd2 = d
+		ydAr.Max.X -= yd2.Width
+	}
+
+	yd, err := axes.NewYDetails(ydAr, yp)
 	if err != nil {
-		return nil, nil, fmt.Errorf("NewYDetails => %v", err)
+		return nil, nil, nil, fmt.Errorf("NewYDetails => %v", err)
 	}
 
 	const xMin = 0
 	xMax := lc.maxXValue()
-	xd, err := lc.xDetails(cvs, yd.Start.X, xMin, xMax)
+	xd, err := lc.xDetails(xArea(cvs, yd2), yd.Start.X, xMin, xMax, lo)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return xd, yd, nil
+	return xd, yd, yd2, nil
 }
 
 // Draw draws the values as line charts.
@@ -309,25 +691,36 @@ func (lc *LineChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		return draw.ResizeNeeded(cvs)
 	}
 
-	xd, yd, err := lc.axesDetails(cvs)
+	xd, yd, yd2, err := lc.axesDetails(cvs)
 	if err != nil {
 		return err
 	}
 
-	adjXD, err := lc.drawSeries(cvs, xd, yd)
+	adjXD, err := lc.drawSeries(cvs, xd, yd, yd2)
 	if err != nil {
 		return err
 	}
-	return lc.drawAxes(cvs, adjXD, yd)
+	return lc.drawAxes(cvs, adjXD, yd, yd2)
 }
 
 // drawAxes draws the X,Y axes and their labels.
-func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) error {
+// The yd2 are the details of the second Y axis or nil if it isn't drawn.
+func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd, yd2 *axes.YDetails) error {
 	lines := []draw.HVLine{
 		{Start: yd.Start, End: yd.End},
 		{Start: xd.Start, End: xd.End},
 	}
-	if err := draw.HVLines(cvs, lines, draw.HVLineCellOpts(lc.opts.axesCellOpts...)); err != nil {
+	if yd2 != nil {
+		lines = append(lines,
+			draw.HVLine{Start: yd2.Start, End: yd2.End},
+			// Connect the X axis with the second Y axis.
+			draw.HVLine{Start: xd.End, End: yd2.End},
+		)
+	}
+	if err := draw.HVLines(cvs, lines,
+		draw.HVLineStyle(lc.opts.axesLineStyle),
+		draw.HVLineCellOpts(lc.opts.axesCellOpts...),
+	); err != nil {
 		return fmt.Errorf("failed to draw the axes: %v", err)
 	}
 
@@ -341,8 +734,19 @@ func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YD
 		}
 	}
 
+	if yd2 != nil {
+		for _, l := range yd2.Labels {
+			if err := draw.Text(cvs, l.Value.Text(), l.Pos,
+				draw.TextOverrunMode(draw.OverrunModeThreeDot),
+				draw.TextCellOpts(lc.opts.secondYLabelCellOpts...),
+			); err != nil {
+				return fmt.Errorf("failed to draw the second Y labels: %v", err)
+			}
+		}
+	}
+
 	for _, l := range xd.Labels {
-		switch lc.opts.xLabelOrientation {
+		switch xd.Properties.LO {
 		case axes.LabelOrientationHorizontal:
 			if err := draw.Text(cvs, l.Value.Text(), l.Pos, draw.TextCellOpts(lc.opts.xLabelCellOpts...)); err != nil {
 				return fmt.Errorf("failed to draw the X horizontal labels: %v", err)
@@ -362,39 +766,44 @@ func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YD
 
 // graphAr returns the area available for the graph itself sized so that it
 // fits between the axes and the canvas borders.
-func (lc *LineChart) graphAr(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) image.Rectangle {
-	return image.Rect(yd.Start.X+1, yd.Start.Y, cvs.Area().Max.X, xd.End.Y)
+func (lc *LineChart) graphAr(cvs *canvas.Canvas, xd *axes.XDetails, yd, yd2 *axes.YDetails) image.Rectangle {
+	return image.Rect(yd.Start.X+1, yd.Start.Y, xArea(cvs, yd2).Max.X, xd.End.Y)
 }
 
 // drawSeries draws the graph representing the stored series.
 // Returns XDetails that might be adjusted to not start at zero value if some
 // of the series didn't fit the graphs and XAxisUnscaled was provided.
 // If the series has NaN values they will be ignored and not draw on the graph.
-func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) (*axes.XDetails, error) {
-	graphAr := lc.graphAr(cvs, xd, yd)
+func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd, yd2 *axes.YDetails) (*axes.XDetails, error) {
+	graphAr := lc.graphAr(cvs, xd, yd, yd2)
 	bc, err := braille.New(graphAr)
 	if err != nil {
 		return nil, err
 	}
 
-	xdForCap, err := lc.xDetailsForCap(cvs, bc, xd, yd)
+	xAr := xArea(cvs, yd2)
+	xdForCap, err := lc.xDetailsForCap(xAr, bc, xd, yd)
 	if err != nil {
 		return nil, err
 	}
 
 	if lc.zoom == nil {
-		z, err := zoom.New(xdForCap, cvs.Area(), graphAr, zoom.ScrollStep(lc.opts.zoomStepPercent))
+		z, err := zoom.New(xdForCap, xAr, graphAr, zoom.ScrollStep(lc.opts.zoomStepPercent))
 		if err != nil {
 			return nil, err
 		}
 		lc.zoom = z
 	} else {
-		if err := lc.zoom.Update(xdForCap, cvs.Area(), graphAr); err != nil {
+		if err := lc.zoom.Update(xdForCap, xAr, graphAr); err != nil {
 			return nil, err
 		}
 	}
 
 	xdZoomed := lc.zoom.Zoom()
+	if err := lc.drawXMarkerLines(bc, xdZoomed); err != nil {
+		return nil, err
+	}
+
 	var names []string
 	for name := range lc.series {
 		names = append(names, name)
@@ -409,52 +818,58 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 		if got := len(sv.values); got <= 1 {
 			continue
 		}
+		ys := yd.Scale
+		if sv.secondYAxis {
+			ys = yd2.Scale
+		}
 
-		var prev float64
-		for i := 1; i < len(sv.values); i++ {
-			v := sv.values[i]
-			prev = sv.values[i-1]
-
-			// Skip the values that are missing.
-			if math.IsNaN(v) || math.IsNaN(prev) {
-				continue
+		// segments are the line segments between the visible values.
+		runs, err := lc.seriesRuns(name, sv, xdZoomed, ys)
+		if err != nil {
+			return nil, err
+		}
+		var segments [][2]image.Point
+		for _, run := range runs {
+			if !lc.opts.disableDownsampling {
+				run = downsample(run)
 			}
-
-			if i < int(xdZoomed.Scale.Min.Value)+1 || i > int(xdZoomed.Scale.Max.Value) {
-				// Don't draw lines for values that aren't supposed to be visible.
-				// These are either values outside of the current zoom or
-				// values at the beginning of a series that falls before athe
-				// start of an unscaled X axis when the XAxisUnscaled option is
-				// provided.
-				continue
+			for i := 1; i < len(run); i++ {
+				segments = append(segments, [2]image.Point{run[i-1], run[i]})
 			}
+		}
 
-			startX, err := xdZoomed.Scale.ValueToPixel(i - 1)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i-1, xdZoomed.Scale, i-1, err)
+		switch {
+		case sv.stacked != nil:
+			if err := lc.drawBand(bc, name, sv, xdZoomed, ys); err != nil {
+				return nil, err
 			}
-			endX, err := xdZoomed.Scale.ValueToPixel(i)
+		case sv.fill:
+			baseY, err := fillBaseline(bc, ys)
 			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i, xdZoomed.Scale, i, err)
+				return nil, fmt.Errorf("failure for series %v: %v", name, err)
 			}
-
-			startY, err := yd.Scale.ValueToPixel(prev)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i-1, yd.Scale, prev, err)
+			for _, seg := range segments {
+				if err := draw.BrailleLineFill(bc, seg[0], seg[1], baseY,
+					draw.BrailleLineCellOpts(sv.fillCellOpts...),
+				); err != nil {
+					return nil, fmt.Errorf("draw.BrailleLineFill => %v", err)
+				}
 			}
-
-			endY, err := yd.Scale.ValueToPixel(v)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i, yd.Scale, v, err)
+		}
+		// dashPos is the position in the dash pattern, it continues across
+		// connected segments.
+		var dashPos int
+		for i, seg := range segments {
+			if i > 0 && seg[0] != segments[i-1][1] {
+				dashPos = 0
 			}
-
-			if err := draw.BrailleLine(bc,
-				image.Point{startX, startY},
-				image.Point{endX, endY},
+			if err := draw.BrailleLine(bc, seg[0], seg[1],
 				draw.BrailleLineCellOpts(sv.seriesCellOpts...),
+				draw.BrailleLineDash(dashPos, sv.dashPattern...),
 			); err != nil {
 				return nil, fmt.Errorf("draw.BrailleLine => %v", err)
 			}
+			dashPos += lineLength(seg[0], seg[1])
 		}
 	}
 
@@ -467,9 +882,430 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 	if err := bc.CopyTo(cvs); err != nil {
 		return nil, fmt.Errorf("bc.Apply => %v", err)
 	}
+	if err := lc.drawMarkers(cvs, graphAr, names, xdZoomed, yd, yd2); err != nil {
+		return nil, err
+	}
+	if err := lc.updateLastRender(graphAr, names, xdZoomed, yd, yd2); err != nil {
+		return nil, err
+	}
+	if err := lc.drawXMarkerLabels(cvs, graphAr, xdZoomed); err != nil {
+		return nil, err
+	}
+	if err := lc.drawLegend(cvs, graphAr, names); err != nil {
+		return nil, err
+	}
 	return xdZoomed, nil
 }
 
+// visibleRange returns the positions of the first and the last value of the
+// series that are visible on the X axis.
+func visibleRange(sv *seriesValues, xdZoomed *axes.XDetails) (int, int) {
+	// Values outside of the X axis are either outside of the current zoom or
+	// at the beginning of a series that falls before the start of an unscaled
+	// X axis when the XAxisUnscaled option is provided.
+	first := int(xdZoomed.Scale.Min.Value)
+	if first < 0 {
+		first = 0
+	}
+	last := int(xdZoomed.Scale.Max.Value)
+	if max := len(sv.values) - 1; last > max {
+		last = max
+	}
+	return first, last
+}
+
+// seriesRuns returns the pixels of the values of the series that are visible
+// on the X axis. The values are split into runs of consecutive values, the
+// line is broken between two runs, because the values between them are
+// missing or cannot be plotted.
+func (lc *LineChart) seriesRuns(name string, sv *seriesValues, xdZoomed *axes.XDetails, ys *axes.YScale) ([][]image.Point, error) {
+	first, last := visibleRange(sv, xdZoomed)
+	plotted := sv.plotted()
+
+	var (
+		runs [][]image.Point
+		run  []image.Point
+	)
+	for i := first; i <= last; i++ {
+		v := lc.clipValue(lc.plotValue(plotted[i]), sv.secondYAxis)
+		// Skip the values that are missing or cannot be plotted.
+		if math.IsNaN(v) {
+			if len(run) > 0 {
+				runs = append(runs, run)
+				run = nil
+			}
+			continue
+		}
+
+		x, err := xdZoomed.Scale.ValueToPixel(i)
+		if err != nil {
+			return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i, xdZoomed.Scale, i, err)
+		}
+		y, err := ys.ValueToPixel(v)
+		if err != nil {
+			return nil, fmt.Errorf("failure for series %v[%d] on scale %v, ValueToPixel(%v) => %v", name, i, ys, v, err)
+		}
+		run = append(run, image.Point{x, y})
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// drawBand fills the band between the line of a stacked series and the line
+// of the series stacked before it, or the baseline for the first stacked
+// series. The band isn't filled around missing values.
+func (lc *LineChart) drawBand(bc *braille.Canvas, name string, sv *seriesValues, xdZoomed *axes.XDetails, ys *axes.YScale) error {
+	baseline, err := fillBaseline(bc, ys)
+	if err != nil {
+		return fmt.Errorf("failure for series %v: %v", name, err)
+	}
+	// pixel returns the pixel of the value at position i or false if the
+	// value cannot be plotted.
+	pixel := func(values []float64, i int) (image.Point, bool, error) {
+		v := lc.clipValue(lc.plotValue(values[i]), sv.secondYAxis)
+		if math.IsNaN(v) {
+			return image.ZP, false, nil
+		}
+		x, err := xdZoomed.Scale.ValueToPixel(i)
+		if err != nil {
+			return image.ZP, false, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i, xdZoomed.Scale, i, err)
+		}
+		y, err := ys.ValueToPixel(v)
+		if err != nil {
+			return image.ZP, false, fmt.Errorf("failure for series %v[%d] on scale %v, ValueToPixel(%v) => %v", name, i, ys, v, err)
+		}
+		return image.Point{x, y}, true, nil
+	}
+	// baseY returns the pixel row of the line of the series stacked before
+	// at position i.
+	baseY := func(i int) (int, error) {
+		if sv.base == nil {
+			return baseline, nil
+		}
+		p, ok, err := pixel(sv.base, i)
+		if err != nil || !ok {
+			return baseline, err
+		}
+		return p.Y, nil
+	}
+
+	opts := sv.seriesCellOpts
+	if sv.fill {
+		opts = sv.fillCellOpts
+	}
+	first, last := visibleRange(sv, xdZoomed)
+	for i := first + 1; i <= last; i++ {
+		start, startOK, err := pixel(sv.stacked, i-1)
+		if err != nil {
+			return err
+		}
+		end, endOK, err := pixel(sv.stacked, i)
+		if err != nil {
+			return err
+		}
+		if !startOK || !endOK {
+			continue
+		}
+		baseStartY, err := baseY(i - 1)
+		if err != nil {
+			return err
+		}
+		baseEndY, err := baseY(i)
+		if err != nil {
+			return err
+		}
+		if err := draw.BrailleBandFill(bc, start, end, baseStartY, baseEndY,
+			draw.BrailleLineCellOpts(opts...),
+		); err != nil {
+			return fmt.Errorf("draw.BrailleBandFill => %v", err)
+		}
+	}
+	return nil
+}
+
+// downsample reduces the pixels of a run of consecutive values to those
+// needed to draw the same line. Of consecutive pixels that fall into the same
+// pixel column only the first, the last, the top and the bottom one are kept
+// in their original order. The line between them covers the same pixels as the
+// line through all of them, so the peaks and the troughs are preserved.
+func downsample(run []image.Point) []image.Point {
+	var res []image.Point
+	for start := 0; start < len(run); {
+		end := start + 1
+		top, bottom := start, start
+		for ; end < len(run) && run[end].X == run[start].X; end++ {
+			if run[end].Y < run[top].Y {
+				top = end
+			}
+			if run[end].Y > run[bottom].Y {
+				bottom = end
+			}
+		}
+
+		keep := []int{start, top, bottom, end - 1}
+		sort.Ints(keep)
+		for i, idx := range keep {
+			if i > 0 && idx == keep[i-1] {
+				continue
+			}
+			res = append(res, run[idx])
+		}
+		start = end
+	}
+	return res
+}
+
+// visibleXMarker returns the pixel column of the X marker and a bool
+// indicating if it falls into the displayed range of the X axis.
+func visibleXMarker(m XMarker, xdZoomed *axes.XDetails) (int, bool, error) {
+	if fx := float64(m.X); fx < xdZoomed.Scale.Min.Value || fx > xdZoomed.Scale.Max.Value {
+		return 0, false, nil
+	}
+	x, err := xdZoomed.Scale.ValueToPixel(m.X)
+	if err != nil {
+		return 0, false, fmt.Errorf("failure for X marker at %d on scale %v, xdZoomed.Scale.ValueToPixel => %v", m.X, xdZoomed.Scale, err)
+	}
+	return x, true, nil
+}
+
+// drawXMarkerLines draws the vertical lines of the visible X markers.
+// Drawn before the series, so that the series keep their color in the cells
+// they share with the lines.
+func (lc *LineChart) drawXMarkerLines(bc *braille.Canvas, xdZoomed *axes.XDetails) error {
+	bottom := bc.Area().Max.Y - 1
+	for _, m := range lc.opts.xMarkers {
+		x, ok, err := visibleXMarker(m, xdZoomed)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := draw.BrailleLine(bc, image.Point{x, 0}, image.Point{x, bottom},
+			draw.BrailleLineCellOpts(cell.FgColor(m.Color)),
+		); err != nil {
+			return fmt.Errorf("failed to draw the X marker at %d: %v", m.X, err)
+		}
+	}
+	return nil
+}
+
+// drawXMarkerLabels draws the labels of the visible X markers on the first
+// line of the graph area, to the right of the marker lines.
+func (lc *LineChart) drawXMarkerLabels(cvs *canvas.Canvas, graphAr image.Rectangle, xdZoomed *axes.XDetails) error {
+	for _, m := range lc.opts.xMarkers {
+		if m.Label == "" {
+			continue
+		}
+		x, ok, err := visibleXMarker(m, xdZoomed)
+		if err != nil {
+			return err
+		}
+		start := image.Point{graphAr.Min.X + x/braille.ColMult + 1, graphAr.Min.Y}
+		if !ok || start.X >= graphAr.Max.X {
+			continue
+		}
+		if err := draw.Text(cvs, m.Label, start,
+			draw.TextMaxX(graphAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(cell.FgColor(m.Color)),
+		); err != nil {
+			return fmt.Errorf("failed to draw the label of the X marker at %d: %v", m.X, err)
+		}
+	}
+	return nil
+}
+
+// legendSwatch is the rune drawn in front of the label of each series in the
+// legend.
+const legendSwatch = '■'
+
+// legendArea returns the area of the legend with entries for the named
+// series inside the graph area. Returns a zero area if the legend doesn't
+// fit.
+func (lc *LineChart) legendArea(graphAr image.Rectangle, names []string) image.Rectangle {
+	var width int
+	for _, name := range names {
+		// The swatch and a space precede the label.
+		if w := runewidth.StringWidth(name) + 2; w > width {
+			width = w
+		}
+	}
+	if max := graphAr.Dx() / 2; width > max {
+		width = max
+	}
+	height := len(names)
+	if height > graphAr.Dy() {
+		height = graphAr.Dy()
+	}
+	// The legend must have room for the swatch and at least one cell of the
+	// label.
+	if width < 3 || height < 1 {
+		return image.ZR
+	}
+
+	min := graphAr.Min
+	switch lc.opts.legendCorner {
+	case CornerTopRight:
+		min.X = graphAr.Max.X - width
+	case CornerBottomRight:
+		min = image.Point{graphAr.Max.X - width, graphAr.Max.Y - height}
+	case CornerBottomLeft:
+		min.Y = graphAr.Max.Y - height
+	}
+	return image.Rect(min.X, min.Y, min.X+width, min.Y+height)
+}
+
+// drawLegend draws the legend with entries for the named series over the
+// graph area if the ShowLegend option was provided.
+func (lc *LineChart) drawLegend(cvs *canvas.Canvas, graphAr image.Rectangle, names []string) error {
+	if !lc.opts.legend || len(names) == 0 {
+		return nil
+	}
+	ar := lc.legendArea(graphAr, names)
+	if ar.Empty() {
+		return nil
+	}
+
+	if err := cvs.SetAreaCells(ar, ' ', cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault)); err != nil {
+		return fmt.Errorf("failed to clear the legend area: %v", err)
+	}
+	for i, name := range names[:ar.Dy()] {
+		start := image.Point{ar.Min.X, ar.Min.Y + i}
+		if _, err := cvs.SetCell(start, legendSwatch, lc.series[name].seriesCellOpts...); err != nil {
+			return fmt.Errorf("failed to draw the legend swatch of series %q: %v", name, err)
+		}
+		if err := draw.Text(cvs, name, start.Add(image.Point{2, 0}),
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return fmt.Errorf("failed to draw the legend label of series %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// lineLength returns the number of pixels between the start and the end of
+// a braille line, i.e. the length of its longer projection.
+func lineLength(start, end image.Point) int {
+	dx := numbers.Abs(end.X - start.X)
+	dy := numbers.Abs(end.Y - start.Y)
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// drawMarkers draws the markers of the series that have them at the visible
+// values. Series are processed in the order of the provided names.
+func (lc *LineChart) drawMarkers(cvs *canvas.Canvas, graphAr image.Rectangle, names []string, xdZoomed *axes.XDetails, yd, yd2 *axes.YDetails) error {
+	for _, name := range names {
+		sv := lc.series[name]
+		r, ok := markerStyleRunes[sv.markers]
+		if !ok || len(sv.values) <= 1 {
+			continue
+		}
+
+		cells, err := lc.valueCells(graphAr, name, xdZoomed, yd, yd2)
+		if err != nil {
+			return err
+		}
+		for _, p := range cells {
+			if _, err := cvs.SetCell(p, r, sv.seriesCellOpts...); err != nil {
+				return fmt.Errorf("failed to draw marker for series %v at %v: %v", name, p, err)
+			}
+		}
+	}
+	return nil
+}
+
+// valueCells returns the cells on the canvas where the values of the named
+// series are plotted. Values that are missing or fall outside of the
+// displayed range of the X axis are omitted.
+func (lc *LineChart) valueCells(graphAr image.Rectangle, name string, xdZoomed *axes.XDetails, yd, yd2 *axes.YDetails) ([]image.Point, error) {
+	sv := lc.series[name]
+	ys := yd.Scale
+	if sv.secondYAxis {
+		ys = yd2.Scale
+	}
+
+	var cells []image.Point
+	for i, raw := range sv.plotted() {
+		v := lc.clipValue(lc.plotValue(raw), sv.secondYAxis)
+		if math.IsNaN(v) {
+			continue
+		}
+		if i < int(xdZoomed.Scale.Min.Value) || i > int(xdZoomed.Scale.Max.Value) {
+			continue
+		}
+
+		x, err := xdZoomed.Scale.ValueToPixel(i)
+		if err != nil {
+			return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i, xdZoomed.Scale, i, err)
+		}
+		y, err := ys.ValueToPixel(v)
+		if err != nil {
+			return nil, fmt.Errorf("failure for series %v[%d] on scale %v, ValueToPixel(%v) => %v", name, i, ys, v, err)
+		}
+		cells = append(cells, graphAr.Min.Add(image.Point{x / braille.ColMult, y / braille.RowMult}))
+	}
+	return cells, nil
+}
+
+// updateLastRender records the cells of the visible values of all the series,
+// see LastRender.
+func (lc *LineChart) updateLastRender(graphAr image.Rectangle, names []string, xdZoomed *axes.XDetails, yd, yd2 *axes.YDetails) error {
+	lc.lastRender = map[string][]image.Point{}
+	for _, name := range names {
+		cells, err := lc.valueCells(graphAr, name, xdZoomed, yd, yd2)
+		if err != nil {
+			return err
+		}
+		lc.lastRender[name] = cells
+	}
+	return nil
+}
+
+// LastRender returns the cells where the values of each of the series were
+// plotted on the last call to Draw, keyed by the name of the series. The
+// points are coordinates on the canvas provided to Draw, i.e. relative to the
+// widget, so they can be compared to the positions of mouse events.
+//
+// The points reflect the scale of the axes and the current zoom. Values that
+// were missing (NaN) or outside of the displayed range of the X axis are
+// omitted, the remaining points are in the order of the values.
+// Returns nil if Draw wasn't called yet.
+func (lc *LineChart) LastRender() map[string][]image.Point {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	if lc.lastRender == nil {
+		return nil
+	}
+	// Copy to avoid external modifications.
+	res := make(map[string][]image.Point, len(lc.lastRender))
+	for name, cells := range lc.lastRender {
+		res[name] = append([]image.Point(nil), cells...)
+	}
+	return res
+}
+
+// fillBaseline returns the pixel row toward which the area under series is
+// filled. This is the zero value if it is on the Y axis, otherwise the
+// boundary of the Y axis closest to zero.
+func fillBaseline(bc *braille.Canvas, ys *axes.YScale) (int, error) {
+	bottom := bc.Area().Max.Y - 1
+	switch {
+	case ys.IsLogarithmic() || ys.Min.Value >= 0:
+		return bottom, nil
+	case ys.Max.Value <= 0:
+		return 0, nil
+	}
+	return ys.ValueToPixel(0)
+}
+
 // highlightRange highlights the range of X columns on the braille canvas.
 func (lc *LineChart) highlightRange(bc *braille.Canvas, hRange *zoom.Range) error {
 	cellAr := bc.CellArea()
@@ -478,8 +1314,18 @@ func (lc *LineChart) highlightRange(bc *braille.Canvas, hRange *zoom.Range) erro
 }
 
 // Keyboard implements widgetapi.Widget.Keyboard.
+// Keyboard events are only supported with the ZoomResetKey option.
 func (lc *LineChart) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
-	return errors.New("the LineChart widget doesn't support keyboard events")
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if !lc.opts.zoomResetKeySet {
+		return errors.New("the LineChart widget doesn't support keyboard events without the ZoomResetKey option")
+	}
+	if k.Key == lc.opts.zoomResetKey && lc.zoom != nil {
+		lc.zoom.Reset()
+	}
+	return nil
 }
 
 // Mouse implements widgetapi.Widget.Mouse.
@@ -499,6 +1345,10 @@ func (lc *LineChart) minSize() image.Point {
 	// - n cells width for the Y axis and its labels as reported by it.
 	// - at least 1 cell width for the graph.
 	reqWidth := axes.RequiredWidth(lc.yMin, lc.yMax) + 1
+	if lc.hasSecondYAxis() {
+		// And n cells width for the second Y axis and its labels.
+		reqWidth += axes.RequiredWidth(lc.y2Min, lc.y2Max)
+	}
 
 	// And for the height:
 	// - n cells width for the X axis and its labels as reported by it.
@@ -512,9 +1362,14 @@ func (lc *LineChart) Options() widgetapi.Options {
 	lc.mu.RLock()
 	defer lc.mu.RUnlock()
 
+	ks := widgetapi.KeyScopeNone
+	if lc.opts.zoomResetKeySet {
+		ks = widgetapi.KeyScopeFocused
+	}
 	return widgetapi.Options{
-		MinimumSize: lc.minSize(),
-		WantMouse:   widgetapi.MouseScopeGlobal,
+		MinimumSize:  lc.minSize(),
+		WantMouse:    widgetapi.MouseScopeGlobal,
+		WantKeyboard: ks,
 	}
 }
 
@@ -19,9 +19,12 @@ import (
 	"image"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/braille/testbraille"
@@ -94,6 +97,40 @@ func TestLineChartDraws(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc:   "fails when YAxisMin isn't a finite number",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisMin(math.Inf(-1)),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails when YAxisMax is NaN",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisMax(math.NaN()),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails when YAxisMin isn't less than YAxisMax",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisMin(1),
+				YAxisMax(1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails when YAxisMin isn't positive on the logarithmic scale",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisMode(YAxisLogarithmic),
+				YAxisMin(0),
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "series fails without name for the series",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -209,6 +246,38 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "fails with axes line style None",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				AxesLineStyle(linestyle.None),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "sets axes line style",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				AxesLineStyle(linestyle.Double),
+			},
+			wantCapacity: 2,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				testdraw.MustText(c, "║", image.Point{1, 0})
+				testdraw.MustText(c, "║", image.Point{1, 1})
+				testdraw.MustText(c, "╚═", image.Point{1, 2})
+
+				// Zero value labels.
+				testdraw.MustText(c, "0", image.Point{0, 1})
+				testdraw.MustText(c, "0", image.Point{2, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "sets label cell options",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -277,36 +346,68 @@ func TestLineChartDraws(t *testing.T) {
 			},
 		},
 		{
-			desc: "custom Y scale, zero based positive, values fit",
-			opts: []Option{
-				YAxisCustomScale(0, 200),
+			desc:   "fails on empty layout for X axis times",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100},
+					SeriesXTimes([]time.Time{
+						time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+					}, ""),
+				)
 			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "fails on X axis times that aren't in order",
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{0, 100})
+				return lc.Series("first", []float64{0, 100},
+					SeriesXTimes([]time.Time{
+						time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+					}, "15:04"),
+				)
 			},
-			wantCapacity: 26,
+			wantWriteErr: true,
+		},
+		{
+			desc:   "draws labels for X axis times",
+			canvas: image.Rect(0, 0, 30, 10),
+			writes: func(lc *LineChart) error {
+				var times []time.Time
+				var values []float64
+				start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+				for i := 0; i <= 60; i++ {
+					times = append(times, start.Add(time.Duration(i)*time.Minute))
+					values = append(values, 0)
+				}
+				values[60] = 100
+				return lc.Series("first", values, SeriesXTimes(times, "15:04"))
+			},
+			wantCapacity: 48,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
-					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{29, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "0", image.Point{5, 7})
-				testdraw.MustText(c, "103.36", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{7, 9})
-				testdraw.MustText(c, "1", image.Point{19, 9})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "10:00", image.Point{6, 9})
+				testdraw.MustText(c, "10:30", image.Point{17, 9})
 
-				// Braille line.
-				graphAr := image.Rect(7, 0, 20, 8)
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 30, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 16})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{46, 31})
+				testdraw.MustBrailleLine(bc, image.Point{46, 31}, image.Point{47, 0})
 				testbraille.MustCopyTo(bc, c)
 
 				testcanvas.MustApply(c, ft)
@@ -314,36 +415,69 @@ func TestLineChartDraws(t *testing.T) {
 			},
 		},
 		{
-			desc: "custom Y scale, zero based negative, values fit",
-			opts: []Option{
-				YAxisCustomScale(-200, 0),
+			desc:   "fails on dash pattern with odd number of lengths",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100},
+					SeriesLineDash(2, 1, 1),
+				)
 			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "fails on dash pattern with non-positive length",
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{0, -200})
+				return lc.Series("first", []float64{0, 100},
+					SeriesLineDash(2, 0),
+				)
 			},
-			wantCapacity: 26,
+			wantWriteErr: true,
+		},
+		{
+			desc:   "fails on unsupported marker style",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100},
+					SeriesMarkers(MarkerStyle(-1)),
+				)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "draws a dashed line",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100},
+					SeriesCellOpts(cell.FgColor(cell.ColorBlue)),
+					SeriesLineDash(2, 1),
+				)
+			},
+			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
-					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "-200", image.Point{2, 7})
-				testdraw.MustText(c, "-96.64", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
 				testdraw.MustText(c, "1", image.Point{19, 9})
 
 				// Braille line.
-				graphAr := image.Rect(7, 0, 20, 8)
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{25, 31})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)),
+					draw.BrailleLineDash(0, 2, 1),
+				)
 				testbraille.MustCopyTo(bc, c)
 
 				testcanvas.MustApply(c, ft)
@@ -351,36 +485,41 @@ func TestLineChartDraws(t *testing.T) {
 			},
 		},
 		{
-			desc: "custom Y scale, negative and positive, values fit",
-			opts: []Option{
-				YAxisCustomScale(-200, 200),
-			},
+			desc:   "dash pattern continues across connected values",
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{0, 100})
+				return lc.Series("first", []float64{0, 50, 100},
+					SeriesLineDash(3, 2),
+				)
 			},
-			wantCapacity: 30,
+			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
-					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "-200", image.Point{0, 7})
-				testdraw.MustText(c, "6.57", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{5, 9})
-				testdraw.MustText(c, "1", image.Point{19, 9})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{12, 9})
+				testdraw.MustText(c, "2", image.Point{19, 9})
 
-				// Braille line.
-				graphAr := image.Rect(5, 0, 20, 8)
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{29, 8})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{13, 16},
+					draw.BrailleLineDash(0, 3, 2),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{13, 16}, image.Point{27, 0},
+					draw.BrailleLineDash(15, 3, 2),
+				)
 				testbraille.MustCopyTo(bc, c)
 
 				testcanvas.MustApply(c, ft)
@@ -388,50 +527,80 @@ func TestLineChartDraws(t *testing.T) {
 			},
 		},
 		{
-			desc: "custom Y scale, negative only, values fit",
-			opts: []Option{
-				YAxisCustomScale(-200, -100),
-			},
+			desc:   "draws markers on a sparse series",
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{-200, -100})
+				return lc.Series("first", []float64{0, math.NaN(), 50, 100},
+					SeriesCellOpts(cell.FgColor(cell.ColorBlue)),
+					SeriesMarkers(MarkerDot),
+				)
 			},
-			wantCapacity: 24,
+			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{7, 0}, End: image.Point{7, 8}},
-					{Start: image.Point{7, 8}, End: image.Point{19, 8}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "-200", image.Point{3, 7})
-				testdraw.MustText(c, "-148.32", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{8, 9})
-				testdraw.MustText(c, "1", image.Point{19, 9})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "3", image.Point{18, 9})
 
-				// Braille line.
-				graphAr := image.Rect(8, 0, 20, 8)
+				// Braille line between the two connected values.
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{23, 0})
+				testdraw.MustBrailleLine(bc, image.Point{17, 16}, image.Point{25, 0},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
 				testbraille.MustCopyTo(bc, c)
 
+				// Markers, including the isolated first value.
+				for _, p := range []image.Point{{6, 7}, {14, 4}, {18, 0}} {
+					testcanvas.MustSetCell(c, p, '•', cell.FgColor(cell.ColorBlue))
+				}
+
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc: "custom Y scale, negative and positive, values don't fit so adjusted",
+			desc: "fails on X marker with negative position",
 			opts: []Option{
-				YAxisCustomScale(-200, 200),
+				XMarkers([]XMarker{{X: -1}}),
+			},
+			canvas:  image.Rect(0, 0, 20, 10),
+			wantErr: true,
+		},
+		{
+			desc: "fails on X marker label with a newline",
+			opts: []Option{
+				XMarkers([]XMarker{{X: 1, Label: "a\nb"}}),
+			},
+			canvas:  image.Rect(0, 0, 20, 10),
+			wantErr: true,
+		},
+		{
+			desc: "draws X markers and clips the ones outside of the X axis",
+			opts: []Option{
+				XMarkers([]XMarker{
+					{X: 2, Color: cell.ColorRed, Label: "dep"},
+					{X: 7, Color: cell.ColorGreen, Label: "out"},
+				}),
 			},
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{-400, 400})
+				return lc.Series("first", []float64{0, math.NaN(), 50, 100},
+					SeriesCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
 			},
 			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
@@ -446,100 +615,167 @@ func TestLineChartDraws(t *testing.T) {
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "-400", image.Point{1, 7})
-				testdraw.MustText(c, "12.96", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
 				testdraw.MustText(c, "0", image.Point{6, 9})
-				testdraw.MustText(c, "1", image.Point{19, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "3", image.Point{18, 9})
 
-				// Braille line.
+				// The marker line under the series.
 				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testdraw.MustBrailleLine(bc, image.Point{17, 0}, image.Point{17, 31},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{17, 16}, image.Point{25, 0},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
 				testbraille.MustCopyTo(bc, c)
 
+				// The marker label.
+				testdraw.MustText(c, "dep", image.Point{15, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "draws anchored Y axis",
+			desc: "fails on unsupported legend corner",
+			opts: []Option{
+				ShowLegend(),
+				LegendCorner(Corner(-1)),
+			},
+			canvas:  image.Rect(0, 0, 20, 10),
+			wantErr: true,
+		},
+		{
+			desc: "draws the legend in the top right corner",
+			opts: []Option{
+				ShowLegend(),
+			},
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{1600, 1900})
+				if err := lc.Series("first", []float64{0, 100},
+					SeriesCellOpts(cell.FgColor(cell.ColorBlue)),
+				); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{100, 0},
+					SeriesCellOpts(cell.FgColor(cell.ColorRed)),
+				)
 			},
-			wantCapacity: 26,
+			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
-					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "0", image.Point{5, 7})
-				testdraw.MustText(c, "980.80", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
 				testdraw.MustText(c, "1", image.Point{19, 9})
 
-				// Braille line.
-				graphAr := image.Rect(7, 0, 20, 8)
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 5}, image.Point{25, 0})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{26, 31},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
 				testbraille.MustCopyTo(bc, c)
 
+				// The legend in the top right corner of the graph, it takes at most half of the graph
+				// width.
+				legendAr := image.Rect(13, 0, 20, 2)
+				testcanvas.MustSetAreaCells(c, legendAr, ' ', cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault))
+				testcanvas.MustSetCell(c, legendAr.Min, '■', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first", legendAr.Min.Add(image.Point{2, 0}))
+				testcanvas.MustSetCell(c, legendAr.Min.Add(image.Point{0, 1}), '■', cell.FgColor(cell.ColorRed))
+				testdraw.MustText(c, "seco…", legendAr.Min.Add(image.Point{2, 1}))
+
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc: "draws adaptive Y axis",
+			desc: "draws the legend in the bottom left corner",
 			opts: []Option{
-				YAxisAdaptive(),
+				ShowLegend(),
+				LegendCorner(CornerBottomLeft),
 			},
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{1600, 1900})
+				if err := lc.Series("first", []float64{0, 100},
+					SeriesCellOpts(cell.FgColor(cell.ColorBlue)),
+				); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{100, 0},
+					SeriesCellOpts(cell.FgColor(cell.ColorRed)),
+				)
 			},
-			wantCapacity: 24,
+			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{7, 0}, End: image.Point{7, 8}},
-					{Start: image.Point{7, 8}, End: image.Point{19, 8}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "1600", image.Point{3, 7})
-				testdraw.MustText(c, "1754.88", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{8, 9})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
 				testdraw.MustText(c, "1", image.Point{19, 9})
 
-				// Braille line.
-				graphAr := image.Rect(8, 0, 20, 8)
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{23, 0})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{26, 31},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
 				testbraille.MustCopyTo(bc, c)
 
+				// The legend in the bottom left corner of the graph, it takes at most half of the graph
+				// width.
+				legendAr := image.Rect(6, 6, 13, 8)
+				testcanvas.MustSetAreaCells(c, legendAr, ' ', cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault))
+				testcanvas.MustSetCell(c, legendAr.Min, '■', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first", legendAr.Min.Add(image.Point{2, 0}))
+				testcanvas.MustSetCell(c, legendAr.Min.Add(image.Point{0, 1}), '■', cell.FgColor(cell.ColorRed))
+				testdraw.MustText(c, "seco…", legendAr.Min.Add(image.Point{2, 1}))
+
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "custom X labels, horizontal by default",
+			desc:   "fills the area under a series",
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
-					0: "start",
-					1: "end",
-				}))
+				return lc.Series("first", []float64{0, 100},
+					SeriesCellOpts(cell.FgColor(cell.ColorBlue)),
+					SeriesFill(cell.FgColor(cell.ColorCyan)),
+				)
 			},
 			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
@@ -556,12 +792,18 @@ func TestLineChartDraws(t *testing.T) {
 				// Value labels.
 				testdraw.MustText(c, "0", image.Point{4, 7})
 				testdraw.MustText(c, "51.68", image.Point{0, 3})
-				testdraw.MustText(c, "start", image.Point{6, 9})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
 
-				// Braille line.
+				// Filled area and the braille line.
 				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testdraw.MustBrailleLineFill(bc, image.Point{0, 31}, image.Point{26, 0}, 31,
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorCyan)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
 				testbraille.MustCopyTo(bc, c)
 
 				testcanvas.MustApply(c, ft)
@@ -569,16 +811,17 @@ func TestLineChartDraws(t *testing.T) {
 			},
 		},
 		{
-			desc: "custom X labels, horizontal with option",
-			opts: []Option{
-				XLabelsHorizontal(),
-			},
+			desc:   "fills the areas of overlapping series in the draw order",
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
-					0: "start",
-					1: "end",
-				}))
+				if err := lc.Series("first", []float64{0, 100},
+					SeriesFill(cell.FgColor(cell.ColorCyan)),
+				); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{100, 0},
+					SeriesFill(cell.FgColor(cell.ColorRed)),
+				)
 			},
 			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
@@ -595,8 +838,718 @@ func TestLineChartDraws(t *testing.T) {
 				// Value labels.
 				testdraw.MustText(c, "0", image.Point{4, 7})
 				testdraw.MustText(c, "51.68", image.Point{0, 3})
-				testdraw.MustText(c, "start", image.Point{6, 9})
-
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Filled areas and the braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLineFill(bc, image.Point{0, 31}, image.Point{26, 0}, 31,
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorCyan)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testdraw.MustBrailleLineFill(bc, image.Point{0, 0}, image.Point{26, 31}, 31,
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{26, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fills toward the zero baseline",
+			opts: []Option{
+				YAxisCustomScale(-200, 200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesFill())
+			},
+			wantCapacity: 30,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
+					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-200", image.Point{0, 7})
+				testdraw.MustText(c, "6.57", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{5, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Filled area and the braille line.
+				graphAr := image.Rect(5, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLineFill(bc, image.Point{0, 16}, image.Point{29, 8}, 16)
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{29, 8})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "stacked series fail when they don't share the X values",
+			opts: []Option{
+				StackedAreas(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{0, 100, 50})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc: "stacks the series and fills the bands between them",
+			opts: []Option{
+				StackedAreas(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{50, 50},
+					SeriesFill(cell.FgColor(cell.ColorCyan)),
+				); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{0, 50},
+					SeriesCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// The band of the first series reaches from the baseline to
+				// its values, the band of the second series from the values
+				// of the first series to the sums.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLineFill(bc, image.Point{0, 16}, image.Point{26, 16}, 31,
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorCyan)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{26, 16})
+				testdraw.MustBrailleBandFill(bc, image.Point{0, 16}, image.Point{26, 0}, 16, 16,
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{26, 0},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "stacked band follows the line of the series below",
+			opts: []Option{
+				StackedAreas(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 50}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{50, 50},
+					SeriesFill(cell.FgColor(cell.ColorRed)),
+				)
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLineFill(bc, image.Point{0, 31}, image.Point{26, 16}, 31)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 16})
+				testdraw.MustBrailleBandFill(bc, image.Point{0, 16}, image.Point{26, 0}, 31, 16,
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom Y scale, zero based positive, values fit",
+			opts: []Option{
+				YAxisCustomScale(0, 200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "103.36", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 16})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "pinned Y bounds clip values outside of the range",
+			opts: []Option{
+				YAxisMin(0),
+				YAxisMax(200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{-50, 400})
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "103.36", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom Y scale, zero based negative, values fit",
+			opts: []Option{
+				YAxisCustomScale(-200, 0),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, -200})
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-200", image.Point{2, 7})
+				testdraw.MustText(c, "-96.64", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{25, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom Y scale, negative and positive, values fit",
+			opts: []Option{
+				YAxisCustomScale(-200, 200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 30,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
+					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-200", image.Point{0, 7})
+				testdraw.MustText(c, "6.57", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{5, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(5, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{29, 8})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom Y scale, negative only, values fit",
+			opts: []Option{
+				YAxisCustomScale(-200, -100),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{-200, -100})
+			},
+			wantCapacity: 24,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{7, 0}, End: image.Point{7, 8}},
+					{Start: image.Point{7, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-200", image.Point{3, 7})
+				testdraw.MustText(c, "-148.32", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{8, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(8, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{23, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom Y scale, negative and positive, values don't fit so adjusted",
+			opts: []Option{
+				YAxisCustomScale(-200, 200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{-400, 400})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-400", image.Point{1, 7})
+				testdraw.MustText(c, "12.96", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws anchored Y axis",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{1600, 1900})
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "980.80", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 5}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws adaptive Y axis",
+			opts: []Option{
+				YAxisAdaptive(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{1600, 1900})
+			},
+			wantCapacity: 24,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{7, 0}, End: image.Point{7, 8}},
+					{Start: image.Point{7, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "1600", image.Point{3, 7})
+				testdraw.MustText(c, "1754.88", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{8, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(8, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{23, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails with negative YAxisLogFloor",
+			opts: []Option{
+				YAxisMode(YAxisLogarithmic),
+				YAxisLogFloor(-1),
+			},
+			canvas:  image.Rect(0, 0, 3, 4),
+			wantErr: true,
+		},
+		{
+			desc: "draws logarithmic Y axis",
+			opts: []Option{
+				YAxisMode(YAxisLogarithmic),
+			},
+			canvas: image.Rect(0, 0, 20, 14),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0.01, 1, 100, 10000})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 12}},
+					{Start: image.Point{5, 12}, End: image.Point{19, 12}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0.01", image.Point{1, 11})
+				testdraw.MustText(c, "0.1", image.Point{2, 9})
+				testdraw.MustText(c, "1", image.Point{4, 7})
+				testdraw.MustText(c, "10", image.Point{3, 5})
+				testdraw.MustText(c, "100", image.Point{2, 4})
+				testdraw.MustText(c, "1000", image.Point{1, 2})
+				testdraw.MustText(c, "10000", image.Point{0, 0})
+				testdraw.MustText(c, "0", image.Point{6, 13})
+				testdraw.MustText(c, "1", image.Point{10, 13})
+				testdraw.MustText(c, "2", image.Point{14, 13})
+				testdraw.MustText(c, "3", image.Point{18, 13})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 12)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 47}, image.Point{8, 31})
+				testdraw.MustBrailleLine(bc, image.Point{8, 31}, image.Point{17, 16})
+				testdraw.MustBrailleLine(bc, image.Point{17, 16}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "logarithmic Y axis skips values that aren't positive",
+			opts: []Option{
+				YAxisMode(YAxisLogarithmic),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{-1, 0, 1, 10})
+			},
+			wantCapacity: 34,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{2, 0}, End: image.Point{2, 8}},
+					{Start: image.Point{2, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "1", image.Point{1, 7})
+				testdraw.MustText(c, "10", image.Point{0, 0})
+				testdraw.MustText(c, "0", image.Point{3, 9})
+				testdraw.MustText(c, "1", image.Point{8, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "3", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(3, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{22, 31}, image.Point{33, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "logarithmic Y axis clamps values to YAxisLogFloor",
+			opts: []Option{
+				YAxisMode(YAxisLogarithmic),
+				YAxisLogFloor(0.1),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 10, 5})
+			},
+			wantCapacity: 32,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{3, 0}, End: image.Point{3, 8}},
+					{Start: image.Point{3, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0.1", image.Point{0, 7})
+				testdraw.MustText(c, "1", image.Point{2, 3})
+				testdraw.MustText(c, "10", image.Point{1, 0})
+				testdraw.MustText(c, "0", image.Point{4, 9})
+				testdraw.MustText(c, "1", image.Point{11, 9})
+				testdraw.MustText(c, "2", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(4, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{15, 0})
+				testdraw.MustBrailleLine(bc, image.Point{15, 0}, image.Point{31, 5})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws second Y axis with independent scale",
+			opts: []Option{
+				SecondYAxisCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{5, 0}, SeriesSecondYAxis())
+			},
+			wantCapacity: 18,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y, X and second Y axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{14, 8}},
+					{Start: image.Point{15, 0}, End: image.Point{15, 8}},
+					{Start: image.Point{14, 8}, End: image.Point{15, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{16, 7}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "2.72", image.Point{16, 3}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{14, 9})
+
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 15, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{17, 0})
+				testdraw.MustBrailleLine(bc, image.Point{0, 2}, image.Point{17, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "custom X labels, horizontal by default",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
+					0: "start",
+					1: "end",
+				}))
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "start", image.Point{6, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom X labels, horizontal with option",
+			opts: []Option{
+				XLabelsHorizontal(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
+					0: "start",
+					1: "end",
+				}))
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "start", image.Point{6, 9})
+
 				// Braille line.
 				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
@@ -619,34 +1572,159 @@ func TestLineChartDraws(t *testing.T) {
 					1: "end",
 				}))
 			},
-			wantCapacity: 26,
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 4}},
+					{Start: image.Point{6, 4}, End: image.Point{19, 4}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 3})
+				testdraw.MustText(c, "80.040", image.Point{0, 0})
+				testdraw.MustVerticalText(c, "start", image.Point{7, 5})
+				testdraw.MustVerticalText(c, "end", image.Point{19, 5})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 4)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 15}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom X labels flow vertically when they don't fit horizontally",
+			opts: []Option{
+				XLabelsFit(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
+					0: "start",
+					1: "end",
+				}))
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 4}},
+					{Start: image.Point{6, 4}, End: image.Point{19, 4}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 3})
+				testdraw.MustText(c, "80.040", image.Point{0, 0})
+				testdraw.MustVerticalText(c, "start", image.Point{7, 5})
+				testdraw.MustVerticalText(c, "end", image.Point{19, 5})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 4)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 15}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "X labels flow horizontally when the canvas is too short for vertical labels",
+			opts: []Option{
+				XLabelsFit(),
+			},
+			canvas: image.Rect(0, 0, 20, 7),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
+					0: "start",
+					1: "end",
+				}))
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 5}},
+					{Start: image.Point{5, 5}, End: image.Point{19, 5}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 4})
+				testdraw.MustText(c, "84.32", image.Point{0, 0})
+				testdraw.MustText(c, "start", image.Point{6, 6})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 5)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 19}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws every second X label",
+			opts: []Option{
+				XLabelsEvery(2),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 50, 100})
+			},
+			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{6, 0}, End: image.Point{6, 4}},
-					{Start: image.Point{6, 4}, End: image.Point{19, 4}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
-				// Value labels.
-				testdraw.MustText(c, "0", image.Point{5, 3})
-				testdraw.MustText(c, "80.040", image.Point{0, 0})
-				testdraw.MustVerticalText(c, "start", image.Point{7, 5})
-				testdraw.MustVerticalText(c, "end", image.Point{19, 5})
+				// Value labels, the label "1" is skipped.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "2", image.Point{19, 9})
 
-				// Braille line.
-				graphAr := image.Rect(7, 0, 20, 4)
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 15}, image.Point{25, 0})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{13, 16})
+				testdraw.MustBrailleLine(bc, image.Point{13, 16}, image.Point{27, 0})
 				testbraille.MustCopyTo(bc, c)
 
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
+		{
+			desc: "fails with negative XLabelsEvery",
+			opts: []Option{
+				XLabelsEvery(-1),
+			},
+			canvas:  image.Rect(0, 0, 3, 4),
+			wantErr: true,
+		},
 		{
 			desc:   "sets series cell options",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -1594,6 +2672,43 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "interior NaN value breaks the line into two segments",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100, math.NaN(), 0, 100})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "4", image.Point{18, 9})
+
+				// Two separate braille lines, none across the NaN value.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{7, 0})
+				testdraw.MustBrailleLine(bc, image.Point{20, 31}, image.Point{27, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "more values than capacity, X rescales with NaN values ignored",
 			canvas: image.Rect(0, 0, 11, 10),
@@ -1906,6 +3021,55 @@ func TestKeyboard(t *testing.T) {
 	}
 }
 
+func TestZoomResetKey(t *testing.T) {
+	lc, err := New(ZoomResetKey(keyboard.KeyEsc))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := lc.Series("series", []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	c, err := canvas.New(image.Rect(0, 0, 20, 10))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := lc.Draw(c, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	base := lc.zoom.Zoom()
+
+	// Keys other than the reset key and resetting without zoom are no-ops.
+	for _, k := range []keyboard.Key{keyboard.KeyEnter, keyboard.KeyEsc} {
+		if err := lc.Keyboard(&terminalapi.Keyboard{Key: k}, &widgetapi.EventMeta{}); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+	if diff := pretty.Compare(base, lc.zoom.Zoom()); diff != "" {
+		t.Errorf("Zoom => unexpected diff after no-op keys (-want, +got):\n%s", diff)
+	}
+
+	for _, m := range []*terminalapi.Mouse{
+		{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+		{Position: image.Point{12, 0}, Button: mouse.ButtonLeft},
+		{Position: image.Point{12, 0}, Button: mouse.ButtonRelease},
+	} {
+		if err := lc.Mouse(m, &widgetapi.EventMeta{}); err != nil {
+			t.Fatalf("Mouse => unexpected error: %v", err)
+		}
+	}
+	if diff := pretty.Compare(base, lc.zoom.Zoom()); diff == "" {
+		t.Fatalf("Zoom => the mouse selection didn't zoom the X axis")
+	}
+
+	if err := lc.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEsc}, &widgetapi.EventMeta{}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare(base, lc.zoom.Zoom()); diff != "" {
+		t.Errorf("Zoom => unexpected diff after the reset key (-want, +got):\n%s", diff)
+	}
+}
+
 func TestMouseDoesNothingWithoutZoomTracker(t *testing.T) {
 	lc, err := New()
 	if err != nil {
@@ -1916,6 +3080,79 @@ func TestMouseDoesNothingWithoutZoomTracker(t *testing.T) {
 	}
 }
 
+func TestLastRender(t *testing.T) {
+	tests := []struct {
+		desc   string
+		series map[string][]float64
+		draw   bool
+		want   map[string][]image.Point
+	}{
+		{
+			desc: "nil before the first draw",
+			series: map[string][]float64{
+				"first": {0, 100},
+			},
+		},
+		{
+			desc: "cells of the values of a single series",
+			series: map[string][]float64{
+				"first": {0, 100},
+			},
+			draw: true,
+			want: map[string][]image.Point{
+				"first": {{6, 7}, {19, 0}},
+			},
+		},
+		{
+			desc: "cells of multiple series, omits missing values",
+			series: map[string][]float64{
+				"first":  {0, 100},
+				"second": {0, math.NaN(), 50, 100},
+			},
+			draw: true,
+			want: map[string][]image.Point{
+				"first":  {{6, 7}, {10, 0}},
+				"second": {{6, 7}, {14, 4}, {18, 0}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for name, values := range tc.series {
+				if err := lc.Series(name, values); err != nil {
+					t.Fatalf("Series => unexpected error: %v", err)
+				}
+			}
+			if tc.draw {
+				cvs := testcanvas.MustNew(image.Rect(0, 0, 20, 10))
+				if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			got := lc.LastRender()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("LastRender => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			// Modifications to the result don't affect the LineChart.
+			for _, cells := range got {
+				for i := range cells {
+					cells[i] = image.Point{-1, -1}
+				}
+			}
+			if diff := pretty.Compare(tc.want, lc.LastRender()); diff != "" {
+				t.Errorf("LastRender after modifying the result => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1931,6 +3168,17 @@ func TestOptions(t *testing.T) {
 				WantMouse:   widgetapi.MouseScopeGlobal,
 			},
 		},
+		{
+			desc: "requests keyboard events with ZoomResetKey",
+			opts: []Option{
+				ZoomResetKey(keyboard.KeyEsc),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 4},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+		},
 		{
 			desc: "reserves space for longer Y labels",
 			addSeries: func(lc *LineChart) error {
@@ -1951,6 +3199,19 @@ func TestOptions(t *testing.T) {
 				WantMouse:   widgetapi.MouseScopeGlobal,
 			},
 		},
+		{
+			desc: "reserves space for the second Y axis",
+			addSeries: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{0, 5}, SeriesSecondYAxis())
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{7, 4},
+				WantMouse:   widgetapi.MouseScopeGlobal,
+			},
+		},
 		{
 			desc: "reserves space for longer vertical X labels",
 			opts: []Option{
@@ -1998,3 +3259,262 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestSeriesFailsWithoutChanges(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []SeriesOption
+	}{
+		{
+			desc: "invalid markers after valid X labels",
+			opts: []SeriesOption{
+				SeriesXLabels(map[int]string{0: "new"}),
+				SeriesMarkers(MarkerStyle(-1)),
+			},
+		},
+		{
+			desc: "invalid dash pattern after valid X times",
+			opts: []SeriesOption{
+				SeriesXTimes([]time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, "15:04"),
+				SeriesLineDash(1),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			wantLabels := map[int]string{0: "old"}
+			if err := lc.Series("first", []float64{1, 2}, SeriesXLabels(wantLabels)); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			if err := lc.Series("second", []float64{3, 4}, tc.opts...); err == nil {
+				t.Fatalf("Series => nil error, want an error")
+			}
+			if diff := pretty.Compare(wantLabels, lc.xLabels); diff != "" {
+				t.Errorf("X labels after the failed Series => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if lc.xTimes != nil {
+				t.Errorf("X times after the failed Series => %v, want nil", lc.xTimes)
+			}
+			if _, ok := lc.series["second"]; ok {
+				t.Errorf("series after the failed Series => contains the failed series, want it absent")
+			}
+		})
+	}
+}
+
+func TestPinnedYBounds(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		values  []float64
+		wantMin float64
+		wantMax float64
+	}{
+		{
+			desc:    "auto-scaled without pinned bounds",
+			values:  []float64{10, 100},
+			wantMin: 10,
+			wantMax: 100,
+		},
+		{
+			desc:    "pinned minimum, auto-scaled maximum",
+			opts:    []Option{YAxisMin(-10)},
+			values:  []float64{10, 100},
+			wantMin: -10,
+			wantMax: 100,
+		},
+		{
+			desc:    "pinned maximum, auto-scaled minimum",
+			opts:    []Option{YAxisMax(50)},
+			values:  []float64{10, 100},
+			wantMin: 10,
+			wantMax: 50,
+		},
+		{
+			desc:    "auto-scaled bound is clipped to the pinned one",
+			opts:    []Option{YAxisMax(5)},
+			values:  []float64{10, 100},
+			wantMin: 5,
+			wantMax: 5,
+		},
+		{
+			desc:    "both bounds pinned",
+			opts:    []Option{YAxisMin(20), YAxisMax(30)},
+			values:  []float64{10, 100},
+			wantMin: 20,
+			wantMax: 30,
+		},
+		{
+			desc:    "pinned bounds don't expand with the custom scale",
+			opts:    []Option{YAxisCustomScale(0, 500), YAxisMax(200)},
+			values:  []float64{10, 100},
+			wantMin: 0,
+			wantMax: 200,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := lc.Series("series", tc.values); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			gotMin, gotMax := lc.yMinMax(false)
+			if gotMin != tc.wantMin || gotMax != tc.wantMax {
+				t.Errorf("yMinMax => (%v, %v), want (%v, %v)", gotMin, gotMax, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	tests := []struct {
+		desc string
+		run  []image.Point
+		want []image.Point
+	}{
+		{
+			desc: "empty run",
+		},
+		{
+			desc: "single point",
+			run:  []image.Point{{0, 0}},
+			want: []image.Point{{0, 0}},
+		},
+		{
+			desc: "keeps points in distinct columns",
+			run:  []image.Point{{0, 3}, {1, 0}, {2, 5}},
+			want: []image.Point{{0, 3}, {1, 0}, {2, 5}},
+		},
+		{
+			desc: "keeps the first, top, bottom and last point in a column",
+			run:  []image.Point{{0, 3}, {0, 2}, {0, 0}, {0, 1}, {0, 9}, {0, 4}, {0, 5}, {1, 5}},
+			want: []image.Point{{0, 3}, {0, 0}, {0, 9}, {0, 5}, {1, 5}},
+		},
+		{
+			desc: "keeps the original order when the bottom comes before the top",
+			run:  []image.Point{{0, 3}, {0, 9}, {0, 4}, {0, 0}, {0, 5}},
+			want: []image.Point{{0, 3}, {0, 9}, {0, 0}, {0, 5}},
+		},
+		{
+			desc: "doesn't duplicate points that are both the first and the top",
+			run:  []image.Point{{0, 0}, {0, 1}, {0, 2}, {0, 3}},
+			want: []image.Point{{0, 0}, {0, 3}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := downsample(tc.run)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("downsample => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// largeSeries returns n values of a noisy sine wave with a single spike.
+func largeSeries(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = 50 + 20*math.Sin(float64(i)/float64(n)*4*math.Pi) + float64(i%7)
+	}
+	values[n/3] = 100
+	return values
+}
+
+func TestDownsamplingDrawsTheSameLine(t *testing.T) {
+	tests := []struct {
+		desc   string
+		values []float64
+		opts   []SeriesOption
+	}{
+		{
+			desc:   "fewer values than pixel columns",
+			values: largeSeries(20),
+		},
+		{
+			desc:   "more values than pixel columns",
+			values: largeSeries(10000),
+		},
+		{
+			desc:   "values with missing parts",
+			values: append(append(largeSeries(5000), math.NaN(), math.NaN()), largeSeries(5000)...),
+		},
+		{
+			desc:   "filled series",
+			values: largeSeries(10000),
+			opts:   []SeriesOption{SeriesFill(cell.FgColor(cell.ColorBlue))},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			draw := func(opts ...Option) *faketerm.Terminal {
+				t.Helper()
+				lc, err := New(opts...)
+				if err != nil {
+					t.Fatalf("New => unexpected error: %v", err)
+				}
+				if err := lc.Series("first", tc.values, tc.opts...); err != nil {
+					t.Fatalf("Series => unexpected error: %v", err)
+				}
+				cvs := testcanvas.MustNew(image.Rect(0, 0, 40, 15))
+				if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+				ft := faketerm.MustNew(cvs.Size())
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			}
+
+			want := draw(DisableDownsampling())
+			got := draw()
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkDraw(b *testing.B) {
+	for _, n := range []int{1000, 100000} {
+		values := largeSeries(n)
+		for _, disabled := range []bool{false, true} {
+			var opts []Option
+			name := fmt.Sprintf("%d values", n)
+			if disabled {
+				opts = append(opts, DisableDownsampling())
+				name += " without downsampling"
+			}
+
+			b.Run(name, func(b *testing.B) {
+				lc, err := New(opts...)
+				if err != nil {
+					b.Fatalf("New => unexpected error: %v", err)
+				}
+				if err := lc.Series("first", values); err != nil {
+					b.Fatalf("Series => unexpected error: %v", err)
+				}
+				cvs := testcanvas.MustNew(image.Rect(0, 0, 80, 24))
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+						b.Fatalf("Draw => unexpected error: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
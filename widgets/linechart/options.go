@@ -17,8 +17,12 @@ package linechart
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/private/wrap"
 	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
 	"github.com/mum4k/termdash/widgets/linechart/internal/zoom"
 )
@@ -33,16 +37,31 @@ type Option interface {
 
 // options stores the provided options.
 type options struct {
-	axesCellOpts        []cell.Option
-	xLabelCellOpts      []cell.Option
-	xLabelOrientation   axes.LabelOrientation
-	yLabelCellOpts      []cell.Option
-	xAxisUnscaled       bool
-	yAxisMode           axes.YScaleMode
-	yAxisCustomScale    *customScale
-	yAxisValueFormatter ValueFormatter
-	zoomHightlightColor cell.Color
-	zoomStepPercent     int
+	axesCellOpts         []cell.Option
+	axesLineStyle        linestyle.LineStyle
+	xLabelCellOpts       []cell.Option
+	xLabelOrientation    axes.LabelOrientation
+	xLabelsFit           bool
+	xLabelEvery          int
+	yLabelCellOpts       []cell.Option
+	secondYLabelCellOpts []cell.Option
+	xAxisUnscaled        bool
+	yAxisMode            axes.YScaleMode
+	yAxisScale           YAxisScale
+	yAxisLogFloor        float64
+	yAxisCustomScale     *customScale
+	yAxisMin             *float64
+	yAxisMax             *float64
+	yAxisValueFormatter  ValueFormatter
+	zoomHightlightColor  cell.Color
+	zoomStepPercent      int
+	zoomResetKey         keyboard.Key
+	zoomResetKeySet      bool
+	xMarkers             []XMarker
+	disableDownsampling  bool
+	stackedAreas         bool
+	legend               bool
+	legendCorner         Corner
 }
 
 // validate validates the provided options.
@@ -55,15 +74,62 @@ func (o *options) validate() error {
 			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as custom Y scale", o.yAxisCustomScale.min, o.yAxisCustomScale.max)
 		}
 	}
+	for _, b := range []struct {
+		name  string
+		value *float64
+	}{
+		{"YAxisMin", o.yAxisMin},
+		{"YAxisMax", o.yAxisMax},
+	} {
+		if b.value == nil {
+			continue
+		}
+		if v := *b.value; math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("invalid %s %v, must be a valid finite number", b.name, v)
+		}
+		if v := *b.value; o.yAxisScale == YAxisLogarithmic && v <= 0 {
+			return fmt.Errorf("invalid %s %v, must be a positive number on the YAxisLogarithmic scale", b.name, v)
+		}
+	}
+	if o.yAxisMin != nil && o.yAxisMax != nil && *o.yAxisMin >= *o.yAxisMax {
+		return fmt.Errorf("the YAxisMin(%v) must be less than the YAxisMax(%v)", *o.yAxisMin, *o.yAxisMax)
+	}
+	if o.xLabelEvery < 0 {
+		return fmt.Errorf("invalid XLabelsEvery %d, must not be negative", o.xLabelEvery)
+	}
+	if o.yAxisLogFloor < 0 || math.IsNaN(o.yAxisLogFloor) {
+		return fmt.Errorf("invalid YAxisLogFloor %v, must not be a negative number", o.yAxisLogFloor)
+	}
+	if o.axesLineStyle == linestyle.None {
+		return fmt.Errorf("invalid AxesLineStyle %v, the axes must be drawn with a line", o.axesLineStyle)
+	}
 	if got, min, max := o.zoomStepPercent, 1, 100; got < min || got > max {
 		return fmt.Errorf("invalid ZoomStepPercent %d, must be in range %d <= value <= %d", got, min, max)
 	}
+	if _, ok := cornerNames[o.legendCorner]; !ok {
+		return fmt.Errorf("unsupported LegendCorner %v", o.legendCorner)
+	}
+	for i, m := range o.xMarkers {
+		if m.X < 0 {
+			return fmt.Errorf("invalid XMarker[%d], the X position %d must not be negative", i, m.X)
+		}
+		if m.Label == "" {
+			continue
+		}
+		if err := wrap.ValidText(m.Label); err != nil {
+			return fmt.Errorf("invalid XMarker[%d] label: %v", i, err)
+		}
+		if strings.ContainsRune(m.Label, '\n') {
+			return fmt.Errorf("invalid XMarker[%d] label %q, newline characters aren't allowed", i, m.Label)
+		}
+	}
 	return nil
 }
 
 // newOptions returns a new options instance.
 func newOptions(opts ...Option) *options {
 	opt := &options{
+		axesLineStyle:       DefaultAxesLineStyle,
 		zoomHightlightColor: cell.ColorNumber(235),
 		zoomStepPercent:     zoom.DefaultScrollStep,
 	}
@@ -88,6 +154,17 @@ func AxesCellOpts(co ...cell.Option) Option {
 	})
 }
 
+// DefaultAxesLineStyle is the default value for the AxesLineStyle option.
+const DefaultAxesLineStyle = linestyle.Light
+
+// AxesLineStyle sets the style of the lines of the X and Y axes.
+// Must not be linestyle.None. Defaults to DefaultAxesLineStyle.
+func AxesLineStyle(ls linestyle.LineStyle) Option {
+	return option(func(opts *options) {
+		opts.axesLineStyle = ls
+	})
+}
+
 // XLabelCellOpts set the cell options for the labels on the X axis.
 func XLabelCellOpts(co ...cell.Option) Option {
 	return option(func(opts *options) {
@@ -100,6 +177,7 @@ func XLabelCellOpts(co ...cell.Option) Option {
 func XLabelsVertical() Option {
 	return option(func(opts *options) {
 		opts.xLabelOrientation = axes.LabelOrientationVertical
+		opts.xLabelsFit = false
 	})
 }
 
@@ -108,6 +186,27 @@ func XLabelsVertical() Option {
 func XLabelsHorizontal() Option {
 	return option(func(opts *options) {
 		opts.xLabelOrientation = axes.LabelOrientationHorizontal
+		opts.xLabelsFit = false
+	})
+}
+
+// XLabelsFit makes the labels under the X axis flow horizontally unless
+// labels would have to be skipped so that they don't overlap. The labels then
+// flow vertically if more of them fit that way and the canvas is tall enough.
+func XLabelsFit() Option {
+	return option(func(opts *options) {
+		opts.xLabelOrientation = axes.LabelOrientationHorizontal
+		opts.xLabelsFit = true
+	})
+}
+
+// XLabelsEvery only draws every n-th label out of the labels that fit under
+// the X axis, starting with the first one. Useful to reduce the density of
+// the labels on wide charts.
+// Defaults to zero which draws all the labels that fit. Must not be negative.
+func XLabelsEvery(n int) Option {
+	return option(func(opts *options) {
+		opts.xLabelEvery = n
 	})
 }
 
@@ -118,6 +217,14 @@ func YLabelCellOpts(co ...cell.Option) Option {
 	})
 }
 
+// SecondYAxisCellOpts set the cell options for the labels on the second Y
+// axis, i.e. the axis of series provided with the SeriesSecondYAxis option.
+func SecondYAxisCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.secondYLabelCellOpts = co
+	})
+}
+
 // YAxisAdaptive makes the Y axis adapt its base value depending on the
 // provided series.
 // Without this option, the Y axis always starts at the zero value regardless of
@@ -132,6 +239,57 @@ func YAxisAdaptive() Option {
 	})
 }
 
+// YAxisScale determines how the values are mapped onto the Y axis.
+type YAxisScale int
+
+// String implements fmt.Stringer()
+func (yas YAxisScale) String() string {
+	if n, ok := yAxisScaleNames[yas]; ok {
+		return n
+	}
+	return "YAxisScaleUnknown"
+}
+
+// yAxisScaleNames maps YAxisScale values to human readable names.
+var yAxisScaleNames = map[YAxisScale]string{
+	YAxisLinear:      "YAxisLinear",
+	YAxisLogarithmic: "YAxisLogarithmic",
+}
+
+const (
+	// YAxisLinear is the default scale where the distance between values on
+	// the Y axis is proportional to their difference.
+	YAxisLinear YAxisScale = iota
+
+	// YAxisLogarithmic is a scale where the distance between values on the Y
+	// axis is proportional to the difference of their log10. The labels are
+	// placed on the powers of ten (1, 10, 100, ...).
+	// Only positive values can be plotted on this scale, values that are
+	// zero or negative aren't drawn unless the YAxisLogFloor option is
+	// provided.
+	YAxisLogarithmic
+)
+
+// YAxisMode sets the scale of the Y axis.
+// Defaults to YAxisLinear.
+func YAxisMode(yas YAxisScale) Option {
+	return option(func(opts *options) {
+		opts.yAxisScale = yas
+	})
+}
+
+// YAxisLogFloor sets the smallest value that is plotted on a Y axis with the
+// YAxisLogarithmic scale. Values smaller than the floor, including zero and
+// negative values, are drawn as if they were equal to the floor.
+// The floor must be a positive number.
+// Without this option, values that are zero or negative aren't drawn.
+// Takes no effect without YAxisMode(YAxisLogarithmic).
+func YAxisLogFloor(floor float64) Option {
+	return option(func(opts *options) {
+		opts.yAxisLogFloor = floor
+	})
+}
+
 // customScale is the custom scale provided via the YAxisCustomScale option.
 type customScale struct {
 	min, max float64
@@ -160,6 +318,34 @@ func YAxisCustomScale(min, max float64) Option {
 	})
 }
 
+// YAxisMin pins the minimum of the Y axis to the provided value. Unlike
+// YAxisCustomScale, the Y axis doesn't expand to include values that are
+// smaller, these are clipped and drawn at the bottom of the plot area instead.
+// If YAxisMax isn't provided, the maximum is still determined from the series.
+// Must be a finite number smaller than the value provided to YAxisMax and
+// positive on the YAxisLogarithmic scale. Applies only to the first Y axis.
+//
+// Providing this option also sets YAxisAdaptive.
+func YAxisMin(min float64) Option {
+	return option(func(opts *options) {
+		opts.yAxisMin = &min
+		opts.yAxisMode = axes.YScaleModeAdaptive
+	})
+}
+
+// YAxisMax pins the maximum of the Y axis to the provided value. Values that
+// are larger are clipped and drawn at the top of the plot area.
+// If YAxisMin isn't provided, the minimum is still determined from the series.
+// See YAxisMin for the restrictions that apply.
+//
+// Providing this option also sets YAxisAdaptive.
+func YAxisMax(max float64) Option {
+	return option(func(opts *options) {
+		opts.yAxisMax = &max
+		opts.yAxisMode = axes.YScaleModeAdaptive
+	})
+}
+
 // XAxisUnscaled when provided, stops the LineChart from rescaling the X axis
 // when it can't fit all the values in the series, instead the LineCharts only
 // displays the last n values that fit into its width. This is useful to create
@@ -186,6 +372,18 @@ func ZoomHightlightColor(c cell.Color) Option {
 	})
 }
 
+// ZoomResetKey sets a keyboard key that resets the zoom, i.e. fully unzooms
+// the X axis and cancels any mouse selection in progress, e.g.
+// keyboard.KeyEsc. The key is processed while the linechart is focused.
+// Setting this option makes the linechart request keyboard events. If not
+// set, the zoom can only be reset by scrolling out with the mouse.
+func ZoomResetKey(k keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.zoomResetKey = k
+		opts.zoomResetKeySet = true
+	})
+}
+
 // ZoomStepPercent sets the zooming step on each mouse scroll event as the
 // percentage of the size of the X axis.
 // The value must be in range 0 < value <= 100.
@@ -210,3 +408,113 @@ func YAxisFormattedValues(vfmt ValueFormatter) Option {
 // representation.
 // The received float64 value could be a math.NaN value.
 type ValueFormatter func(value float64) string
+
+// XMarker marks a position on the X axis with a vertical line, e.g. to
+// annotate events on a time axis.
+type XMarker struct {
+	// X is the position on the X axis, i.e. the index of the value in the
+	// series.
+	X int
+	// Color is the color of the line and of the label.
+	Color cell.Color
+	// Label is an optional text displayed at the top of the line.
+	// Must not contain newline characters.
+	Label string
+}
+
+// XMarkers draws a vertical line across the graph at the X position of each
+// of the markers. The label of a marker is displayed to the right of the top
+// of its line, inside the graph so that it never overlaps the axis labels.
+// Markers outside of the displayed (possibly zoomed) range of the X axis
+// aren't drawn.
+func XMarkers(markers []XMarker) Option {
+	return option(func(opts *options) {
+		// Copy to avoid external modifications.
+		opts.xMarkers = append([]XMarker(nil), markers...)
+	})
+}
+
+// ShowLegend draws a legend with an entry for each series inside the area of
+// the graph. Each entry consists of a swatch drawn with the cell options of
+// the series and the label of the series. The legend is drawn over the graph
+// with the default background and takes at most half of the width of the
+// graph, longer labels are truncated. Entries that don't fit the height of
+// the graph aren't displayed.
+// The position of the legend is set by the LegendCorner option.
+func ShowLegend() Option {
+	return option(func(opts *options) {
+		opts.legend = true
+	})
+}
+
+// Corner identifies one of the corners of the graph.
+type Corner int
+
+// String implements fmt.Stringer()
+func (c Corner) String() string {
+	if n, ok := cornerNames[c]; ok {
+		return n
+	}
+	return "CornerUnknown"
+}
+
+// cornerNames maps Corner values to human readable names.
+var cornerNames = map[Corner]string{
+	CornerTopRight:    "CornerTopRight",
+	CornerTopLeft:     "CornerTopLeft",
+	CornerBottomRight: "CornerBottomRight",
+	CornerBottomLeft:  "CornerBottomLeft",
+}
+
+const (
+	// CornerTopRight is the top right corner of the graph.
+	CornerTopRight Corner = iota
+
+	// CornerTopLeft is the top left corner of the graph.
+	CornerTopLeft
+
+	// CornerBottomRight is the bottom right corner of the graph.
+	CornerBottomRight
+
+	// CornerBottomLeft is the bottom left corner of the graph.
+	CornerBottomLeft
+)
+
+// LegendCorner sets the corner of the graph where the legend is drawn.
+// Only has an effect together with the ShowLegend option.
+// Defaults to CornerTopRight.
+func LegendCorner(c Corner) Option {
+	return option(func(opts *options) {
+		opts.legendCorner = c
+	})
+}
+
+// DisableDownsampling disables the downsampling of large series.
+// By default, of the consecutive values of a series that fall into the same
+// pixel column of the graph only the first, the last, the smallest and the
+// largest value are plotted. This draws the same line, including all the
+// peaks and troughs, but the time to draw it doesn't grow with the number of
+// values beyond the width of the graph. The dash pattern set by
+// SeriesLineDash is applied to the downsampled line, so it can differ for
+// series with more values than pixel columns.
+func DisableDownsampling() Option {
+	return option(func(opts *options) {
+		opts.disableDownsampling = true
+	})
+}
+
+// StackedAreas stacks the series on top of each other. Each series is
+// plotted on top of the cumulative sum of the series before it, so the line
+// of the last series represents the total. The band between the line of the
+// series and the line of the series before it is filled with the cell
+// options provided with SeriesFill or with those of the series itself.
+// The series are stacked in alphabetical order based on their name. Series
+// bound to the second Y axis aren't stacked.
+// All the stacked series must share the X values, i.e. they must have the
+// same number of values. Missing values (math.NaN) count as zero toward the
+// sum.
+func StackedAreas() Option {
+	return option(func(opts *options) {
+		opts.stackedAreas = true
+	})
+}
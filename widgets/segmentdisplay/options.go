@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/segdisp/sixteen"
 )
 
 // options.go contains configurable options for SegmentDisplay.
@@ -41,7 +43,11 @@ type options struct {
 	hAlign          align.Horizontal
 	vAlign          align.Vertical
 	maximizeSegSize bool
+	rightToLeft     bool
 	gapPercent      int
+	fallbackChar    rune
+	fixedWidth      int
+	placeholderOpts []cell.Option
 }
 
 // validate validates the provided options.
@@ -49,15 +55,25 @@ func (o *options) validate() error {
 	if min, max := 0, 100; o.gapPercent < min || o.gapPercent > max {
 		return fmt.Errorf("invalid GapPercent %d, must be %d <= value <= %d", o.gapPercent, min, max)
 	}
+	if min := 0; o.fixedWidth < min {
+		return fmt.Errorf("invalid FixedWidth %d, must be %d <= value", o.fixedWidth, min)
+	}
+	if ok, _ := sixteen.SupportsChars(string(o.fallbackChar)); !ok {
+		return fmt.Errorf("invalid FallbackChar %q, the display doesn't support this character", o.fallbackChar)
+	}
 	return nil
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
-		hAlign:     align.HorizontalCenter,
-		vAlign:     align.VerticalMiddle,
-		gapPercent: DefaultGapPercent,
+		hAlign:       align.HorizontalCenter,
+		vAlign:       align.VerticalMiddle,
+		gapPercent:   DefaultGapPercent,
+		fallbackChar: DefaultFallbackChar,
+		placeholderOpts: []cell.Option{
+			cell.FgColor(cell.ColorNumber(DefaultPlaceholderColorNumber)),
+		},
 	}
 }
 
@@ -100,6 +116,27 @@ func MaximizeDisplayedText() Option {
 	})
 }
 
+// RightToLeft tells the widget to fill the display from the right, i.e. the
+// last character of the text is always displayed in the rightmost segment.
+// When the text doesn't fit, it is trimmed on the left. Useful when displaying
+// numbers that should grow from the right.
+// The horizontal alignment set by AlignHorizontal doesn't apply in this mode.
+func RightToLeft() Option {
+	return option(func(opts *options) {
+		opts.rightToLeft = true
+	})
+}
+
+// LeftToRight tells the widget to fill the display from the left, i.e. the
+// first character of the text is displayed in the leftmost segment. When the
+// text doesn't fit, it is trimmed on the right.
+// This is the default behavior.
+func LeftToRight() Option {
+	return option(func(opts *options) {
+		opts.rightToLeft = false
+	})
+}
+
 // DefaultGapPercent is the default value for the GapPercent option.
 const DefaultGapPercent = 20
 
@@ -110,3 +147,43 @@ func GapPercent(perc int) Option {
 		opts.gapPercent = perc
 	})
 }
+
+// DefaultFallbackChar is the default value for the FallbackChar option.
+const DefaultFallbackChar = '?'
+
+// FallbackChar sets the character that is displayed instead of characters
+// the display doesn't support, i.e. anything outside of the printable ASCII
+// range. The fallback character itself must be a printable ASCII character.
+// Use SegmentDisplay.Substituted to find out which characters were replaced.
+// Defaults to DefaultFallbackChar.
+func FallbackChar(r rune) Option {
+	return option(func(opts *options) {
+		opts.fallbackChar = r
+	})
+}
+
+// FixedWidth tells the widget to always display the specified number of
+// characters. The space for all of them is reserved up-front, so the
+// characters don't shift as the length of the text changes, e.g. when
+// displaying a clock or a counter. Shorter text is padded on the left with
+// placeholder segments that have all the segments set and look like an unlit
+// display, see PlaceholderCellOpts. Longer text is trimmed on the left.
+// The zero value disables the fixed width, this is the default behavior.
+func FixedWidth(chars int) Option {
+	return option(func(opts *options) {
+		opts.fixedWidth = chars
+	})
+}
+
+// DefaultPlaceholderColorNumber is the default color number of the
+// placeholder segments displayed with the FixedWidth option.
+const DefaultPlaceholderColorNumber = 236
+
+// PlaceholderCellOpts sets the cell options for the placeholder segments
+// displayed with the FixedWidth option.
+// Defaults to DefaultPlaceholderColorNumber as the foreground color.
+func PlaceholderCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.placeholderOpts = co
+	})
+}
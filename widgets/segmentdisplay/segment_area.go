@@ -47,6 +47,22 @@ func (sa *segArea) needArea() image.Rectangle {
 	)
 }
 
+// needAreaFor returns the area required for the first n segments and the gaps
+// between them. The n must not be larger than the number of segments we can
+// fit.
+func (sa *segArea) needAreaFor(n int) image.Rectangle {
+	gaps := sa.gaps
+	if n-1 < gaps {
+		gaps = n - 1
+	}
+	return image.Rect(
+		0,
+		0,
+		sa.segment.Dx()*n+gaps*sa.gapPixels,
+		sa.segment.Dy(),
+	)
+}
+
 // newSegArea calculates the area for segments given available canvas area,
 // length of the text to be displayed and the size of gap between segments
 func newSegArea(cvsAr image.Rectangle, textLen, gapPercent int) (*segArea, error) {
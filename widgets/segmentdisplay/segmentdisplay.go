@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/attrrange"
 	"github.com/mum4k/termdash/private/canvas"
@@ -39,14 +40,18 @@ import (
 // maximizing the segment size or with fitting the entire text depending on the
 // provided options.
 //
-// Segment displays support only a subset of ASCII characters, provided options
-// determine the behavior when an unsupported character is encountered.
+// Segment displays support only the printable ASCII characters, provided
+// options determine the behavior when an unsupported character is
+// encountered.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type SegmentDisplay struct {
 	// buff contains the text to be displayed.
 	buff strings.Builder
 
+	// chars are the characters from buff displayed in individual segments.
+	chars []*dispChar
+
 	// givenWOpts are write options given for the text in buff.
 	givenWOpts []*writeOptions
 	// wOptsTracker tracks the positions in a buff to which the givenWOpts apply.
@@ -56,6 +61,10 @@ type SegmentDisplay struct {
 	// time Draw was called.
 	lastCanFit int
 
+	// substituted are the unsupported characters that were replaced with the
+	// fallback character by the last call to Write.
+	substituted []rune
+
 	// dotChars are characters that are drawn using the dot segment.
 	// All other characters are draws using the 16-segment display.
 	dotChars map[rune]bool
@@ -104,7 +113,9 @@ func NewChunk(text string, wOpts ...WriteOption) *TextChunk {
 
 // Write writes text for the widget to display. Subsequent calls replace text
 // written previously. All the provided text chunks are broken into characters
-// and each character is displayed in one segment.
+// and each character is displayed in one segment. The only exception is a dot
+// that follows a digit, which is displayed as the decimal point of the digit's
+// segment, so e.g. "3.14" occupies three segments.
 //
 // The provided write options determine the behavior when text contains
 // unsupported characters and set cell options for cells that contain
@@ -134,10 +145,14 @@ func (sd *SegmentDisplay) Write(chunks []*TextChunk, opts ...Option) error {
 		if tc.text == "" {
 			return fmt.Errorf("text chunk[%d] is empty, all chunks must contains some text", i)
 		}
-		if ok, badRunes := sixteen.SupportsChars(tc.text); !ok && tc.wOpts.errOnUnsupported {
+		ok, badRunes := sixteen.SupportsChars(tc.text)
+		if !ok && tc.wOpts.errOnUnsupported {
 			return fmt.Errorf("text chunk[%d] contains unsupported characters %v, clean the text or provide the WriteSanitize option", i, badRunes)
 		}
-		text := sixteen.Sanitize(tc.text)
+		if !ok {
+			sd.addSubstituted(tc.text)
+		}
+		text := sixteen.SanitizeWith(tc.text, sd.opts.fallbackChar)
 
 		pos := sd.buff.Len()
 		sd.givenWOpts = append(sd.givenWOpts, tc.wOpts)
@@ -147,9 +162,77 @@ func (sd *SegmentDisplay) Write(chunks []*TextChunk, opts ...Option) error {
 		}
 		sd.buff.WriteString(text)
 	}
+	sd.chars = dispChars(sd.buff.String())
 	return nil
 }
 
+// dispChar is a character displayed in one segment.
+type dispChar struct {
+	// r is the displayed character.
+	r rune
+	// pos is the byte position of the character in the text.
+	pos int
+	// dp indicates whether the decimal point of the segment is set.
+	dp bool
+	// placeholder indicates that this is a placeholder segment that pads the
+	// text to the width set by the FixedWidth option.
+	placeholder bool
+}
+
+// dispChars breaks the text into characters displayed in individual segments.
+// A dot that follows a digit doesn't occupy its own segment, it sets the
+// decimal point in the segment of the digit instead.
+func dispChars(text string) []*dispChar {
+	var res []*dispChar
+	for i, r := range text {
+		if r == '.' && len(res) > 0 {
+			if last := res[len(res)-1]; !last.dp && last.r >= '0' && last.r <= '9' {
+				last.dp = true
+				continue
+			}
+		}
+		res = append(res, &dispChar{r: r, pos: i})
+	}
+	return res
+}
+
+// addSubstituted records the unsupported characters in the text in the order
+// of their first appearance.
+// Caller must hold sd.mu.
+func (sd *SegmentDisplay) addSubstituted(text string) {
+	for _, r := range text {
+		if ok, _ := sixteen.SupportsChars(string(r)); ok {
+			continue
+		}
+		seen := false
+		for _, s := range sd.substituted {
+			if s == r {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			sd.substituted = append(sd.substituted, r)
+		}
+	}
+}
+
+// Substituted returns the characters the display doesn't support that were
+// replaced with the fallback character by the last call to Write. The
+// characters are returned in the order of their first appearance in the text,
+// each only once. Returns nil if all the characters were supported.
+func (sd *SegmentDisplay) Substituted() []rune {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	if len(sd.substituted) == 0 {
+		return nil
+	}
+	res := make([]rune, len(sd.substituted))
+	copy(res, sd.substituted)
+	return res
+}
+
 // Capacity returns the number of characters that can fit into the canvas.
 // This is essentially the number of individual segments that can fit on the
 // canvas at the time the last call to draw. Returns zero if draw wasn't
@@ -175,22 +258,43 @@ func (sd *SegmentDisplay) Reset() {
 // Caller must hold sd.mu.
 func (sd *SegmentDisplay) reset() {
 	sd.buff.Reset()
+	sd.chars = nil
 	sd.givenWOpts = nil
+	sd.substituted = nil
 	sd.wOptsTracker = attrrange.NewTracker()
 }
 
+// displayed returns the characters to display. These are the written
+// characters, padded or trimmed on the left if the FixedWidth option is set.
+// Caller must hold sd.mu.
+func (sd *SegmentDisplay) displayed() []*dispChar {
+	width := sd.opts.fixedWidth
+	switch {
+	case width == 0:
+		return sd.chars
+	case len(sd.chars) >= width:
+		return sd.chars[len(sd.chars)-width:]
+	}
+
+	var res []*dispChar
+	for i := len(sd.chars); i < width; i++ {
+		res = append(res, &dispChar{placeholder: true})
+	}
+	return append(res, sd.chars...)
+}
+
 // preprocess determines the size of individual segments maximizing their
 // height or the amount of displayed characters based on the specified options.
+// The textLen is the number of characters to display.
 // Returns the area required for a single segment, the text that we can fit and
 // size of gaps between segments in cells.
-func (sd *SegmentDisplay) preprocess(cvsAr image.Rectangle) (*segArea, error) {
-	textLen := sd.buff.Len() // We're guaranteed by Write to only have ASCII characters.
+func (sd *SegmentDisplay) preprocess(cvsAr image.Rectangle, textLen int) (*segArea, error) {
 	segAr, err := newSegArea(cvsAr, textLen, sd.opts.gapPercent)
 	if err != nil {
 		return nil, err
 	}
 
-	need := sd.buff.Len()
+	need := textLen
 	if (need > 0 && need <= segAr.canFit) || sd.opts.maximizeSegSize {
 		return segAr, nil
 	}
@@ -208,30 +312,33 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	sd.mu.Lock()
 	defer sd.mu.Unlock()
 
-	segAr, err := sd.preprocess(cvs.Area())
+	chars := sd.displayed()
+	segAr, err := sd.preprocess(cvs.Area(), len(chars))
 	if err != nil {
 		return err
 	}
 
 	sd.lastCanFit = segAr.canFit
-	if sd.buff.Len() == 0 {
+	if len(chars) == 0 {
 		return nil
 	}
 
-	text := sd.buff.String()
-	aligned, err := alignfor.Rectangle(cvs.Area(), segAr.needArea(), sd.opts.hAlign, sd.opts.vAlign)
-	if err != nil {
-		return fmt.Errorf("alignfor.Rectangle => %v", err)
+	needAr, hAlign := segAr.needArea(), sd.opts.hAlign
+	if sd.opts.rightToLeft {
+		if len(chars) > segAr.canFit {
+			chars = chars[len(chars)-segAr.canFit:]
+		}
+		needAr, hAlign = segAr.needAreaFor(len(chars)), align.HorizontalRight
 	}
-
-	optRange, err := sd.wOptsTracker.ForPosition(0) // Text options for the current byte.
+	aligned, err := alignfor.Rectangle(cvs.Area(), needAr, hAlign, sd.opts.vAlign)
 	if err != nil {
-		return err
+		return fmt.Errorf("alignfor.Rectangle => %v", err)
 	}
 
+	var optRange *attrrange.AttrRange // Text options for the current byte.
 	gaps := segAr.gaps
 	startX := aligned.Min.X
-	for i, c := range text {
+	for i, c := range chars {
 		if i >= segAr.canFit {
 			break
 		}
@@ -249,16 +356,22 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 			return fmt.Errorf("canvas.New => %v", err)
 		}
 
-		if i >= optRange.High { // Get the next write options.
-			or, err := sd.wOptsTracker.ForPosition(i)
-			if err != nil {
+		if c.placeholder {
+			if err := sd.drawPlaceholder(dCvs); err != nil {
+				return err
+			}
+		} else {
+			if optRange == nil || c.pos >= optRange.High { // Get the next write options.
+				or, err := sd.wOptsTracker.ForPosition(c.pos)
+				if err != nil {
+					return err
+				}
+				optRange = or
+			}
+			wOpts := sd.givenWOpts[optRange.AttrIdx]
+			if err := sd.drawChar(dCvs, c, wOpts); err != nil {
 				return err
 			}
-			optRange = or
-		}
-		wOpts := sd.givenWOpts[optRange.AttrIdx]
-		if err := sd.drawChar(dCvs, c, wOpts); err != nil {
-			return err
 		}
 
 		if err := dCvs.CopyTo(cvs); err != nil {
@@ -269,10 +382,10 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 }
 
 // drawChar draws a single character onto the provided canvas.
-func (sd *SegmentDisplay) drawChar(dCvs *canvas.Canvas, c rune, wOpts *writeOptions) error {
-	if sd.dotChars[c] {
+func (sd *SegmentDisplay) drawChar(dCvs *canvas.Canvas, c *dispChar, wOpts *writeOptions) error {
+	if sd.dotChars[c.r] {
 		disp := dotseg.New()
-		if err := disp.SetCharacter(c); err != nil {
+		if err := disp.SetCharacter(c.r); err != nil {
 			return fmt.Errorf("dotseg.Display.SetCharacter => %v", err)
 		}
 		if err := disp.Draw(dCvs, dotseg.CellOpts(wOpts.cellOpts...)); err != nil {
@@ -282,15 +395,35 @@ func (sd *SegmentDisplay) drawChar(dCvs *canvas.Canvas, c rune, wOpts *writeOpti
 	}
 
 	disp := sixteen.New()
-	if err := disp.SetCharacter(c); err != nil {
+	if err := disp.SetCharacter(c.r); err != nil {
 		return fmt.Errorf("sixteen.Display.SetCharacter => %v", err)
 	}
+	if c.dp {
+		if err := disp.SetSegment(sixteen.DP); err != nil {
+			return fmt.Errorf("sixteen.Display.SetSegment => %v", err)
+		}
+	}
 	if err := disp.Draw(dCvs, sixteen.CellOpts(wOpts.cellOpts...)); err != nil {
 		return fmt.Errorf("sixteen.Display.Draw => %v", err)
 	}
 	return nil
 }
 
+// drawPlaceholder draws a placeholder segment with all the segments set onto
+// the provided canvas.
+func (sd *SegmentDisplay) drawPlaceholder(dCvs *canvas.Canvas) error {
+	disp := sixteen.New()
+	for _, s := range sixteen.AllSegments() {
+		if err := disp.SetSegment(s); err != nil {
+			return fmt.Errorf("sixteen.Display.SetSegment => %v", err)
+		}
+	}
+	if err := disp.Draw(dCvs, sixteen.CellOpts(sd.opts.placeholderOpts...)); err != nil {
+		return fmt.Errorf("sixteen.Display.Draw => %v", err)
+	}
+	return nil
+}
+
 // Keyboard input isn't supported on the SegmentDisplay widget.
 func (*SegmentDisplay) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
 	return errors.New("the SegmentDisplay widget doesn't support keyboard events")
@@ -301,6 +434,22 @@ func (*SegmentDisplay) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) er
 	return errors.New("the SegmentDisplay widget doesn't support mouse events")
 }
 
+// PreferredSize implements widgetapi.PreferredSizer.PreferredSize.
+// The preferred size fits the displayed characters drawn with the smallest
+// supported segments. Has no preference when there is nothing to display.
+func (sd *SegmentDisplay) PreferredSize() image.Point {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	n := len(sd.displayed())
+	if n == 0 {
+		return image.ZP
+	}
+	seg := image.Point{segdisp.MinCols, segdisp.MinRows}
+	gapPixels := seg.Y * sd.opts.gapPercent / 100
+	return image.Point{n*seg.X + (n-1)*gapPixels, seg.Y}
+}
+
 // Options implements widgetapi.Widget.Options.
 func (sd *SegmentDisplay) Options() widgetapi.Options {
 	return widgetapi.Options{
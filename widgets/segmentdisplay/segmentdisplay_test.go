@@ -51,6 +51,33 @@ func mustDrawChar(cvs *canvas.Canvas, char rune, ar image.Rectangle, cOpts ...ce
 	testcanvas.MustCopyTo(c, cvs)
 }
 
+// mustDrawCharDP draws the provided character with the decimal point set in
+// the area of the canvas or panics.
+func mustDrawCharDP(cvs *canvas.Canvas, char rune, ar image.Rectangle, cOpts ...cell.Option) {
+	c := testcanvas.MustNew(ar)
+	d := sixteen.New()
+	testsixteen.MustSetCharacter(d, char)
+	if err := d.SetSegment(sixteen.DP); err != nil {
+		panic(err)
+	}
+	testsixteen.MustDraw(d, c, sixteen.CellOpts(cOpts...))
+	testcanvas.MustCopyTo(c, cvs)
+}
+
+// mustDrawPlaceholder draws a placeholder segment with all the segments set
+// in the area of the canvas or panics.
+func mustDrawPlaceholder(cvs *canvas.Canvas, ar image.Rectangle, cOpts ...cell.Option) {
+	c := testcanvas.MustNew(ar)
+	d := sixteen.New()
+	for _, s := range sixteen.AllSegments() {
+		if err := d.SetSegment(s); err != nil {
+			panic(err)
+		}
+	}
+	testsixteen.MustDraw(d, c, sixteen.CellOpts(cOpts...))
+	testcanvas.MustCopyTo(c, cvs)
+}
+
 func TestSegmentDisplay(t *testing.T) {
 	tests := []struct {
 		desc          string
@@ -80,6 +107,14 @@ func TestSegmentDisplay(t *testing.T) {
 			canvas:     image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows),
 			wantNewErr: true,
 		},
+		{
+			desc: "New fails on unsupported FallbackChar",
+			opts: []Option{
+				FallbackChar('\t'),
+			},
+			canvas:     image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows),
+			wantNewErr: true,
+		},
 		{
 			desc:   "write fails on invalid GapPercent (too low)",
 			canvas: image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows),
@@ -198,13 +233,13 @@ func TestSegmentDisplay(t *testing.T) {
 			wantCapacity: 3,
 		},
 		{
-			desc: "uses the dot segment for a dot",
+			desc: "uses the dot segment for a dot that doesn't follow a digit",
 			opts: []Option{
 				GapPercent(0),
 			},
 			canvas: image.Rect(0, 0, segdisp.MinCols*3, segdisp.MinRows),
 			update: func(sd *SegmentDisplay) error {
-				return sd.Write([]*TextChunk{NewChunk("1.3")})
+				return sd.Write([]*TextChunk{NewChunk("A.3")})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -214,7 +249,7 @@ func TestSegmentDisplay(t *testing.T) {
 					char rune
 					area image.Rectangle
 				}{
-					{'1', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows)},
+					{'A', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows)},
 					{'.', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows)},
 					{'3', image.Rect(segdisp.MinCols*2, 0, segdisp.MinCols*3, segdisp.MinRows)},
 				} {
@@ -226,6 +261,259 @@ func TestSegmentDisplay(t *testing.T) {
 			},
 			wantCapacity: 3,
 		},
+		{
+			desc: "dot that follows a digit sets the decimal point of its segment",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*3, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("3.14")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawCharDP(cvs, '3', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
+				mustDrawChar(cvs, '1', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
+				mustDrawChar(cvs, '4', image.Rect(segdisp.MinCols*2, 0, segdisp.MinCols*3, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "only the first of consecutive dots sets the decimal point",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1..")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawCharDP(cvs, '1', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
+				mustDrawChar(cvs, '.', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "decimal point uses the cell options of the digit",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteCellOpts(cell.FgColor(cell.ColorRed))),
+					NewChunk(".2", WriteCellOpts(cell.FgColor(cell.ColorBlue))),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawCharDP(cvs, '1', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows), cell.FgColor(cell.ColorRed))
+				mustDrawChar(cvs, '2', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows), cell.FgColor(cell.ColorBlue))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "right to left fills the display from the right",
+			opts: []Option{
+				GapPercent(0),
+				RightToLeft(),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*3, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
+				mustDrawChar(cvs, '2', image.Rect(segdisp.MinCols*2, 0, segdisp.MinCols*3, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "right to left ignores horizontal alignment",
+			opts: []Option{
+				GapPercent(0),
+				RightToLeft(),
+				AlignHorizontal(align.HorizontalLeft),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*3, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(segdisp.MinCols*2, 0, segdisp.MinCols*3, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "right to left trims the text on the left",
+			opts: []Option{
+				GapPercent(0),
+				RightToLeft(),
+				MaximizeSegmentHeight(),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*3, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12345")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '3', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
+				mustDrawChar(cvs, '4', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
+				mustDrawChar(cvs, '5', image.Rect(segdisp.MinCols*2, 0, segdisp.MinCols*3, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "fails on negative fixed width",
+			opts: []Option{
+				FixedWidth(-1),
+			},
+			canvas:     image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows),
+			wantNewErr: true,
+		},
+		{
+			desc: "fixed width pads the text with placeholders on the left",
+			opts: []Option{
+				GapPercent(0),
+				FixedWidth(4),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*5, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				placeholderOpts := cell.FgColor(cell.ColorNumber(DefaultPlaceholderColorNumber))
+				mustDrawPlaceholder(cvs, image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows), placeholderOpts)
+				mustDrawPlaceholder(cvs, image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows), placeholderOpts)
+				mustDrawChar(cvs, '1', image.Rect(segdisp.MinCols*2, 0, segdisp.MinCols*3, segdisp.MinRows))
+				mustDrawChar(cvs, '2', image.Rect(segdisp.MinCols*3, 0, segdisp.MinCols*4, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 5,
+		},
+		{
+			desc: "fixed width draws only placeholders without text",
+			opts: []Option{
+				GapPercent(0),
+				FixedWidth(2),
+				PlaceholderCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawPlaceholder(cvs, image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows), cell.FgColor(cell.ColorRed))
+				mustDrawPlaceholder(cvs, image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows), cell.FgColor(cell.ColorRed))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "fixed width trims longer text on the left",
+			opts: []Option{
+				GapPercent(0),
+				FixedWidth(2),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("123")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '2', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
+				mustDrawChar(cvs, '3', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "right to left with a gap and a decimal point",
+			opts: []Option{
+				RightToLeft(),
+			},
+			canvas: image.Rect(0, 0, 20, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1.5")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawCharDP(cvs, '1', image.Rect(7, 0, 13, segdisp.MinRows))
+				mustDrawChar(cvs, '5', image.Rect(14, 0, 20, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "options given to Write override those given to New so fills from the left",
+			opts: []Option{
+				GapPercent(0),
+				RightToLeft(),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*3, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12")}, LeftToRight())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '1', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
+				mustDrawChar(cvs, '2', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
 		{
 			desc: "write sanitizes text by default",
 			opts: []Option{
@@ -239,6 +527,7 @@ func TestSegmentDisplay(t *testing.T) {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
 
+				mustDrawChar(cvs, DefaultFallbackChar, image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
 				mustDrawChar(cvs, '1', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
 
 				testcanvas.MustApply(cvs, ft)
@@ -259,6 +548,29 @@ func TestSegmentDisplay(t *testing.T) {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
 
+				mustDrawChar(cvs, DefaultFallbackChar, image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
+				mustDrawChar(cvs, '1', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "write sanitizes text with custom fallback character",
+			opts: []Option{
+				GapPercent(0),
+				FallbackChar('_'),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("←1")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(cvs, '_', image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows))
 				mustDrawChar(cvs, '1', image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows))
 
 				testcanvas.MustApply(cvs, ft)
@@ -266,6 +578,17 @@ func TestSegmentDisplay(t *testing.T) {
 			},
 			wantCapacity: 2,
 		},
+		{
+			desc: "write fails on unsupported fallback character",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1")}, FallbackChar('←'))
+			},
+			wantUpdateErr: true,
+		},
 		{
 			desc:   "aligns segment vertical middle by default",
 			canvas: image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows+2),
@@ -344,6 +667,38 @@ func TestSegmentDisplay(t *testing.T) {
 			},
 			wantCapacity: 2,
 		},
+		{
+			desc: "colors a negative sign differently from the digits",
+			opts: []Option{
+				GapPercent(0),
+			},
+			canvas: image.Rect(0, 0, segdisp.MinCols*2, segdisp.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write(
+					[]*TextChunk{
+						NewChunk("-", WriteCellOpts(cell.FgColor(cell.ColorRed))),
+						NewChunk("1"),
+					})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(
+					cvs, '-',
+					image.Rect(0, 0, segdisp.MinCols, segdisp.MinRows),
+					cell.FgColor(cell.ColorRed),
+				)
+				mustDrawChar(
+					cvs, '1',
+					image.Rect(segdisp.MinCols, 0, segdisp.MinCols*2, segdisp.MinRows),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
 		{
 			desc: "reset resets the text content and reports capacity when maximizing fit and with gaps",
 			opts: []Option{
@@ -977,6 +1332,51 @@ func TestSegmentDisplay(t *testing.T) {
 	}
 }
 
+func TestSubstituted(t *testing.T) {
+	tests := []struct {
+		desc   string
+		chunks []*TextChunk
+		want   []rune
+	}{
+		{
+			desc:   "nothing substituted when all characters are supported",
+			chunks: []*TextChunk{NewChunk("abc ~")},
+		},
+		{
+			desc: "reports substituted characters in order of appearance",
+			chunks: []*TextChunk{
+				NewChunk("a→b←"),
+				NewChunk("→\tc"),
+			},
+			want: []rune{'→', '←', '\t'},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sd, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := sd.Write([]*TextChunk{NewChunk("↑")}); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+			if err := sd.Write(tc.chunks); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+
+			if diff := pretty.Compare(tc.want, sd.Substituted()); diff != "" {
+				t.Errorf("Substituted => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			sd.Reset()
+			if got := sd.Substituted(); got != nil {
+				t.Errorf("Substituted after Reset => %q, want nil", got)
+			}
+		})
+	}
+}
+
 func TestKeyboard(t *testing.T) {
 	sd, err := New()
 	if err != nil {
@@ -1013,3 +1413,68 @@ func TestOptions(t *testing.T) {
 	}
 
 }
+
+func TestPreferredSize(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		text string
+		want image.Point
+	}{
+		{
+			desc: "no preference without text",
+			want: image.ZP,
+		},
+		{
+			desc: "fits the text with gaps between the segments",
+			text: "12",
+			want: image.Point{13, 5},
+		},
+		{
+			desc: "fits the text without gaps",
+			opts: []Option{GapPercent(0)},
+			text: "12",
+			want: image.Point{12, 5},
+		},
+		{
+			desc: "fits the fixed width",
+			opts: []Option{FixedWidth(3)},
+			text: "1",
+			want: image.Point{20, 5},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sd, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.text != "" {
+				if err := sd.Write([]*TextChunk{NewChunk(tc.text)}); err != nil {
+					t.Fatalf("Write => unexpected error: %v", err)
+				}
+			}
+
+			got := sd.PreferredSize()
+			if got != tc.want {
+				t.Errorf("PreferredSize => %v, want %v", got, tc.want)
+			}
+			if got == image.ZP {
+				return
+			}
+
+			// All the characters fit onto a canvas of the preferred size.
+			cvs, err := canvas.New(image.Rectangle{Max: got})
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := sd.Draw(cvs, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if got, want := sd.Capacity(), len(sd.displayed()); got != want {
+				t.Errorf("Capacity => %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -55,7 +55,8 @@ func WriteCellOpts(opts ...cell.Option) WriteOption {
 }
 
 // WriteSanitize instructs Write to sanitize the text, replacing all characters
-// the display doesn't support with a space ' ' character.
+// the display doesn't support with the character set by the FallbackChar
+// option.
 // This is the default behavior.
 func WriteSanitize(opts ...cell.Option) WriteOption {
 	return writeOption(func(wOpts *writeOptions) {
@@ -40,14 +40,25 @@ func (o option) set(opts *options) {
 type options struct {
 	label         string
 	labelCellOpts []cell.Option
-	height        int
-	color         cell.Color
+	// showCurrent indicates if the last data point should be displayed.
+	showCurrent     bool
+	currentCellOpts []cell.Option
+	height          int
+	color           cell.Color
+	// colorSet indicates if the color was set by the Color option.
+	colorSet bool
+	baseline bool
+	negColor cell.Color
+	// minValue and maxValue when set pin the range of the displayed values.
+	minValue *int
+	maxValue *int
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
-		color: DefaultColor,
+		color:    DefaultColor,
+		negColor: DefaultNegativeColor,
 	}
 }
 
@@ -56,10 +67,17 @@ func (o *options) validate() error {
 	if got, min := o.height, 0; got < min {
 		return fmt.Errorf("invalid Height %d, must be %d <= Height", got, min)
 	}
+	if o.minValue != nil && o.maxValue != nil && *o.minValue >= *o.maxValue {
+		return fmt.Errorf("the MinValue(%d) must be less than the MaxValue(%d)", *o.minValue, *o.maxValue)
+	}
 	return nil
 }
 
 // Label adds a label above the SparkLine.
+// Without any cell options, the label uses the Foreground color of the theme
+// applied to the container if there is one.
+// The line with the label is omitted if the SparkLine has no fixed height and
+// the canvas is only one line tall.
 func Label(text string, cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
 		opts.label = text
@@ -67,6 +85,19 @@ func Label(text string, cOpts ...cell.Option) Option {
 	})
 }
 
+// ShowCurrent displays the value of the last data point right-aligned on the
+// line above the SparkLine, i.e. on the same line as the label if there is
+// one. The label is trimmed if needed to make space for the value.
+// Without any cell options, the value uses the same cell options as the label.
+// The line with the value is omitted if the SparkLine has no fixed height and
+// the canvas is only one line tall.
+func ShowCurrent(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.showCurrent = true
+		opts.currentCellOpts = cOpts
+	})
+}
+
 // Height sets a fixed height for the SparkLine.
 // If not provided or set to zero, the SparkLine takes all the available
 // vertical space in the container. Must be a positive or zero integer.
@@ -80,9 +111,61 @@ func Height(h int) Option {
 const DefaultColor = cell.ColorGreen
 
 // Color sets the color of the SparkLine.
-// Defaults to DefaultColor if not set.
+// Defaults to the Accent color of the theme applied to the container if there
+// is one, or to DefaultColor otherwise.
 func Color(c cell.Color) Option {
 	return option(func(opts *options) {
 		opts.color = c
+		opts.colorSet = true
+	})
+}
+
+// Baseline allows the SparkLine to display negative values.
+// The SparkLine places a baseline that represents zero, values above zero are
+// drawn upward from the baseline and values below zero downward. The position
+// of the baseline is determined by the ratio of the largest and the smallest
+// visible value. When all the visible values are non-negative, the baseline is
+// the bottom of the SparkLine and it is drawn the same way as without this
+// option.
+func Baseline() Option {
+	return option(func(opts *options) {
+		opts.baseline = true
+	})
+}
+
+// DefaultNegativeColor is the default value for the NegativeColor option.
+const DefaultNegativeColor = cell.ColorRed
+
+// NegativeColor sets the color of the bars that represent negative values.
+// Only has effect together with the Baseline option.
+// Defaults to DefaultNegativeColor if not set.
+func NegativeColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.negColor = c
+	})
+}
+
+// MinValue pins the smallest value the SparkLine can display, values at or
+// below it are displayed as empty. By default the bars start at zero, or at
+// the smallest visible value with the Baseline option.
+// Without the Baseline option, or when the value isn't negative, the bottom
+// of the SparkLine represents the value and the bars grow from it. With the
+// Baseline option and a negative value, the value determines the extent
+// below the baseline.
+// Use together with MaxValue to make multiple SparkLines share the same
+// scale.
+func MinValue(v int) Option {
+	return option(func(opts *options) {
+		opts.minValue = &v
+	})
+}
+
+// MaxValue pins the largest value the SparkLine can display, values at or
+// above it are displayed as full bars. By default the SparkLine scales to the
+// largest visible value.
+// Must be larger than the value provided with MinValue.
+func MaxValue(v int) Option {
+	return option(func(opts *options) {
+		opts.maxValue = &v
 	})
 }
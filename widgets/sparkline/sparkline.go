@@ -25,6 +25,7 @@ import (
 	"github.com/mum4k/termdash/private/area"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
@@ -32,7 +33,8 @@ import (
 // SparkLine draws a graph showing a series of values as vertical bars.
 //
 // Bars can have sub-cell height. The graphs scale adjusts dynamically based on
-// the largest visible value.
+// the largest visible value. With the Baseline option, negative values are
+// drawn downward from a baseline that represents zero.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type SparkLine struct {
@@ -42,6 +44,9 @@ type SparkLine struct {
 	// lastWidth is the width of the canvas as of the last time when Draw was called.
 	lastWidth int
 
+	// theme is the theme provided on the last call to Draw.
+	theme *cell.Theme
+
 	// mu protects the SparkLine.
 	mu sync.Mutex
 
@@ -71,6 +76,10 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	defer sl.mu.Unlock()
 
 	sl.lastWidth = cvs.Area().Dx()
+	sl.theme = nil
+	if meta != nil {
+		sl.theme = meta.Theme
+	}
 	needAr, err := area.FromSize(sl.minSize())
 	if err != nil {
 		return err
@@ -88,40 +97,163 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		curX = ar.Min.X
 	}
 
-	for _, v := range visible {
-		blocks := toBlocks(v, max, ar.Dy())
-		curY := ar.Max.Y - 1
-		for i := 0; i < blocks.full; i++ {
-			if _, err := cvs.SetCell(
-				image.Point{curX, curY},
-				sparks[len(sparks)-1], // Last spark represents full cell.
-				cell.FgColor(sl.opts.color),
-			); err != nil {
+	var min int
+	if sl.opts.baseline {
+		min = visibleMin(visible)
+	}
+	if pinned := sl.opts.minValue; pinned != nil {
+		min = *pinned
+	}
+	if pinned := sl.opts.maxValue; pinned != nil {
+		max = *pinned
+	}
+
+	if !sl.opts.baseline || min >= 0 {
+		// The bars grow from the bottom which represents the min value.
+		for _, v := range visible {
+			v = clamp(v, min, max)
+			if err := sl.drawUp(cvs, image.Point{curX, ar.Max.Y - 1}, toBlocks(v-min, max-min, ar.Dy())); err != nil {
 				return err
 			}
+			curX++
+		}
+		return sl.drawHeaderAbove(cvs, ar)
+	}
 
-			curY--
+	posRows, negRows := splitRows(max, min, ar.Dy())
+	// baseY is the first row below the baseline.
+	baseY := ar.Min.Y + posRows
+	for _, v := range visible {
+		v = clamp(v, min, max)
+		if v >= 0 {
+			if err := sl.drawUp(cvs, image.Point{curX, baseY - 1}, toBlocks(v, max, posRows)); err != nil {
+				return err
+			}
+		} else {
+			if err := sl.drawDown(cvs, image.Point{curX, baseY}, toBlocks(-v, -min, negRows)); err != nil {
+				return err
+			}
 		}
+		curX++
+	}
+	return sl.drawHeaderAbove(cvs, ar)
+}
+
+// drawHeaderAbove draws the header immediately above the area of the
+// SparkLine, if there is one and it fits.
+func (sl *SparkLine) drawHeaderAbove(cvs *canvas.Canvas, ar image.Rectangle) error {
+	if sl.hasHeader() && ar.Min.Y > cvs.Area().Min.Y {
+		return sl.drawHeader(cvs, ar.Min.Y-1)
+	}
+	return nil
+}
 
-		if blocks.partSpark != 0 {
-			if _, err := cvs.SetCell(
-				image.Point{curX, curY},
-				blocks.partSpark,
-				cell.FgColor(sl.opts.color),
+// hasHeader determines if the SparkLine has a line with the label or the
+// current value.
+func (sl *SparkLine) hasHeader() bool {
+	return sl.opts.label != "" || sl.opts.showCurrent
+}
+
+// drawHeader draws the label and the current value on the line at the
+// specified Y coordinate.
+func (sl *SparkLine) drawHeader(cvs *canvas.Canvas, y int) error {
+	cvsAr := cvs.Area()
+	labelMaxX := cvsAr.Max.X
+	if sl.opts.showCurrent && len(sl.data) > 0 {
+		cur := fmt.Sprint(sl.data[len(sl.data)-1])
+		start := image.Point{cvsAr.Max.X - runewidth.StringWidth(cur), y}
+		if start.X >= cvsAr.Min.X {
+			if err := draw.Text(cvs, cur, start,
+				draw.TextCellOpts(sl.currentCellOpts()...),
 			); err != nil {
 				return err
 			}
+			// One cell of space between the label and the value.
+			labelMaxX = start.X - 1
 		}
+	}
 
-		curX++
+	if sl.opts.label == "" || labelMaxX <= cvsAr.Min.X {
+		return nil
+	}
+	return draw.Text(cvs, sl.opts.label, image.Point{cvsAr.Min.X, y},
+		draw.TextCellOpts(sl.labelCellOpts()...),
+		draw.TextMaxX(labelMaxX),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// color returns the color of the bars that represent positive values.
+func (sl *SparkLine) color() cell.Color {
+	if !sl.opts.colorSet && sl.theme != nil {
+		return sl.theme.Accent
+	}
+	return sl.opts.color
+}
+
+// labelCellOpts returns the cell options for the label.
+func (sl *SparkLine) labelCellOpts() []cell.Option {
+	if len(sl.opts.labelCellOpts) == 0 && sl.theme != nil {
+		return []cell.Option{cell.FgColor(sl.theme.Foreground)}
 	}
+	return sl.opts.labelCellOpts
+}
 
-	if sl.opts.label != "" {
-		// Label is placed immediately above the SparkLine.
-		lStart := image.Point{ar.Min.X, ar.Min.Y - 1}
-		if err := draw.Text(cvs, sl.opts.label, lStart,
-			draw.TextCellOpts(sl.opts.labelCellOpts...),
-			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+// currentCellOpts returns the cell options for the current value.
+func (sl *SparkLine) currentCellOpts() []cell.Option {
+	if len(sl.opts.currentCellOpts) == 0 {
+		return sl.labelCellOpts()
+	}
+	return sl.opts.currentCellOpts
+}
+
+// drawUp draws a bar that represents a positive value, the bar grows upward
+// from the start cell.
+func (sl *SparkLine) drawUp(cvs *canvas.Canvas, start image.Point, b blocks) error {
+	cur := start
+	for i := 0; i < b.full; i++ {
+		if _, err := cvs.SetCell(
+			cur,
+			sparks[len(sparks)-1], // Last spark represents full cell.
+			cell.FgColor(sl.color()),
+		); err != nil {
+			return err
+		}
+		cur.Y--
+	}
+
+	if b.partSpark != 0 {
+		if _, err := cvs.SetCell(cur, b.partSpark, cell.FgColor(sl.color())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawDown draws a bar that represents a negative value, the bar grows
+// downward from the start cell.
+func (sl *SparkLine) drawDown(cvs *canvas.Canvas, start image.Point, b blocks) error {
+	cur := start
+	for i := 0; i < b.full; i++ {
+		if _, err := cvs.SetCell(
+			cur,
+			sparks[len(sparks)-1], // Last spark represents full cell.
+			cell.FgColor(sl.opts.negColor),
+		); err != nil {
+			return err
+		}
+		cur.Y++
+	}
+
+	if b.partSpark != 0 {
+		// The sparks grow from the bottom of the cell, the top part of the
+		// cell is filled by drawing the complementary spark with inverted
+		// colors.
+		if _, err := cvs.SetCell(
+			cur,
+			invertSpark(b.partSpark),
+			cell.FgColor(sl.opts.negColor),
+			cell.Inverse(),
 		); err != nil {
 			return err
 		}
@@ -148,7 +280,8 @@ func (sl *SparkLine) ValueCapacity() int {
 // (i.e. a missing bar).
 //
 // At least one data point must be provided. All data points must be positive
-// integers.
+// integers, unless the Baseline option is set in which case negative data
+// points are also accepted.
 //
 // The last added data point will be the one displayed all the way on the right
 // of the SparkLine. If there are more data points than we can fit bars to the
@@ -165,7 +298,7 @@ func (sl *SparkLine) Add(data []int, opts ...Option) error {
 	}
 
 	for i, d := range data {
-		if d < 0 {
+		if d < 0 && !sl.opts.baseline {
 			return fmt.Errorf("data point[%d]: %v must be a positive integer", i, d)
 		}
 	}
@@ -204,8 +337,8 @@ func (sl *SparkLine) area(cvs *canvas.Canvas) image.Rectangle {
 	} else {
 		minY = cvsAr.Min.Y
 
-		if sl.opts.label != "" {
-			minY++ // Reserve one line for the label.
+		if sl.hasHeader() && cvsAr.Dy() > 1 {
+			minY++ // Reserve one line for the label and the current value.
 		}
 	}
 	return image.Rect(
@@ -220,17 +353,16 @@ func (sl *SparkLine) area(cvs *canvas.Canvas) image.Rectangle {
 func (sl *SparkLine) minSize() image.Point {
 	const minWidth = 1 // At least one data point.
 
-	var minHeight int
 	if sl.opts.height > 0 {
-		minHeight = sl.opts.height
-	} else {
-		minHeight = 1 // At least one line of characters.
-	}
-
-	if sl.opts.label != "" {
-		minHeight++ // One line for the text label.
+		minHeight := sl.opts.height
+		if sl.hasHeader() {
+			minHeight++ // One line for the label and the current value.
+		}
+		return image.Point{minWidth, minHeight}
 	}
-	return image.Point{minWidth, minHeight}
+	// At least one line of characters. The line with the label and the
+	// current value is omitted when there is no space for it.
+	return image.Point{minWidth, 1}
 }
 
 // Options implements widgetapi.Widget.Options.
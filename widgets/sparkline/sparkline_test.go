@@ -168,6 +168,198 @@ func TestSparkLine(t *testing.T) {
 			},
 			wantCapacity: 9,
 		},
+		{
+			desc: "baseline doesn't change the display of non-negative values",
+			opts: []Option{
+				Baseline(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+			},
+			canvas: image.Rect(0, 0, 9, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▁▂▃▄▅▆▇█", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 9,
+		},
+		{
+			desc: "baseline draws negative values downward",
+			opts: []Option{
+				Baseline(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{4, -4, 8, -8, 0, -1})
+			},
+			canvas: image.Rect(0, 0, 6, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▄', cell.FgColor(DefaultColor))
+				testcanvas.MustSetCell(c, image.Point{1, 1}, '▄', cell.FgColor(DefaultNegativeColor), cell.Inverse())
+				testcanvas.MustSetCell(c, image.Point{2, 0}, '█', cell.FgColor(DefaultColor))
+				testcanvas.MustSetCell(c, image.Point{3, 1}, '█', cell.FgColor(DefaultNegativeColor))
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '▇', cell.FgColor(DefaultNegativeColor), cell.Inverse())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 6,
+		},
+		{
+			desc: "baseline scales both sides to the combined range",
+			opts: []Option{
+				Baseline(),
+				NegativeColor(cell.ColorBlue),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{24, -8})
+			},
+			canvas: image.Rect(0, 0, 2, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for y := 0; y < 3; y++ {
+					testcanvas.MustSetCell(c, image.Point{0, y}, '█', cell.FgColor(DefaultColor))
+				}
+				testcanvas.MustSetCell(c, image.Point{1, 3}, '█', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "baseline at the top when all values are negative",
+			opts: []Option{
+				Baseline(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{-8, -12})
+			},
+			canvas: image.Rect(0, 0, 2, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '█', cell.FgColor(DefaultNegativeColor))
+				testcanvas.MustSetCell(c, image.Point{0, 1}, '▅', cell.FgColor(DefaultNegativeColor), cell.Inverse())
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '█', cell.FgColor(DefaultNegativeColor))
+				testcanvas.MustSetCell(c, image.Point{1, 1}, '█', cell.FgColor(DefaultNegativeColor))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "fails when MinValue isn't less than MaxValue",
+			opts: []Option{
+				MinValue(5),
+				MaxValue(5),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "scales the values to the pinned max value",
+			opts: []Option{
+				MaxValue(16),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{4, 8})
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▂▄", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "values above the pinned max value are full",
+			opts: []Option{
+				MaxValue(8),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{4, 20})
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▄█", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "the bars grow from the pinned min value",
+			opts: []Option{
+				MinValue(8),
+				MaxValue(16),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{4, 12, 16})
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// The value below the min value is empty.
+				testdraw.MustText(c, "▄█", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "baseline with the pinned range clamps the negative values",
+			opts: []Option{
+				Baseline(),
+				MinValue(-8),
+				MaxValue(8),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{4, -4, -20})
+			},
+			canvas: image.Rect(0, 0, 3, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▄', cell.FgColor(DefaultColor))
+				testcanvas.MustSetCell(c, image.Point{1, 1}, '▄', cell.FgColor(DefaultNegativeColor), cell.Inverse())
+				testcanvas.MustSetCell(c, image.Point{2, 1}, '█', cell.FgColor(DefaultNegativeColor))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
 		{
 			desc: "single height sparkline with label",
 			opts: []Option{
@@ -191,6 +383,169 @@ func TestSparkLine(t *testing.T) {
 			},
 			wantCapacity: 9,
 		},
+		{
+			desc: "omits the label on a single line canvas",
+			opts: []Option{
+				Label("Hello"),
+				ShowCurrent(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 8, 3, 2, 1, 1})
+			},
+			canvas: image.Rect(0, 0, 9, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▁▂▃█▃▂▁▁", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 9,
+		},
+		{
+			desc: "displays the current value without a label",
+			opts: []Option{
+				ShowCurrent(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 8, 3, 2, 1, 1})
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "1", image.Point{8, 0})
+				testdraw.MustText(c, "▁▂▃█▃▂▁▁", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 9,
+		},
+		{
+			desc: "displays the label and the current value",
+			opts: []Option{
+				Label("Hi", cell.FgColor(cell.ColorBlue)),
+				ShowCurrent(cell.FgColor(cell.ColorRed)),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 8, 3, 2, 1, 10})
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Hi", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+				testdraw.MustText(c, "10", image.Point{7, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testdraw.MustText(c, "▁▂▂▆▂▂▁█", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 9,
+		},
+		{
+			desc: "trims the label to make space for the current value",
+			opts: []Option{
+				Label("Hello world"),
+				ShowCurrent(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{100})
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Hell…", image.Point{0, 0})
+				testdraw.MustText(c, "100", image.Point{6, 0})
+				testdraw.MustText(c, "█", image.Point{8, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 9,
+		},
+		{
+			desc: "uses theme colors for options that aren't set",
+			opts: []Option{
+				Label("Hello"),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 8, 3, 2, 1, 1})
+			},
+			meta: &widgetapi.Meta{
+				Theme: &cell.Theme{
+					Foreground: cell.ColorWhite,
+					Accent:     cell.ColorBlue,
+				},
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Hello", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorWhite),
+				))
+				testdraw.MustText(c, "▁▂▃█▃▂▁▁", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 9,
+		},
+		{
+			desc: "options take precedence over the theme",
+			opts: []Option{
+				Label("Hello", cell.FgColor(cell.ColorYellow)),
+				Color(cell.ColorMagenta),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 8, 3, 2, 1, 1})
+			},
+			meta: &widgetapi.Meta{
+				Theme: &cell.Theme{
+					Foreground: cell.ColorWhite,
+					Accent:     cell.ColorBlue,
+				},
+			},
+			canvas: image.Rect(0, 0, 9, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Hello", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorYellow),
+				))
+				testdraw.MustText(c, "▁▂▃█▃▂▁▁", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorMagenta),
+				))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 9,
+		},
 		{
 			desc: "too long label is trimmed",
 			opts: []Option{
@@ -504,6 +859,44 @@ func TestSparkLine(t *testing.T) {
 	}
 }
 
+func TestPinnedRangeSharesScale(t *testing.T) {
+	// lastColumn draws a SparkLine with the pinned range and returns the
+	// cells of the column with the last value.
+	lastColumn := func(data []int) *faketerm.Terminal {
+		sl, err := New(MinValue(0), MaxValue(10))
+		if err != nil {
+			t.Fatalf("New => unexpected error: %v", err)
+		}
+		if err := sl.Add(data); err != nil {
+			t.Fatalf("Add => unexpected error: %v", err)
+		}
+		c := testcanvas.MustNew(image.Rect(0, 0, len(data), 3))
+		if err := sl.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+
+		last := testcanvas.MustNew(image.Rect(0, 0, 1, 3))
+		for y := 0; y < 3; y++ {
+			cl, err := c.Cell(image.Point{len(data) - 1, y})
+			if err != nil {
+				t.Fatalf("Cell => unexpected error: %v", err)
+			}
+			testcanvas.MustSetCell(last, image.Point{0, y}, cl.Rune, cl.Opts)
+		}
+		ft := faketerm.MustNew(last.Size())
+		testcanvas.MustApply(last, ft)
+		return ft
+	}
+
+	// The same value is drawn with the same height even though the largest
+	// values of the two SparkLines differ.
+	small := lastColumn([]int{1, 5})
+	large := lastColumn([]int{10, 5})
+	if diff := faketerm.Diff(small, large); diff != "" {
+		t.Errorf("Draw => the same values differ on the two SparkLines: %v", diff)
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -524,7 +917,7 @@ func TestOptions(t *testing.T) {
 				Label("foo"),
 			},
 			want: widgetapi.Options{
-				MinimumSize:  image.Point{1, 2},
+				MinimumSize:  image.Point{1, 1},
 				WantKeyboard: widgetapi.KeyScopeNone,
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
@@ -554,6 +947,19 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "current value and fixed height",
+			opts: []Option{
+				ShowCurrent(),
+				Height(3),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 4},
+				MaximumSize:  image.Point{1, 4},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -48,6 +48,54 @@ func visibleMax(data []int, width int) ([]int, int) {
 	return data, max
 }
 
+// visibleMin returns the minimum among the data points or zero if none of
+// them is negative.
+func visibleMin(data []int) int {
+	var min int
+	for _, v := range data {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// clamp returns the value clamped into the range min <= value <= max.
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// splitRows splits the vertical cells available to the SparkLine into the
+// rows above the baseline that display positive values and the rows below it
+// that display negative values. The split is proportional to the max and min
+// visible values, so that both sides share the same scale.
+func splitRows(max, min, vertCells int) (pos, neg int) {
+	switch {
+	case min >= 0:
+		return vertCells, 0
+	case max <= 0:
+		return 0, vertCells
+	}
+
+	pos = int(math.Round(float64(vertCells) * float64(max) / float64(max-min)))
+	if vertCells >= 2 {
+		// Both sides need at least one row.
+		if pos < 1 {
+			pos = 1
+		}
+		if pos > vertCells-1 {
+			pos = vertCells - 1
+		}
+	}
+	return pos, vertCells - pos
+}
+
 // blocks represents the building blocks that display one value on a SparkLine.
 // I.e. one vertical bar.
 type blocks struct {
@@ -88,6 +136,18 @@ func toBlocks(value, max, vertCells int) blocks {
 	return b
 }
 
+// invertSpark returns the spark character that complements the provided
+// spark to a full cell. Used to draw partial blocks that grow downward by
+// inverting the colors of the cell.
+func invertSpark(r rune) rune {
+	for i, s := range sparks {
+		if s == r {
+			return sparks[len(sparks)-2-i]
+		}
+	}
+	return 0
+}
+
 // init ensures that all spark characters are half-width runes.
 // The SparkLine widget assumes that each value can be represented in a column
 // that has a width of one cell.
@@ -98,6 +98,99 @@ func TestVisibleMax(t *testing.T) {
 	}
 }
 
+func TestVisibleMin(t *testing.T) {
+	tests := []struct {
+		desc string
+		data []int
+		want int
+	}{
+		{
+			desc: "zero for no data",
+			want: 0,
+		},
+		{
+			desc: "zero when all values are positive",
+			data: []int{3, 1, 2},
+			want: 0,
+		},
+		{
+			desc: "the smallest negative value",
+			data: []int{3, -1, -5, 2},
+			want: -5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := visibleMin(tc.data); got != tc.want {
+				t.Errorf("visibleMin => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitRows(t *testing.T) {
+	tests := []struct {
+		desc      string
+		max       int
+		min       int
+		vertCells int
+		wantPos   int
+		wantNeg   int
+	}{
+		{
+			desc:      "all rows positive when there are no negative values",
+			max:       10,
+			min:       0,
+			vertCells: 3,
+			wantPos:   3,
+			wantNeg:   0,
+		},
+		{
+			desc:      "all rows negative when there are no positive values",
+			max:       0,
+			min:       -10,
+			vertCells: 3,
+			wantPos:   0,
+			wantNeg:   3,
+		},
+		{
+			desc:      "rows split proportionally",
+			max:       30,
+			min:       -10,
+			vertCells: 4,
+			wantPos:   3,
+			wantNeg:   1,
+		},
+		{
+			desc:      "each side gets at least one row",
+			max:       100,
+			min:       -1,
+			vertCells: 4,
+			wantPos:   3,
+			wantNeg:   1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotPos, gotNeg := splitRows(tc.max, tc.min, tc.vertCells)
+			if gotPos != tc.wantPos || gotNeg != tc.wantNeg {
+				t.Errorf("splitRows => (%v, %v), want (%v, %v)", gotPos, gotNeg, tc.wantPos, tc.wantNeg)
+			}
+		})
+	}
+}
+
+func TestInvertSpark(t *testing.T) {
+	for i, s := range sparks[:len(sparks)-1] {
+		want := sparks[len(sparks)-2-i]
+		if got := invertSpark(s); got != want {
+			t.Errorf("invertSpark(%q) => %q, want %q", s, got, want)
+		}
+	}
+}
+
 func TestToBlocks(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -0,0 +1,74 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// bidi.go contains code that orders text written with the WriteRTL option for
+// display.
+
+import (
+	"github.com/mum4k/termdash/private/canvas/buffer"
+)
+
+// visualOrder returns the cells of the wrapped line in the order they are
+// displayed from left to right and whether the line should be right-aligned.
+//
+// This isn't the full unicode bidirectional algorithm. The direction of a line
+// is determined by its first cell. Lines that start with right-to-left text are
+// displayed reversed and right-aligned, with the runs of left-to-right text in
+// them keeping their order. In other lines only the runs of right-to-left text
+// are reversed.
+func visualOrder(line []*buffer.Cell, rtl map[*buffer.Cell]bool) ([]*buffer.Cell, bool) {
+	if len(rtl) == 0 {
+		return line, false
+	}
+
+	var (
+		cells  []*buffer.Cell
+		hasRTL bool
+	)
+	for _, c := range line {
+		if c.Rune == '\n' {
+			continue
+		}
+		cells = append(cells, c)
+		hasRTL = hasRTL || rtl[c]
+	}
+	if !hasRTL {
+		return line, false
+	}
+
+	rtlLine := rtl[cells[0]]
+	if rtlLine {
+		reverseCells(cells)
+	}
+	for start := 0; start < len(cells); {
+		end := start + 1
+		for end < len(cells) && rtl[cells[end]] == rtl[cells[start]] {
+			end++
+		}
+		if rtl[cells[start]] != rtlLine {
+			reverseCells(cells[start:end])
+		}
+		start = end
+	}
+	return cells, rtlLine
+}
+
+// reverseCells reverses the order of the cells in place.
+func reverseCells(cells []*buffer.Cell) {
+	for i, j := 0, len(cells)-1; i < j; i, j = i+1, j-1 {
+		cells[i], cells[j] = cells[j], cells[i]
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// highlight.go contains code that highlights the occurrences of a term in the
+// text content.
+
+import (
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+)
+
+// Highlight highlights all the occurrences of the term in the text content by
+// applying the provided cell options over the cell options the text was
+// written with. Cell options not set by the highlight are preserved. The
+// highlight is applied on the next call to Draw and also covers text written
+// after this call. Use the HighlightIgnoreCase option to match the term
+// case-insensitively.
+//
+// Providing an empty term removes the highlight.
+func (t *Text) Highlight(term string, opts ...cell.Option) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.highlightTerm = []rune(term)
+	t.highlightOpts = opts
+}
+
+// runesEqual determines if the two runes are equal, optionally ignoring their
+// case.
+func runesEqual(a, b rune, ignoreCase bool) bool {
+	if a == b {
+		return true
+	}
+	return ignoreCase && unicode.ToLower(a) == unicode.ToLower(b)
+}
+
+// highlighted returns the cells of the content that are part of an occurrence
+// of the highlighted term. Occurrences don't overlap, the content is scanned
+// from the beginning.
+func (t *Text) highlighted() map[*buffer.Cell]bool {
+	term := t.highlightTerm
+	if len(term) == 0 {
+		return nil
+	}
+
+	res := map[*buffer.Cell]bool{}
+	for i := 0; i+len(term) <= len(t.content); {
+		match := true
+		for j, r := range term {
+			if !runesEqual(t.content[i+j].Rune, r, t.opts.highlightIgnoreCase) {
+				match = false
+				break
+			}
+		}
+		if !match {
+			i++
+			continue
+		}
+		for _, c := range t.content[i : i+len(term)] {
+			res[c] = true
+		}
+		i += len(term)
+	}
+	return res
+}
+
+// cellOpts returns the cell options the cell should be drawn with, including
+// the highlight if the cell is part of an occurrence of the highlighted term
+// and the selection options if the cell is selected.
+func (t *Text) cellOpts(c *buffer.Cell, highlighted, selected map[*buffer.Cell]bool) *cell.Options {
+	if !highlighted[c] && !selected[c] {
+		return c.Opts
+	}
+	opts := cell.NewOptions(c.Opts)
+	if highlighted[c] {
+		for _, o := range t.highlightOpts {
+			o.Set(opts)
+		}
+	}
+	if selected[c] {
+		for _, o := range t.opts.selectionOpts {
+			o.Set(opts)
+		}
+	}
+	return opts
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// line_numbers.go contains code that draws the gutter with line numbers.
+
+import (
+	"image"
+	"strconv"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// wrapNumbered wraps the content the same way as wrap.Cells, but each line of
+// the content is wrapped separately. Returns the wrapped lines and for each of
+// them the number of the content line it belongs to, starting at one.
+func wrapNumbered(content []*buffer.Cell, width int, m wrap.Mode) ([][]*buffer.Cell, []int, error) {
+	var (
+		lines [][]*buffer.Cell
+		nums  []int
+	)
+	start, num := 0, 1
+	for i := 0; i <= len(content); i++ {
+		if i < len(content) && content[i].Rune != '\n' {
+			continue
+		}
+
+		// An empty content line still occupies one line.
+		wr := [][]*buffer.Cell{nil}
+		if i > start {
+			var err error
+			wr, err = wrap.Cells(content[start:i], width, m)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		for _, line := range wr {
+			lines = append(lines, line)
+			nums = append(nums, num)
+		}
+		start, num = i+1, num+1
+	}
+	return lines, nums, nil
+}
+
+// contentLines returns the number of lines in the content.
+func contentLines(content []*buffer.Cell) int {
+	lines := 1
+	for _, c := range content {
+		if c.Rune == '\n' {
+			lines++
+		}
+	}
+	return lines
+}
+
+// gutterWidth returns the width of the gutter in cells when displaying the
+// specified number of lines. The gutter fits the largest line number and one
+// cell that separates it from the text.
+func gutterWidth(lines int) int {
+	return len(strconv.Itoa(lines)) + 1
+}
+
+// drawLineNumbers draws the right-aligned line numbers into the gutter for
+// the wrapped lines starting at fromLine. Lines that continue a wrapped
+// content line are left blank unless configured otherwise. Lines replaced with
+// the scroll markers don't get a number.
+func (t *Text) drawLineNumbers(cvs *canvas.Canvas, gutter, fromLine int) error {
+	height := cvs.Area().Dy()
+	lines := len(t.wrapped)
+	for y := 0; y < height && fromLine+y < lines; y++ {
+		idx := fromLine + y
+		if height >= minLinesForMarkers {
+			if y == 0 && fromLine > 0 {
+				continue // Scroll up marker.
+			}
+			if y == height-1 && height < lines-fromLine {
+				continue // Scroll down marker.
+			}
+		}
+		if idx > 0 && t.lineNums[idx] == t.lineNums[idx-1] && !t.opts.repeatLineNumbers {
+			continue
+		}
+
+		num := strconv.Itoa(t.lineNums[idx])
+		x := gutter - 1 - len(num)
+		for _, r := range num {
+			if _, err := cvs.SetCell(image.Point{x, y}, r, cell.FgColor(t.opts.lineNumbersColor)); err != nil {
+				return err
+			}
+			x++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+func TestGutterWidth(t *testing.T) {
+	tests := []struct {
+		desc  string
+		lines int
+		want  int
+	}{
+		{
+			desc:  "single line",
+			lines: 1,
+			want:  2,
+		},
+		{
+			desc:  "largest single digit number",
+			lines: 9,
+			want:  2,
+		},
+		{
+			desc:  "two digits",
+			lines: 10,
+			want:  3,
+		},
+		{
+			desc:  "three digits",
+			lines: 100,
+			want:  4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := gutterWidth(tc.lines); got != tc.want {
+				t.Errorf("gutterWidth(%d) => %d, want %d", tc.lines, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrapNumbered(t *testing.T) {
+	tests := []struct {
+		desc      string
+		text      string
+		width     int
+		mode      wrap.Mode
+		wantLines []string
+		wantNums  []int
+	}{
+		{
+			desc:      "single line",
+			text:      "hello",
+			width:     10,
+			mode:      wrap.AtRunes,
+			wantLines: []string{"hello"},
+			wantNums:  []int{1},
+		},
+		{
+			desc:      "wrapped lines keep the number",
+			text:      "hello world\nshort",
+			width:     5,
+			mode:      wrap.AtRunes,
+			wantLines: []string{"hello", " worl", "d", "short"},
+			wantNums:  []int{1, 1, 1, 2},
+		},
+		{
+			desc:      "wraps at words",
+			text:      "hello world\nshort",
+			width:     8,
+			mode:      wrap.AtWords,
+			wantLines: []string{"hello", "world", "short"},
+			wantNums:  []int{1, 1, 2},
+		},
+		{
+			desc:      "empty lines are numbered",
+			text:      "a\n\nb\n",
+			width:     5,
+			mode:      wrap.AtRunes,
+			wantLines: []string{"a", "", "b", ""},
+			wantNums:  []int{1, 2, 3, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var content []*buffer.Cell
+			for _, r := range tc.text {
+				content = append(content, buffer.NewCell(r))
+			}
+
+			lines, nums, err := wrapNumbered(content, tc.width, tc.mode)
+			if err != nil {
+				t.Fatalf("wrapNumbered => unexpected error: %v", err)
+			}
+
+			var gotLines []string
+			for _, line := range lines {
+				var s []rune
+				for _, c := range line {
+					s = append(s, c.Rune)
+				}
+				gotLines = append(gotLines, string(s))
+			}
+			if diff := pretty.Compare(tc.wantLines, gotLines); diff != "" {
+				t.Errorf("wrapNumbered => unexpected lines, diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantNums, nums); diff != "" {
+				t.Errorf("wrapNumbered => unexpected numbers, diff (-want, +got):\n%s", diff)
+			}
+
+			wantLines, err := wrap.Cells(content, tc.width, tc.mode)
+			if err != nil {
+				t.Fatalf("wrap.Cells => unexpected error: %v", err)
+			}
+			if got, want := len(lines), len(wantLines); got != want {
+				t.Errorf("wrapNumbered => %d lines, wrap.Cells returns %d", got, want)
+			}
+		})
+	}
+}
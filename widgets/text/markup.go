@@ -0,0 +1,324 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// markup.go contains code that writes text with inline styling markup.
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// markupColors maps the color names usable in the markup tags to colors.
+var markupColors = map[string]cell.Color{
+	"default": cell.ColorDefault,
+	"black":   cell.ColorBlack,
+	"maroon":  cell.ColorMaroon,
+	"green":   cell.ColorGreen,
+	"olive":   cell.ColorOlive,
+	"navy":    cell.ColorNavy,
+	"purple":  cell.ColorPurple,
+	"teal":    cell.ColorTeal,
+	"silver":  cell.ColorSilver,
+	"gray":    cell.ColorGray,
+	"red":     cell.ColorRed,
+	"lime":    cell.ColorLime,
+	"yellow":  cell.ColorYellow,
+	"blue":    cell.ColorBlue,
+	"fuchsia": cell.ColorFuchsia,
+	"aqua":    cell.ColorAqua,
+	"white":   cell.ColorWhite,
+	"magenta": cell.ColorMagenta,
+	"cyan":    cell.ColorCyan,
+}
+
+// markupRun is a part of the text that has the same cell options.
+type markupRun struct {
+	text string
+	opts *cell.Options
+}
+
+// markupStyle is the style set by the markup tags.
+type markupStyle struct {
+	fg        cell.Color
+	bg        cell.Color
+	bold      bool
+	italic    bool
+	underline bool
+}
+
+// newMarkupStyle returns the style of the provided cell options.
+func newMarkupStyle(opts *cell.Options) markupStyle {
+	return markupStyle{
+		fg:        opts.FgColor,
+		bg:        opts.BgColor,
+		bold:      opts.Bold,
+		italic:    opts.Italic,
+		underline: opts.Underline,
+	}
+}
+
+// withTag returns the style after applying the tag, i.e. the content between
+// the square brackets. The base is the style the reset tag returns to.
+// Returns false if the tag isn't valid.
+func (ms markupStyle) withTag(tag string, base markupStyle) (markupStyle, bool) {
+	switch tag {
+	case "reset":
+		return base, true
+	case "bold":
+		ms.bold = true
+		return ms, true
+	case "italic":
+		ms.italic = true
+		return ms, true
+	case "underline":
+		ms.underline = true
+		return ms, true
+	}
+
+	if name := strings.TrimPrefix(tag, "bg:"); name != tag {
+		color, ok := markupColor(name)
+		if !ok {
+			return ms, false
+		}
+		ms.bg = color
+		return ms, true
+	}
+	color, ok := markupColor(tag)
+	if !ok {
+		return ms, false
+	}
+	ms.fg = color
+	return ms, true
+}
+
+// cellOpts returns the cell options for text in this style. The base are the
+// options provided to WriteMarkup, the bold and underline indicate if the text
+// is between the emphasis markers.
+func (ms markupStyle) cellOpts(base *cell.Options, bold, underline bool) *cell.Options {
+	opts := *base
+	opts.FgColor = ms.fg
+	opts.BgColor = ms.bg
+	opts.Bold = ms.bold || bold
+	opts.Italic = ms.italic
+	opts.Underline = ms.underline || underline
+	return &opts
+}
+
+// markupColor returns the color specified in a markup tag, either its name,
+// its number or its RGB value in the #rrggbb format.
+func markupColor(s string) (cell.Color, bool) {
+	if c, ok := markupColors[s]; ok {
+		return c, true
+	}
+	if hex := strings.TrimPrefix(s, "#"); hex != s {
+		if len(hex) != 6 {
+			return 0, false
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return 0, false
+		}
+		return cell.ColorRGB24(int(v>>16), int(v>>8&0xff), int(v&0xff)), true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 255 {
+		return 0, false
+	}
+	return cell.ColorNumber(n), true
+}
+
+// isMarkupSpecial asserts whether the rune has a special meaning in the markup
+// and can be escaped with a backslash.
+func isMarkupSpecial(r rune) bool {
+	switch r {
+	case '\\', '[', ']', '*', '_':
+		return true
+	}
+	return false
+}
+
+// isWordRune asserts whether the rune is part of a word. Emphasis markers
+// don't open or close within words, e.g. in snake_case_names.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// canOpen asserts whether the emphasis marker at the index can open
+// emphasized text.
+func canOpen(rs []rune, i int) bool {
+	if i > 0 && isWordRune(rs[i-1]) {
+		return false
+	}
+	return i+1 < len(rs) && !unicode.IsSpace(rs[i+1])
+}
+
+// canClose asserts whether the emphasis marker at the index can close
+// emphasized text that was opened at the index open.
+func canClose(rs []rune, open, i int) bool {
+	if i <= open+1 || unicode.IsSpace(rs[i-1]) {
+		return false
+	}
+	return i+1 == len(rs) || !isWordRune(rs[i+1])
+}
+
+// hasCloser asserts whether the emphasis marker opened at the index is
+// closed later in the text.
+func hasCloser(rs []rune, open int) bool {
+	for i := open + 1; i < len(rs); i++ {
+		switch {
+		case rs[i] == '\\' && i+1 < len(rs) && isMarkupSpecial(rs[i+1]):
+			i++
+		case rs[i] == rs[open] && canClose(rs, open, i):
+			return true
+		}
+	}
+	return false
+}
+
+// tagEnd returns the index of the square bracket that closes the tag opened
+// at the index or -1 if the tag isn't closed on the same line.
+func tagEnd(rs []rune, open int) int {
+	for i := open + 1; i < len(rs); i++ {
+		switch rs[i] {
+		case ']':
+			return i
+		case '[', '\n':
+			return -1
+		}
+	}
+	return -1
+}
+
+// parseMarkup splits the markup into runs of text with the same cell options.
+// The base are the cell options of text outside of any markup.
+func parseMarkup(markup string, base *cell.Options) []*markupRun {
+	var (
+		rs        = []rune(markup)
+		runs      []*markupRun
+		cur       []rune
+		baseStyle = newMarkupStyle(base)
+		style     = baseStyle
+		// The indexes of the emphasis markers that opened the bold and
+		// underlined text or -1 if the text isn't emphasized.
+		boldAt      = -1
+		underlineAt = -1
+	)
+	flush := func() {
+		if len(cur) == 0 {
+			return
+		}
+		runs = append(runs, &markupRun{
+			text: string(cur),
+			opts: style.cellOpts(base, boldAt >= 0, underlineAt >= 0),
+		})
+		cur = nil
+	}
+
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch r {
+		case '\\':
+			if i+1 < len(rs) && isMarkupSpecial(rs[i+1]) {
+				i++
+				r = rs[i]
+			}
+
+		case '[':
+			if end := tagEnd(rs, i); end >= 0 {
+				if next, ok := style.withTag(string(rs[i+1:end]), baseStyle); ok {
+					flush()
+					style = next
+					i = end
+					continue
+				}
+			}
+
+		case '*', '_':
+			at := &boldAt
+			if r == '_' {
+				at = &underlineAt
+			}
+			if *at >= 0 && canClose(rs, *at, i) {
+				flush()
+				*at = -1
+				continue
+			}
+			if *at < 0 && canOpen(rs, i) && hasCloser(rs, i) {
+				flush()
+				*at = i
+				continue
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return runs
+}
+
+// WriteMarkup writes text with inline styling markup for the widget to
+// display. Accepts the same options as Write, the cell options provided with
+// WriteCellOpts apply to text outside of any markup.
+//
+// The markup supports the following:
+//
+//	[red]        sets the foreground color, see below for the colors.
+//	[bg:red]     sets the background color.
+//	[bold]       makes the text bold.
+//	[italic]     makes the text italic.
+//	[underline]  makes the text underlined.
+//	[reset]      resets the style set by the tags above.
+//	*text*       makes the text bold.
+//	_text_       makes the text underlined.
+//
+// Colors are specified by their name (default, black, maroon, green, olive,
+// navy, purple, teal, silver, gray, red, lime, yellow, blue, fuchsia, aqua,
+// white, magenta, cyan), by their number (0-255) or by their RGB value in the
+// #rrggbb format. The emphasis markers don't open or close within words, so
+// snake_case_names are written as they are.
+//
+// A backslash escapes any of the characters '\', '[', ']', '*' and '_', which
+// are then written literally. Markup that isn't valid, e.g. an unknown tag or
+// an emphasis marker without its closing marker, is also written literally.
+//
+// Returns an error if the text without the markup isn't valid for Write.
+func (t *Text) WriteMarkup(markup string, wOpts ...WriteOption) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	opts := newWriteOptions(wOpts...)
+	runs := parseMarkup(markup, opts.cellOpts)
+	var plain strings.Builder
+	for _, run := range runs {
+		plain.WriteString(run.text)
+	}
+	if err := wrap.ValidText(plain.String()); err != nil {
+		return err
+	}
+
+	if opts.replace {
+		t.reset()
+	}
+	for _, run := range runs {
+		runOpts := *opts
+		runOpts.cellOpts = run.opts
+		t.write(run.text, &runOpts)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestParseMarkup(t *testing.T) {
+	tests := []struct {
+		desc   string
+		markup string
+		base   *cell.Options
+		want   []*markupRun
+	}{
+		{
+			desc:   "text without markup",
+			markup: "hello",
+			want: []*markupRun{
+				{text: "hello", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "foreground color and reset",
+			markup: "a[red]b[reset]c",
+			want: []*markupRun{
+				{text: "a", opts: cell.NewOptions()},
+				{text: "b", opts: cell.NewOptions(cell.FgColor(cell.ColorRed))},
+				{text: "c", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "background, numbered and RGB colors",
+			markup: "[bg:blue]a[196]b[#ff8000]c",
+			want: []*markupRun{
+				{text: "a", opts: cell.NewOptions(cell.BgColor(cell.ColorBlue))},
+				{text: "b", opts: cell.NewOptions(cell.BgColor(cell.ColorBlue), cell.FgColor(cell.ColorNumber(196)))},
+				{text: "c", opts: cell.NewOptions(cell.BgColor(cell.ColorBlue), cell.FgColor(cell.ColorRGB24(255, 128, 0)))},
+			},
+		},
+		{
+			desc:   "attribute tags",
+			markup: "[bold]a[italic]b[underline]c",
+			want: []*markupRun{
+				{text: "a", opts: cell.NewOptions(cell.Bold())},
+				{text: "b", opts: cell.NewOptions(cell.Bold(), cell.Italic())},
+				{text: "c", opts: cell.NewOptions(cell.Bold(), cell.Italic(), cell.Underline())},
+			},
+		},
+		{
+			desc:   "emphasis markers",
+			markup: "a *bold* and _underlined_ text",
+			want: []*markupRun{
+				{text: "a ", opts: cell.NewOptions()},
+				{text: "bold", opts: cell.NewOptions(cell.Bold())},
+				{text: " and ", opts: cell.NewOptions()},
+				{text: "underlined", opts: cell.NewOptions(cell.Underline())},
+				{text: " text", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "nested emphasis and tags",
+			markup: "*a _b [red]c_* d",
+			want: []*markupRun{
+				{text: "a ", opts: cell.NewOptions(cell.Bold())},
+				{text: "b ", opts: cell.NewOptions(cell.Bold(), cell.Underline())},
+				{text: "c", opts: cell.NewOptions(cell.Bold(), cell.Underline(), cell.FgColor(cell.ColorRed))},
+				{text: " d", opts: cell.NewOptions(cell.FgColor(cell.ColorRed))},
+			},
+		},
+		{
+			desc:   "reset returns to the base options",
+			markup: "a[red][bold]b[reset]c",
+			base:   cell.NewOptions(cell.FgColor(cell.ColorGreen), cell.Italic()),
+			want: []*markupRun{
+				{text: "a", opts: cell.NewOptions(cell.FgColor(cell.ColorGreen), cell.Italic())},
+				{text: "b", opts: cell.NewOptions(cell.FgColor(cell.ColorRed), cell.Italic(), cell.Bold())},
+				{text: "c", opts: cell.NewOptions(cell.FgColor(cell.ColorGreen), cell.Italic())},
+			},
+		},
+		{
+			desc:   "escaped special characters are literal",
+			markup: `\[red\] \*a\* \_b\_ \\`,
+			want: []*markupRun{
+				{text: `[red] *a* _b_ \`, opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "backslash before other characters is literal",
+			markup: `a\b\`,
+			want: []*markupRun{
+				{text: `a\b\`, opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "unknown and unclosed tags are literal",
+			markup: "[foo]a[red b[#12]c[bg:nope]d[256]",
+			want: []*markupRun{
+				{text: "[foo]a[red b[#12]c[bg:nope]d[256]", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "tags don't span lines",
+			markup: "[re\nd]",
+			want: []*markupRun{
+				{text: "[re\nd]", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "unclosed emphasis markers are literal",
+			markup: "2 * 3 = *6 and _x",
+			want: []*markupRun{
+				{text: "2 * 3 = *6 and _x", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "emphasis markers within words are literal",
+			markup: "snake_case_name a*b*c",
+			want: []*markupRun{
+				{text: "snake_case_name a*b*c", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "empty emphasis is literal",
+			markup: "** __",
+			want: []*markupRun{
+				{text: "** __", opts: cell.NewOptions()},
+			},
+		},
+		{
+			desc:   "escaped marker doesn't close emphasis",
+			markup: `*a\*b*`,
+			want: []*markupRun{
+				{text: "a*b", opts: cell.NewOptions(cell.Bold())},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			base := tc.base
+			if base == nil {
+				base = cell.NewOptions()
+			}
+			got := parseMarkup(tc.markup, base)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("parseMarkup => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteMarkup(t *testing.T) {
+	widget, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := widget.Write("x"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if err := widget.WriteMarkup("[red]ab[reset]*c*", WriteCellOpts(cell.BgColor(cell.ColorBlue))); err != nil {
+		t.Fatalf("WriteMarkup => unexpected error: %v", err)
+	}
+	if err := widget.WriteMarkup("[red]", WriteReplace()); err == nil {
+		t.Errorf("WriteMarkup => nil error for markup without any text, want an error")
+	}
+
+	cvs := testcanvas.MustNew(image.Rect(0, 0, 5, 1))
+	if err := widget.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	got := faketerm.MustNew(cvs.Size())
+	testcanvas.MustApply(cvs, got)
+
+	want := faketerm.MustNew(cvs.Size())
+	wc := testcanvas.MustNew(want.Area())
+	testdraw.MustText(wc, "x", image.Point{0, 0})
+	testdraw.MustText(wc, "ab", image.Point{1, 0}, draw.TextCellOpts(
+		cell.FgColor(cell.ColorRed),
+		cell.BgColor(cell.ColorBlue),
+	))
+	testdraw.MustText(wc, "c", image.Point{3, 0}, draw.TextCellOpts(
+		cell.BgColor(cell.ColorBlue),
+		cell.Bold(),
+	))
+	testcanvas.MustApply(wc, want)
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
@@ -17,6 +17,7 @@ package text
 import (
 	"fmt"
 
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/wrap"
@@ -32,32 +33,56 @@ type Option interface {
 
 // options stores the provided options.
 type options struct {
-	scrollUp         rune
-	scrollDown       rune
-	wrapMode         wrap.Mode
-	rollContent      bool
-	maxTextCells     int
-	disableScrolling bool
-	mouseUpButton    mouse.Button
-	mouseDownButton  mouse.Button
-	keyUp            keyboard.Key
-	keyDown          keyboard.Key
-	keyPgUp          keyboard.Key
-	keyPgDown        keyboard.Key
+	scrollUp            rune
+	scrollDown          rune
+	scrollLeft          rune
+	scrollRight         rune
+	wrapMode            wrap.Mode
+	wrapNone            bool
+	rollContent         bool
+	maxTextCells        int
+	disableScrolling    bool
+	highlightIgnoreCase bool
+	lineNumbers         bool
+	repeatLineNumbers   bool
+	lineNumbersColor    cell.Color
+	mouseUpButton       mouse.Button
+	mouseDownButton     mouse.Button
+	keyUp               keyboard.Key
+	keyDown             keyboard.Key
+	keyPgUp             keyboard.Key
+	keyPgDown           keyboard.Key
+	keyLeft             keyboard.Key
+	keyRight            keyboard.Key
+	keySelectUp         keyboard.Key
+	keySelectDown       keyboard.Key
+	keySelectLeft       keyboard.Key
+	keySelectRight      keyboard.Key
+	selectionOpts       []cell.Option
 }
 
 // newOptions returns a new options instance.
 func newOptions(opts ...Option) *options {
 	opt := &options{
-		scrollUp:        DefaultScrollUpRune,
-		scrollDown:      DefaultScrollDownRune,
-		mouseUpButton:   DefaultScrollMouseButtonUp,
-		mouseDownButton: DefaultScrollMouseButtonDown,
-		keyUp:           DefaultScrollKeyUp,
-		keyDown:         DefaultScrollKeyDown,
-		keyPgUp:         DefaultScrollKeyPageUp,
-		keyPgDown:       DefaultScrollKeyPageDown,
-		maxTextCells:    DefaultMaxTextCells,
+		scrollUp:         DefaultScrollUpRune,
+		scrollDown:       DefaultScrollDownRune,
+		scrollLeft:       DefaultScrollLeftRune,
+		scrollRight:      DefaultScrollRightRune,
+		mouseUpButton:    DefaultScrollMouseButtonUp,
+		mouseDownButton:  DefaultScrollMouseButtonDown,
+		keyUp:            DefaultScrollKeyUp,
+		keyDown:          DefaultScrollKeyDown,
+		keyPgUp:          DefaultScrollKeyPageUp,
+		keyPgDown:        DefaultScrollKeyPageDown,
+		keyLeft:          DefaultScrollKeyLeft,
+		keyRight:         DefaultScrollKeyRight,
+		keySelectUp:      DefaultSelectionKeyUp,
+		keySelectDown:    DefaultSelectionKeyDown,
+		keySelectLeft:    DefaultSelectionKeyLeft,
+		keySelectRight:   DefaultSelectionKeyRight,
+		selectionOpts:    []cell.Option{cell.Inverse()},
+		maxTextCells:     DefaultMaxTextCells,
+		lineNumbersColor: cell.ColorNumber(DefaultLineNumbersColorNumber),
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -76,6 +101,21 @@ func (o *options) validate() error {
 	if len(keys) != 4 {
 		return fmt.Errorf("invalid ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
 	}
+	if o.wrapNone {
+		keys[o.keyLeft] = true
+		keys[o.keyRight] = true
+		if len(keys) != 6 {
+			return fmt.Errorf("invalid ScrollKeysHorizontal(left:%v, right:%v), the keys must be unique and different from the ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v)", o.keyLeft, o.keyRight, o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
+		}
+	}
+	scrollKeys := len(keys)
+	keys[o.keySelectUp] = true
+	keys[o.keySelectDown] = true
+	keys[o.keySelectLeft] = true
+	keys[o.keySelectRight] = true
+	if len(keys) != scrollKeys+4 {
+		return fmt.Errorf("invalid SelectionKeys(up:%v, down:%v, left:%v, right:%v), the keys must be unique and different from the scroll keys", o.keySelectUp, o.keySelectDown, o.keySelectLeft, o.keySelectRight)
+	}
 	if o.mouseUpButton == o.mouseDownButton {
 		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
 	}
@@ -115,6 +155,7 @@ const (
 func WrapAtWords() Option {
 	return option(func(opts *options) {
 		opts.wrapMode = wrap.AtWords
+		opts.wrapNone = false
 	})
 }
 
@@ -124,12 +165,45 @@ func WrapAtWords() Option {
 func WrapAtRunes() Option {
 	return option(func(opts *options) {
 		opts.wrapMode = wrap.AtRunes
+		opts.wrapNone = false
+	})
+}
+
+// WrapNone configures the text widget so that lines that are longer than the
+// width of the widget are neither wrapped nor trimmed. Instead the content can
+// be scrolled horizontally using the keyboard while the widget is focused, see
+// ScrollKeysHorizontal. Scroll markers are drawn in the bottom right corner of
+// the widget when there is more content to the left or to the right.
+// This option has no effect if scrolling was disabled with DisableScrolling.
+func WrapNone() Option {
+	return option(func(opts *options) {
+		opts.wrapMode = wrap.Never
+		opts.wrapNone = true
 	})
 }
 
+// ScrollRunesHorizontal configures the scroll runes shown in the bottom right
+// corner of a text widget that scrolls horizontally. Only used with WrapNone.
+// If not provided, the default horizontal scroll runes will be used.
+func ScrollRunesHorizontal(left, right rune) Option {
+	return option(func(opts *options) {
+		opts.scrollLeft = left
+		opts.scrollRight = right
+	})
+}
+
+// The default scroll runes for horizontal content scrolling.
+const (
+	DefaultScrollLeftRune  = '⇦'
+	DefaultScrollRightRune = '⇨'
+)
+
 // RollContent configures the text widget so that it rolls the text content up
 // if more text than the size of the container is added. If not provided, the
 // content is trimmed instead.
+// Rolling pauses when the user scrolls up so that the last line isn't
+// visible and resumes when the user scrolls back down to the last line or
+// when Text.Follow is called.
 func RollContent() Option {
 	return option(func(opts *options) {
 		opts.rollContent = true
@@ -144,6 +218,14 @@ func DisableScrolling() Option {
 	})
 }
 
+// HighlightIgnoreCase makes the Highlight method match the highlighted term
+// case-insensitively.
+func HighlightIgnoreCase() Option {
+	return option(func(opts *options) {
+		opts.highlightIgnoreCase = true
+	})
+}
+
 // The default mouse buttons for content scrolling.
 const (
 	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
@@ -180,6 +262,22 @@ func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
 	})
 }
 
+// The default keys for horizontal content scrolling.
+const (
+	DefaultScrollKeyLeft  = keyboard.KeyArrowLeft
+	DefaultScrollKeyRight = keyboard.KeyArrowRight
+)
+
+// ScrollKeysHorizontal configures the keyboard keys that scroll the content
+// horizontally. Only used with WrapNone. The provided keys must be unique and
+// must differ from the keys provided to ScrollKeys.
+func ScrollKeysHorizontal(left, right keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyLeft = left
+		opts.keyRight = right
+	})
+}
+
 // The default value for the MaxTextCells option.
 // Use zero as no limit, for logs you may wish to try 10,000 or higher.
 const (
@@ -199,3 +297,68 @@ func MaxTextCells(max int) Option {
 		opts.maxTextCells = max
 	})
 }
+
+// ShowLineNumbers displays a gutter with right-aligned line numbers to the
+// left of the text. The gutter is as wide as the largest line number plus one
+// cell that separates it from the text. The numbers count the lines of the
+// content, i.e. the lines separated by newline characters, lines wrapped to
+// the width of the widget keep the number of the line they continue.
+func ShowLineNumbers() Option {
+	return option(func(opts *options) {
+		opts.lineNumbers = true
+	})
+}
+
+// RepeatWrappedLineNumbers when used with ShowLineNumbers displays the line
+// number on every line a wrapped content line occupies. By default the line
+// number is only displayed on the first of them.
+func RepeatWrappedLineNumbers() Option {
+	return option(func(opts *options) {
+		opts.repeatLineNumbers = true
+	})
+}
+
+// DefaultLineNumbersColorNumber is the default color number for the
+// LineNumbersColor option.
+const DefaultLineNumbersColorNumber = 245
+
+// LineNumbersColor sets the color of the line numbers displayed with
+// ShowLineNumbers.
+// Defaults to DefaultLineNumbersColorNumber.
+func LineNumbersColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.lineNumbersColor = c
+	})
+}
+
+// The default keys that select the text content.
+const (
+	DefaultSelectionKeyUp    = keyboard.KeyShiftArrowUp
+	DefaultSelectionKeyDown  = keyboard.KeyShiftArrowDown
+	DefaultSelectionKeyLeft  = keyboard.KeyShiftArrowLeft
+	DefaultSelectionKeyRight = keyboard.KeyShiftArrowRight
+)
+
+// SelectionKeys configures the keyboard keys that select the text content.
+// The first use of any of the keys starts the selection at the beginning of
+// the first visible line, the keys then move the end of the selection by one
+// cell to the left or to the right or onto the previous or the next line.
+// The selected text is available via Text.Selection. The provided keys must
+// be unique and must differ from the scroll keys.
+// The keys aren't received when DisableScrolling is set.
+func SelectionKeys(up, down, left, right keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keySelectUp = up
+		opts.keySelectDown = down
+		opts.keySelectLeft = left
+		opts.keySelectRight = right
+	})
+}
+
+// SelectionCellOpts sets the cell options applied over the cell options of
+// the selected text. Defaults to inverting the selected cells.
+func SelectionCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.selectionOpts = cOpts
+	})
+}
@@ -52,12 +52,23 @@ type scrollTracker struct {
 
 	// state is the state of the scrolling FSM.
 	state rollState
+	// rolling indicates that the content is rolled as new content arrives.
+	rolling bool
+	// paused indicates that the FSM is in the rollingPaused state.
+	paused bool
+
+	// scrollCol stores user requests to scroll left (negative) or right
+	// (positive) by columns. Only used when the lines aren't wrapped.
+	scrollCol int
+
+	// firstCol tracks the first column that will be printed.
+	firstCol int
 }
 
 // newScrollTracker returns a new scroll tracker.
 func newScrollTracker(opts *options) *scrollTracker {
 	if opts.rollContent {
-		return &scrollTracker{state: rollToEnd}
+		return &scrollTracker{state: rollToEnd, rolling: true}
 	}
 	return &scrollTracker{state: rollingDisabled}
 }
@@ -82,6 +93,45 @@ func (st *scrollTracker) downOnePage() {
 	st.scrollPage++
 }
 
+// follow processes a user request to resume rolling of the content, i.e.
+// to scroll to the last line and keep it visible as new content arrives.
+// Discards any outstanding vertical scroll requests.
+func (st *scrollTracker) follow() {
+	if !st.rolling {
+		return
+	}
+	st.state = rollToEnd
+	st.paused = false
+	st.scroll = 0
+	st.scrollPage = 0
+}
+
+// following returns true if the last line of the content is kept visible as
+// new content arrives, i.e. the content is rolled and the user didn't pause
+// the rolling by scrolling up.
+func (st *scrollTracker) following() bool {
+	return st.rolling && !st.paused
+}
+
+// leftOneCol processes a user request to scroll left by one column.
+func (st *scrollTracker) leftOneCol() {
+	st.scrollCol--
+}
+
+// rightOneCol processes a user request to scroll right by one column.
+func (st *scrollTracker) rightOneCol() {
+	st.scrollCol++
+}
+
+// firstColumn processes any outstanding horizontal scroll requests and returns
+// the number of the first column that should be drawn on a canvas of the
+// specified width if the longest line of text is cols columns wide.
+func (st *scrollTracker) firstColumn(cols, width int) int {
+	st.firstCol = normalizeScroll(st.firstCol+st.scrollCol, cols, width)
+	st.scrollCol = 0
+	return st.firstCol
+}
+
 // doScroll processes any outstanding scroll requests and calculates the
 // resulting first line.
 func (st *scrollTracker) doScroll(lines, height int) int {
@@ -124,6 +174,7 @@ func rollToEnd(st *scrollTracker, lines, height int) rollState {
 	if lastLineVisible(st.first, lines, height) {
 		return rollToEnd
 	}
+	st.paused = true
 	return rollingPaused
 }
 
@@ -132,6 +183,7 @@ func rollToEnd(st *scrollTracker, lines, height int) rollState {
 func rollingPaused(st *scrollTracker, lines, height int) rollState {
 	st.first = st.doScroll(lines, height)
 	if lastLineVisible(st.first, lines, height) {
+		st.paused = false
 		return rollToEnd
 	}
 	return rollingPaused
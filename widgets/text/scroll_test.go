@@ -170,30 +170,37 @@ func TestScrollTrackerContentRolling(t *testing.T) {
 		height int
 		events func()
 		want   int
+		// wantFollowing is the expected result of following after the
+		// first line was determined.
+		wantFollowing bool
 	}{
 		{
-			desc:   "all content fits, draws from the first line",
-			lines:  2,
-			height: 2,
-			want:   0,
+			desc:          "all content fits, draws from the first line",
+			lines:         2,
+			height:        2,
+			want:          0,
+			wantFollowing: true,
 		},
 		{
-			desc:   "content doesn't fit, draws up to the last line",
-			lines:  4,
-			height: 2,
-			want:   2,
+			desc:          "content doesn't fit, draws up to the last line",
+			lines:         4,
+			height:        2,
+			want:          2,
+			wantFollowing: true,
 		},
 		{
-			desc:   "draws up to the last line when height decreases",
-			lines:  4,
-			height: 1,
-			want:   3,
+			desc:          "draws up to the last line when height decreases",
+			lines:         4,
+			height:        1,
+			want:          3,
+			wantFollowing: true,
 		},
 		{
-			desc:   "draws up to the last line when height increases",
-			lines:  4,
-			height: 2,
-			want:   2,
+			desc:          "draws up to the last line when height increases",
+			lines:         4,
+			height:        2,
+			want:          2,
+			wantFollowing: true,
 		},
 		{
 			desc:   "user scrolling breaks away from the last line",
@@ -219,13 +226,15 @@ func TestScrollTrackerContentRolling(t *testing.T) {
 				st.downOneLine()
 				st.downOneLine()
 			},
-			want: 3,
+			want:          3,
+			wantFollowing: true,
 		},
 		{
-			desc:   "rolling of new content resumes",
-			lines:  6,
-			height: 2,
-			want:   4,
+			desc:          "rolling of new content resumes",
+			lines:         6,
+			height:        2,
+			want:          4,
+			wantFollowing: true,
 		},
 		{
 			desc:   "scroll up breaks away from the last line again",
@@ -243,16 +252,38 @@ func TestScrollTrackerContentRolling(t *testing.T) {
 			want:   3,
 		},
 		{
-			desc:   "resize so that the last line becomes visible",
-			lines:  7,
-			height: 7,
-			want:   0,
+			desc:          "resize so that the last line becomes visible",
+			lines:         7,
+			height:        7,
+			want:          0,
+			wantFollowing: true,
+		},
+		{
+			desc:          "rolls content after the resize",
+			lines:         8,
+			height:        7,
+			want:          1,
+			wantFollowing: true,
 		},
 		{
-			desc:   "rolls content after the resize",
+			desc:   "scroll up pauses rolling",
 			lines:  8,
 			height: 7,
-			want:   1,
+			events: func() {
+				st.upOneLine()
+			},
+			want: 0,
+		},
+		{
+			desc:   "follow resumes rolling without scrolling down",
+			lines:  9,
+			height: 7,
+			events: func() {
+				st.upOneLine()
+				st.follow()
+			},
+			want:          2,
+			wantFollowing: true,
 		},
 	}
 
@@ -265,10 +296,100 @@ func TestScrollTrackerContentRolling(t *testing.T) {
 			if got != tc.want {
 				t.Errorf("firstLine => got %d, want %d", got, tc.want)
 			}
+			if got := st.following(); got != tc.wantFollowing {
+				t.Errorf("following => got %v, want %v", got, tc.wantFollowing)
+			}
+		})
+	}
+}
+
+func TestScrollTrackerColumns(t *testing.T) {
+	tests := []struct {
+		desc   string
+		cols   int
+		width  int
+		events func(*scrollTracker)
+		want   int
+	}{
+		{
+			desc:  "starts from the first column",
+			cols:  10,
+			width: 5,
+			want:  0,
+		},
+		{
+			desc:  "user can scroll right by a column",
+			cols:  10,
+			width: 5,
+			events: func(st *scrollTracker) {
+				st.rightOneCol()
+			},
+			want: 1,
+		},
+		{
+			desc:  "scroll left capped at the first column",
+			cols:  10,
+			width: 5,
+			events: func(st *scrollTracker) {
+				st.rightOneCol()
+				st.leftOneCol()
+				st.leftOneCol()
+			},
+			want: 0,
+		},
+		{
+			desc:  "scroll right capped at the last column",
+			cols:  10,
+			width: 5,
+			events: func(st *scrollTracker) {
+				for i := 0; i < 8; i++ {
+					st.rightOneCol()
+				}
+			},
+			want: 5,
+		},
+		{
+			desc:  "no scrolling when the content fits",
+			cols:  5,
+			width: 5,
+			events: func(st *scrollTracker) {
+				st.rightOneCol()
+			},
+			want: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			st := newScrollTracker(&options{})
+			if tc.events != nil {
+				tc.events(st)
+			}
+			got := st.firstColumn(tc.cols, tc.width)
+			if got != tc.want {
+				t.Errorf("firstColumn => got %d, want %d", got, tc.want)
+			}
 		})
 	}
 }
 
+func TestScrollTrackerColumnsClampedBetweenDraws(t *testing.T) {
+	st := newScrollTracker(&options{})
+	for i := 0; i < 8; i++ {
+		st.rightOneCol()
+	}
+	if got, want := st.firstColumn(10, 5), 5; got != want {
+		t.Fatalf("firstColumn => got %d, want %d", got, want)
+	}
+
+	// Scrolling past the right end doesn't accumulate, a single scroll left
+	// moves away from the end.
+	st.leftOneCol()
+	if got, want := st.firstColumn(10, 5), 4; got != want {
+		t.Errorf("firstColumn => got %d, want %d", got, want)
+	}
+}
+
 func TestNormalizeScroll(t *testing.T) {
 	tests := []struct {
 		desc   string
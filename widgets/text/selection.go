@@ -0,0 +1,243 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// selection.go contains code that selects a part of the text content with the
+// keyboard.
+
+import (
+	"strings"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/runewidth"
+)
+
+// selection is the selected part of the text content.
+// Both positions are indexes into the content, the selection covers the
+// content between them regardless of their order.
+type selection struct {
+	// anchor is the position where the selection started.
+	anchor int
+	// cursor is the position the selection keys move.
+	cursor int
+}
+
+// bounds returns the start and the end of the selected content.
+func (s *selection) bounds() (int, int) {
+	if s.anchor < s.cursor {
+		return s.anchor, s.cursor
+	}
+	return s.cursor, s.anchor
+}
+
+// lineRange is one line of the wrapped content. Contains the cells of the
+// content at indexes start <= idx < end.
+type lineRange struct {
+	start int
+	end   int
+}
+
+// Selection returns the currently selected text or an empty string if
+// nothing is selected. Selections spanning multiple lines include the
+// newline characters and any spaces dropped when wrapping the lines.
+func (t *Text) Selection() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.sel == nil {
+		return ""
+	}
+	start, end := t.sel.bounds()
+	var b strings.Builder
+	for _, c := range t.content[start:end] {
+		b.WriteRune(c.Rune)
+	}
+	return b.String()
+}
+
+// ClearSelection removes the selection. The next use of the selection keys
+// starts a new selection.
+func (t *Text) ClearSelection() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sel = nil
+}
+
+// lineRanges returns the ranges of the content displayed on each of the
+// wrapped lines as of the last call to Draw.
+func (t *Text) lineRanges() []lineRange {
+	idx := make(map[*buffer.Cell]int, len(t.content))
+	for i, c := range t.content {
+		idx[c] = i
+	}
+
+	var (
+		ranges []lineRange
+		next   int
+	)
+	for _, line := range t.wrapped {
+		if len(line) == 0 {
+			// Empty lines don't have any cells, they are between two
+			// newline characters.
+			ranges = append(ranges, lineRange{next, next})
+		} else {
+			first, ok1 := idx[line[0]]
+			last, ok2 := idx[line[len(line)-1]]
+			if !ok1 || !ok2 {
+				// Cells that were trimmed since the last call to Draw.
+				continue
+			}
+			ranges = append(ranges, lineRange{first, last + 1})
+			next = last + 1
+		}
+		if next < len(t.content) && t.content[next].Rune == '\n' {
+			next++
+		}
+	}
+	return ranges
+}
+
+// lineOf returns the index of the line the content position is on.
+func lineOf(lines []lineRange, pos int) int {
+	var l int
+	for i, line := range lines {
+		if line.start <= pos {
+			l = i
+		}
+	}
+	return l
+}
+
+// cellsTo returns the number of cells the content of the line takes up to,
+// but not including, the content position.
+func (t *Text) cellsTo(line lineRange, pos int) int {
+	if pos > line.end {
+		pos = line.end
+	}
+	var cells int
+	for _, c := range t.content[line.start:pos] {
+		cells += runewidth.RuneWidth(c.Rune)
+	}
+	return cells
+}
+
+// posAt returns the content position on the line that is at or left of the
+// cell.
+func (t *Text) posAt(line lineRange, cell int) int {
+	var cells int
+	for pos := line.start; pos < line.end; pos++ {
+		cells += runewidth.RuneWidth(t.content[pos].Rune)
+		if cells > cell {
+			return pos
+		}
+	}
+	return line.end
+}
+
+// moveSelection processes a press of one of the selection keys. The first
+// press starts the selection at the beginning of the first visible line.
+// Scrolls the content so that the line with the cursor stays visible.
+func (t *Text) moveSelection(k keyboard.Key) {
+	lines := t.lineRanges()
+	if len(lines) == 0 {
+		return
+	}
+	if t.sel == nil {
+		from := t.lastFromLine
+		if from >= len(lines) {
+			from = len(lines) - 1
+		}
+		start := lines[from].start
+		t.sel = &selection{anchor: start, cursor: start}
+	}
+
+	cur := t.sel.cursor
+	l := lineOf(lines, cur)
+	switch k {
+	case t.opts.keySelectLeft:
+		if cur > 0 {
+			cur--
+		}
+	case t.opts.keySelectRight:
+		if cur < len(t.content) {
+			cur++
+		}
+	case t.opts.keySelectUp:
+		if l > 0 {
+			cur = t.posAt(lines[l-1], t.cellsTo(lines[l], cur))
+		} else {
+			cur = 0
+		}
+	case t.opts.keySelectDown:
+		if l < len(lines)-1 {
+			cur = t.posAt(lines[l+1], t.cellsTo(lines[l], cur))
+		} else {
+			cur = len(t.content)
+		}
+	}
+	t.sel.cursor = cur
+
+	// Keep the line with the cursor visible.
+	switch cl := lineOf(lines, cur); {
+	case cl < t.lastFromLine:
+		for ; t.lastFromLine > cl; t.lastFromLine-- {
+			t.scroll.upOneLine()
+		}
+	case t.lastHeight > 0 && cl >= t.lastFromLine+t.lastHeight:
+		for ; cl >= t.lastFromLine+t.lastHeight; t.lastFromLine++ {
+			t.scroll.downOneLine()
+		}
+	}
+}
+
+// isSelectionKey asserts whether the key is one of the selection keys.
+func (t *Text) isSelectionKey(k keyboard.Key) bool {
+	switch k {
+	case t.opts.keySelectUp, t.opts.keySelectDown, t.opts.keySelectLeft, t.opts.keySelectRight:
+		return true
+	}
+	return false
+}
+
+// selected returns the cells of the content that are selected.
+func (t *Text) selected() map[*buffer.Cell]bool {
+	if t.sel == nil {
+		return nil
+	}
+	start, end := t.sel.bounds()
+	if start == end {
+		return nil
+	}
+	res := make(map[*buffer.Cell]bool, end-start)
+	for _, c := range t.content[start:end] {
+		res[c] = true
+	}
+	return res
+}
+
+// trimSelection shifts the selection after the first n cells of the content
+// were removed.
+func (t *Text) trimSelection(n int) {
+	if t.sel == nil {
+		return
+	}
+	for _, pos := range []*int{&t.sel.anchor, &t.sel.cursor} {
+		*pos -= n
+		if *pos < 0 {
+			*pos = 0
+		}
+	}
+}
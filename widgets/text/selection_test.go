@@ -0,0 +1,228 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestSelection(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		text   string
+		keys   []keyboard.Key
+		want   string
+	}{
+		{
+			desc:   "nothing selected without the selection keys",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "abcdef",
+			want:   "",
+		},
+		{
+			desc:   "selects to the right",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "abcdef",
+			keys:   []keyboard.Key{keyboard.KeyShiftArrowRight, keyboard.KeyShiftArrowRight},
+			want:   "ab",
+		},
+		{
+			desc:   "selection spans a wrap boundary",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "abcdef",
+			keys:   []keyboard.Key{keyboard.KeyShiftArrowRight, keyboard.KeyShiftArrowRight, keyboard.KeyShiftArrowDown},
+			want:   "abcde",
+		},
+		{
+			desc:   "selection moves right across a wrap boundary",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "abcdef",
+			keys: []keyboard.Key{
+				keyboard.KeyShiftArrowRight,
+				keyboard.KeyShiftArrowRight,
+				keyboard.KeyShiftArrowRight,
+				keyboard.KeyShiftArrowRight,
+			},
+			want: "abcd",
+		},
+		{
+			desc:   "selection shrinks when moving back",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "abcdef",
+			keys:   []keyboard.Key{keyboard.KeyShiftArrowDown, keyboard.KeyShiftArrowRight, keyboard.KeyShiftArrowUp},
+			want:   "a",
+		},
+		{
+			desc:   "selection stays within the content",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "abcdef",
+			keys: []keyboard.Key{
+				keyboard.KeyShiftArrowLeft,
+				keyboard.KeyShiftArrowDown,
+				keyboard.KeyShiftArrowDown,
+				keyboard.KeyShiftArrowRight,
+			},
+			want: "abcdef",
+		},
+		{
+			desc:   "selection includes the space dropped when wrapping at words",
+			opts:   []Option{WrapAtWords()},
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "ab cd",
+			keys:   []keyboard.Key{keyboard.KeyShiftArrowDown},
+			want:   "ab ",
+		},
+		{
+			desc:   "selection includes newlines and empty lines",
+			canvas: image.Rect(0, 0, 3, 3),
+			text:   "ab\n\ncd",
+			keys: []keyboard.Key{
+				keyboard.KeyShiftArrowRight,
+				keyboard.KeyShiftArrowDown,
+				keyboard.KeyShiftArrowDown,
+				keyboard.KeyShiftArrowRight,
+			},
+			want: "ab\n\nc",
+		},
+		{
+			desc:   "custom selection keys",
+			opts:   []Option{SelectionKeys('u', 'd', 'l', 'r')},
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "abcdef",
+			keys:   []keyboard.Key{'d', 'r', keyboard.KeyShiftArrowRight},
+			want:   "abcd",
+		},
+		{
+			desc:   "selection starts on the first visible line",
+			canvas: image.Rect(0, 0, 3, 1),
+			text:   "abcdef",
+			keys:   []keyboard.Key{keyboard.KeyArrowDown, keyboard.KeyShiftArrowRight},
+			want:   "d",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cvs, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Options provided by the test case override the wrapping mode.
+			widget, err := New(append([]Option{WrapAtRunes()}, tc.opts...)...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := widget.Write(tc.text); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+			if err := widget.Draw(cvs, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, k := range tc.keys {
+				if err := widget.Keyboard(&terminalapi.Keyboard{Key: k}, &widgetapi.EventMeta{}); err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+				// Scrolling takes effect when the widget is drawn.
+				if err := widget.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			if got := widget.Selection(); got != tc.want {
+				t.Errorf("Selection => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSelectionDraws(t *testing.T) {
+	widget, err := New(
+		WrapAtRunes(),
+		SelectionCellOpts(cell.BgColor(cell.ColorBlue)),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := widget.Write("abcdef"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	cvs := testcanvas.MustNew(image.Rect(0, 0, 3, 2))
+	if err := widget.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	for _, k := range []keyboard.Key{keyboard.KeyShiftArrowRight, keyboard.KeyShiftArrowDown} {
+		if err := widget.Keyboard(&terminalapi.Keyboard{Key: k}, &widgetapi.EventMeta{}); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+	if err := widget.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	got := faketerm.MustNew(cvs.Size())
+	testcanvas.MustApply(cvs, got)
+
+	want := faketerm.MustNew(cvs.Size())
+	wc := testcanvas.MustNew(want.Area())
+	selected := draw.TextCellOpts(cell.BgColor(cell.ColorBlue))
+	testdraw.MustText(wc, "abc", image.Point{0, 0}, selected)
+	testdraw.MustText(wc, "d", image.Point{0, 1}, selected)
+	testdraw.MustText(wc, "ef", image.Point{1, 1})
+	testcanvas.MustApply(wc, want)
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+
+	// Clearing the selection removes the highlighting.
+	widget.ClearSelection()
+	if got := widget.Selection(); got != "" {
+		t.Errorf("Selection after ClearSelection => %q, want empty", got)
+	}
+	if err := widget.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	got = faketerm.MustNew(cvs.Size())
+	testcanvas.MustApply(cvs, got)
+	want = faketerm.MustNew(cvs.Size())
+	wc = testcanvas.MustNew(want.Area())
+	testdraw.MustText(wc, "abc", image.Point{0, 0})
+	testdraw.MustText(wc, "def", image.Point{0, 1})
+	testcanvas.MustApply(wc, want)
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("Draw after ClearSelection => %v", diff)
+	}
+}
+
+func TestSelectionKeysValidation(t *testing.T) {
+	if _, err := New(SelectionKeys('a', 'b', 'c', keyboard.KeyArrowUp)); err == nil {
+		t.Errorf("New(SelectionKeys with a scroll key) => nil error, want an error")
+	}
+	if _, err := New(SelectionKeys('a', 'a', 'c', 'd')); err == nil {
+		t.Errorf("New(SelectionKeys with duplicate keys) => nil error, want an error")
+	}
+}
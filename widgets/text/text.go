@@ -21,8 +21,10 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/draw"
 	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/private/wrap"
 	"github.com/mum4k/termdash/terminal/terminalapi"
@@ -31,12 +33,14 @@ import (
 
 // Text displays a block of text.
 //
-// Each line of the text is either trimmed or wrapped according to the provided
-// options. The entire text content is either trimmed or rolled up through the
-// canvas according to the provided options.
+// Each line of the text is either trimmed, wrapped or scrolled horizontally
+// according to the provided options. The entire text content is either
+// trimmed or rolled up through the canvas according to the provided options.
 //
 // By default the widget supports scrolling of content with either the keyboard
-// or mouse. See the options for the default keys and mouse buttons.
+// or mouse. See the options for the default keys and mouse buttons. The
+// content can also be selected with the keyboard, see SelectionKeys and
+// Text.Selection.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type Text struct {
@@ -45,6 +49,9 @@ type Text struct {
 	content []*buffer.Cell
 	// wrapped is the content wrapped to the current width of the canvas.
 	wrapped [][]*buffer.Cell
+	// lineNums are the numbers of the content lines the wrapped lines belong
+	// to. Only populated when line numbers are displayed.
+	lineNums []int
 
 	// scroll tracks scrolling the position.
 	scroll *scrollTracker
@@ -57,6 +64,22 @@ type Text struct {
 	// invalidated.
 	contentChanged bool
 
+	// rtl are the content cells written with the WriteRTL option.
+	rtl map[*buffer.Cell]bool
+
+	// highlightTerm is the term whose occurrences are highlighted.
+	highlightTerm []rune
+	// highlightOpts are the cell options applied to the occurrences.
+	highlightOpts []cell.Option
+
+	// sel is the selected part of the content, nil if nothing is selected.
+	sel *selection
+	// lastFromLine is the first drawn line and lastHeight the height of the
+	// canvas as of the last call to Draw. Used to keep the line with the
+	// selection cursor visible.
+	lastFromLine int
+	lastHeight   int
+
 	// mu protects the Text widget.
 	mu sync.Mutex
 
@@ -87,11 +110,34 @@ func (t *Text) Reset() {
 func (t *Text) reset() {
 	t.content = nil
 	t.wrapped = nil
+	t.lineNums = nil
+	t.rtl = nil
+	t.sel = nil
 	t.scroll = newScrollTracker(t.opts)
 	t.lastWidth = 0
 	t.contentChanged = true
 }
 
+// Follow resumes rolling of the content after the user paused it by scrolling
+// up, the last line becomes visible on the next redraw and is kept visible
+// as new content arrives. Has no effect unless the widget was created with
+// the RollContent option.
+func (t *Text) Follow() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scroll.follow()
+}
+
+// Following returns true if the widget keeps the last line of the content
+// visible as new content arrives. This is false if the widget wasn't created
+// with the RollContent option or if the user paused the rolling by scrolling
+// up, as of the last redraw.
+func (t *Text) Following() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.scroll.following()
+}
+
 // contentCells calculates the number of cells the content takes to display on
 // terminal.
 func (t *Text) contentCells() int {
@@ -122,21 +168,37 @@ func (t *Text) Write(text string, wOpts ...WriteOption) error {
 	if opts.replace {
 		t.reset()
 	}
+	t.write(text, opts)
+	return nil
+}
 
+// write appends the text to the content.
+// Caller must hold t.mu and validate the text.
+func (t *Text) write(text string, opts *writeOptions) {
 	truncated := truncateToCells(text, t.opts.maxTextCells)
 	textCells := runewidth.StringWidth(truncated, runewidth.CountAsWidth('\n', 1))
 	contentCells := t.contentCells()
 	// If MaxTextCells has been set, limit the content if needed.
 	if t.opts.maxTextCells > 0 && contentCells+textCells > t.opts.maxTextCells {
 		diff := contentCells + textCells - t.opts.maxTextCells
+		for _, c := range t.content[:diff] {
+			delete(t.rtl, c)
+		}
 		t.content = t.content[diff:]
+		t.trimSelection(diff)
 	}
 
 	for _, r := range truncated {
-		t.content = append(t.content, buffer.NewCell(r, opts.cellOpts))
+		c := buffer.NewCell(r, opts.cellOpts)
+		t.content = append(t.content, c)
+		if opts.rtl {
+			if t.rtl == nil {
+				t.rtl = map[*buffer.Cell]bool{}
+			}
+			t.rtl[c] = true
+		}
 	}
 	t.contentChanged = true
-	return nil
 }
 
 // minLinesForMarkers are the minimum amount of lines required on the canvas in
@@ -180,17 +242,137 @@ func (t *Text) drawScrollDown(cvs *canvas.Canvas, cur image.Point, fromLine int)
 	return false, nil
 }
 
-// draw draws the text context on the canvas starting at the specified line.
-func (t *Text) draw(cvs *canvas.Canvas) error {
+// minColsForMarkers are the minimum amount of columns required on the canvas
+// in order to draw the horizontal scroll markers ('⇦' and '⇨').
+const minColsForMarkers = 3
+
+// drawScrollHorizontal draws the horizontal scroll markers into the last two
+// cells of the bottom line if there is text to the "left" or to the "right" of
+// the canvas due to the scrolling position. The cols is the width of the
+// longest line of text.
+func (t *Text) drawScrollHorizontal(cvs *canvas.Canvas, fromCol, cols int) error {
+	width := cvs.Area().Dx()
+	height := cvs.Area().Dy()
+	left := fromCol > 0
+	right := fromCol+width < cols
+	if width < minColsForMarkers || (!left && !right) {
+		return nil
+	}
+
+	y := height - 1
+	// If the cell before the markers contains a full-width rune, it would be
+	// cut in half by the markers, clear it first.
+	prev, err := cvs.Cell(image.Point{width - 3, y})
+	if err != nil {
+		return err
+	}
+	if runewidth.RuneWidth(prev.Rune) == 2 {
+		if _, err := cvs.SetCell(image.Point{width - 3, y}, 0); err != nil {
+			return err
+		}
+	}
+
+	markers := []struct {
+		r    rune
+		draw bool
+	}{
+		{t.opts.scrollLeft, left},
+		{t.opts.scrollRight, right},
+	}
+	for i, m := range markers {
+		r := m.r
+		if !m.draw {
+			r = 0
+		}
+		cells, err := cvs.SetCell(image.Point{width - 2 + i, y}, r)
+		if err != nil {
+			return err
+		}
+		if m.draw && cells != 1 {
+			panic(fmt.Errorf("invalid horizontal scroll marker, it occupies %d cells, the implementation only supports scroll markers that occupy exactly one cell", cells))
+		}
+	}
+	return nil
+}
+
+// lineCells returns the number of cells the line takes on the terminal,
+// excluding the newline character.
+func lineCells(line []*buffer.Cell) int {
+	cells := 0
+	for _, c := range line {
+		if c.Rune == '\n' {
+			continue
+		}
+		cells += runewidth.RuneWidth(c.Rune)
+	}
+	return cells
+}
+
+// maxLineCells returns the number of cells the longest line takes on the
+// terminal.
+func maxLineCells(lines [][]*buffer.Cell) int {
+	max := 0
+	for _, line := range lines {
+		if c := lineCells(line); c > max {
+			max = c
+		}
+	}
+	return max
+}
+
+// drawShifted draws the line starting at the specified column of the text.
+// The cols is the width of the longest line of text, right-to-left lines are
+// right-aligned to it or to the canvas if the canvas is wider.
+// Full-width runes that are cut by either edge of the canvas are skipped.
+func (t *Text) drawShifted(cvs *canvas.Canvas, cur image.Point, line []*buffer.Cell, fromCol, cols int, highlighted, selected map[*buffer.Cell]bool) error {
+	width := cvs.Area().Dx()
+	col := 0
+	line, right := visualOrder(line, t.rtl)
+	if right {
+		if cols < width {
+			cols = width
+		}
+		col = cols - lineCells(line)
+	}
+	for _, cell := range line {
+		if cell.Rune == '\n' {
+			continue
+		}
+		rw := runewidth.RuneWidth(cell.Rune)
+		x := col - fromCol
+		col += rw
+		if x < 0 {
+			continue
+		}
+		if x+rw > width {
+			break
+		}
+		if _, err := cvs.SetCell(image.Point{x, cur.Y}, cell.Rune, t.cellOpts(cell, highlighted, selected)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// draw draws the text context on the canvas starting at the line determined
+// by the scrolling position. Returns the index of the first drawn line.
+func (t *Text) draw(cvs *canvas.Canvas) (int, error) {
 	var cur image.Point // Tracks the current drawing position on the canvas.
 	height := cvs.Area().Dy()
 	fromLine := t.scroll.firstLine(len(t.wrapped), height)
 
+	var fromCol, cols int
+	if t.opts.wrapNone {
+		cols = maxLineCells(t.wrapped)
+		fromCol = t.scroll.firstColumn(cols, cvs.Area().Dx())
+	}
+	highlighted, selected := t.highlighted(), t.selected()
+
 	for _, line := range t.wrapped[fromLine:] {
 		// Scroll up marker.
 		scrlUp, err := t.drawScrollUp(cvs, cur, fromLine)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		if scrlUp {
 			cur = image.Point{0, cur.Y + 1} // Move to the next line.
@@ -201,31 +383,52 @@ func (t *Text) draw(cvs *canvas.Canvas) error {
 		// Scroll down marker.
 		scrlDown, err := t.drawScrollDown(cvs, cur, fromLine)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		if scrlDown || cur.Y >= height {
 			break // Skip all lines falling after (under) the canvas.
 		}
 
+		if t.opts.wrapNone {
+			if err := t.drawShifted(cvs, cur, line, fromCol, cols, highlighted, selected); err != nil {
+				return 0, err
+			}
+			cur = image.Point{0, cur.Y + 1} // Move to the next line.
+			continue
+		}
+
+		line, right := visualOrder(line, t.rtl)
+		if right {
+			// Right-to-left lines that don't fit are trimmed like other lines.
+			if free := cvs.Area().Dx() - lineCells(line); free > 0 {
+				cur.X = free
+			}
+		}
 		for _, cell := range line {
 			tr, err := lineTrim(cvs, cur, cell.Rune, t.opts)
 			if err != nil {
-				return err
+				return 0, err
 			}
 			cur = tr.curPoint
 			if tr.trimmed {
 				break // Skip over any characters trimmed on the current line.
 			}
 
-			cells, err := cvs.SetCell(cur, cell.Rune, cell.Opts)
+			cells, err := cvs.SetCell(cur, cell.Rune, t.cellOpts(cell, highlighted, selected))
 			if err != nil {
-				return err
+				return 0, err
 			}
 			cur = image.Point{cur.X + cells, cur.Y} // Move within the same line.
 		}
 		cur = image.Point{0, cur.Y + 1} // Move to the next line.
 	}
-	return nil
+
+	if t.opts.wrapNone {
+		if err := t.drawScrollHorizontal(cvs, fromCol, cols); err != nil {
+			return 0, err
+		}
+	}
+	return fromLine, nil
 }
 
 // Draw draws the text onto the canvas.
@@ -235,14 +438,26 @@ func (t *Text) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	defer t.mu.Unlock()
 
 	width := cvs.Area().Dx()
+	textCvs := cvs
+	var gutter int
+	if t.opts.lineNumbers {
+		gutter = gutterWidth(contentLines(t.content))
+		if width-gutter < 1 {
+			return draw.ResizeNeeded(cvs)
+		}
+		tc, err := canvas.New(image.Rect(gutter, 0, width, cvs.Area().Dy()))
+		if err != nil {
+			return err
+		}
+		textCvs = tc
+	}
+
 	if len(t.content) > 0 && (t.contentChanged || t.lastWidth != width) {
 		// The previous text preprocessing (line wrapping) is invalidated when
 		// new text is added or the width of the canvas changed.
-		wr, err := wrap.Cells(t.content, width, t.opts.wrapMode)
-		if err != nil {
+		if err := t.wrap(width - gutter); err != nil {
 			return err
 		}
-		t.wrapped = wr
 	}
 	t.lastWidth = width
 
@@ -250,19 +465,50 @@ func (t *Text) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		return nil // Nothing to draw if there's no text.
 	}
 
-	if err := t.draw(cvs); err != nil {
+	fromLine, err := t.draw(textCvs)
+	if err != nil {
 		return err
 	}
+	t.lastFromLine, t.lastHeight = fromLine, textCvs.Area().Dy()
+	if t.opts.lineNumbers {
+		if err := t.drawLineNumbers(cvs, gutter, fromLine); err != nil {
+			return err
+		}
+		if err := textCvs.CopyTo(cvs); err != nil {
+			return err
+		}
+	}
 	t.contentChanged = false
 	return nil
 }
 
+// wrap wraps the content to the specified width.
+func (t *Text) wrap(width int) error {
+	if t.opts.lineNumbers {
+		wr, nums, err := wrapNumbered(t.content, width, t.opts.wrapMode)
+		if err != nil {
+			return err
+		}
+		t.wrapped, t.lineNums = wr, nums
+		return nil
+	}
+
+	wr, err := wrap.Cells(t.content, width, t.opts.wrapMode)
+	if err != nil {
+		return err
+	}
+	t.wrapped = wr
+	return nil
+}
+
 // Keyboard implements widgetapi.Widget.Keyboard.
 func (t *Text) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	switch {
+	case t.isSelectionKey(k.Key):
+		t.moveSelection(k.Key)
 	case k.Key == t.opts.keyUp:
 		t.scroll.upOneLine()
 	case k.Key == t.opts.keyDown:
@@ -271,6 +517,10 @@ func (t *Text) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) erro
 		t.scroll.upOnePage()
 	case k.Key == t.opts.keyPgDown:
 		t.scroll.downOnePage()
+	case t.opts.wrapNone && k.Key == t.opts.keyLeft:
+		t.scroll.leftOneCol()
+	case t.opts.wrapNone && k.Key == t.opts.keyRight:
+		t.scroll.rightOneCol()
 	}
 	return nil
 }
@@ -289,6 +539,45 @@ func (t *Text) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
 	return nil
 }
 
+// PreferredSize implements widgetapi.PreferredSizer.PreferredSize.
+// The preferred size fits all the lines of the content without trimming them,
+// including the gutter with line numbers if displayed. Text that wraps its
+// lines has no preferred width, its preferred height are the wrapped lines as
+// of the last call to Draw. Has no preference when there is no content.
+func (t *Text) PreferredSize() image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.content) == 0 {
+		return image.ZP
+	}
+	lines := contentLines(t.content)
+	var gutter int
+	if t.opts.lineNumbers {
+		gutter = gutterWidth(lines)
+	}
+
+	if t.opts.wrapMode != wrap.Never {
+		if len(t.wrapped) > lines {
+			lines = len(t.wrapped)
+		}
+		return image.Point{0, lines}
+	}
+
+	var width, cur int
+	for _, c := range t.content {
+		if c.Rune == '\n' {
+			cur = 0
+			continue
+		}
+		cur += runewidth.RuneWidth(c.Rune)
+		if cur > width {
+			width = cur
+		}
+	}
+	return image.Point{gutter + width, lines}
+}
+
 // Options of the widget
 func (t *Text) Options() widgetapi.Options {
 	var ks widgetapi.KeyScope
@@ -224,6 +224,169 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "marks the written text as a link",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				if err := widget.Write("see "); err != nil {
+					return err
+				}
+				if err := widget.Write("docs", WriteLink("https://example.com"), WriteCellOpts(cell.Underline())); err != nil {
+					return err
+				}
+				return widget.Write("\nend")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "see ", image.Point{0, 0})
+				testdraw.MustText(c, "docs", image.Point{4, 0}, draw.TextCellOpts(
+					cell.Underline(),
+					cell.Link("https://example.com"),
+				))
+				testdraw.MustText(c, "end", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right-to-left text is reversed and right-aligned",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				return widget.Write("abc\nשלום", WriteRTL())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "cba", image.Point{7, 0})
+				testdraw.MustText(c, "םולש", image.Point{6, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right-to-left run in a left-to-right line is reversed in place",
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				if err := widget.Write("hi "); err != nil {
+					return err
+				}
+				if err := widget.Write("abc", WriteRTL(), WriteCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				return widget.Write(" ok")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "hi ", image.Point{0, 0})
+				testdraw.MustText(c, "cba", image.Point{3, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, " ok", image.Point{6, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "left-to-right run in a right-to-left line keeps its order",
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				if err := widget.Write("ab ", WriteRTL()); err != nil {
+					return err
+				}
+				if err := widget.Write("xy"); err != nil {
+					return err
+				}
+				return widget.Write(" cd", WriteRTL())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "dc xy ba", image.Point{2, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right-to-left text is wrapped before it is reversed",
+			canvas: image.Rect(0, 0, 5, 2),
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abc def", WriteRTL())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "d cba", image.Point{0, 0})
+				testdraw.MustText(c, "fe", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right-to-left text that doesn't fit is trimmed",
+			canvas: image.Rect(0, 0, 5, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("abcdefg", WriteRTL())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "gfed…", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right-to-left text is right-aligned without wrapping",
+			canvas: image.Rect(0, 0, 6, 2),
+			opts: []Option{
+				WrapNone(),
+			},
+			writes: func(widget *Text) error {
+				if err := widget.Write("ab\n"); err != nil {
+					return err
+				}
+				return widget.Write("xyz", WriteRTL())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testdraw.MustText(c, "zyx", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "dropping old content forgets its direction",
+			canvas: image.Rect(0, 0, 6, 1),
+			opts: []Option{
+				MaxTextCells(3),
+			},
+			writes: func(widget *Text) error {
+				if err := widget.Write("abc", WriteRTL()); err != nil {
+					return err
+				}
+				return widget.Write("xyz")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "xyz", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "trims long lines",
 			canvas: image.Rect(0, 0, 10, 4),
@@ -355,6 +518,309 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "fails when horizontal scroll keys aren't unique",
+			opts: []Option{
+				WrapNone(),
+				ScrollKeysHorizontal(keyboard.KeyArrowUp, keyboard.KeyArrowRight),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "horizontal scroll keys are ignored without WrapNone",
+			opts: []Option{
+				ScrollKeysHorizontal(keyboard.KeyArrowUp, keyboard.KeyArrowRight),
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("0123456789")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowRight,
+				}, &widgetapi.EventMeta{})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "0123…", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlights multiple matches across wrapped lines",
+			canvas: image.Rect(0, 0, 3, 3),
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				widget.Highlight("foo", cell.BgColor(cell.ColorRed))
+				return widget.Write("xfoo foo")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				hl := draw.TextCellOpts(cell.BgColor(cell.ColorRed))
+				testdraw.MustText(c, "x", image.Point{0, 0})
+				testdraw.MustText(c, "fo", image.Point{1, 0}, hl)
+				testdraw.MustText(c, "o", image.Point{0, 1}, hl)
+				testdraw.MustText(c, " ", image.Point{1, 1})
+				testdraw.MustText(c, "f", image.Point{2, 1}, hl)
+				testdraw.MustText(c, "oo", image.Point{0, 2}, hl)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlight preserves the cell options of the written text",
+			canvas: image.Rect(0, 0, 4, 1),
+			opts: []Option{
+				HighlightIgnoreCase(),
+			},
+			writes: func(widget *Text) error {
+				widget.Highlight("BA", cell.BgColor(cell.ColorYellow))
+				if err := widget.Write("ab", WriteCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				return widget.Write("AB", WriteCellOpts(cell.FgColor(cell.ColorBlue)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testdraw.MustText(c, "b", image.Point{1, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+					cell.BgColor(cell.ColorYellow),
+				))
+				testdraw.MustText(c, "A", image.Point{2, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+					cell.BgColor(cell.ColorYellow),
+				))
+				testdraw.MustText(c, "B", image.Point{3, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlight is case-sensitive by default",
+			canvas: image.Rect(0, 0, 4, 1),
+			writes: func(widget *Text) error {
+				widget.Highlight("BA", cell.BgColor(cell.ColorYellow))
+				return widget.Write("abAB")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abAB", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlights matches when not wrapping",
+			canvas: image.Rect(0, 0, 5, 2),
+			opts: []Option{
+				WrapNone(),
+			},
+			writes: func(widget *Text) error {
+				widget.Highlight("ab", cell.Bold())
+				return widget.Write("xab\nab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "x", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{1, 0}, draw.TextCellOpts(cell.Bold()))
+				testdraw.MustText(c, "ab", image.Point{0, 1}, draw.TextCellOpts(cell.Bold()))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "empty term removes the highlight",
+			canvas: image.Rect(0, 0, 3, 1),
+			writes: func(widget *Text) error {
+				widget.Highlight("ab", cell.BgColor(cell.ColorRed))
+				widget.Highlight("")
+				return widget.Write("abc")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abc", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "WrapNone draws long lines unwrapped with the right scroll marker",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				WrapNone(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("0123456789\nab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "01234", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{0, 1})
+				testdraw.MustText(c, "⇨", image.Point{4, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "WrapNone doesn't draw scroll markers when the content fits",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				WrapNone(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("01234\nab")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowRight,
+				}, &widgetapi.EventMeta{})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "01234", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "WrapNone scrolls right on right arrow a column at a time",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				WrapNone(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("0123456789\nab")
+			},
+			events: func(widget *Text) {
+				for i := 0; i < 2; i++ {
+					widget.Keyboard(&terminalapi.Keyboard{
+						Key: keyboard.KeyArrowRight,
+					}, &widgetapi.EventMeta{})
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "23456", image.Point{0, 0})
+				testdraw.MustText(c, "⇦⇨", image.Point{3, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "WrapNone scrolling right is clamped at the end of the longest line",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				WrapNone(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("0123456789\nab")
+			},
+			events: func(widget *Text) {
+				for i := 0; i < 20; i++ {
+					widget.Keyboard(&terminalapi.Keyboard{
+						Key: keyboard.KeyArrowRight,
+					}, &widgetapi.EventMeta{})
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "56789", image.Point{0, 0})
+				testdraw.MustText(c, "⇦", image.Point{3, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "WrapNone scrolling left is clamped at the first column",
+			canvas: image.Rect(0, 0, 5, 3),
+			opts: []Option{
+				WrapNone(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("0123456789\nab")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowRight,
+				}, &widgetapi.EventMeta{})
+				for i := 0; i < 3; i++ {
+					widget.Keyboard(&terminalapi.Keyboard{
+						Key: keyboard.KeyArrowLeft,
+					}, &widgetapi.EventMeta{})
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "01234", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{0, 1})
+				testdraw.MustText(c, "⇨", image.Point{4, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "WrapNone skips full-width runes cut by the canvas edges",
+			canvas: image.Rect(0, 0, 4, 2),
+			opts: []Option{
+				WrapNone(),
+				ScrollRunesHorizontal('<', '>'),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a世界b")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowRight,
+				}, &widgetapi.EventMeta{})
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowRight,
+				}, &widgetapi.EventMeta{})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "界b", image.Point{1, 0})
+				testdraw.MustText(c, "<", image.Point{2, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "scrolls down on down arrow a line at a time",
 			canvas: image.Rect(0, 0, 10, 3),
@@ -496,6 +962,105 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "shows line numbers of wrapped lines",
+			canvas: image.Rect(0, 0, 12, 5),
+			opts: []Option{
+				WrapAtRunes(),
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world\nshort\nand long again")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				numOpts := draw.TextCellOpts(cell.FgColor(cell.ColorNumber(DefaultLineNumbersColorNumber)))
+				testdraw.MustText(c, "1", image.Point{0, 0}, numOpts)
+				testdraw.MustText(c, "hello worl", image.Point{2, 0})
+				testdraw.MustText(c, "d", image.Point{2, 1})
+				testdraw.MustText(c, "2", image.Point{0, 2}, numOpts)
+				testdraw.MustText(c, "short", image.Point{2, 2})
+				testdraw.MustText(c, "3", image.Point{0, 3}, numOpts)
+				testdraw.MustText(c, "and long a", image.Point{2, 3})
+				testdraw.MustText(c, "gain", image.Point{2, 4})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "repeats line numbers of wrapped lines in custom color",
+			canvas: image.Rect(0, 0, 12, 5),
+			opts: []Option{
+				WrapAtRunes(),
+				ShowLineNumbers(),
+				RepeatWrappedLineNumbers(),
+				LineNumbersColor(cell.ColorRed),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world\nshort\nand long again")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				numOpts := draw.TextCellOpts(cell.FgColor(cell.ColorRed))
+				testdraw.MustText(c, "1", image.Point{0, 0}, numOpts)
+				testdraw.MustText(c, "hello worl", image.Point{2, 0})
+				testdraw.MustText(c, "1", image.Point{0, 1}, numOpts)
+				testdraw.MustText(c, "d", image.Point{2, 1})
+				testdraw.MustText(c, "2", image.Point{0, 2}, numOpts)
+				testdraw.MustText(c, "short", image.Point{2, 2})
+				testdraw.MustText(c, "3", image.Point{0, 3}, numOpts)
+				testdraw.MustText(c, "and long a", image.Point{2, 3})
+				testdraw.MustText(c, "3", image.Point{0, 4}, numOpts)
+				testdraw.MustText(c, "gain", image.Point{2, 4})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "line numbers are right-aligned in a gutter sized to the largest number",
+			canvas: image.Rect(0, 0, 6, 3),
+			opts: []Option{
+				ShowLineNumbers(),
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("1\n2\n3\n4\n5\n6\n7\n8\n9\nten\neleven")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				numOpts := draw.TextCellOpts(cell.FgColor(cell.ColorNumber(DefaultLineNumbersColorNumber)))
+				testdraw.MustText(c, "⇧", image.Point{3, 0})
+				testdraw.MustText(c, "10", image.Point{0, 1}, numOpts)
+				testdraw.MustText(c, "ten", image.Point{3, 1})
+				testdraw.MustText(c, "11", image.Point{0, 2}, numOpts)
+				testdraw.MustText(c, "el…", image.Point{3, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "requests a resize when there is no space for text next to the gutter",
+			canvas: image.Rect(0, 0, 2, 3),
+			opts: []Option{
+				ShowLineNumbers(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "wraps lines at full-width rune boundaries",
 			canvas: image.Rect(0, 0, 10, 6),
@@ -1083,6 +1648,74 @@ func TestOptions(t *testing.T) {
 	}
 }
 
+func TestPreferredSize(t *testing.T) {
+	tests := []struct {
+		desc  string
+		opts  []Option
+		write string
+		// drawWidth when positive is the width of the canvas the text is
+		// drawn on before the preferred size is queried.
+		drawWidth int
+		want      image.Point
+	}{
+		{
+			desc: "no preference without content",
+			want: image.ZP,
+		},
+		{
+			desc:  "fits the longest line and all the lines",
+			write: "ab\n世界x\n",
+			want:  image.Point{5, 3},
+		},
+		{
+			desc:  "includes the gutter with line numbers",
+			opts:  []Option{ShowLineNumbers()},
+			write: "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nlonger",
+			want:  image.Point{9, 11},
+		},
+		{
+			desc:  "no preferred width when wrapping",
+			opts:  []Option{WrapAtRunes()},
+			write: "abcd\nef",
+			want:  image.Point{0, 2},
+		},
+		{
+			desc:      "height of the wrapped lines once drawn",
+			opts:      []Option{WrapAtRunes()},
+			write:     "abcd\nef",
+			drawWidth: 2,
+			want:      image.Point{0, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			text, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.write != "" {
+				if err := text.Write(tc.write); err != nil {
+					t.Fatalf("Write => unexpected error: %v", err)
+				}
+			}
+			if tc.drawWidth > 0 {
+				cvs, err := canvas.New(image.Rect(0, 0, tc.drawWidth, 5))
+				if err != nil {
+					t.Fatalf("canvas.New => unexpected error: %v", err)
+				}
+				if err := text.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			if got := text.PreferredSize(); got != tc.want {
+				t.Errorf("PreferredSize => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestTruncateToCells(t *testing.T) {
 	tests := []struct {
 		desc     string
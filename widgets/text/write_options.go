@@ -29,7 +29,9 @@ type WriteOption interface {
 // writeOptions stores the provided options.
 type writeOptions struct {
 	cellOpts *cell.Options
+	link     string
 	replace  bool
+	rtl      bool
 }
 
 // newWriteOptions returns new writeOptions instance.
@@ -40,6 +42,9 @@ func newWriteOptions(wOpts ...WriteOption) *writeOptions {
 	for _, o := range wOpts {
 		o.set(wo)
 	}
+	if wo.link != "" {
+		wo.cellOpts.Link = wo.link
+	}
 	return wo
 }
 
@@ -65,3 +70,26 @@ func WriteReplace() WriteOption {
 		wOpts.replace = true
 	})
 }
+
+// WriteLink marks the written text as a hyperlink to the provided URL.
+// Terminals that support OSC 8 hyperlinks make the text clickable, other
+// terminals display it as plain text styled by the WriteCellOpts option.
+// Only works when using the tcell backend.
+func WriteLink(url string) WriteOption {
+	return writeOption(func(wOpts *writeOptions) {
+		wOpts.link = url
+	})
+}
+
+// WriteRTL marks the written text as right-to-left text, e.g. Arabic or Hebrew.
+// The text is displayed in the reversed order. Lines that start with
+// right-to-left text are right-aligned and the left-to-right text in them is
+// displayed after the right-to-left text before it.
+// This is a simplified version of the unicode bidirectional algorithm, the
+// direction of the text is determined only by this option and not by the
+// written runes.
+func WriteRTL() WriteOption {
+	return writeOption(func(wOpts *writeOptions) {
+		wOpts.rtl = true
+	})
+}
@@ -332,15 +332,34 @@ func (fe *fieldEditor) reset() {
 	*fe = *newFieldEditor(fe.onChange)
 }
 
-// insert inserts the rune at the current position of the cursor.
-func (fe *fieldEditor) insert(r rune) {
-	rw := runewidth.RuneWidth(r)
-	if rw == 0 {
-		// Don't insert invisible runes.
+// contentWith returns the content of the field as it would be if the runes
+// were inserted at the current position of the cursor. Doesn't modify the
+// field.
+func (fe *fieldEditor) contentWith(rs ...rune) string {
+	var b strings.Builder
+	b.WriteString(string(fe.data[:fe.curDataPos]))
+	b.WriteString(string(rs))
+	b.WriteString(string(fe.data[fe.curDataPos:]))
+	return b.String()
+}
+
+// insert inserts the runes at the current position of the cursor.
+// The onChange handler is called once after all the runes are inserted.
+func (fe *fieldEditor) insert(rs ...rune) {
+	var inserted bool
+	for _, r := range rs {
+		if r != '\n' && runewidth.RuneWidth(r) == 0 {
+			// Don't insert invisible runes. Newlines are only inserted in
+			// the multi-line mode.
+			continue
+		}
+		fe.data.insertAt(fe.curDataPos, r)
+		fe.curDataPos++
+		inserted = true
+	}
+	if !inserted {
 		return
 	}
-	fe.data.insertAt(fe.curDataPos, r)
-	fe.curDataPos++
 	if fe.onChange != nil {
 		fe.onChange(string(fe.data))
 	}
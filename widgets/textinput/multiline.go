@@ -0,0 +1,223 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+// multiline.go contains code that lays out and navigates the content of a
+// multi-line text input field.
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/private/runewidth"
+)
+
+// visLine is one line of text as displayed in the multi-line text input
+// field. Contains the runes of the field data at indexes start <= idx < end.
+type visLine struct {
+	start int
+	end   int
+}
+
+// multiLineEditor lays out the data of a field editor into lines that fit
+// the width of the text input field and moves the cursor between them.
+// Long lines are wrapped at rune boundaries. The last cell of each line is
+// reserved for the cursor, the same way the single-line field reserves it.
+// This object isn't thread-safe.
+type multiLineEditor struct {
+	// fe is the field editor that holds the data and the cursor position.
+	fe *fieldEditor
+
+	// firstLine is the index of the first displayed line.
+	firstLine int
+
+	// width is the width of the text input field last time viewFor was
+	// called.
+	width int
+}
+
+// newMultiLineEditor returns a new multiLineEditor that edits the data in the
+// field editor.
+func newMultiLineEditor(fe *fieldEditor) *multiLineEditor {
+	return &multiLineEditor{fe: fe}
+}
+
+// layout splits the data into lines that fit the specified width. Lines
+// aren't wrapped if the width isn't known yet, i.e. it is zero.
+// Always returns at least one, possibly empty, line.
+func (me *multiLineEditor) layout(width int) []visLine {
+	var (
+		lines []visLine
+		start int
+		cells int
+	)
+	for i, r := range me.fe.data {
+		if r == '\n' {
+			lines = append(lines, visLine{start, i})
+			start, cells = i+1, 0
+			continue
+		}
+
+		rw := runewidth.RuneWidth(r)
+		if width > 0 && cells+rw > width-1 && i > start {
+			lines = append(lines, visLine{start, i})
+			start, cells = i, 0
+		}
+		cells += rw
+	}
+	return append(lines, visLine{start, len(me.fe.data)})
+}
+
+// wrapped asserts whether the i-th line continues on the next line, i.e. it
+// wasn't ended by a newline.
+func wrapped(lines []visLine, i int) bool {
+	return i+1 < len(lines) && lines[i+1].start == lines[i].end
+}
+
+// curLine returns the index of the line the cursor is on.
+func (me *multiLineEditor) curLine(lines []visLine) int {
+	var l int
+	for i, line := range lines {
+		if line.start <= me.fe.curDataPos {
+			l = i
+		}
+	}
+	return l
+}
+
+// cellsTo returns the number of cells the runes of the line take up to, but
+// not including, the data index.
+func (me *multiLineEditor) cellsTo(line visLine, idx int) int {
+	var cells int
+	for _, r := range me.fe.data[line.start:idx] {
+		cells += runewidth.RuneWidth(r)
+	}
+	return cells
+}
+
+// posAt returns the data index of the cursor position in the i-th line that
+// is at or left of the cell.
+func (me *multiLineEditor) posAt(lines []visLine, i, cell int) int {
+	line := lines[i]
+	last := line.end
+	if wrapped(lines, i) {
+		// The end of a wrapped line is the start of the next line.
+		last--
+	}
+
+	var cells int
+	for pos := line.start; pos < last; pos++ {
+		cells += runewidth.RuneWidth(me.fe.data[pos])
+		if cells > cell {
+			return pos
+		}
+	}
+	return last
+}
+
+// cursorVertical moves the cursor by the number of lines, up if negative.
+// The cursor stays in the same column if the target line is long enough.
+func (me *multiLineEditor) cursorVertical(by int) {
+	lines := me.layout(me.width)
+	cur := me.curLine(lines)
+	target := cur + by
+	switch {
+	case target < 0:
+		me.fe.cursorStart()
+		return
+	case target >= len(lines):
+		me.fe.cursorEnd()
+		return
+	}
+	col := me.cellsTo(lines[cur], me.fe.curDataPos)
+	me.fe.curDataPos = me.posAt(lines, target, col)
+}
+
+// cursorUp moves the cursor one line up.
+func (me *multiLineEditor) cursorUp() {
+	me.cursorVertical(-1)
+}
+
+// cursorDown moves the cursor one line down.
+func (me *multiLineEditor) cursorDown() {
+	me.cursorVertical(1)
+}
+
+// cursorLineStart moves the cursor to the start of the current line.
+func (me *multiLineEditor) cursorLineStart() {
+	lines := me.layout(me.width)
+	me.fe.curDataPos = lines[me.curLine(lines)].start
+}
+
+// cursorLineEnd moves the cursor to the end of the current line.
+func (me *multiLineEditor) cursorLineEnd() {
+	lines := me.layout(me.width)
+	cur := me.curLine(lines)
+	me.fe.curDataPos = me.posAt(lines, cur, math.MaxInt32)
+}
+
+// cursorRelCell sets the cursor onto the cell within the visible area.
+// Cells after the end of a line move the cursor to the end of that line,
+// cells below the last line move it onto the last line.
+func (me *multiLineEditor) cursorRelCell(p image.Point) {
+	lines := me.layout(me.width)
+	target := me.firstLine + p.Y
+	if target >= len(lines) {
+		target = len(lines) - 1
+	}
+	me.fe.curDataPos = me.posAt(lines, target, p.X)
+}
+
+// viewFor returns the currently visible lines inside a text field with the
+// specified width and height and the cursor position within the field.
+// Scrolls the visible lines so that the cursor is visible.
+func (me *multiLineEditor) viewFor(width, height int) ([]string, image.Point, error) {
+	if min := minFieldWidth; width < min {
+		return nil, image.ZP, fmt.Errorf("width %d is too small, the minimum is %d", width, min)
+	}
+	if min := minFieldHeight; height < min {
+		return nil, image.ZP, fmt.Errorf("height %d is too small, the minimum is %d", height, min)
+	}
+	me.width = width
+
+	lines := me.layout(width)
+	cur := me.curLine(lines)
+	if max := len(lines) - height; me.firstLine > max {
+		me.firstLine = max
+	}
+	if me.firstLine < 0 {
+		me.firstLine = 0
+	}
+	switch {
+	case cur < me.firstLine:
+		me.firstLine = cur
+	case cur >= me.firstLine+height:
+		me.firstLine = cur - height + 1
+	}
+
+	var visible []string
+	for _, line := range lines[me.firstLine:] {
+		if len(visible) == height {
+			break
+		}
+		visible = append(visible, string(me.fe.data[line.start:line.end]))
+	}
+	curPos := image.Point{
+		X: me.cellsTo(lines[cur], me.fe.curDataPos),
+		Y: cur - me.firstLine,
+	}
+	return visible, curPos, nil
+}
@@ -0,0 +1,379 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// insertText inserts the runes of the text into the editor.
+func insertText(me *multiLineEditor, text string) {
+	me.fe.insert([]rune(text)...)
+}
+
+func TestMultiLineEditor(t *testing.T) {
+	tests := []struct {
+		desc        string
+		width       int
+		height      int
+		ops         func(*multiLineEditor) error
+		wantView    []string
+		wantCur     image.Point
+		wantContent string
+		wantErr     bool
+	}{
+		{
+			desc:    "fails for width too small",
+			width:   3,
+			height:  1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails for height too small",
+			width:   4,
+			height:  0,
+			wantErr: true,
+		},
+		{
+			desc:     "no data",
+			width:    5,
+			height:   2,
+			wantView: []string{""},
+		},
+		{
+			desc:   "newlines start new lines",
+			width:  5,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "ab\nc")
+				return nil
+			},
+			wantView:    []string{"ab", "c"},
+			wantCur:     image.Point{1, 1},
+			wantContent: "ab\nc",
+		},
+		{
+			desc:   "newline at the end starts an empty line",
+			width:  5,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "ab\n")
+				return nil
+			},
+			wantView:    []string{"ab", ""},
+			wantCur:     image.Point{0, 1},
+			wantContent: "ab\n",
+		},
+		{
+			desc:   "long lines are wrapped, the last cell is reserved for the cursor",
+			width:  4,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abcdefg")
+				return nil
+			},
+			wantView:    []string{"abc", "def", "g"},
+			wantCur:     image.Point{1, 2},
+			wantContent: "abcdefg",
+		},
+		{
+			desc:   "wraps before a full-width rune that doesn't fit",
+			width:  4,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "ab世")
+				return nil
+			},
+			wantView:    []string{"ab", "世"},
+			wantCur:     image.Point{2, 1},
+			wantContent: "ab世",
+		},
+		{
+			desc:   "cursor down keeps the column",
+			width:  10,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abcd\nxyz")
+				if _, _, err := me.viewFor(10, 3); err != nil {
+					return err
+				}
+				me.fe.cursorStart()
+				me.fe.cursorRight()
+				me.fe.cursorRight()
+				me.cursorDown()
+				return nil
+			},
+			wantView:    []string{"abcd", "xyz"},
+			wantCur:     image.Point{2, 1},
+			wantContent: "abcd\nxyz",
+		},
+		{
+			desc:   "cursor up moves to the end of a shorter line",
+			width:  10,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abcd\nxy\nfghij")
+				if _, _, err := me.viewFor(10, 3); err != nil {
+					return err
+				}
+				me.cursorUp()
+				return nil
+			},
+			wantView:    []string{"abcd", "xy", "fghij"},
+			wantCur:     image.Point{2, 1},
+			wantContent: "abcd\nxy\nfghij",
+		},
+		{
+			desc:   "cursor up from a shorter line",
+			width:  10,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abcd\nxy\nfghij")
+				if _, _, err := me.viewFor(10, 3); err != nil {
+					return err
+				}
+				me.cursorUp()
+				me.cursorUp()
+				return nil
+			},
+			wantView:    []string{"abcd", "xy", "fghij"},
+			wantCur:     image.Point{2, 0},
+			wantContent: "abcd\nxy\nfghij",
+		},
+		{
+			desc:   "cursor up on the first line moves to the start",
+			width:  10,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abcd")
+				if _, _, err := me.viewFor(10, 3); err != nil {
+					return err
+				}
+				me.cursorUp()
+				return nil
+			},
+			wantView:    []string{"abcd"},
+			wantCur:     image.Point{0, 0},
+			wantContent: "abcd",
+		},
+		{
+			desc:   "cursor down on the last line moves to the end",
+			width:  10,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abcd")
+				if _, _, err := me.viewFor(10, 3); err != nil {
+					return err
+				}
+				me.fe.cursorStart()
+				me.cursorDown()
+				return nil
+			},
+			wantView:    []string{"abcd"},
+			wantCur:     image.Point{4, 0},
+			wantContent: "abcd",
+		},
+		{
+			desc:   "cursor up onto a wrapped line stays on that line",
+			width:  4,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abcdef")
+				if _, _, err := me.viewFor(4, 3); err != nil {
+					return err
+				}
+				me.cursorUp()
+				return nil
+			},
+			wantView:    []string{"abc", "def"},
+			wantCur:     image.Point{2, 0},
+			wantContent: "abcdef",
+		},
+		{
+			desc:   "moves to the start and the end of the line",
+			width:  10,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "abc\ndef")
+				if _, _, err := me.viewFor(10, 3); err != nil {
+					return err
+				}
+				me.cursorLineStart()
+				me.fe.cursorRight()
+				me.cursorLineEnd()
+				return nil
+			},
+			wantView:    []string{"abc", "def"},
+			wantCur:     image.Point{3, 1},
+			wantContent: "abc\ndef",
+		},
+		{
+			desc:   "deleting the newline joins the lines",
+			width:  10,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "ab\ncd")
+				if _, _, err := me.viewFor(10, 3); err != nil {
+					return err
+				}
+				me.cursorLineStart()
+				me.fe.deleteBefore()
+				return nil
+			},
+			wantView:    []string{"abcd"},
+			wantCur:     image.Point{2, 0},
+			wantContent: "abcd",
+		},
+		{
+			desc:   "viewport scrolls down to the cursor",
+			width:  5,
+			height: 2,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "a\nb\nc\nd")
+				return nil
+			},
+			wantView:    []string{"c", "d"},
+			wantCur:     image.Point{1, 1},
+			wantContent: "a\nb\nc\nd",
+		},
+		{
+			desc:   "viewport scrolls up to the cursor",
+			width:  5,
+			height: 2,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "a\nb\nc\nd")
+				if _, _, err := me.viewFor(5, 2); err != nil {
+					return err
+				}
+				me.cursorUp()
+				me.cursorUp()
+				me.cursorUp()
+				return nil
+			},
+			wantView:    []string{"a", "b"},
+			wantCur:     image.Point{1, 0},
+			wantContent: "a\nb\nc\nd",
+		},
+		{
+			desc:   "viewport keeps its position while the cursor is visible",
+			width:  5,
+			height: 2,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "a\nb\nc\nd")
+				if _, _, err := me.viewFor(5, 2); err != nil {
+					return err
+				}
+				me.cursorUp()
+				return nil
+			},
+			wantView:    []string{"c", "d"},
+			wantCur:     image.Point{1, 0},
+			wantContent: "a\nb\nc\nd",
+		},
+		{
+			desc:   "viewport follows when the height increases",
+			width:  5,
+			height: 4,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "a\nb\nc\nd")
+				_, _, err := me.viewFor(5, 2)
+				return err
+			},
+			wantView:    []string{"a", "b", "c", "d"},
+			wantCur:     image.Point{1, 3},
+			wantContent: "a\nb\nc\nd",
+		},
+		{
+			desc:   "mouse moves the cursor",
+			width:  5,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "ab\ncdef")
+				if _, _, err := me.viewFor(5, 3); err != nil {
+					return err
+				}
+				me.cursorRelCell(image.Point{1, 0})
+				return nil
+			},
+			wantView:    []string{"ab", "cdef"},
+			wantCur:     image.Point{1, 0},
+			wantContent: "ab\ncdef",
+		},
+		{
+			desc:   "mouse after the end of the line moves the cursor to the end",
+			width:  5,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "ab\ncdef")
+				if _, _, err := me.viewFor(5, 3); err != nil {
+					return err
+				}
+				me.cursorRelCell(image.Point{4, 0})
+				return nil
+			},
+			wantView:    []string{"ab", "cdef"},
+			wantCur:     image.Point{2, 0},
+			wantContent: "ab\ncdef",
+		},
+		{
+			desc:   "mouse below the last line moves the cursor onto the last line",
+			width:  5,
+			height: 3,
+			ops: func(me *multiLineEditor) error {
+				insertText(me, "ab\ncdef")
+				if _, _, err := me.viewFor(5, 3); err != nil {
+					return err
+				}
+				me.cursorRelCell(image.Point{0, 2})
+				return nil
+			},
+			wantView:    []string{"ab", "cdef"},
+			wantCur:     image.Point{0, 1},
+			wantContent: "ab\ncdef",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			me := newMultiLineEditor(newFieldEditor(nil))
+			if tc.ops != nil {
+				if err := tc.ops(me); err != nil {
+					t.Fatalf("ops => unexpected error: %v", err)
+				}
+			}
+
+			gotView, gotCur, err := me.viewFor(tc.width, tc.height)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("viewFor(%d, %d) => unexpected error: %v, wantErr: %v", tc.width, tc.height, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.wantView, gotView); diff != "" {
+				t.Errorf("viewFor(%d, %d) => unexpected view, diff (-want, +got):\n%s", tc.width, tc.height, diff)
+			}
+			if gotCur != tc.wantCur {
+				t.Errorf("viewFor(%d, %d) => cursor at %v, want %v", tc.width, tc.height, gotCur, tc.wantCur)
+			}
+			if got := me.fe.content(); got != tc.wantContent {
+				t.Errorf("content -> %q, want %q", got, tc.wantContent)
+			}
+		})
+	}
+}
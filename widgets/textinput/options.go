@@ -22,6 +22,7 @@ import (
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/private/wrap"
@@ -62,10 +63,25 @@ type options struct {
 	defaultText  string
 
 	filter                   FilterFn
+	validator                ValidatorFn
+	errorColor               cell.Color
 	onSubmit                 SubmitFn
 	onChange                 ChangeFn
 	clearOnSubmit            bool
 	exclusiveKeyboardOnFocus bool
+	captureKeysOnFocus       []keyboard.Key
+	multiLine                bool
+}
+
+// validateMask validates the rune used to hide the text.
+func validateMask(r rune) error {
+	if err := wrap.ValidText(string(r)); err != nil {
+		return fmt.Errorf("rune %c(%d): %v", r, r, err)
+	}
+	if got, want := runewidth.RuneWidth(r), 1; got != want {
+		return fmt.Errorf("rune %c(%d), has rune width of %d cells, only runes with width of %d are accepted", r, r, got, want)
+	}
+	return nil
 }
 
 // validate validates the provided options.
@@ -77,11 +93,8 @@ func (o *options) validate() error {
 		return fmt.Errorf("invalid MaxWidthCells(%d), must be value in range %d <= value", *cells, min)
 	}
 	if r := o.hideTextWith; r != 0 {
-		if err := wrap.ValidText(string(r)); err != nil {
-			return fmt.Errorf("invalid HideTextWidth rune %c(%d): %v", r, r, err)
-		}
-		if got, want := runewidth.RuneWidth(r), 1; got != want {
-			return fmt.Errorf("invalid HideTextWidth rune %c(%d), has rune width of %d cells, only runes with width of %d are accepted", r, r, got, want)
+		if err := validateMask(r); err != nil {
+			return fmt.Errorf("invalid HideTextWidth %v", err)
 		}
 	}
 	if o.defaultText != "" {
@@ -89,8 +102,8 @@ func (o *options) validate() error {
 			return fmt.Errorf("invalid DefaultText: %v", err)
 		}
 		for _, r := range o.defaultText {
-			if r == '\n' {
-				return errors.New("invalid DefaultText: newline characters aren't allowed")
+			if r == '\n' && !o.multiLine {
+				return errors.New("invalid DefaultText: newline characters are only allowed with the MultiLine option")
 			}
 		}
 	}
@@ -105,6 +118,7 @@ func newOptions() *options {
 		highlightedColor: cell.ColorNumber(DefaultHighlightedColorNumber),
 		cursorColor:      cell.ColorNumber(DefaultCursorColorNumber),
 		labelAlign:       DefaultLabelAlign,
+		errorColor:       DefaultErrorColor,
 	}
 }
 
@@ -231,6 +245,8 @@ func PlaceHolderColor(c cell.Color) Option {
 // the text. Useful for fields that accept sensitive information like
 // passwords.
 // The rune must be a printable rune with cell width of one.
+// The mask can be changed after the widget is created by calling
+// TextInput.SetMask or TextInput.ClearMask.
 func HideTextWith(r rune) Option {
 	return option(func(opts *options) {
 		opts.hideTextWith = r
@@ -250,6 +266,35 @@ func Filter(fn FilterFn) Option {
 	})
 }
 
+// ValidatorFn if provided is used to validate the text in the text input
+// field. The argument text is all the text the field would contain if the
+// keystroke was accepted. Returning an error rejects the keystroke and the
+// error message is displayed beneath the field.
+type ValidatorFn func(text string) error
+
+// Validator sets a function that validates the text each time the user types
+// a rune into the field. Unlike Filter, the function sees the full candidate
+// text. Rejected keystrokes are ignored and the message of the returned error
+// is displayed on the line beneath the field until the next accepted
+// keystroke, so the widget needs one more line of height.
+func Validator(fn ValidatorFn) Option {
+	return option(func(opts *options) {
+		opts.validator = fn
+	})
+}
+
+// DefaultErrorColor is the default value for the ErrorColor option.
+const DefaultErrorColor = cell.ColorRed
+
+// ErrorColor sets the color of the error message displayed when the Validator
+// rejects a keystroke.
+// Defaults to DefaultErrorColor.
+func ErrorColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.errorColor = c
+	})
+}
+
 // SubmitFn if provided is called when the user submits the content of the text
 // input field, the argument text contains all the text in the field.
 // Submitting the input field clears its content.
@@ -301,6 +346,16 @@ func ExclusiveKeyboardOnFocus() Option {
 	})
 }
 
+// CaptureKeysOnFocus when set ensures that when this widget is focused, the
+// specified keys are delivered only to this widget and the container doesn't
+// act on them. E.g. capture the arrow keys to keep moving the cursor with them
+// when the container uses the arrow keys to move the keyboard focus.
+func CaptureKeysOnFocus(keys ...keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.captureKeysOnFocus = append([]keyboard.Key(nil), keys...)
+	})
+}
+
 // DefaultText sets the text to be present in a newly created input field.
 // The text must not contain any control or space characters other than ' '.
 // The user can edit this text as normal.
@@ -309,3 +364,17 @@ func DefaultText(text string) Option {
 		opts.defaultText = text
 	})
 }
+
+// MultiLine makes the text input field hold multiple lines of text.
+// The Enter key inserts a newline instead of submitting the content, so the
+// OnSubmit callback isn't called and the ClearOnSubmit option has no effect,
+// use TextInput.Read or TextInput.ReadAndClear to get the text. Lines longer
+// than the width of the field are wrapped. The up and down arrows move the
+// cursor between the lines, Home and End move it to the start and the end of
+// the current line. The field takes all the height available to the widget
+// and scrolls the lines so that the cursor is always visible.
+func MultiLine() Option {
+	return option(func(opts *options) {
+		opts.multiLine = true
+	})
+}
@@ -16,6 +16,7 @@
 package textinput
 
 import (
+	"fmt"
 	"image"
 	"strings"
 	"sync"
@@ -51,11 +52,18 @@ type TextInput struct {
 
 	// editor tracks the edits and the state of the text input field.
 	editor *fieldEditor
+	// multi lays out the content of the field into lines in the multi-line
+	// mode. Nil unless the MultiLine option was provided.
+	multi *multiLineEditor
 
 	// forField is the area that was occupied by the text input field last
 	// time Draw() was called.
 	forField image.Rectangle
 
+	// validationErr is the error returned by the validator for the last
+	// rejected keystroke. Nil if the last keystroke was accepted.
+	validationErr error
+
 	// opts are the provided options.
 	opts *options
 }
@@ -73,6 +81,9 @@ func New(opts ...Option) (*TextInput, error) {
 		editor: newFieldEditor(opt.onChange),
 		opts:   opt,
 	}
+	if opt.multiLine {
+		ti.multi = newMultiLineEditor(ti.editor)
+	}
 	for _, r := range ti.opts.defaultText {
 		ti.editor.insert(r)
 	}
@@ -91,6 +102,7 @@ var (
 )
 
 // Read reads the content of the text input field.
+// In the multi-line mode, the lines are separated by newline characters.
 func (ti *TextInput) Read() string {
 	ti.mu.Lock()
 	defer ti.mu.Unlock()
@@ -108,6 +120,31 @@ func (ti *TextInput) ReadAndClear() string {
 	return c
 }
 
+// SetMask hides the text in the input field, displaying the rune r instead of
+// each of the characters. Can be used to toggle the visibility of sensitive
+// information like passwords at runtime. The content of the field and the
+// position of the cursor are preserved, the change is visible on the next
+// call to Draw.
+// The rune must be a printable rune with cell width of one.
+func (ti *TextInput) SetMask(r rune) error {
+	if err := validateMask(r); err != nil {
+		return fmt.Errorf("invalid mask %v", err)
+	}
+
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	ti.opts.hideTextWith = r
+	return nil
+}
+
+// ClearMask removes the mask set by SetMask or the HideTextWith option, the
+// text in the input field becomes visible on the next call to Draw.
+func (ti *TextInput) ClearMask() {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	ti.opts.hideTextWith = 0
+}
+
 // drawLabel draws the text label in the area.
 func (ti *TextInput) drawLabel(cvs *canvas.Canvas, labelAr image.Rectangle) error {
 	start, err := alignfor.Text(labelAr, ti.opts.label, ti.opts.labelAlign, align.VerticalMiddle)
@@ -139,12 +176,50 @@ func (ti *TextInput) drawField(cvs *canvas.Canvas, text string) error {
 	)
 }
 
-// drawCursor draws the cursor within the text input field.
-func (ti *TextInput) drawCursor(cvs *canvas.Canvas, curPos int) error {
-	p := image.Point{
-		curPos + ti.forField.Min.X,
-		ti.forField.Min.Y,
+// drawMultiLine draws the visible lines of the multi-line text input field
+// and the cursor or the placeholder.
+func (ti *TextInput) drawMultiLine(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	lines, curPos, err := ti.multi.viewFor(ti.forField.Dx(), ti.forField.Dy())
+	if err != nil {
+		return err
 	}
+
+	if err := cvs.SetAreaCells(ti.forField, textFieldRune, cell.BgColor(ti.opts.fillColor)); err != nil {
+		return err
+	}
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if ti.opts.hideTextWith != 0 {
+			line = hideText(line, ti.opts.hideTextWith)
+		}
+		if err := draw.Text(
+			cvs, line, image.Point{ti.forField.Min.X, ti.forField.Min.Y + i},
+			draw.TextMaxX(ti.forField.Max.X),
+			draw.TextCellOpts(cell.FgColor(ti.opts.textColor)),
+		); err != nil {
+			return err
+		}
+	}
+
+	if meta.Focused {
+		return ti.drawCursor(cvs, curPos)
+	}
+	if ti.opts.placeHolder != "" && len(ti.editor.data) == 0 {
+		return draw.Text(
+			cvs, ti.opts.placeHolder, ti.forField.Min,
+			draw.TextMaxX(ti.forField.Max.X),
+			draw.TextCellOpts(cell.FgColor(ti.opts.placeHolderColor)),
+		)
+	}
+	return nil
+}
+
+// drawCursor draws the cursor within the text input field.
+// The cursor position is relative to the top left corner of the field.
+func (ti *TextInput) drawCursor(cvs *canvas.Canvas, curPos image.Point) error {
+	p := ti.forField.Min.Add(curPos)
 	if err := cvs.SetCellOpts(
 		p,
 		cell.FgColor(ti.opts.highlightedColor),
@@ -160,13 +235,41 @@ func (ti *TextInput) drawCursor(cvs *canvas.Canvas, curPos int) error {
 	return nil
 }
 
+// drawError draws the message of the validation error in the area.
+func (ti *TextInput) drawError(cvs *canvas.Canvas, errAr image.Rectangle) error {
+	msg := strings.SplitN(ti.validationErr.Error(), "\n", 2)[0]
+	if err := wrap.ValidText(msg); err != nil || errAr.Dx() < 1 {
+		return nil
+	}
+	return draw.Text(
+		cvs, msg, errAr.Min,
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextMaxX(errAr.Max.X),
+		draw.TextCellOpts(cell.FgColor(ti.opts.errorColor)),
+	)
+}
+
 // Draw draws the TextInput widget onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (ti *TextInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	ti.mu.Lock()
 	defer ti.mu.Unlock()
 
-	labelAr, textAr, err := split(cvs.Area(), ti.opts.label, ti.opts.widthPerc)
+	ar := cvs.Area()
+	var errAr image.Rectangle
+	if ti.opts.validator != nil {
+		// The last line is reserved for the validation errors.
+		if ar.Dy() < 2 {
+			return draw.ResizeNeeded(cvs)
+		}
+		a, e, err := area.HSplitCells(ar, ar.Dy()-1)
+		if err != nil {
+			return err
+		}
+		ar, errAr = a, e
+	}
+
+	labelAr, textAr, err := split(ar, ti.opts.label, ti.opts.widthPerc)
 	if err != nil {
 		return err
 	}
@@ -187,12 +290,23 @@ func (ti *TextInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		}
 	}
 
+	if ti.validationErr != nil {
+		errAr.Min.X = textAr.Min.X
+		if err := ti.drawError(cvs, errAr); err != nil {
+			return err
+		}
+	}
+
 	if ti.opts.border != linestyle.None {
 		if err := draw.Border(cvs, textAr, draw.BorderCellOpts(cell.FgColor(ti.opts.borderColor))); err != nil {
 			return err
 		}
 	}
 
+	if ti.multi != nil {
+		return ti.drawMultiLine(cvs, meta)
+	}
+
 	text, curPos, err := ti.editor.viewFor(ti.forField.Dx())
 	if err != nil {
 		return err
@@ -203,7 +317,7 @@ func (ti *TextInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	if meta.Focused {
-		if err := ti.drawCursor(cvs, curPos); err != nil {
+		if err := ti.drawCursor(cvs, image.Point{curPos, 0}); err != nil {
 			return err
 		}
 	} else if ti.opts.placeHolder != "" && text == "" {
@@ -226,6 +340,7 @@ func (ti *TextInput) keyboard(k *terminalapi.Keyboard) (bool, string) {
 	ti.mu.Lock()
 	defer ti.mu.Unlock()
 
+	ti.validationErr = nil
 	switch k.Key {
 	case keyboard.KeyBackspace, keyboard.KeyBackspace2:
 		ti.editor.deleteBefore()
@@ -239,13 +354,35 @@ func (ti *TextInput) keyboard(k *terminalapi.Keyboard) (bool, string) {
 	case keyboard.KeyArrowRight:
 		ti.editor.cursorRight()
 
+	case keyboard.KeyArrowUp:
+		if ti.multi != nil {
+			ti.multi.cursorUp()
+		}
+
+	case keyboard.KeyArrowDown:
+		if ti.multi != nil {
+			ti.multi.cursorDown()
+		}
+
 	case keyboard.KeyHome, keyboard.KeyCtrlA:
-		ti.editor.cursorStart()
+		if ti.multi != nil {
+			ti.multi.cursorLineStart()
+		} else {
+			ti.editor.cursorStart()
+		}
 
 	case keyboard.KeyEnd, keyboard.KeyCtrlE:
-		ti.editor.cursorEnd()
+		if ti.multi != nil {
+			ti.multi.cursorLineEnd()
+		} else {
+			ti.editor.cursorEnd()
+		}
 
 	case keyboard.KeyEnter:
+		if ti.multi != nil {
+			ti.insertValidated('\n')
+			return false, ""
+		}
 		text := ti.editor.content()
 		if ti.opts.clearOnSubmit {
 			ti.editor.reset()
@@ -263,12 +400,25 @@ func (ti *TextInput) keyboard(k *terminalapi.Keyboard) (bool, string) {
 			// Ignore filtered runes.
 			return false, ""
 		}
-		ti.editor.insert(rune(k.Key))
+		ti.insertValidated(rune(k.Key))
 	}
 
 	return false, ""
 }
 
+// insertValidated inserts the runes at the position of the cursor unless the
+// validator rejects the resulting text.
+// Caller must hold ti.mu.
+func (ti *TextInput) insertValidated(rs ...rune) {
+	if ti.opts.validator != nil {
+		if err := ti.opts.validator(ti.editor.contentWith(rs...)); err != nil {
+			ti.validationErr = err
+			return
+		}
+	}
+	ti.editor.insert(rs...)
+}
+
 // Keyboard processes keyboard events.
 // Implements widgetapi.Widget.Keyboard.
 func (ti *TextInput) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
@@ -281,6 +431,38 @@ func (ti *TextInput) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta
 	return nil
 }
 
+// Paste inserts the pasted text at the position of the cursor in one step.
+// Runes that aren't supported or are rejected by the filter are skipped, so
+// are newlines unless the field holds multiple lines of text, see the
+// MultiLine option. Nothing is inserted if the validator rejects the
+// resulting text.
+// Implements widgetapi.Paster.Paste.
+func (ti *TextInput) Paste(p *terminalapi.Paste, meta *widgetapi.EventMeta) error {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	ti.validationErr = nil
+	var rs []rune
+	for _, r := range p.Text {
+		switch {
+		case r == '\n':
+			if ti.multi == nil {
+				continue
+			}
+		case wrap.ValidText(string(r)) != nil:
+			continue
+		case ti.opts.filter != nil && !ti.opts.filter(r):
+			continue
+		}
+		rs = append(rs, r)
+	}
+	if len(rs) == 0 {
+		return nil
+	}
+	ti.insertValidated(rs...)
+	return nil
+}
+
 // Mouse processes mouse events.
 // Implements widgetapi.Widget.Mouse.
 func (ti *TextInput) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
@@ -291,6 +473,10 @@ func (ti *TextInput) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) erro
 		return nil
 	}
 
+	if ti.multi != nil {
+		ti.multi.cursorRelCell(m.Position.Sub(ti.forField.Min))
+		return nil
+	}
 	cellIdx := m.Position.X - ti.forField.Min.X
 	ti.editor.cursorRelCell(cellIdx)
 	return nil
@@ -314,12 +500,21 @@ func (ti *TextInput) Options() widgetapi.Options {
 		needWidth += 2
 		needHeight += 2
 	}
+	if ti.opts.validator != nil {
+		// One line for the validation errors.
+		needHeight++
+	}
 
 	maxWidth := 0
 	if ti.opts.maxWidthCells != nil {
 		additional := *ti.opts.maxWidthCells - minFieldWidth
 		maxWidth = needWidth + additional
 	}
+	maxHeight := needHeight
+	if ti.opts.multiLine {
+		// The multi-line field takes all the available height.
+		maxHeight = 0
+	}
 
 	return widgetapi.Options{
 		MinimumSize: image.Point{
@@ -328,11 +523,12 @@ func (ti *TextInput) Options() widgetapi.Options {
 		},
 		MaximumSize: image.Point{
 			maxWidth,
-			needHeight,
+			maxHeight,
 		},
 		WantKeyboard:             widgetapi.KeyScopeFocused,
 		WantMouse:                widgetapi.MouseScopeWidget,
 		ExclusiveKeyboardOnFocus: ti.opts.exclusiveKeyboardOnFocus,
+		CaptureKeysOnFocus:       ti.opts.captureKeysOnFocus,
 	}
 }
 
@@ -16,6 +16,7 @@ package textinput
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"sync"
 	"testing"
@@ -64,6 +65,16 @@ func (ct *callbackTracker) submit(text string) error {
 	return nil
 }
 
+// digitsOnly is a ValidatorFn that accepts only text made of digits.
+func digitsOnly(text string) error {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("not a digit: %c", r)
+		}
+	}
+	return nil
+}
+
 func TestTextInput(t *testing.T) {
 	// Makes the empty text input field visible and cursor in test outputs.
 	textFieldRune = '_'
@@ -851,6 +862,127 @@ func TestTextInput(t *testing.T) {
 			},
 		},
 
+		{
+			desc: "requests a resize when there is no line for the validation errors",
+			opts: []Option{
+				Validator(digitsOnly),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			meta:   &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "validator rejects keystrokes and displays the error",
+			opts: []Option{
+				Validator(digitsOnly),
+			},
+			canvas: image.Rect(0, 0, 20, 2),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: '1'},
+				&terminalapi.Keyboard{Key: '2'},
+				&terminalapi.Keyboard{Key: 'a'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 20, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"12",
+					image.Point{0, 0},
+				)
+				testdraw.MustText(
+					cvs,
+					"not a digit: a",
+					image.Point{0, 1},
+					draw.TextCellOpts(cell.FgColor(DefaultErrorColor)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "validation error is cleared by an accepted keystroke",
+			opts: []Option{
+				Validator(digitsOnly),
+			},
+			canvas: image.Rect(0, 0, 20, 2),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: '1'},
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: '2'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 20, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"12",
+					image.Point{0, 0},
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "validation error is aligned with the field and in custom color",
+			opts: []Option{
+				Validator(digitsOnly),
+				ErrorColor(cell.ColorYellow),
+				Label("hi:"),
+			},
+			canvas: image.Rect(0, 0, 12, 2),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'x'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(
+					cvs,
+					"hi:",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(3, 0, 12, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"not a di…",
+					image.Point{3, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+
 		{
 			desc:   "displays written text with full-width runes",
 			canvas: image.Rect(0, 0, 4, 1),
@@ -1475,6 +1607,103 @@ func TestTextInput(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "multi-line field inserts newline on enter and doesn't submit",
+			opts: []Option{
+				MultiLine(),
+			},
+			canvas: image.Rect(0, 0, 6, 3),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: 'c'},
+			},
+			callback: &callbackTracker{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					cvs.Area(),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"ab",
+					image.Point{0, 0},
+				)
+				testdraw.MustText(
+					cvs,
+					"c",
+					image.Point{0, 1},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{1, 1},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
+		{
+			desc: "multi-line field moves the cursor up and down",
+			opts: []Option{
+				MultiLine(),
+			},
+			canvas: image.Rect(0, 0, 6, 3),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					cvs.Area(),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"ab",
+					image.Point{0, 0},
+				)
+				testdraw.MustText(
+					cvs,
+					"c",
+					image.Point{0, 1},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{1, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1592,6 +1821,7 @@ func TestTextInput(t *testing.T) {
 func TestTextInputRead(t *testing.T) {
 	tests := []struct {
 		desc   string
+		opts   []Option
 		events []terminalapi.Event
 		want   string
 	}{
@@ -1609,11 +1839,24 @@ func TestTextInputRead(t *testing.T) {
 			},
 			want: "abc",
 		},
+		{
+			desc: "reads multiple lines",
+			opts: []Option{
+				MultiLine(),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: 'c'},
+			},
+			want: "ab\nc",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			ti, err := New()
+			ti, err := New(tc.opts...)
 			if err != nil {
 				t.Fatalf("New => unexpected error: %v", err)
 			}
@@ -1658,6 +1901,200 @@ func TestTextInputRead(t *testing.T) {
 	}
 }
 
+func TestPaste(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		events []terminalapi.Event
+		want   string
+		// wantChanges is the number of calls to the OnChange callback.
+		wantChanges int
+	}{
+		{
+			desc: "inserts the pasted text in one step",
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "hello 世界"},
+			},
+			want:        "hello 世界",
+			wantChanges: 1,
+		},
+		{
+			desc: "inserts the pasted text at the cursor",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'z'},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Paste{Text: "bcd"},
+				&terminalapi.Keyboard{Key: 'e'},
+			},
+			want:        "abcdez",
+			wantChanges: 4,
+		},
+		{
+			desc: "skips newlines and control characters",
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "ab\ncd\t\n"},
+			},
+			want:        "abcd",
+			wantChanges: 1,
+		},
+		{
+			desc: "applies the filter to the pasted runes",
+			opts: []Option{
+				Filter(func(r rune) bool {
+					return r != 'x'
+				}),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "axbxc"},
+			},
+			want:        "abc",
+			wantChanges: 1,
+		},
+		{
+			desc: "nothing is inserted when the validator rejects the result",
+			opts: []Option{
+				Validator(digitsOnly),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "12"},
+				&terminalapi.Paste{Text: "3a"},
+			},
+			want:        "12",
+			wantChanges: 1,
+		},
+		{
+			desc: "empty paste doesn't change the content",
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "\n"},
+			},
+			want: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var changes int
+			opts := append(tc.opts, OnChange(func(string) {
+				changes++
+			}))
+			ti, err := New(opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := ti.Keyboard(e, &widgetapi.EventMeta{}); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+
+				case *terminalapi.Paste:
+					if err := ti.Paste(e, &widgetapi.EventMeta{}); err != nil {
+						t.Fatalf("Paste => unexpected error: %v", err)
+					}
+
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+			}
+
+			if got := ti.Read(); got != tc.want {
+				t.Errorf("Read => %q, want %q", got, tc.want)
+			}
+			if changes != tc.wantChanges {
+				t.Errorf("OnChange called %d times, want %d", changes, tc.wantChanges)
+			}
+		})
+	}
+}
+
+func TestSetMask(t *testing.T) {
+	ti, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	for _, r := range "a世" {
+		if err := ti.Keyboard(&terminalapi.Keyboard{Key: keyboard.Key(r)}, &widgetapi.EventMeta{}); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+
+	// drawWith draws the widget and returns the expected terminal with the
+	// text and the cursor at curX.
+	drawWith := func(text string, curX int) (got, want *faketerm.Terminal) {
+		t.Helper()
+		c, err := canvas.New(image.Rect(0, 0, 10, 1))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := ti.Draw(c, &widgetapi.Meta{Focused: true}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		got = faketerm.MustNew(c.Size())
+		if err := c.Apply(got); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+
+		want = faketerm.MustNew(c.Size())
+		cvs := testcanvas.MustNew(want.Area())
+		testcanvas.MustSetAreaCells(
+			cvs,
+			cvs.Area(),
+			textFieldRune,
+			cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+		)
+		testdraw.MustText(cvs, text, image.Point{0, 0})
+		testcanvas.MustSetCell(
+			cvs,
+			image.Point{curX, 0},
+			cursorRune,
+			cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+			cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+		)
+		testcanvas.MustApply(cvs, want)
+		return got, want
+	}
+
+	if err := ti.SetMask('世'); err == nil {
+		t.Errorf("SetMask('世') => got nil error, want an error for full-width rune")
+	}
+	if err := ti.SetMask(0x007f); err == nil {
+		t.Errorf("SetMask(0x007f) => got nil error, want an error for control rune")
+	}
+
+	if err := ti.SetMask('*'); err != nil {
+		t.Fatalf("SetMask => unexpected error: %v", err)
+	}
+	if got, want := drawWith("***", 3); faketerm.Diff(want, got) != "" {
+		t.Errorf("Draw after SetMask => %v", faketerm.Diff(want, got))
+	}
+
+	// Continue editing while masked.
+	if err := ti.Keyboard(&terminalapi.Keyboard{Key: 'b'}, &widgetapi.EventMeta{}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if err := ti.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft}, &widgetapi.EventMeta{}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if got, want := drawWith("****", 3); faketerm.Diff(want, got) != "" {
+		t.Errorf("Draw after editing masked text => %v", faketerm.Diff(want, got))
+	}
+	if got, want := ti.Read(), "a世b"; got != want {
+		t.Errorf("Read after editing masked text => %q, want %q", got, want)
+	}
+
+	ti.ClearMask()
+	if got, want := drawWith("a世b", 3); faketerm.Diff(want, got) != "" {
+		t.Errorf("Draw after ClearMask => %v", faketerm.Diff(want, got))
+	}
+	if got, want := ti.Read(), "a世b"; got != want {
+		t.Errorf("Read after ClearMask => %q, want %q", got, want)
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1673,6 +2110,18 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeWidget,
 			},
 		},
+		{
+			desc: "no label and no border, has validator",
+			opts: []Option{
+				Validator(digitsOnly),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{4, 2},
+				MaximumSize:  image.Point{0, 2},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
 		{
 			desc: "no label and no border, max width specified",
 			opts: []Option{
@@ -1787,6 +2236,19 @@ func TestOptions(t *testing.T) {
 				ExclusiveKeyboardOnFocus: true,
 			},
 		},
+		{
+			desc: "requests CaptureKeysOnFocus",
+			opts: []Option{
+				CaptureKeysOnFocus(keyboard.KeyArrowLeft, keyboard.KeyArrowRight),
+			},
+			want: widgetapi.Options{
+				MinimumSize:        image.Point{4, 1},
+				MaximumSize:        image.Point{0, 1},
+				WantKeyboard:       widgetapi.KeyScopeFocused,
+				WantMouse:          widgetapi.MouseScopeWidget,
+				CaptureKeysOnFocus: []keyboard.Key{keyboard.KeyArrowLeft, keyboard.KeyArrowRight},
+			},
+		},
 	}
 
 	for _, tc := range tests {